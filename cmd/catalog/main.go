@@ -0,0 +1,180 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+
+	productapp "main/internal/application/product"
+	categoryservice "main/internal/domain/category/service"
+	productservice "main/internal/domain/product/service"
+	"main/internal/domain/product/valueobject"
+	sharedvo "main/internal/domain/shared/valueobject"
+	"main/internal/infrastructure/eventbus"
+	"main/internal/infrastructure/idgen"
+	"main/internal/infrastructure/persistence/mongodb"
+	mongoutil "main/utility/mongodb"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gcmd"
+	"github.com/gogf/gf/v2/os/gctx"
+)
+
+// 商品目录批量导入导出命令
+//
+//	catalog import -f products.csv [--format csv] [--locale zh-CN] [--dry-run] --actor alice
+//	catalog export -f products.csv [--format csv] [--locale zh-CN] [--status on_sale]
+func main() {
+	ctx := gctx.GetInitCtx()
+	root := &gcmd.Command{
+		Name:  "catalog",
+		Usage: "catalog COMMAND [OPTION]",
+		Brief: "商品目录批量导入导出",
+	}
+	if err := root.AddCommand(importCommand, exportCommand); err != nil {
+		g.Log().Fatal(ctx, err)
+	}
+	root.Run(ctx)
+}
+
+// importCommand 批量导入商品
+var importCommand = &gcmd.Command{
+	Name:  "import",
+	Usage: "catalog import -f FILE [--format csv|json] [--locale LOCALE] [--dry-run] --actor NAME",
+	Brief: "从 CSV 或 JSON 文件批量导入商品，按 SKU 外部编码新增或更新",
+	Arguments: []gcmd.Argument{
+		{Name: "file", Short: "f", Brief: "商品目录文件"},
+		{Name: "format", Brief: "文件格式，为空时根据文件扩展名判断"},
+		{Name: "locale", Brief: "CSV 中价格的地区格式，如 zh-CN、en-US、ja-JP、de-DE，为空时使用默认地区"},
+		{Name: "dry-run", Orphan: true, Brief: "仅校验并输出逐行报告，不保存"},
+		{Name: "actor", Brief: "操作人，库存变动以其名义记录流水"},
+	},
+	Func: func(ctx context.Context, parser *gcmd.Parser) error {
+		filename := parser.GetOpt("file").String()
+		actor := parser.GetOpt("actor").String()
+		if filename == "" || actor == "" {
+			return gerror.New("--file and --actor are required")
+		}
+		format, err := productapp.ParseCatalogFormat(parser.GetOpt("format").String(), filename)
+		if err != nil {
+			return err
+		}
+
+		file, err := os.Open(filename)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		rows, err := productapp.DecodeCatalog(format, file, sharedvo.Locale(parser.GetOpt("locale").String()))
+		if err != nil {
+			return err
+		}
+
+		report, err := newProductApp(ctx).ImportCatalog(ctx, productapp.ImportCatalogCommand{
+			Rows:   rows,
+			DryRun: parser.GetOpt("dry-run") != nil,
+			Actor:  actor,
+		})
+		if err != nil {
+			return err
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err = encoder.Encode(report); err != nil {
+			return err
+		}
+		if report.Failed > 0 {
+			return gerror.Newf("%d of %d rows failed", report.Failed, len(report.Rows))
+		}
+		return nil
+	},
+}
+
+// exportCommand 导出商品
+var exportCommand = &gcmd.Command{
+	Name:  "export",
+	Usage: "catalog export [-f FILE] [--format csv|json] [--locale LOCALE] [--status STATUS]",
+	Brief: "导出商品目录，格式与导入相同",
+	Arguments: []gcmd.Argument{
+		{Name: "file", Short: "f", Brief: "输出文件，为空时输出到标准输出"},
+		{Name: "format", Brief: "文件格式，为空时根据文件扩展名判断，默认 csv"},
+		{Name: "locale", Brief: "CSV 中价格的地区格式，如 zh-CN、en-US、ja-JP、de-DE，为空时使用默认地区"},
+		{Name: "status", Brief: "商品状态，为空时导出除已删除外的全部商品"},
+	},
+	Func: func(ctx context.Context, parser *gcmd.Parser) error {
+		filename := parser.GetOpt("file").String()
+		formatName := parser.GetOpt("format").String()
+		if formatName == "" && filename == "" {
+			formatName = string(productapp.CatalogFormatCSV)
+		}
+		format, err := productapp.ParseCatalogFormat(formatName, filename)
+		if err != nil {
+			return err
+		}
+
+		rows, err := newProductApp(ctx).ExportCatalog(ctx, productapp.ExportCatalogQuery{
+			Status: valueobject.ProductStatus(parser.GetOpt("status").String()),
+		})
+		if err != nil {
+			return err
+		}
+
+		out := os.Stdout
+		if filename != "" {
+			if out, err = os.Create(filename); err != nil {
+				return err
+			}
+			defer out.Close()
+		}
+		return productapp.EncodeCatalog(format, out, rows, sharedvo.Locale(parser.GetOpt("locale").String()))
+	},
+}
+
+// newProductApp 创建商品应用服务，导入导出不涉及图片、库存预占和定时调价
+func newProductApp(ctx context.Context) *productapp.ProductApplicationService {
+	var cfg mongoutil.Config
+	if err := g.Cfg().MustGet(ctx, "mongodb").Scan(&cfg); err != nil {
+		g.Log().Fatalf(ctx, "failed to load mongodb config: %+v", err)
+	}
+	mongoDb, err := mongodb.NewDatabase(ctx, cfg)
+	if err != nil {
+		g.Log().Fatalf(ctx, "failed to connect to mongodb: %+v", err)
+	}
+	productRepo, err := mongodb.NewProductRepository(ctx, mongoDb)
+	if err != nil {
+		g.Log().Fatalf(ctx, "failed to create product repository: %+v", err)
+	}
+	movementRepo, err := mongodb.NewStockMovementRepository(ctx, mongoDb)
+	if err != nil {
+		g.Log().Fatalf(ctx, "failed to create stock movement repository: %+v", err)
+	}
+	priceHistoryRepo, err := mongodb.NewPriceHistoryRepository(ctx, mongoDb)
+	if err != nil {
+		g.Log().Fatalf(ctx, "failed to create price history repository: %+v", err)
+	}
+	categoryRepo, err := mongodb.NewCategoryRepository(ctx, mongoDb)
+	if err != nil {
+		g.Log().Fatalf(ctx, "failed to create category repository: %+v", err)
+	}
+	idGenCfg, err := idgen.LoadConfig(ctx)
+	if err != nil {
+		g.Log().Fatalf(ctx, "failed to load id generator config: %+v", err)
+	}
+	// 命令行工具与服务同时运行，使用单独的 Snowflake 节点号
+	if idGenCfg.Generator == idgen.GeneratorSnowflake && idGenCfg.CatalogNode == idGenCfg.Node {
+		g.Log().Fatalf(ctx, "idGenerator.catalogNode must differ from idGenerator.node: %d", idGenCfg.Node)
+	}
+	idGenCfg.Node = idGenCfg.CatalogNode
+	idGenerator, err := idgen.NewIdGenerator(idGenCfg)
+	if err != nil {
+		g.Log().Fatalf(ctx, "failed to create id generator: %+v", err)
+	}
+	return productapp.NewProductApplicationService(
+		productservice.NewProductService(productRepo, movementRepo, priceHistoryRepo, eventbus.NewSimpleEventBus(), idGenerator),
+		nil,
+		nil,
+		nil,
+		categoryservice.NewCategoryService(categoryRepo, idGenerator),
+	)
+}
@@ -7,6 +7,8 @@ import (
 
 	"main/internal/application/order"
 	"main/internal/application/product"
+	productvo "main/internal/domain/product/valueobject"
+	sharedvo "main/internal/domain/shared/valueobject"
 	"main/internal/infrastructure/persistence/mongodb"
 	"main/utility/mongodb"
 )
@@ -20,13 +22,18 @@ func main() {
 		Database: "ddd_example",
 	}
 
-	// 2. 创建仓储实例
-	productRepo, err := mongodb.NewProductRepository(ctx, mongoConfig)
+	// 2. 连接数据库并创建仓储实例，所有仓储共用同一个客户端
+	mongoDb, err := mongodb.NewDatabase(ctx, mongoConfig)
+	if err != nil {
+		log.Fatalf("Failed to connect to mongodb: %v", err)
+	}
+
+	productRepo, err := mongodb.NewProductRepository(ctx, mongoDb)
 	if err != nil {
 		log.Fatalf("Failed to create product repository: %v", err)
 	}
 
-	orderRepo, err := mongodb.NewOrderRepository(ctx, mongoConfig)
+	orderRepo, err := mongodb.NewOrderRepository(ctx, mongoDb)
 	if err != nil {
 		log.Fatalf("Failed to create order repository: %v", err)
 	}
@@ -39,8 +46,18 @@ func main() {
 	product1, err := productService.CreateProduct(ctx, product.CreateProductCommand{
 		Name:        "iPhone 15",
 		Description: "Latest iPhone model",
-		Price:       7999.00,
-		Stock:       100,
+		SKUs: []product.SKUCommand{
+			{
+				Options: productvo.SKUOptions{{Name: "颜色", Value: "黑色"}, {Name: "容量", Value: "128GB"}},
+				Price:   7999.00,
+				Stock:   60,
+			},
+			{
+				Options: productvo.SKUOptions{{Name: "颜色", Value: "黑色"}, {Name: "容量", Value: "256GB"}},
+				Price:   8999.00,
+				Stock:   40,
+			},
+		},
 	})
 	if err != nil {
 		log.Fatalf("Failed to create product: %v", err)
@@ -50,8 +67,12 @@ func main() {
 	product2, err := productService.CreateProduct(ctx, product.CreateProductCommand{
 		Name:        "AirPods Pro",
 		Description: "Wireless earbuds",
-		Price:       1999.00,
-		Stock:       50,
+		SKUs: []product.SKUCommand{
+			{
+				Price: 1999.00,
+				Stock: 50,
+			},
+		},
 	})
 	if err != nil {
 		log.Fatalf("Failed to create product: %v", err)
@@ -63,11 +84,11 @@ func main() {
 		UserId: "user123",
 		Items: []order.OrderItemCommand{
 			{
-				ProductId: product1.Id,
+				SkuId:    product1.SKUs[0].Id,
 				Quantity: 1,
 			},
 			{
-				ProductId: product2.Id,
+				SkuId:    product2.SKUs[0].Id,
 				Quantity: 2,
 			},
 		},
@@ -98,6 +119,9 @@ func main() {
 	}
 	fmt.Printf("Found %d products\n", len(products))
 	for _, p := range products {
-		fmt.Printf("- %s: ¥%.2f (Stock: %d)\n", p.Name, p.Price.Amount(), p.Stock)
+		fmt.Printf("- %s (Stock: %d)\n", p.Name, p.TotalStock())
+		for _, sku := range p.SKUs {
+			fmt.Printf("  - %s: %s (Stock: %d)\n", sku.Name(), sku.Price.Format(sharedvo.LocaleZhCN), sku.Stock)
+		}
 	}
 }
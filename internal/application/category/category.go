@@ -0,0 +1,196 @@
+package category
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"main/internal/domain/category/entity"
+	"main/internal/domain/category/service"
+	"main/internal/domain/category/valueobject"
+	productentity "main/internal/domain/product/entity"
+	productservice "main/internal/domain/product/service"
+	productvo "main/internal/domain/product/valueobject"
+)
+
+// CategoryApplication 类目应用服务
+// 负责类目树维护以及按类目浏览商品的用例编排
+type CategoryApplication struct {
+	categoryService *service.CategoryService       // 类目领域服务
+	productService  *productservice.ProductService // 商品领域服务
+}
+
+// NewCategoryApplication 创建类目应用服务实例
+func NewCategoryApplication(
+	categoryService *service.CategoryService,
+	productService *productservice.ProductService,
+) *CategoryApplication {
+	return &CategoryApplication{
+		categoryService: categoryService,
+		productService:  productService,
+	}
+}
+
+// CategoryNode 类目树节点
+type CategoryNode struct {
+	*entity.Category
+	Children []*CategoryNode `json:"children"`
+}
+
+// CreateCategoryCommand 创建类目命令
+type CreateCategoryCommand struct {
+	Name      string
+	ParentId  string // 父类目，为空时创建根类目
+	SortOrder int
+}
+
+// CreateCategory 创建类目
+func (s *CategoryApplication) CreateCategory(ctx context.Context, cmd CreateCategoryCommand) (*entity.Category, error) {
+	category, err := s.categoryService.CreateCategory(ctx, cmd.Name, cmd.ParentId, cmd.SortOrder)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to create category")
+	}
+	return category, nil
+}
+
+// RenameCategoryCommand 重命名类目命令
+type RenameCategoryCommand struct {
+	Id   string
+	Name string
+}
+
+// RenameCategory 重命名类目
+func (s *CategoryApplication) RenameCategory(ctx context.Context, cmd RenameCategoryCommand) (*entity.Category, error) {
+	category, err := s.categoryService.RenameCategory(ctx, cmd.Id, cmd.Name)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to rename category")
+	}
+	return category, nil
+}
+
+// MoveCategoryCommand 移动类目命令
+type MoveCategoryCommand struct {
+	Id        string
+	ParentId  string // 新的父类目，为空时移动为根类目
+	SortOrder int    // 在新的同级类目中的排序
+}
+
+// MoveCategory 将类目连同其子树移动到新的父类目下
+func (s *CategoryApplication) MoveCategory(ctx context.Context, cmd MoveCategoryCommand) (*entity.Category, error) {
+	category, err := s.categoryService.MoveCategory(ctx, cmd.Id, cmd.ParentId, cmd.SortOrder)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to move category")
+	}
+	return category, nil
+}
+
+// ReorderCategoriesCommand 重排类目命令
+type ReorderCategoriesCommand struct {
+	ParentId    string   // 父类目，为空时重排根类目
+	CategoryIds []string // 父类目下所有直接子类目的新顺序
+}
+
+// ReorderCategories 重排父类目下的直接子类目
+func (s *CategoryApplication) ReorderCategories(ctx context.Context, cmd ReorderCategoriesCommand) ([]*entity.Category, error) {
+	categories, err := s.categoryService.ReorderCategories(ctx, cmd.ParentId, cmd.CategoryIds)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to reorder categories")
+	}
+	return categories, nil
+}
+
+// DeleteCategoryCommand 删除类目命令
+type DeleteCategoryCommand struct {
+	Id string
+}
+
+// DeleteCategory 删除类目
+// 只能删除没有子类目且没有商品的类目
+func (s *CategoryApplication) DeleteCategory(ctx context.Context, cmd DeleteCategoryCommand) error {
+	// 1. 检查类目下是否还有商品
+	products, err := s.productService.ListProductsByCategories(ctx, []string{cmd.Id})
+	if err != nil {
+		return gerror.Wrap(err, "failed to list category products")
+	}
+	if len(products) > 0 {
+		return gerror.Wrapf(valueobject.ErrCategoryNotEmpty, "category %s has %d products", cmd.Id, len(products))
+	}
+
+	// 2. 删除类目
+	if err = s.categoryService.DeleteCategory(ctx, cmd.Id); err != nil {
+		return gerror.Wrap(err, "failed to delete category")
+	}
+	return nil
+}
+
+// GetCategoryQuery 获取类目查询
+type GetCategoryQuery struct {
+	Id string
+}
+
+// GetCategory 获取类目
+func (s *CategoryApplication) GetCategory(ctx context.Context, query GetCategoryQuery) (*entity.Category, error) {
+	category, err := s.categoryService.GetCategory(ctx, query.Id)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to get category")
+	}
+	return category, nil
+}
+
+// GetCategoryTree 获取完整的类目树，各级类目按同级排序排列
+func (s *CategoryApplication) GetCategoryTree(ctx context.Context) ([]*CategoryNode, error) {
+	categories, err := s.categoryService.ListCategories(ctx)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to list categories")
+	}
+
+	// 类目已按层级排序，父类目的节点总是先于子类目创建
+	var (
+		roots    = make([]*CategoryNode, 0)
+		nodeById = make(map[string]*CategoryNode, len(categories))
+	)
+	for _, category := range categories {
+		node := &CategoryNode{Category: category, Children: make([]*CategoryNode, 0)}
+		nodeById[category.Id] = node
+		if parent, ok := nodeById[category.ParentId]; ok {
+			parent.Children = append(parent.Children, node)
+		} else {
+			roots = append(roots, node)
+		}
+	}
+	return roots, nil
+}
+
+// ListCategoryProductsQuery 类目商品列表查询
+type ListCategoryProductsQuery struct {
+	CategoryId string
+	Status     productvo.ProductStatus // 为空时不按状态过滤
+}
+
+// ListCategoryProducts 获取类目及其所有后代类目下的商品
+func (s *CategoryApplication) ListCategoryProducts(ctx context.Context, query ListCategoryProductsQuery) ([]*productentity.Product, error) {
+	// 1. 获取子树中所有类目
+	categoryIds, err := s.categoryService.SubtreeIds(ctx, query.CategoryId)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to get category subtree")
+	}
+
+	// 2. 获取子树下的商品
+	products, err := s.productService.ListProductsByCategories(ctx, categoryIds)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to list category products")
+	}
+
+	// 如果指定了状态，过滤商品列表
+	if query.Status != "" {
+		filtered := make([]*productentity.Product, 0)
+		for _, p := range products {
+			if p.Status == query.Status {
+				filtered = append(filtered, p)
+			}
+		}
+		return filtered, nil
+	}
+
+	return products, nil
+}
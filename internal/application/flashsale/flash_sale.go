@@ -0,0 +1,130 @@
+package flashsale
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"main/internal/domain/flashsale/entity"
+	"main/internal/domain/flashsale/service"
+	"main/internal/domain/flashsale/valueobject"
+	productservice "main/internal/domain/product/service"
+	sharedvo "main/internal/domain/shared/valueobject"
+)
+
+// FlashSaleApplication 秒杀活动应用服务
+// 负责秒杀活动管理相关的用例编排
+type FlashSaleApplication struct {
+	flashSaleService *service.FlashSaleService      // 秒杀领域服务
+	productService   *productservice.ProductService // 商品领域服务，用于校验活动商品
+}
+
+// NewFlashSaleApplication 创建秒杀活动应用服务实例
+func NewFlashSaleApplication(
+	flashSaleService *service.FlashSaleService,
+	productService *productservice.ProductService,
+) *FlashSaleApplication {
+	return &FlashSaleApplication{
+		flashSaleService: flashSaleService,
+		productService:   productService,
+	}
+}
+
+// FlashSaleItemCommand 秒杀活动商品命令
+type FlashSaleItemCommand struct {
+	SkuId        string
+	Price        float64 // 秒杀价，货币与 SKU 价格一致
+	Stock        int     // 活动库存
+	PerUserLimit int     // 每个用户可购买的数量，0 表示不限购
+}
+
+// CreateFlashSaleCommand 创建秒杀活动命令
+type CreateFlashSaleCommand struct {
+	Name    string
+	StartAt int64
+	EndAt   int64
+	Items   []FlashSaleItemCommand
+}
+
+// CreateFlashSale 创建秒杀活动
+// 活动商品必须是未删除商品的 SKU，秒杀价使用 SKU 价格的货币
+func (s *FlashSaleApplication) CreateFlashSale(ctx context.Context, cmd CreateFlashSaleCommand) (*entity.FlashSale, error) {
+	// 1. 校验活动商品并转换为领域对象
+	items := make([]*entity.FlashSaleItem, 0, len(cmd.Items))
+	for _, item := range cmd.Items {
+		product, err := s.productService.GetProductBySKU(ctx, item.SkuId)
+		if err != nil {
+			return nil, gerror.Wrapf(err, "failed to get product of sku %s", item.SkuId)
+		}
+		if product.IsDeleted() {
+			return nil, gerror.Wrapf(valueobject.ErrInvalidFlashSaleItem, "product %s is deleted", product.Id)
+		}
+		sku, err := product.FindSKU(item.SkuId)
+		if err != nil {
+			return nil, gerror.Wrapf(err, "failed to get sku %s", item.SkuId)
+		}
+		price, err := sharedvo.NewMoney(item.Price, sku.Price.Currency())
+		if err != nil {
+			return nil, gerror.Wrapf(err, "invalid flash sale price of sku %s", item.SkuId)
+		}
+		items = append(items, entity.NewFlashSaleItem(product.Id, sku.Id, price, item.Stock, item.PerUserLimit))
+	}
+
+	// 2. 调用领域服务创建活动
+	flashSale, err := s.flashSaleService.CreateFlashSale(ctx, cmd.Name, cmd.StartAt, cmd.EndAt, items)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to create flash sale")
+	}
+	return flashSale, nil
+}
+
+// CancelFlashSaleCommand 取消秒杀活动命令
+type CancelFlashSaleCommand struct {
+	Id string
+}
+
+// CancelFlashSale 取消秒杀活动
+func (s *FlashSaleApplication) CancelFlashSale(ctx context.Context, cmd CancelFlashSaleCommand) (*entity.FlashSale, error) {
+	flashSale, err := s.flashSaleService.CancelFlashSale(ctx, cmd.Id)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to cancel flash sale")
+	}
+	return flashSale, nil
+}
+
+// GetFlashSaleQuery 获取秒杀活动查询
+type GetFlashSaleQuery struct {
+	Id string
+}
+
+// GetFlashSale 获取秒杀活动
+func (s *FlashSaleApplication) GetFlashSale(ctx context.Context, query GetFlashSaleQuery) (*entity.FlashSale, error) {
+	flashSale, err := s.flashSaleService.GetFlashSale(ctx, query.Id)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to get flash sale")
+	}
+	return flashSale, nil
+}
+
+// ListFlashSales 获取秒杀活动列表
+func (s *FlashSaleApplication) ListFlashSales(ctx context.Context) ([]*entity.FlashSale, error) {
+	flashSales, err := s.flashSaleService.ListFlashSales(ctx)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to list flash sales")
+	}
+	return flashSales, nil
+}
+
+// ListPurchasesQuery 获取秒杀活动购买记录查询
+type ListPurchasesQuery struct {
+	FlashSaleId string
+}
+
+// ListPurchases 获取秒杀活动的购买记录
+func (s *FlashSaleApplication) ListPurchases(ctx context.Context, query ListPurchasesQuery) ([]*entity.Purchase, error) {
+	purchases, err := s.flashSaleService.ListPurchases(ctx, query.FlashSaleId)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to list flash sale purchases")
+	}
+	return purchases, nil
+}
@@ -0,0 +1,227 @@
+package inventory
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"main/internal/domain/inventory/entity"
+	"main/internal/domain/inventory/service"
+	"main/internal/domain/inventory/valueobject"
+	productservice "main/internal/domain/product/service"
+	productvo "main/internal/domain/product/valueobject"
+)
+
+// InventoryApplication 库存应用服务
+// 负责仓库维护、分仓库存调整与调拨，以及商品在各仓库的可售数量查询
+type InventoryApplication struct {
+	warehouseService *service.WarehouseService      // 仓库领域服务
+	inventoryService *service.InventoryService      // 库存领域服务
+	productService   *productservice.ProductService // 商品领域服务
+}
+
+// NewInventoryApplication 创建库存应用服务实例
+func NewInventoryApplication(
+	warehouseService *service.WarehouseService,
+	inventoryService *service.InventoryService,
+	productService *productservice.ProductService,
+) *InventoryApplication {
+	return &InventoryApplication{
+		warehouseService: warehouseService,
+		inventoryService: inventoryService,
+		productService:   productService,
+	}
+}
+
+// CreateWarehouseCommand 创建仓库命令
+type CreateWarehouseCommand struct {
+	Code     string
+	Name     string
+	Location valueobject.Location
+	Priority int
+}
+
+// CreateWarehouse 创建仓库
+func (s *InventoryApplication) CreateWarehouse(ctx context.Context, cmd CreateWarehouseCommand) (*entity.Warehouse, error) {
+	warehouse, err := s.warehouseService.CreateWarehouse(ctx, cmd.Code, cmd.Name, cmd.Location, cmd.Priority)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to create warehouse")
+	}
+	return warehouse, nil
+}
+
+// UpdateWarehouseCommand 更新仓库命令
+type UpdateWarehouseCommand struct {
+	Id       string
+	Name     string
+	Location valueobject.Location
+	Priority int
+	Active   bool
+}
+
+// UpdateWarehouse 更新仓库
+func (s *InventoryApplication) UpdateWarehouse(ctx context.Context, cmd UpdateWarehouseCommand) (*entity.Warehouse, error) {
+	warehouse, err := s.warehouseService.UpdateWarehouse(ctx, cmd.Id, cmd.Name, cmd.Location, cmd.Priority, cmd.Active)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to update warehouse")
+	}
+	return warehouse, nil
+}
+
+// ListWarehouses 获取所有仓库
+func (s *InventoryApplication) ListWarehouses(ctx context.Context) ([]*entity.Warehouse, error) {
+	warehouses, err := s.warehouseService.ListWarehouses(ctx)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to list warehouses")
+	}
+	return warehouses, nil
+}
+
+// AdjustInventoryCommand 调整库存命令
+type AdjustInventoryCommand struct {
+	ProductId   string
+	WarehouseId string
+	SkuId       string
+	Delta       int                      // 在库数量的增量，入库为正，出库或损耗为负
+	Reason      productvo.MovementReason // 变动原因，仅限入库、人工调整和退货
+	Actor       string
+	Reference   string // 关联单据，如入库单号、退货单号
+	Note        string
+}
+
+// AdjustInventory 调整 SKU 在仓库中的在库数量，并同步商品的汇总库存
+// 商品汇总库存调整失败时撤销仓库库存的调整
+func (s *InventoryApplication) AdjustInventory(ctx context.Context, cmd AdjustInventoryCommand) (*entity.Inventory, error) {
+	// 1. 确认 SKU 属于该商品
+	product, err := s.productService.GetProduct(ctx, cmd.ProductId)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to get product")
+	}
+	if _, err = product.FindSKU(cmd.SkuId); err != nil {
+		return nil, gerror.Wrap(err, "failed to get sku")
+	}
+
+	// 2. 检查变动原因，避免仓库库存调整后商品汇总库存因原因无效而无法同步
+	if !cmd.Reason.IsManual() {
+		return nil, gerror.Wrapf(productvo.ErrInvalidMovement, "stock cannot be adjusted for reason %s", cmd.Reason)
+	}
+
+	// 3. 调整仓库库存
+	inventory, err := s.inventoryService.Adjust(ctx, cmd.ProductId, cmd.WarehouseId, cmd.SkuId, cmd.Delta)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to adjust inventory")
+	}
+
+	// 4. 同步商品的汇总库存并记录库存流水
+	item := productvo.StockItem{SkuId: cmd.SkuId, WarehouseId: cmd.WarehouseId, Quantity: cmd.Delta}
+	source := productvo.MovementSource{Actor: cmd.Actor, Reference: cmd.Reference, Note: cmd.Note}
+	if _, err = s.productService.AdjustStock(ctx, item, cmd.Reason, source); err != nil {
+		if _, rollbackErr := s.inventoryService.Adjust(ctx, cmd.ProductId, cmd.WarehouseId, cmd.SkuId, -cmd.Delta); rollbackErr != nil {
+			return nil, gerror.Wrapf(err, "failed to adjust product stock, and failed to roll back inventory: %v", rollbackErr)
+		}
+		return nil, gerror.Wrap(err, "failed to adjust product stock")
+	}
+	return inventory, nil
+}
+
+// TransferInventoryCommand 调拨库存命令
+type TransferInventoryCommand struct {
+	ProductId       string
+	SkuId           string
+	FromWarehouseId string
+	ToWarehouseId   string
+	Quantity        int
+	Actor           string
+	Reference       string // 关联单据，如调拨单号
+}
+
+// TransferInventory 在仓库之间调拨 SKU 的可售库存，商品的汇总库存不变，调出和调入记录为调拨流水
+func (s *InventoryApplication) TransferInventory(
+	ctx context.Context,
+	cmd TransferInventoryCommand,
+) ([]*entity.Inventory, error) {
+	from, to, err := s.inventoryService.Transfer(
+		ctx,
+		cmd.ProductId,
+		cmd.SkuId,
+		cmd.FromWarehouseId,
+		cmd.ToWarehouseId,
+		cmd.Quantity,
+	)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to transfer inventory")
+	}
+	err = s.productService.RecordTransfer(
+		ctx,
+		cmd.ProductId,
+		cmd.SkuId,
+		cmd.FromWarehouseId,
+		cmd.ToWarehouseId,
+		cmd.Quantity,
+		productvo.MovementSource{Actor: cmd.Actor, Reference: cmd.Reference},
+	)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to record inventory transfer")
+	}
+	return []*entity.Inventory{from, to}, nil
+}
+
+// WarehouseStockView SKU 在单个仓库的库存视图
+type WarehouseStockView struct {
+	WarehouseId string `json:"warehouseId"`
+	OnHand      int    `json:"onHand"`
+	Reserved    int    `json:"reserved"`
+	Available   int    `json:"available"`
+}
+
+// SKUAvailabilityView SKU 汇总可售数量视图
+type SKUAvailabilityView struct {
+	SkuId      string               `json:"skuId"`
+	OnHand     int                  `json:"onHand"`    // 所有仓库的在库数量
+	Reserved   int                  `json:"reserved"`  // 所有仓库的预占数量
+	Available  int                  `json:"available"` // 所有仓库的可售数量
+	Warehouses []WarehouseStockView `json:"warehouses"`
+}
+
+// ProductAvailabilityView 商品分仓可售数量视图
+type ProductAvailabilityView struct {
+	ProductId string                `json:"productId"`
+	SKUs      []SKUAvailabilityView `json:"skus"`
+}
+
+// GetProductAvailability 获取商品各 SKU 在所有仓库的汇总可售数量及分仓明细
+func (s *InventoryApplication) GetProductAvailability(
+	ctx context.Context,
+	productId string,
+) (*ProductAvailabilityView, error) {
+	inventories, err := s.inventoryService.GetProductInventory(ctx, productId)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to get product inventory")
+	}
+
+	totals := entity.Aggregate(inventories)
+	view := &ProductAvailabilityView{
+		ProductId: productId,
+		SKUs:      make([]SKUAvailabilityView, len(totals)),
+	}
+	for i, total := range totals {
+		sku := SKUAvailabilityView{
+			SkuId:     total.SkuId,
+			OnHand:    total.OnHand,
+			Reserved:  total.Reserved,
+			Available: total.Available(),
+		}
+		for _, inv := range inventories {
+			if item := inv.Item(total.SkuId); item != nil {
+				sku.Warehouses = append(sku.Warehouses, WarehouseStockView{
+					WarehouseId: inv.WarehouseId,
+					OnHand:      item.OnHand,
+					Reserved:    item.Reserved,
+					Available:   item.Available(),
+				})
+			}
+		}
+		view.SKUs[i] = sku
+	}
+	return view, nil
+}
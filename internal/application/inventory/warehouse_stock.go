@@ -0,0 +1,34 @@
+package inventory
+
+import (
+	"context"
+
+	"main/internal/domain/inventory/service"
+	productservice "main/internal/domain/product/service"
+	productvo "main/internal/domain/product/valueobject"
+)
+
+// warehouseStock 以库存领域服务实现商品上下文的分仓库存端口
+type warehouseStock struct {
+	inventoryService *service.InventoryService
+}
+
+// NewWarehouseStock 创建分仓库存端口的实现，供库存预占服务同步变更仓库库存
+func NewWarehouseStock(inventoryService *service.InventoryService) productservice.WarehouseStock {
+	return &warehouseStock{inventoryService: inventoryService}
+}
+
+// Reserve 在仓库中预占 SKU 库存
+func (w *warehouseStock) Reserve(ctx context.Context, productId string, item productvo.StockItem) error {
+	return w.inventoryService.Reserve(ctx, productId, item.WarehouseId, item.SkuId, item.Quantity)
+}
+
+// Release 释放仓库中预占的 SKU 库存
+func (w *warehouseStock) Release(ctx context.Context, productId string, item productvo.StockItem) error {
+	return w.inventoryService.Release(ctx, productId, item.WarehouseId, item.SkuId, item.Quantity)
+}
+
+// Commit 确认仓库中预占的 SKU 库存
+func (w *warehouseStock) Commit(ctx context.Context, productId string, item productvo.StockItem) error {
+	return w.inventoryService.Commit(ctx, productId, item.WarehouseId, item.SkuId, item.Quantity)
+}
@@ -2,45 +2,77 @@ package order
 
 import (
 	"context"
+	"time"
 
 	"github.com/gogf/gf/v2/errors/gerror"
 
+	flashsaleservice "main/internal/domain/flashsale/service"
+	flashsalevo "main/internal/domain/flashsale/valueobject"
+	inventoryservice "main/internal/domain/inventory/service"
+	inventoryvo "main/internal/domain/inventory/valueobject"
 	"main/internal/domain/order/entity"
 	orderservice "main/internal/domain/order/service"
 	"main/internal/domain/order/valueobject"
+	pricingservice "main/internal/domain/pricing/service"
+	productentity "main/internal/domain/product/entity"
 	productservice "main/internal/domain/product/service"
+	productvo "main/internal/domain/product/valueobject"
+	sharedservice "main/internal/domain/shared/service"
 	sharedvo "main/internal/domain/shared/valueobject"
 )
 
 // OrderApplication 订单应用服务
 // 应用服务负责用例编排和协调不同的领域服务
 type OrderApplication struct {
-	orderService   *orderservice.OrderService     // 订单领域服务
-	productService *productservice.ProductService // 商品领域服务
+	orderService       *orderservice.OrderService           // 订单领域服务
+	productService     *productservice.ProductService       // 商品领域服务
+	reservationService *productservice.ReservationService   // 库存预占领域服务
+	scheduleService    *productservice.PriceScheduleService // 定时调价服务，解析下单时生效的 SKU 价格
+	flashSaleService   *flashsaleservice.FlashSaleService   // 秒杀领域服务，负责秒杀价、活动库存和限购
+	inventoryService   *inventoryservice.InventoryService   // 库存领域服务，负责分配发货仓库
+	pricingService     *pricingservice.PricingService       // 定价领域服务
+	currencyConverter  *sharedservice.CurrencyConverter     // 货币换算领域服务
 }
 
 // NewOrderApplication 创建订单应用服务实例
 func NewOrderApplication(
 	orderService *orderservice.OrderService,
 	productService *productservice.ProductService,
+	reservationService *productservice.ReservationService,
+	scheduleService *productservice.PriceScheduleService,
+	flashSaleService *flashsaleservice.FlashSaleService,
+	inventoryService *inventoryservice.InventoryService,
+	pricingService *pricingservice.PricingService,
+	currencyConverter *sharedservice.CurrencyConverter,
 ) *OrderApplication {
 	return &OrderApplication{
-		orderService:   orderService,
-		productService: productService,
+		orderService:       orderService,
+		productService:     productService,
+		reservationService: reservationService,
+		scheduleService:    scheduleService,
+		flashSaleService:   flashSaleService,
+		inventoryService:   inventoryService,
+		pricingService:     pricingService,
+		currencyConverter:  currencyConverter,
 	}
 }
 
 // CreateOrderCommand 创建订单命令
 type CreateOrderCommand struct {
-	UserId string
-	Items  []OrderItemCommand
-	Remark string
+	UserId        string
+	CustomerGroup string // 客户分组，用于匹配协议价目表，为空时只适用通用价目表
+	Items         []OrderItemCommand
+	Remark        string
+	// Strategy 发货仓库的分配策略，为空时使用默认策略
+	Strategy inventoryvo.AllocationStrategy
+	// Destination 收货地址的位置，用于按最近仓库分配，为空时按仓库优先级分配
+	Destination *inventoryvo.Location
 }
 
 // OrderItemCommand 订单项命令
 type OrderItemCommand struct {
-	ProductId string
-	Quantity  int
+	SkuId    string // 购买的 SKU，商品由 SKU 确定
+	Quantity int
 }
 
 // CreateOrder 创建订单
@@ -49,49 +81,129 @@ type OrderItemCommand struct {
 // 2. 协调不同领域服务
 // 3. 事务处理
 func (s *OrderApplication) CreateOrder(ctx context.Context, cmd CreateOrderCommand) (*entity.Order, error) {
-	// 1. 验证商品信息并检查库存
+	// 1. 验证商品信息并解析价格
+	now := time.Now().UnixMilli()
 	orderItems := make([]*entity.OrderItem, 0, len(cmd.Items))
-	for _, item := range cmd.Items {
-		// 获取商品信息
-		product, err := s.productService.GetProduct(ctx, item.ProductId)
+	requests := make([]inventoryvo.AllocationRequest, 0, len(cmd.Items))
+	purchases := make([]flashsalevo.PurchaseLine, 0)
+	for _, item := range mergeItemCommands(cmd.Items) {
+		// 获取商品和 SKU 信息
+		product, err := s.productService.GetProductBySKU(ctx, item.SkuId)
 		if err != nil {
 			return nil, gerror.Wrap(err, "failed to get product")
 		}
+		sku, err := product.FindSKU(item.SkuId)
+		if err != nil {
+			return nil, gerror.Wrap(err, "failed to get sku")
+		}
+
+		// 进行中的秒杀活动以秒杀价购买，不再匹配价目表
+		unitPrice, purchase, err := s.resolveFlashSalePrice(ctx, product.Id, sku.Id, item.Quantity, now)
+		if err != nil {
+			return nil, err
+		}
+		if purchase != nil {
+			purchases = append(purchases, *purchase)
+		} else {
+			// 解析下单时生效的 SKU 价格，定时调价到期而调度器尚未执行时以定时调价为准
+			basePrice, err := s.scheduleService.EffectivePrice(ctx, sku, now)
+			if err != nil {
+				return nil, gerror.Wrap(err, "failed to resolve effective sku price")
+			}
 
-		// 检查库存
-		if !s.productService.HasSufficientStock(ctx, item.ProductId, item.Quantity) {
-			return nil, gerror.Newf(
-				"insufficient stock for product %s",
-				item.ProductId,
+			// 解析客户分组和购买数量对应的单价，价目表按商品定价，未命中时使用 SKU 生效价格
+			price, err := s.pricingService.ResolvePrice(
+				ctx,
+				product.Id,
+				basePrice,
+				cmd.CustomerGroup,
+				item.Quantity,
+				now,
 			)
+			if err != nil {
+				return nil, gerror.Wrap(err, "failed to resolve product price")
+			}
+			unitPrice = price.UnitPrice
 		}
 
 		// 创建订单项
 		orderItem := entity.NewOrderItem(
 			product.Id,
 			product.Name,
+			sku.Id,
+			sku.Name(),
 			item.Quantity,
-			product.Price.Amount(),
+			unitPrice,
 		)
+		if !product.IsBundle() {
+			orderItems = append(orderItems, orderItem)
+			requests = appendAllocationRequest(requests, inventoryvo.AllocationRequest{
+				ProductId: product.Id,
+				SkuId:     sku.Id,
+				Quantity:  item.Quantity,
+			})
+			continue
+		}
+
+		// 套装不持有库存，预占各组件的库存，并记录套装价格在组件间的分摊
+		lines, err := s.bundleLines(ctx, product, orderItem)
+		if err != nil {
+			return nil, err
+		}
 		orderItems = append(orderItems, orderItem)
+		for _, line := range lines {
+			requests = appendAllocationRequest(requests, inventoryvo.AllocationRequest{
+				ProductId: line.Product.Id,
+				SkuId:     line.SKU.Id,
+				Quantity:  line.Quantity * item.Quantity,
+			})
+		}
+	}
+
+	// 2. 按分配策略选择发货仓库，一个订单项可能由多个仓库发出
+	allocations, err := s.inventoryService.Allocate(ctx, cmd.Strategy, cmd.Destination, requests)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to allocate warehouses")
+	}
+	stockItems := make(productvo.StockItems, len(allocations))
+	for i, allocation := range allocations {
+		stockItems[i] = productvo.StockItem{
+			SkuId:       allocation.SkuId,
+			WarehouseId: allocation.WarehouseId,
+			Quantity:    allocation.Quantity,
+		}
 	}
 
-	// 2. 创建订单（使用订单领域服务）
+	// 3. 创建订单（使用订单领域服务）
 	order, err := s.orderService.CreateOrder(ctx, cmd.UserId, orderItems)
 	if err != nil {
 		return nil, gerror.Wrap(err, "failed to create order")
 	}
 
-	// 3. 预扣库存
-	for _, item := range cmd.Items {
-		if err = s.productService.ReserveStock(ctx, item.ProductId, item.Quantity); err != nil {
-			// 如果预扣库存失败，应该回滚订单创建
-			// 这里可以通过发布事件来处理，或者使用分布式事务
-			return nil, gerror.Wrap(err, "failed to reserve stock")
+	// 4. 原子地占用秒杀活动库存和用户的限购名额，活动库存不足或超出限购时全部不占用并取消订单
+	if len(purchases) > 0 {
+		if _, err = s.flashSaleService.Hold(ctx, order.Id, cmd.UserId, purchases); err != nil {
+			if cancelErr := s.orderService.CancelOrder(ctx, order.Id); cancelErr != nil {
+				return nil, gerror.Wrapf(err, "failed to hold flash sale stock, and failed to cancel order: %v", cancelErr)
+			}
+			return nil, gerror.Wrap(err, "failed to hold flash sale stock")
+		}
+	}
+
+	// 5. 为订单原子地预占所有 SKU 及其发货仓库的库存，库存不足时全部不预占，归还秒杀活动库存并取消订单
+	if _, err = s.reservationService.Hold(ctx, order.Id, stockItems); err != nil {
+		if len(purchases) > 0 {
+			if releaseErr := s.flashSaleService.Release(ctx, order.Id); releaseErr != nil {
+				return nil, gerror.Wrapf(err, "failed to reserve stock, and failed to release flash sale stock: %v", releaseErr)
+			}
 		}
+		if cancelErr := s.orderService.CancelOrder(ctx, order.Id); cancelErr != nil {
+			return nil, gerror.Wrapf(err, "failed to reserve stock, and failed to cancel order: %v", cancelErr)
+		}
+		return nil, gerror.Wrap(err, "failed to reserve stock")
 	}
 
-	// 4. 更新订单备注
+	// 6. 更新订单备注
 	if cmd.Remark != "" {
 		order.UpdateRemark(cmd.Remark)
 		if err = s.orderService.UpdateOrder(ctx, order); err != nil {
@@ -102,10 +214,135 @@ func (s *OrderApplication) CreateOrder(ctx context.Context, cmd CreateOrderComma
 	return order, nil
 }
 
+// mergeItemCommands 合并相同 SKU 的订单项命令
+// 阶梯价按购买数量解析，同一 SKU 须按合并后的数量解析单价
+func mergeItemCommands(items []OrderItemCommand) []OrderItemCommand {
+	merged := make([]OrderItemCommand, 0, len(items))
+	for _, item := range items {
+		found := false
+		for i := range merged {
+			if merged[i].SkuId == item.SkuId {
+				merged[i].Quantity += item.Quantity
+				found = true
+				break
+			}
+		}
+		if !found {
+			merged = append(merged, item)
+		}
+	}
+	return merged
+}
+
+// bundleLines 解析套装订单项的组件，将订单项单价按组件原价的比例分摊到各组件并记录在订单项上
+func (s *OrderApplication) bundleLines(
+	ctx context.Context,
+	bundle *productentity.Product,
+	orderItem *entity.OrderItem,
+) (productservice.BundleLines, error) {
+	if bundle.Status != productvo.ProductStatusOnSale {
+		return nil, gerror.Wrapf(productvo.ErrProductUnavailable, "bundle %s is not on sale", bundle.Id)
+	}
+	lines, err := s.productService.ResolveBundle(ctx, bundle)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to resolve bundle components")
+	}
+	prices, err := lines.Apportion(orderItem.Price)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to apportion bundle price")
+	}
+	components := make([]*entity.OrderItemComponent, len(lines))
+	for i, line := range lines {
+		components[i] = entity.NewOrderItemComponent(
+			line.Product.Id,
+			line.Product.Name,
+			line.SKU.Id,
+			line.SKU.Name(),
+			line.Quantity,
+			prices[i],
+		)
+	}
+	if err = orderItem.SetComponents(components); err != nil {
+		return nil, gerror.Wrap(err, "failed to set bundle components")
+	}
+	return lines, nil
+}
+
+// appendAllocationRequest 添加仓库分配请求，同一 SKU 的数量合并到已有的请求中
+// 套装组件与单独购买的 SKU 或其他套装的组件可能相同
+func appendAllocationRequest(
+	requests []inventoryvo.AllocationRequest,
+	request inventoryvo.AllocationRequest,
+) []inventoryvo.AllocationRequest {
+	for i := range requests {
+		if requests[i].SkuId == request.SkuId {
+			requests[i].Quantity += request.Quantity
+			return requests
+		}
+	}
+	return append(requests, request)
+}
+
+// resolveFlashSalePrice 解析 SKU 在进行中的秒杀活动中的秒杀价及需要占用的活动库存
+// 没有进行中的秒杀活动时返回 nil
+func (s *OrderApplication) resolveFlashSalePrice(
+	ctx context.Context,
+	productId string,
+	skuId string,
+	quantity int,
+	at int64,
+) (*sharedvo.Money, *flashsalevo.PurchaseLine, error) {
+	flashSale, item, err := s.flashSaleService.FindEffective(ctx, skuId, at)
+	if err != nil {
+		return nil, nil, gerror.Wrap(err, "failed to resolve flash sale price")
+	}
+	if item == nil {
+		return nil, nil, nil
+	}
+	return item.Price, &flashsalevo.PurchaseLine{
+		FlashSaleId: flashSale.Id,
+		ProductId:   productId,
+		SkuId:       skuId,
+		Quantity:    quantity,
+	}, nil
+}
+
+// ApplyDiscountCommand 应用订单优惠命令
+type ApplyDiscountCommand struct {
+	OrderId string
+	Amount  float64 // 订单级优惠金额，将按比例分摊到各订单项
+}
+
+// ApplyDiscount 应用订单级优惠
+func (s *OrderApplication) ApplyDiscount(ctx context.Context, cmd ApplyDiscountCommand) (*entity.Order, error) {
+	// 1. 获取订单信息
+	order, err := s.orderService.GetOrder(ctx, cmd.OrderId)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to get order")
+	}
+
+	// 2. 应用优惠（由订单聚合负责分摊到订单项）
+	discount, err := sharedvo.NewMoney(cmd.Amount, order.TotalAmount.Currency())
+	if err != nil {
+		return nil, gerror.Wrap(err, "invalid discount amount")
+	}
+	if err = order.ApplyDiscount(discount); err != nil {
+		return nil, gerror.Wrap(err, "failed to apply discount")
+	}
+
+	// 3. 保存订单
+	if err = s.orderService.UpdateOrder(ctx, order); err != nil {
+		return nil, gerror.Wrap(err, "failed to update order")
+	}
+
+	return order, nil
+}
+
 // PayOrderCommand 支付订单命令
 type PayOrderCommand struct {
 	OrderId        string
 	Amount         float64
+	Currency       string // 支付货币，为空时使用订单货币
 	PaymentMethod  valueobject.PaymentMethod
 	PaymentChannel valueobject.PaymentChannel
 	TradeNo        string
@@ -113,20 +350,60 @@ type PayOrderCommand struct {
 
 // PayOrder 支付订单
 func (s *OrderApplication) PayOrder(ctx context.Context, cmd PayOrderCommand) error {
-	// 1. 创建支付信息值对象
+	// 1. 以订单货币创建支付信息值对象
+	order, err := s.orderService.GetOrder(ctx, cmd.OrderId)
+	if err != nil {
+		return gerror.Wrap(err, "failed to get order")
+	}
+	currency := cmd.Currency
+	if currency == "" {
+		currency = order.Currency()
+	}
+	amount, err := sharedvo.NewMoney(cmd.Amount, currency)
+	if err != nil {
+		return gerror.Wrap(err, "invalid payment amount")
+	}
 	paymentInfo := valueobject.NewPaymentInfo(
-		sharedvo.NewMoney(cmd.Amount, "CNY"),
+		amount,
 		cmd.PaymentMethod,
 		cmd.PaymentChannel,
 		cmd.TradeNo,
 		nil,
 	)
 
-	// 2. 调用领域服务处理支付
-	if err := s.orderService.PayOrder(ctx, cmd.OrderId, paymentInfo); err != nil {
+	// 2. 支付前检查订单状态和金额
+	if err = order.CheckPayment(paymentInfo); err != nil {
+		return gerror.Wrap(err, "invalid payment")
+	}
+
+	// 3. 以条件更新确认秒杀购买记录和库存预占，确认后不再因过期被释放，已过期时不能支付
+	if err = s.flashSaleService.Commit(ctx, cmd.OrderId); err != nil {
+		return gerror.Wrap(err, "failed to commit flash sale purchase")
+	}
+	if err = s.reservationService.Commit(ctx, cmd.OrderId); err != nil {
+		if revertErr := s.flashSaleService.Revert(ctx, cmd.OrderId); revertErr != nil {
+			return gerror.Wrapf(err, "failed to commit stock reservation, and failed to revert flash sale purchase: %v", revertErr)
+		}
+		return gerror.Wrap(err, "failed to commit stock reservation")
+	}
+
+	// 4. 调用领域服务处理支付，支付失败时撤销两者的确认，到期后自动释放
+	if err = s.orderService.PayOrder(ctx, cmd.OrderId, paymentInfo); err != nil {
+		reservationErr := s.reservationService.Revert(ctx, cmd.OrderId)
+		purchaseErr := s.flashSaleService.Revert(ctx, cmd.OrderId)
+		if reservationErr != nil || purchaseErr != nil {
+			return gerror.Wrapf(err, "failed to pay order, and failed to revert stock reservation: %v, flash sale purchase: %v",
+				reservationErr, purchaseErr,
+			)
+		}
 		return gerror.Wrap(err, "failed to pay order")
 	}
 
+	// 5. 订单支付成功后结算库存预占
+	if err = s.reservationService.Settle(ctx, cmd.OrderId); err != nil {
+		return gerror.Wrap(err, "order paid but failed to settle stock reservation")
+	}
+
 	return nil
 }
 
@@ -148,17 +425,62 @@ func (s *OrderApplication) CancelOrder(ctx context.Context, cmd CancelOrderComma
 		return gerror.Wrap(err, "failed to cancel order")
 	}
 
-	// 3. 释放库存
-	for _, item := range order.Items {
-		if err := s.productService.ReleaseStock(ctx, item.ProductId, item.Quantity); err != nil {
-			// 如果释放库存失败，应该通过事件或其他方式来处理不一致
-			return gerror.Wrap(err, "failed to release stock")
-		}
+	// 3. 释放订单预占的库存
+	if err := s.reservationService.Release(ctx, order.Id); err != nil {
+		// 如果释放库存失败，应该通过事件或其他方式来处理不一致
+		return gerror.Wrap(err, "failed to release stock")
+	}
+
+	// 4. 归还订单占用的秒杀活动库存和限购名额
+	if err := s.flashSaleService.Release(ctx, order.Id); err != nil {
+		return gerror.Wrap(err, "failed to release flash sale stock")
 	}
 
 	return nil
 }
 
+// AllocateRefundQuery 部分退款分摊查询
+type AllocateRefundQuery struct {
+	OrderId string
+	Amount  float64 // 退款金额，以订单货币表示
+}
+
+// ItemRefund 订单项分摊到的退款金额
+type ItemRefund struct {
+	OrderItemId string
+	SkuId       string
+	Amount      *sharedvo.Money
+}
+
+// AllocateRefund 将部分退款金额按实付金额比例分摊到订单项
+func (s *OrderApplication) AllocateRefund(ctx context.Context, query AllocateRefundQuery) ([]*ItemRefund, error) {
+	// 1. 获取订单信息
+	order, err := s.orderService.GetOrder(ctx, query.OrderId)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to get order")
+	}
+
+	// 2. 由订单聚合分摊退款金额
+	amount, err := sharedvo.NewMoney(query.Amount, order.Currency())
+	if err != nil {
+		return nil, gerror.Wrap(err, "invalid refund amount")
+	}
+	amounts, err := order.AllocateRefund(amount)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to allocate refund")
+	}
+
+	refunds := make([]*ItemRefund, len(order.Items))
+	for i, item := range order.Items {
+		refunds[i] = &ItemRefund{
+			OrderItemId: item.Id,
+			SkuId:       item.SkuId,
+			Amount:      amounts[i],
+		}
+	}
+	return refunds, nil
+}
+
 // GetOrderQuery 获取订单查询
 type GetOrderQuery struct {
 	OrderId string
@@ -187,3 +509,49 @@ func (s *OrderApplication) ListOrdersByUser(ctx context.Context, query ListOrder
 	}
 	return orders, nil
 }
+
+// GetUserOrderTotalQuery 用户订单金额汇总查询
+type GetUserOrderTotalQuery struct {
+	UserId   string
+	Status   valueobject.OrderStatus
+	Currency string // 汇总使用的货币，为空时使用默认货币
+}
+
+// UserOrderTotal 用户订单金额汇总报表
+type UserOrderTotal struct {
+	UserId      string
+	OrderCount  int
+	Total       *sharedvo.Money             // 换算为汇总货币后的总金额
+	Conversions []*sharedservice.Conversion // 每笔订单金额的换算记录，包含所用汇率及其生效时间
+}
+
+// GetUserOrderTotal 汇总用户订单金额
+// 不同货币的订单金额按当前汇率换算为同一货币后再汇总
+func (s *OrderApplication) GetUserOrderTotal(ctx context.Context, query GetUserOrderTotalQuery) (*UserOrderTotal, error) {
+	// 1. 获取订单列表
+	orders, err := s.orderService.ListOrdersByUser(ctx, query.UserId, query.Status)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to list orders")
+	}
+
+	// 2. 换算并汇总金额
+	currency := query.Currency
+	if currency == "" {
+		currency = sharedvo.DefaultCurrency
+	}
+	amounts := make([]*sharedvo.Money, 0, len(orders))
+	for _, order := range orders {
+		amounts = append(amounts, order.TotalAmount)
+	}
+	total, conversions, err := s.currencyConverter.Sum(ctx, currency, amounts)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to sum order amounts")
+	}
+
+	return &UserOrderTotal{
+		UserId:      query.UserId,
+		OrderCount:  len(orders),
+		Total:       total,
+		Conversions: conversions,
+	}, nil
+}
@@ -0,0 +1,23 @@
+package order
+
+import (
+	"context"
+
+	"main/internal/domain/order/repository"
+	productservice "main/internal/domain/product/service"
+)
+
+// orderReferences 以订单仓储实现商品上下文的订单引用端口
+type orderReferences struct {
+	orderRepo repository.OrderRepository
+}
+
+// NewOrderReferences 创建订单引用端口的实现，供商品清除服务检查商品是否仍被未完结的订单引用
+func NewOrderReferences(orderRepo repository.OrderRepository) productservice.OrderReferences {
+	return &orderReferences{orderRepo: orderRepo}
+}
+
+// HasOpenOrders 检查是否存在引用该商品且尚未送达或取消的订单
+func (r *orderReferences) HasOpenOrders(ctx context.Context, productId string) (bool, error) {
+	return r.orderRepo.ExistsOpenByProductId(ctx, productId)
+}
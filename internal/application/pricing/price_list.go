@@ -0,0 +1,176 @@
+package pricing
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"main/internal/domain/pricing/entity"
+	"main/internal/domain/pricing/service"
+	"main/internal/domain/pricing/valueobject"
+	sharedvo "main/internal/domain/shared/valueobject"
+)
+
+// PriceListApplication 价目表应用服务
+// 负责价目表管理相关的用例编排
+type PriceListApplication struct {
+	pricingService *service.PricingService // 定价领域服务
+}
+
+// NewPriceListApplication 创建价目表应用服务实例
+func NewPriceListApplication(pricingService *service.PricingService) *PriceListApplication {
+	return &PriceListApplication{
+		pricingService: pricingService,
+	}
+}
+
+// CreatePriceListCommand 创建价目表命令
+type CreatePriceListCommand struct {
+	Name          string
+	CustomerGroup string
+	Currency      string
+	Priority      int
+	ValidFrom     int64
+	ValidTo       int64
+}
+
+// CreatePriceList 创建价目表
+func (s *PriceListApplication) CreatePriceList(ctx context.Context, cmd CreatePriceListCommand) (*entity.PriceList, error) {
+	priceList, err := s.pricingService.CreatePriceList(
+		ctx,
+		cmd.Name,
+		cmd.CustomerGroup,
+		cmd.Currency,
+		cmd.Priority,
+		cmd.ValidFrom,
+		cmd.ValidTo,
+	)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to create price list")
+	}
+	return priceList, nil
+}
+
+// UpdatePriceListCommand 更新价目表命令
+type UpdatePriceListCommand struct {
+	Id            string
+	Name          string
+	CustomerGroup string
+	Priority      int
+	ValidFrom     int64
+	ValidTo       int64
+	Status        valueobject.PriceListStatus
+}
+
+// UpdatePriceList 更新价目表
+func (s *PriceListApplication) UpdatePriceList(ctx context.Context, cmd UpdatePriceListCommand) (*entity.PriceList, error) {
+	priceList, err := s.pricingService.UpdatePriceList(
+		ctx,
+		cmd.Id,
+		cmd.Name,
+		cmd.CustomerGroup,
+		cmd.Priority,
+		cmd.ValidFrom,
+		cmd.ValidTo,
+		cmd.Status,
+	)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to update price list")
+	}
+	return priceList, nil
+}
+
+// PriceTierCommand 阶梯价格命令
+type PriceTierCommand struct {
+	MinQuantity int
+	Price       float64
+}
+
+// SetPriceListEntryCommand 设置价目表商品价格命令
+type SetPriceListEntryCommand struct {
+	PriceListId string
+	ProductId   string
+	Tiers       []PriceTierCommand
+}
+
+// SetPriceListEntry 设置价目表中商品的阶梯价格
+func (s *PriceListApplication) SetPriceListEntry(ctx context.Context, cmd SetPriceListEntryCommand) (*entity.PriceList, error) {
+	// 1. 获取价目表以确定价格货币
+	priceList, err := s.pricingService.GetPriceList(ctx, cmd.PriceListId)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to get price list")
+	}
+
+	// 2. 转换命令到领域对象参数
+	tiers := make([]*valueobject.PriceTier, 0, len(cmd.Tiers))
+	for _, tier := range cmd.Tiers {
+		price, err := sharedvo.NewMoney(tier.Price, priceList.Currency)
+		if err != nil {
+			return nil, gerror.Wrap(err, "invalid tier price")
+		}
+		tiers = append(tiers, valueobject.NewPriceTier(tier.MinQuantity, price))
+	}
+
+	// 3. 调用领域服务设置价格
+	priceList, err = s.pricingService.SetEntry(ctx, cmd.PriceListId, cmd.ProductId, tiers)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to set price list entry")
+	}
+	return priceList, nil
+}
+
+// RemovePriceListEntryCommand 移除价目表商品价格命令
+type RemovePriceListEntryCommand struct {
+	PriceListId string
+	ProductId   string
+}
+
+// RemovePriceListEntry 移除价目表中商品的价格
+func (s *PriceListApplication) RemovePriceListEntry(ctx context.Context, cmd RemovePriceListEntryCommand) (*entity.PriceList, error) {
+	priceList, err := s.pricingService.RemoveEntry(ctx, cmd.PriceListId, cmd.ProductId)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to remove price list entry")
+	}
+	return priceList, nil
+}
+
+// DeletePriceListCommand 删除价目表命令
+type DeletePriceListCommand struct {
+	Id string
+}
+
+// DeletePriceList 删除价目表
+func (s *PriceListApplication) DeletePriceList(ctx context.Context, cmd DeletePriceListCommand) error {
+	if err := s.pricingService.DeletePriceList(ctx, cmd.Id); err != nil {
+		return gerror.Wrap(err, "failed to delete price list")
+	}
+	return nil
+}
+
+// GetPriceListQuery 获取价目表查询
+type GetPriceListQuery struct {
+	Id string
+}
+
+// GetPriceList 获取价目表
+func (s *PriceListApplication) GetPriceList(ctx context.Context, query GetPriceListQuery) (*entity.PriceList, error) {
+	priceList, err := s.pricingService.GetPriceList(ctx, query.Id)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to get price list")
+	}
+	return priceList, nil
+}
+
+// ListPriceListsQuery 价目表列表查询
+type ListPriceListsQuery struct {
+	CustomerGroup string
+}
+
+// ListPriceLists 获取价目表列表
+func (s *PriceListApplication) ListPriceLists(ctx context.Context, query ListPriceListsQuery) ([]*entity.PriceList, error) {
+	priceLists, err := s.pricingService.ListPriceLists(ctx, query.CustomerGroup)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to list price lists")
+	}
+	return priceLists, nil
+}
@@ -0,0 +1,147 @@
+package product
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"main/internal/domain/product/entity"
+	"main/internal/domain/product/valueobject"
+	sharedvo "main/internal/domain/shared/valueobject"
+)
+
+// BundleComponentCommand 套装组件命令
+type BundleComponentCommand struct {
+	SkuId    string // 组件 SKU，商品由 SKU 确定
+	Quantity int    // 每套包含的数量
+}
+
+// CreateBundleCommand 创建套装命令
+type CreateBundleCommand struct {
+	Name        string
+	Description string
+	CategoryId  string // 所属类目，为空表示未分类
+	Price       float64
+	Currency    string // 套装价格货币，为空时使用默认货币，须与组件 SKU 价格货币一致
+	Components  []BundleComponentCommand
+}
+
+// CreateBundle 创建套装商品，套装创建后处于草稿状态，发布后才能下单
+func (s *ProductApplicationService) CreateBundle(ctx context.Context, cmd CreateBundleCommand) (*entity.Product, error) {
+	// 1. 检查类目是否存在
+	if err := s.checkCategory(ctx, cmd.CategoryId); err != nil {
+		return nil, err
+	}
+
+	// 2. 转换命令到领域对象参数
+	price, err := sharedvo.NewMoney(cmd.Price, currencyOrDefault(cmd.Currency))
+	if err != nil {
+		return nil, gerror.Wrap(err, "invalid bundle price")
+	}
+	components, err := s.bundleComponents(ctx, cmd.Components)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. 调用领域服务创建套装
+	bundle, err := s.productService.CreateBundle(ctx, cmd.Name, cmd.Description, cmd.CategoryId, price, components)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to create bundle")
+	}
+	return bundle, nil
+}
+
+// SetBundleComponentsCommand 替换套装组件命令
+type SetBundleComponentsCommand struct {
+	Id         string
+	Components []BundleComponentCommand
+}
+
+// SetBundleComponents 替换套装的组件，已下单的订单仍按下单时的组件履约
+func (s *ProductApplicationService) SetBundleComponents(
+	ctx context.Context,
+	cmd SetBundleComponentsCommand,
+) (*entity.Product, error) {
+	components, err := s.bundleComponents(ctx, cmd.Components)
+	if err != nil {
+		return nil, err
+	}
+	bundle, err := s.productService.SetBundleComponents(ctx, cmd.Id, components)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to set bundle components")
+	}
+	return bundle, nil
+}
+
+// BundleComponentView 套装组件视图
+type BundleComponentView struct {
+	ProductId   string          `json:"productId"`
+	ProductName string          `json:"productName"`
+	SkuId       string          `json:"skuId"`
+	SkuName     string          `json:"skuName"`
+	Quantity    int             `json:"quantity"`  // 每套包含的数量
+	Price       *sharedvo.Money `json:"price"`     // 组件 SKU 的单价
+	Available   int             `json:"available"` // 组件 SKU 的可售数量
+}
+
+// BundleView 套装视图
+type BundleView struct {
+	Bundle     *entity.Product       `json:"bundle"`
+	Available  int                   `json:"available"` // 由组件的可售数量推算的套装可售数量
+	Components []BundleComponentView `json:"components"`
+}
+
+// GetBundleQuery 获取套装查询
+type GetBundleQuery struct {
+	Id string
+}
+
+// GetBundle 获取套装及其组件的当前库存，套装的可售数量由组件推算
+func (s *ProductApplicationService) GetBundle(ctx context.Context, query GetBundleQuery) (*BundleView, error) {
+	bundle, err := s.productService.GetProduct(ctx, query.Id)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to get bundle")
+	}
+	lines, err := s.productService.ResolveBundle(ctx, bundle)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to resolve bundle components")
+	}
+
+	view := &BundleView{
+		Bundle:     bundle,
+		Available:  lines.Available(),
+		Components: make([]BundleComponentView, len(lines)),
+	}
+	for i, line := range lines {
+		view.Components[i] = BundleComponentView{
+			ProductId:   line.Product.Id,
+			ProductName: line.Product.Name,
+			SkuId:       line.SKU.Id,
+			SkuName:     line.SKU.Name(),
+			Quantity:    line.Quantity,
+			Price:       line.SKU.Price,
+			Available:   line.SKU.Available(),
+		}
+	}
+	return view, nil
+}
+
+// bundleComponents 将组件命令转换为套装组件，按 SKU 查找所属商品
+func (s *ProductApplicationService) bundleComponents(
+	ctx context.Context,
+	cmds []BundleComponentCommand,
+) (valueobject.BundleComponents, error) {
+	components := make(valueobject.BundleComponents, len(cmds))
+	for i, cmd := range cmds {
+		product, err := s.productService.GetProductBySKU(ctx, cmd.SkuId)
+		if err != nil {
+			return nil, gerror.Wrapf(err, "failed to find component sku %s", cmd.SkuId)
+		}
+		components[i] = valueobject.BundleComponent{
+			ProductId: product.Id,
+			SkuId:     cmd.SkuId,
+			Quantity:  cmd.Quantity,
+		}
+	}
+	return components, nil
+}
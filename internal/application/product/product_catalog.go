@@ -0,0 +1,324 @@
+package product
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"main/internal/domain/product/entity"
+	"main/internal/domain/product/valueobject"
+)
+
+// pendingProductId 预演导入时新商品的占位 Id，正式导入时由商品服务通过 Id 生成器分配
+const pendingProductId = "pending"
+
+// CatalogImportAction 导入行的处理结果
+type CatalogImportAction string
+
+const (
+	CatalogImportCreate CatalogImportAction = "create" // 新增 SKU
+	CatalogImportUpdate CatalogImportAction = "update" // 更新已有 SKU
+	CatalogImportSkip   CatalogImportAction = "skip"   // 同一商品的其他行有错误，整个商品未导入
+	CatalogImportError  CatalogImportAction = "error"  // 该行有错误
+)
+
+// CatalogRowResult 导入行的处理结果
+type CatalogRowResult struct {
+	Row        int                 `json:"row"`
+	ExternalId string              `json:"externalId"`
+	ProductId  string              `json:"productId"` // 所属商品，新商品在预演时为空
+	Action     CatalogImportAction `json:"action"`
+	Error      string              `json:"error,omitempty"`
+}
+
+// CatalogImportReport 商品目录导入报告
+type CatalogImportReport struct {
+	DryRun  bool               `json:"dryRun"`
+	Created int                `json:"created"` // 新建（预演时为将要新建）的商品数
+	Updated int                `json:"updated"` // 更新（预演时为将要更新）的商品数
+	Failed  int                `json:"failed"`  // 有错误的行数
+	Rows    []CatalogRowResult `json:"rows"`
+}
+
+// ImportCatalogCommand 导入商品目录命令
+type ImportCatalogCommand struct {
+	Rows   []CatalogRow
+	DryRun bool   // 仅校验并生成报告，不保存
+	Actor  string // 操作人，库存变动以其名义记录流水
+}
+
+// catalogGroup 导入文件中属于同一商品的行
+type catalogGroup struct {
+	product *entity.Product // 已有商品，为空表示新建
+	rows    []int           // 行在导入文件中的下标
+}
+
+// ImportCatalog 按 SKU 外部编码批量导入商品
+// 行按商品 Id 归组，未填写商品 Id 时归入外部编码所属的商品，都没有时按商品名称归组并新建商品；
+// 商品的名称、描述和类目取自该商品的第一行。
+// 每个商品应用全部行后通过 Product.Validate 校验，任一行有错误时该商品整体不导入
+func (s *ProductApplicationService) ImportCatalog(ctx context.Context, cmd ImportCatalogCommand) (*CatalogImportReport, error) {
+	report := &CatalogImportReport{
+		DryRun: cmd.DryRun,
+		Rows:   make([]CatalogRowResult, len(cmd.Rows)),
+	}
+	for i, row := range cmd.Rows {
+		report.Rows[i] = CatalogRowResult{Row: row.Row, ExternalId: row.ExternalId, ProductId: row.ProductId}
+	}
+	fail := func(i int, err error) {
+		report.Rows[i].Action = CatalogImportError
+		report.Rows[i].Error = err.Error()
+	}
+
+	// 1. 归组
+	groups, order, err := s.groupCatalogRows(ctx, cmd.Rows, fail)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. 逐个商品校验并导入
+	source := valueobject.MovementSource{Actor: cmd.Actor, Note: "catalog import"}
+	for _, key := range order {
+		group := groups[key]
+		first := cmd.Rows[group.rows[0]]
+		planned, skus := s.planCatalogProduct(ctx, group, cmd.Rows, report)
+		if planned == nil {
+			continue
+		}
+		if cmd.DryRun {
+			report.count(group.product == nil)
+			continue
+		}
+
+		id := ""
+		if group.product != nil {
+			id = group.product.Id
+		}
+		product, err := s.productService.ImportProduct(
+			ctx, id, first.Name, first.Description, first.CategoryId, skus, source,
+		)
+		if err != nil {
+			for _, i := range group.rows {
+				fail(i, err)
+			}
+			continue
+		}
+		for _, i := range group.rows {
+			report.Rows[i].ProductId = product.Id
+		}
+		report.count(group.product == nil)
+	}
+
+	for _, row := range report.Rows {
+		if row.Action == CatalogImportError {
+			report.Failed++
+		}
+	}
+	return report, nil
+}
+
+// groupCatalogRows 将导入行按所属商品归组，返回分组及其出现顺序
+// 无法归组的行通过 fail 记录错误
+func (s *ProductApplicationService) groupCatalogRows(
+	ctx context.Context,
+	rows []CatalogRow,
+	fail func(int, error),
+) (map[string]*catalogGroup, []string, error) {
+	// 1. 校验外部编码
+	valid := make([]bool, len(rows))
+	seen := make(map[string]bool, len(rows))
+	externalIds := make([]string, 0, len(rows))
+	for i, row := range rows {
+		switch {
+		case row.err != nil:
+			fail(i, row.err)
+		case row.ExternalId == "":
+			fail(i, gerror.Wrap(valueobject.ErrInvalidSKU, "sku external id is required"))
+		case seen[row.ExternalId]:
+			fail(i, gerror.Wrapf(valueobject.ErrDuplicateSKU, "duplicate sku external id %s in file", row.ExternalId))
+		default:
+			valid[i] = true
+			seen[row.ExternalId] = true
+			externalIds = append(externalIds, row.ExternalId)
+		}
+	}
+
+	// 2. 查找外部编码所属的商品
+	products, err := s.productService.FindProductsByExternalIds(ctx, externalIds)
+	if err != nil {
+		return nil, nil, gerror.Wrap(err, "failed to find products by sku external id")
+	}
+	owners := make(map[string]*entity.Product, len(externalIds))
+	byId := make(map[string]*entity.Product, len(products))
+	for _, product := range products {
+		byId[product.Id] = product
+		for _, sku := range product.SKUs {
+			if sku.ExternalId != "" {
+				owners[sku.ExternalId] = product
+			}
+		}
+	}
+
+	// 3. 按商品归组
+	groups := make(map[string]*catalogGroup)
+	order := make([]string, 0)
+	for i, row := range rows {
+		if !valid[i] {
+			continue
+		}
+		product := owners[row.ExternalId]
+		if row.ProductId != "" {
+			if product != nil && product.Id != row.ProductId {
+				fail(i, gerror.Wrapf(valueobject.ErrDuplicateSKU,
+					"sku external id %s belongs to product %s", row.ExternalId, product.Id,
+				))
+				continue
+			}
+			if product == nil {
+				product = byId[row.ProductId]
+			}
+			if product == nil {
+				if product, err = s.productService.GetProduct(ctx, row.ProductId); err != nil {
+					fail(i, err)
+					continue
+				}
+				byId[product.Id] = product
+			}
+		}
+		if product != nil && product.IsDeleted() {
+			fail(i, gerror.Wrapf(valueobject.ErrProductUnavailable, "product %s is deleted", product.Id))
+			continue
+		}
+
+		key := "new:" + row.Name
+		if product != nil {
+			key = product.Id
+		}
+		group, ok := groups[key]
+		if !ok {
+			group = &catalogGroup{product: product}
+			groups[key] = group
+			order = append(order, key)
+		}
+		group.rows = append(group.rows, i)
+	}
+	return groups, order, nil
+}
+
+// planCatalogProduct 在商品副本上应用分组中的各行并逐行校验，记录各行的处理结果
+// 全部行通过校验时返回应用后的商品和待导入的 SKU，否则返回 nil
+func (s *ProductApplicationService) planCatalogProduct(
+	ctx context.Context,
+	group *catalogGroup,
+	rows []CatalogRow,
+	report *CatalogImportReport,
+) (*entity.Product, []*entity.SKU) {
+	first := rows[group.rows[0]]
+	var planned *entity.Product
+	if group.product != nil {
+		planned = group.product.Clone()
+	} else {
+		planned = entity.NewProduct(pendingProductId, "", "", "", nil, valueobject.ProductStatusDraft, 0, 0)
+	}
+	planned.Name = first.Name
+	planned.Description = first.Description
+	planned.AssignCategory(first.CategoryId)
+	categoryErr := s.checkCategory(ctx, first.CategoryId)
+
+	skus := make([]*entity.SKU, 0, len(group.rows))
+	failed := false
+	for _, i := range group.rows {
+		row := rows[i]
+		result := &report.Rows[i]
+		err := categoryErr
+		var sku *entity.SKU
+		if err == nil {
+			sku, err = row.toSKU()
+		}
+		added := false
+		if err == nil {
+			added, err = planned.UpsertSKU(sku)
+		}
+		if err == nil {
+			err = planned.Validate()
+		}
+		if err != nil {
+			result.Action = CatalogImportError
+			result.Error = err.Error()
+			failed = true
+			continue
+		}
+
+		result.Action = CatalogImportUpdate
+		if added {
+			result.Action = CatalogImportCreate
+		}
+		if group.product != nil {
+			result.ProductId = group.product.Id
+		}
+		skus = append(skus, sku)
+	}
+
+	if failed {
+		for _, i := range group.rows {
+			if report.Rows[i].Action != CatalogImportError {
+				report.Rows[i].Action = CatalogImportSkip
+			}
+		}
+		return nil, nil
+	}
+	return planned, skus
+}
+
+// count 统计新建或更新的商品数
+func (r *CatalogImportReport) count(created bool) {
+	if created {
+		r.Created++
+	} else {
+		r.Updated++
+	}
+}
+
+// ExportCatalogQuery 导出商品目录查询
+type ExportCatalogQuery struct {
+	Status valueobject.ProductStatus // 商品状态，为空时导出除已删除外的全部商品
+}
+
+// ExportCatalog 导出商品目录，每个 SKU 一行，格式与导入相同
+func (s *ProductApplicationService) ExportCatalog(ctx context.Context, query ExportCatalogQuery) ([]CatalogRow, error) {
+	var products []*entity.Product
+	var err error
+	if query.Status == valueobject.ProductStatusDeleted {
+		products, err = s.productService.ListDeletedProducts(ctx)
+	} else {
+		products, err = s.productService.ListProducts(ctx)
+	}
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to list products")
+	}
+
+	rows := make([]CatalogRow, 0, len(products))
+	for _, product := range products {
+		if query.Status != "" && product.Status != query.Status {
+			continue
+		}
+		for _, sku := range product.SKUs {
+			rows = append(rows, CatalogRow{
+				Row:         len(rows) + 1,
+				ProductId:   product.Id,
+				Name:        product.Name,
+				Description: product.Description,
+				CategoryId:  product.CategoryId,
+				Status:      string(product.Status),
+				ExternalId:  sku.ExternalId,
+				Options:     sku.Options.Key(),
+				Price:       json.Number(sku.Price.AmountString()),
+				Currency:    sku.Price.Currency(),
+				Stock:       sku.Stock,
+				Barcode:     sku.Barcode,
+			})
+		}
+	}
+	return rows, nil
+}
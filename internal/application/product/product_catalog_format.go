@@ -0,0 +1,202 @@
+package product
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"main/internal/domain/product/entity"
+	"main/internal/domain/product/valueobject"
+	sharedvo "main/internal/domain/shared/valueobject"
+)
+
+// CatalogFormat 商品目录文件格式
+type CatalogFormat string
+
+const (
+	CatalogFormatCSV  CatalogFormat = "csv"
+	CatalogFormatJSON CatalogFormat = "json"
+)
+
+// catalogColumns CSV 文件的列，与 CatalogRow 的 json 字段名一致
+var catalogColumns = []string{
+	"productId", "name", "description", "categoryId", "status",
+	"externalId", "options", "price", "currency", "stock", "barcode",
+}
+
+// CatalogRow 商品目录中的一行，对应一个 SKU
+type CatalogRow struct {
+	Row         int         `json:"-"`         // 数据行号，从 1 开始
+	ProductId   string      `json:"productId"` // 所属商品，为空时按外部编码或商品名称归组
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	CategoryId  string      `json:"categoryId"`
+	Status      string      `json:"status"`     // 商品状态，仅导出时填写，导入时忽略
+	ExternalId  string      `json:"externalId"` // SKU 外部编码，导入时必填
+	Options     string      `json:"options"`    // 规格组合，如 "尺码=M;颜色=红色"，单规格商品为空
+	Price       json.Number `json:"price"`      // 精确的十进制数，小数位数不能超过货币精度，读写不经过浮点数
+	Currency    string      `json:"currency"`   // 为空时使用默认货币
+	Stock       int         `json:"stock"`      // 在库数量
+	Barcode     string      `json:"barcode"`
+
+	err error // 解析该行时的错误，在导入报告中体现
+}
+
+// decimalLocale 十进制数的格式与 en-US 一致：小数点为 "."，没有千位分隔符
+const decimalLocale = sharedvo.LocaleEnUS
+
+// toSKU 将导入行转换为 SKU 实体
+func (r CatalogRow) toSKU() (*entity.SKU, error) {
+	options, err := valueobject.ParseSKUOptions(r.Options)
+	if err != nil {
+		return nil, err
+	}
+	price, err := sharedvo.ParseMoney(r.Price.String(), currencyOrDefault(r.Currency), decimalLocale)
+	if err != nil {
+		return nil, gerror.Wrapf(valueobject.ErrInvalidPrice, "invalid price %q: %v", r.Price, err)
+	}
+	sku := entity.NewSKU("", options, price, r.Stock, r.Barcode)
+	sku.ExternalId = r.ExternalId
+	return sku, nil
+}
+
+// ParseCatalogFormat 解析文件格式，为空时根据文件名的扩展名判断
+func ParseCatalogFormat(format string, filename string) (CatalogFormat, error) {
+	if format == "" {
+		format = strings.TrimPrefix(path.Ext(filename), ".")
+	}
+	switch f := CatalogFormat(strings.ToLower(format)); f {
+	case CatalogFormatCSV, CatalogFormatJSON:
+		return f, nil
+	default:
+		return "", gerror.Newf("unsupported catalog format %q, expected csv or json", format)
+	}
+}
+
+// DecodeCatalog 读取商品目录
+// CSV 中的价格按 locale 的金额格式解析，可以带货币符号和千位分隔符，locale 为空时使用默认地区；
+// JSON 中的价格为十进制数。
+// 文件本身格式错误时返回错误，单行的字段错误记录在该行上，在导入报告中体现
+func DecodeCatalog(format CatalogFormat, reader io.Reader, locale sharedvo.Locale) ([]CatalogRow, error) {
+	switch format {
+	case CatalogFormatCSV:
+		return decodeCatalogCSV(reader, locale)
+	case CatalogFormatJSON:
+		var rows []CatalogRow
+		if err := json.NewDecoder(reader).Decode(&rows); err != nil {
+			return nil, gerror.Wrap(err, "invalid json catalog")
+		}
+		for i := range rows {
+			rows[i].Row = i + 1
+		}
+		return rows, nil
+	default:
+		return nil, gerror.Newf("unsupported catalog format %q", format)
+	}
+}
+
+// EncodeCatalog 写出商品目录
+// CSV 中的价格按 locale 的金额格式输出，locale 为空时使用默认地区；JSON 中的价格为十进制数
+func EncodeCatalog(format CatalogFormat, writer io.Writer, rows []CatalogRow, locale sharedvo.Locale) error {
+	switch format {
+	case CatalogFormatCSV:
+		return encodeCatalogCSV(writer, rows, locale)
+	case CatalogFormatJSON:
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(rows)
+	default:
+		return gerror.Newf("unsupported catalog format %q", format)
+	}
+}
+
+// decodeCatalogCSV 读取 CSV 格式的商品目录，第一行为列名，列的顺序不限，未知的列忽略
+func decodeCatalogCSV(reader io.Reader, locale sharedvo.Locale) ([]CatalogRow, error) {
+	r := csv.NewReader(reader)
+	r.TrimLeadingSpace = true
+	r.FieldsPerRecord = -1 // 缺少的末尾列视为空
+	header, err := r.Read()
+	if err == io.EOF {
+		return []CatalogRow{}, nil
+	}
+	if err != nil {
+		return nil, gerror.Wrap(err, "invalid csv catalog header")
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		// 电子表格软件导出的 UTF-8 文件可能带有 BOM
+		name = strings.TrimPrefix(strings.TrimSpace(name), "\ufeff")
+		columns[strings.ToLower(name)] = i
+	}
+
+	rows := make([]CatalogRow, 0)
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, gerror.Wrap(err, "invalid csv catalog")
+		}
+		get := func(column string) string {
+			if i, ok := columns[strings.ToLower(column)]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		row := CatalogRow{
+			Row:         len(rows) + 1,
+			ProductId:   get("productId"),
+			Name:        get("name"),
+			Description: get("description"),
+			CategoryId:  get("categoryId"),
+			Status:      get("status"),
+			ExternalId:  get("externalId"),
+			Options:     get("options"),
+			Currency:    get("currency"),
+			Barcode:     get("barcode"),
+		}
+		if price, err := sharedvo.ParseMoney(get("price"), currencyOrDefault(row.Currency), locale); err != nil {
+			row.err = gerror.Wrapf(valueobject.ErrInvalidPrice, "invalid price %q: %v", get("price"), err)
+		} else {
+			row.Price = json.Number(price.AmountString())
+		}
+		if stock := get("stock"); stock != "" {
+			if row.Stock, err = strconv.Atoi(stock); err != nil {
+				row.err = gerror.Wrapf(valueobject.ErrInvalidStock, "invalid stock %q", stock)
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// encodeCatalogCSV 写出 CSV 格式的商品目录，价格不带货币符号，货币单独成列
+func encodeCatalogCSV(writer io.Writer, rows []CatalogRow, locale sharedvo.Locale) error {
+	w := csv.NewWriter(writer)
+	if err := w.Write(catalogColumns); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		price, err := sharedvo.ParseMoney(row.Price.String(), currencyOrDefault(row.Currency), decimalLocale)
+		if err != nil {
+			return gerror.Wrapf(err, "invalid price of row %d", row.Row)
+		}
+		record := []string{
+			row.ProductId, row.Name, row.Description, row.CategoryId, row.Status,
+			row.ExternalId, row.Options, price.FormatWith(locale, sharedvo.CurrencyDisplayNone),
+			row.Currency, strconv.Itoa(row.Stock), row.Barcode,
+		}
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
@@ -0,0 +1,54 @@
+package product
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"main/internal/domain/product/entity"
+)
+
+// UploadImageCommand 上传商品图片命令
+type UploadImageCommand struct {
+	ProductId string
+	Data      []byte // 图片文件内容
+}
+
+// UploadImage 上传商品图片
+func (s *ProductApplicationService) UploadImage(ctx context.Context, cmd UploadImageCommand) (*entity.Product, error) {
+	product, err := s.imageService.UploadImage(ctx, cmd.ProductId, cmd.Data)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to upload product image")
+	}
+	return product, nil
+}
+
+// RemoveImageCommand 移除商品图片命令
+type RemoveImageCommand struct {
+	ProductId string
+	ImageId   string
+}
+
+// RemoveImage 移除商品图片
+func (s *ProductApplicationService) RemoveImage(ctx context.Context, cmd RemoveImageCommand) (*entity.Product, error) {
+	product, err := s.imageService.RemoveImage(ctx, cmd.ProductId, cmd.ImageId)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to remove product image")
+	}
+	return product, nil
+}
+
+// ReorderImagesCommand 调整商品图片顺序命令
+type ReorderImagesCommand struct {
+	ProductId string
+	ImageIds  []string // 全部图片 Id，按展示顺序排列
+}
+
+// ReorderImages 调整商品图片顺序
+func (s *ProductApplicationService) ReorderImages(ctx context.Context, cmd ReorderImagesCommand) (*entity.Product, error) {
+	product, err := s.imageService.ReorderImages(ctx, cmd.ProductId, cmd.ImageIds)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to reorder product images")
+	}
+	return product, nil
+}
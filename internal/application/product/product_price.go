@@ -0,0 +1,107 @@
+package product
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"main/internal/domain/product/entity"
+	"main/internal/domain/product/valueobject"
+	sharedvo "main/internal/domain/shared/valueobject"
+)
+
+// ListPriceHistoryQuery 获取价格历史查询
+type ListPriceHistoryQuery struct {
+	ProductId string
+	SkuId     string // 为空时返回所有 SKU 的价格历史
+	Limit     int
+}
+
+// ListPriceHistory 获取商品的价格历史，按时间倒序
+func (s *ProductApplicationService) ListPriceHistory(
+	ctx context.Context,
+	query ListPriceHistoryQuery,
+) ([]*entity.PriceChange, error) {
+	changes, err := s.productService.ListPriceHistory(ctx, query.ProductId, query.SkuId, query.Limit)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to list price history")
+	}
+	return changes, nil
+}
+
+// SchedulePriceChangeCommand 定时调价命令
+type SchedulePriceChangeCommand struct {
+	ProductId string
+	SkuId     string
+	Price     float64
+	Currency  string // 价格货币，为空时使用默认货币
+	StartAt   int64  // 开始时间（毫秒）
+	EndAt     int64  // 结束时间（毫秒），为 0 表示永久调价
+	Actor     string
+	Note      string
+}
+
+// SchedulePriceChange 创建定时调价，到开始时间时应用，到结束时间时恢复原价
+func (s *ProductApplicationService) SchedulePriceChange(
+	ctx context.Context,
+	cmd SchedulePriceChangeCommand,
+) (*entity.PriceSchedule, error) {
+	price, err := sharedvo.NewMoney(cmd.Price, currencyOrDefault(cmd.Currency))
+	if err != nil {
+		return nil, gerror.Wrap(err, "invalid scheduled price")
+	}
+
+	schedule, err := s.scheduleService.Schedule(
+		ctx, cmd.ProductId, cmd.SkuId, price, cmd.StartAt, cmd.EndAt, cmd.Actor, cmd.Note,
+	)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to schedule price change")
+	}
+	return schedule, nil
+}
+
+// CancelPriceScheduleCommand 取消定时调价命令
+type CancelPriceScheduleCommand struct {
+	ProductId string
+	Id        string
+	Actor     string // 操作人，恢复原价时以其名义记录价格历史
+}
+
+// CancelPriceSchedule 取消定时调价，已开始的定时调价恢复原价
+func (s *ProductApplicationService) CancelPriceSchedule(
+	ctx context.Context,
+	cmd CancelPriceScheduleCommand,
+) (*entity.PriceSchedule, error) {
+	schedule, err := s.scheduleService.GetSchedule(ctx, cmd.Id)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to get price schedule")
+	}
+	if schedule.ProductId != cmd.ProductId {
+		return nil, gerror.Wrapf(valueobject.ErrPriceScheduleNotFound,
+			"price schedule %s not found in product %s", cmd.Id, cmd.ProductId,
+		)
+	}
+
+	schedule, err = s.scheduleService.Cancel(ctx, cmd.Id, cmd.Actor)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to cancel price schedule")
+	}
+	return schedule, nil
+}
+
+// ListPriceSchedulesQuery 获取定时调价查询
+type ListPriceSchedulesQuery struct {
+	ProductId string
+}
+
+// ListPriceSchedules 获取商品的定时调价，按开始时间排序
+func (s *ProductApplicationService) ListPriceSchedules(
+	ctx context.Context,
+	query ListPriceSchedulesQuery,
+) ([]*entity.PriceSchedule, error) {
+	schedules, err := s.scheduleService.ListProductSchedules(ctx, query.ProductId)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to list price schedules")
+	}
+	return schedules, nil
+}
@@ -0,0 +1,112 @@
+package product
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"main/internal/domain/product/entity"
+)
+
+// defaultVelocityDays 未指定时计算销售速度的统计天数
+const defaultVelocityDays = 30
+
+// SetLowStockThresholdCommand 设置低库存阈值命令
+type SetLowStockThresholdCommand struct {
+	Id        string
+	Threshold int // 为 0 表示不预警
+}
+
+// SetLowStockThreshold 设置商品的低库存阈值
+func (s *ProductApplicationService) SetLowStockThreshold(
+	ctx context.Context,
+	cmd SetLowStockThresholdCommand,
+) (*entity.Product, error) {
+	product, err := s.productService.SetLowStockThreshold(ctx, cmd.Id, cmd.Threshold)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to set low stock threshold")
+	}
+	return product, nil
+}
+
+// ReplenishmentItem 补货报表项
+type ReplenishmentItem struct {
+	ProductId     string   `json:"productId"`
+	Name          string   `json:"name"`
+	Status        string   `json:"status"`
+	Threshold     int      `json:"threshold"`
+	OnHand        int      `json:"onHand"`        // 在库数量总和
+	Available     int      `json:"available"`     // 可售数量总和
+	Sold          int      `json:"sold"`          // 统计期内售出的数量
+	DailyVelocity float64  `json:"dailyVelocity"` // 统计期内平均每天售出的数量
+	DaysOfCover   *float64 `json:"daysOfCover"`   // 按销售速度可售卖的天数，统计期内没有销售时为空
+}
+
+// ReplenishmentReportQuery 补货报表查询
+type ReplenishmentReportQuery struct {
+	Days int // 计算销售速度的统计天数，为 0 时使用默认值
+}
+
+// GetReplenishmentReport 获取可售数量不高于低库存阈值的商品及其近期销售速度
+// 按可售天数升序排列，最紧急的在前；没有销售的商品排在最后
+func (s *ProductApplicationService) GetReplenishmentReport(
+	ctx context.Context,
+	query ReplenishmentReportQuery,
+) ([]ReplenishmentItem, error) {
+	days := query.Days
+	if days <= 0 {
+		days = defaultVelocityDays
+	}
+
+	// 1. 查找低库存商品
+	products, err := s.productService.ListLowStockProducts(ctx)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to list low stock products")
+	}
+
+	// 2. 统计近期销售
+	productIds := make([]string, len(products))
+	for i, product := range products {
+		productIds[i] = product.Id
+	}
+	since := time.Now().AddDate(0, 0, -days).UnixMilli()
+	sales, err := s.productService.SalesSince(ctx, productIds, since)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to sum recent sales")
+	}
+
+	// 3. 计算销售速度和可售天数
+	items := make([]ReplenishmentItem, len(products))
+	for i, product := range products {
+		item := ReplenishmentItem{
+			ProductId:     product.Id,
+			Name:          product.Name,
+			Status:        string(product.Status),
+			Threshold:     product.LowStockThreshold,
+			OnHand:        product.TotalStock(),
+			Available:     product.TotalAvailable(),
+			Sold:          sales[product.Id],
+			DailyVelocity: float64(sales[product.Id]) / float64(days),
+		}
+		if item.DailyVelocity > 0 {
+			cover := math.Round(float64(item.Available)/item.DailyVelocity*10) / 10
+			item.DaysOfCover = &cover
+		}
+		items[i] = item
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := items[i].DaysOfCover, items[j].DaysOfCover
+		switch {
+		case a == nil || b == nil:
+			return a != nil
+		case *a != *b:
+			return *a < *b
+		default:
+			return items[i].Available < items[j].Available
+		}
+	})
+	return items, nil
+}
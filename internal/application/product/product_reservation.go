@@ -0,0 +1,63 @@
+package product
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"main/internal/domain/product/entity"
+	"main/internal/domain/product/valueobject"
+)
+
+// SKUStockView SKU 库存视图
+type SKUStockView struct {
+	SkuId     string `json:"skuId"`
+	Name      string `json:"name"`
+	OnHand    int    `json:"onHand"`    // 在库数量
+	Reserved  int    `json:"reserved"`  // 被未支付订单预占的数量
+	Available int    `json:"available"` // 可售数量
+}
+
+// ProductReservationView 商品库存预占视图
+type ProductReservationView struct {
+	ProductId    string                `json:"productId"`
+	SKUs         []SKUStockView        `json:"skus"`
+	Reservations []*entity.Reservation `json:"reservations"`
+}
+
+// GetProductReservationsQuery 获取商品库存预占查询
+type GetProductReservationsQuery struct {
+	ProductId string
+	State     valueobject.ReservationState // 为空时返回所有状态的预占
+}
+
+// GetProductReservations 获取商品各 SKU 的在库、预占和可售数量以及预占记录
+func (s *ProductApplicationService) GetProductReservations(
+	ctx context.Context,
+	query GetProductReservationsQuery,
+) (*ProductReservationView, error) {
+	product, err := s.productService.GetProduct(ctx, query.ProductId)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to get product")
+	}
+	reservations, err := s.reservationService.ListProductReservations(ctx, product.Id, query.State)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to list stock reservations")
+	}
+
+	view := &ProductReservationView{
+		ProductId:    product.Id,
+		SKUs:         make([]SKUStockView, len(product.SKUs)),
+		Reservations: reservations,
+	}
+	for i, sku := range product.SKUs {
+		view.SKUs[i] = SKUStockView{
+			SkuId:     sku.Id,
+			Name:      sku.Name(),
+			OnHand:    sku.Stock,
+			Reserved:  sku.Reserved,
+			Available: sku.Available(),
+		}
+	}
+	return view, nil
+}
@@ -5,7 +5,9 @@ import (
 
 	"github.com/gogf/gf/v2/errors/gerror"
 
+	categoryservice "main/internal/domain/category/service"
 	"main/internal/domain/product/entity"
+	"main/internal/domain/product/repository"
 	"main/internal/domain/product/service"
 	"main/internal/domain/product/valueobject"
 	sharedvo "main/internal/domain/shared/valueobject"
@@ -14,24 +16,46 @@ import (
 // ProductApplicationService 商品应用服务
 // 应用服务负责用例编排，但不包含业务规则
 type ProductApplicationService struct {
-	productService *service.ProductService // 商品领域服务
+	productService     *service.ProductService          // 商品领域服务
+	imageService       *service.ProductImageService     // 商品图片服务
+	reservationService *service.ReservationService      // 库存预占服务
+	scheduleService    *service.PriceScheduleService    // 定时调价服务
+	categoryService    *categoryservice.CategoryService // 类目领域服务
 }
 
 // NewProductApplicationService 创建商品应用服务实例
 func NewProductApplicationService(
 	productService *service.ProductService,
+	imageService *service.ProductImageService,
+	reservationService *service.ReservationService,
+	scheduleService *service.PriceScheduleService,
+	categoryService *categoryservice.CategoryService,
 ) *ProductApplicationService {
 	return &ProductApplicationService{
-		productService: productService,
+		productService:     productService,
+		imageService:       imageService,
+		reservationService: reservationService,
+		scheduleService:    scheduleService,
+		categoryService:    categoryService,
 	}
 }
 
+// SKUCommand SKU 命令
+type SKUCommand struct {
+	Options valueobject.SKUOptions // 规格选项组合，单规格商品为空
+	Price   float64
+	Stock   int
+	Barcode string
+}
+
 // CreateProductCommand 创建商品命令
 type CreateProductCommand struct {
 	Name        string
 	Description string
-	Price       float64
-	Stock       int
+	CategoryId  string // 所属类目，为空表示未分类
+	Currency    string // SKU 价格货币，为空时使用默认货币
+	SKUs        []SKUCommand
+	Actor       string // 操作人，SKU 的初始库存以其名义记录入库流水
 }
 
 // CreateProduct 创建商品
@@ -41,17 +65,29 @@ type CreateProductCommand struct {
 // 3. 事务处理
 // 4. 不包含业务规则
 func (s *ProductApplicationService) CreateProduct(ctx context.Context, cmd CreateProductCommand) (*entity.Product, error) {
-	// 1. 转换命令到领域对象参数
-	price := sharedvo.NewMoney(cmd.Price, "CNY")
+	// 1. 检查类目是否存在
+	if err := s.checkCategory(ctx, cmd.CategoryId); err != nil {
+		return nil, err
+	}
+
+	// 2. 转换命令到领域对象参数
+	skus := make([]*entity.SKU, 0, len(cmd.SKUs))
+	for _, skuCmd := range cmd.SKUs {
+		sku, err := newSKU(cmd.Currency, skuCmd)
+		if err != nil {
+			return nil, err
+		}
+		skus = append(skus, sku)
+	}
 
-	// 2. 调用领域服务创建商品
+	// 3. 调用领域服务创建商品
 	product, err := s.productService.CreateProduct(
 		ctx,
-		"", // ID will be assigned by infrastructure layer
 		cmd.Name,
 		cmd.Description,
-		price,
-		cmd.Stock,
+		cmd.CategoryId,
+		skus,
+		valueobject.MovementSource{Actor: cmd.Actor},
 	)
 	if err != nil {
 		return nil, gerror.Wrap(err, "failed to create product")
@@ -65,25 +101,17 @@ type UpdateProductCommand struct {
 	Id          string
 	Name        string
 	Description string
-	Price       float64
-	Stock       int
-	Status      valueobject.ProductStatus
 }
 
 // UpdateProduct 更新商品
+// SKU 通过 AddSKU、UpdateSKU、RemoveSKU 命令维护，
+// 商品状态需通过 PublishProduct、TakeProductOffSale 等生命周期命令变更
 func (s *ProductApplicationService) UpdateProduct(ctx context.Context, cmd UpdateProductCommand) (*entity.Product, error) {
-	// 1. 转换命令到领域对象参数
-	price := sharedvo.NewMoney(cmd.Price, "CNY")
-
-	// 2. 调用领域服务更新商品
 	product, err := s.productService.UpdateProduct(
 		ctx,
 		cmd.Id,
 		cmd.Name,
 		cmd.Description,
-		price,
-		cmd.Stock,
-		cmd.Status,
 	)
 	if err != nil {
 		return nil, gerror.Wrap(err, "failed to update product")
@@ -92,17 +120,159 @@ func (s *ProductApplicationService) UpdateProduct(ctx context.Context, cmd Updat
 	return product, nil
 }
 
+// AssignCategoryCommand 商品归类命令
+type AssignCategoryCommand struct {
+	Id         string
+	CategoryId string // 为空时取消分类
+}
+
+// AssignCategory 将商品归入类目
+func (s *ProductApplicationService) AssignCategory(ctx context.Context, cmd AssignCategoryCommand) (*entity.Product, error) {
+	if err := s.checkCategory(ctx, cmd.CategoryId); err != nil {
+		return nil, err
+	}
+
+	product, err := s.productService.AssignCategory(ctx, cmd.Id, cmd.CategoryId)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to assign product category")
+	}
+	return product, nil
+}
+
+// AddSKUCommand 添加 SKU 命令
+type AddSKUCommand struct {
+	ProductId string
+	Currency  string // 价格货币，为空时使用默认货币
+	SKU       SKUCommand
+	Actor     string // 操作人，SKU 的初始库存以其名义记录入库流水
+}
+
+// AddSKU 为商品添加 SKU
+func (s *ProductApplicationService) AddSKU(ctx context.Context, cmd AddSKUCommand) (*entity.Product, error) {
+	sku, err := newSKU(cmd.Currency, cmd.SKU)
+	if err != nil {
+		return nil, err
+	}
+
+	product, err := s.productService.AddSKU(ctx, cmd.ProductId, sku, valueobject.MovementSource{Actor: cmd.Actor})
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to add sku")
+	}
+	return product, nil
+}
+
+// UpdateSKUCommand 更新 SKU 命令
+// SKU 的规格组合创建后不可修改，如需调整请移除后重新添加；
+// 库存不能直接修改，需通过 AdjustStock 登记变动原因
+type UpdateSKUCommand struct {
+	ProductId string
+	SkuId     string
+	Price     float64
+	Currency  string // 价格货币，为空时使用默认货币
+	Barcode   string
+	Actor     string // 操作人，价格变更以其名义记录价格历史
+}
+
+// UpdateSKU 更新 SKU 的价格和条码
+func (s *ProductApplicationService) UpdateSKU(ctx context.Context, cmd UpdateSKUCommand) (*entity.Product, error) {
+	price, err := sharedvo.NewMoney(cmd.Price, currencyOrDefault(cmd.Currency))
+	if err != nil {
+		return nil, gerror.Wrap(err, "invalid sku price")
+	}
+
+	product, err := s.productService.UpdateSKU(
+		ctx, cmd.ProductId, cmd.SkuId, price, cmd.Barcode, valueobject.MovementSource{Actor: cmd.Actor},
+	)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to update sku")
+	}
+	return product, nil
+}
+
+// RemoveSKUCommand 移除 SKU 命令
+type RemoveSKUCommand struct {
+	ProductId string
+	SkuId     string
+	Actor     string // 操作人，SKU 的剩余库存以其名义记录调整流水
+}
+
+// RemoveSKU 移除商品的 SKU
+func (s *ProductApplicationService) RemoveSKU(ctx context.Context, cmd RemoveSKUCommand) (*entity.Product, error) {
+	product, err := s.productService.RemoveSKU(ctx, cmd.ProductId, cmd.SkuId, valueobject.MovementSource{Actor: cmd.Actor})
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to remove sku")
+	}
+	return product, nil
+}
+
+// PublishProductCommand 发布商品命令
+type PublishProductCommand struct {
+	Id string
+}
+
+// PublishProduct 发布商品
+func (s *ProductApplicationService) PublishProduct(ctx context.Context, cmd PublishProductCommand) (*entity.Product, error) {
+	product, err := s.productService.PublishProduct(ctx, cmd.Id)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to publish product")
+	}
+	return product, nil
+}
+
+// TakeProductOffSaleCommand 下架商品命令
+type TakeProductOffSaleCommand struct {
+	Id string
+}
+
+// TakeProductOffSale 下架商品
+func (s *ProductApplicationService) TakeProductOffSale(ctx context.Context, cmd TakeProductOffSaleCommand) (*entity.Product, error) {
+	product, err := s.productService.TakeProductOffSale(ctx, cmd.Id)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to take product off sale")
+	}
+	return product, nil
+}
+
+// MarkProductSoldOutCommand 标记商品售罄命令
+type MarkProductSoldOutCommand struct {
+	Id string
+}
+
+// MarkProductSoldOut 标记商品售罄
+func (s *ProductApplicationService) MarkProductSoldOut(ctx context.Context, cmd MarkProductSoldOutCommand) (*entity.Product, error) {
+	product, err := s.productService.MarkProductSoldOut(ctx, cmd.Id)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to mark product sold out")
+	}
+	return product, nil
+}
+
 // DeleteProductCommand 删除商品命令
 type DeleteProductCommand struct {
 	Id string
 }
 
 // DeleteProduct 删除商品
-func (s *ProductApplicationService) DeleteProduct(ctx context.Context, cmd DeleteProductCommand) error {
-	if err := s.productService.DeleteProduct(ctx, cmd.Id); err != nil {
-		return gerror.Wrap(err, "failed to delete product")
+func (s *ProductApplicationService) DeleteProduct(ctx context.Context, cmd DeleteProductCommand) (*entity.Product, error) {
+	product, err := s.productService.DeleteProduct(ctx, cmd.Id)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to delete product")
 	}
-	return nil
+	return product, nil
+}
+
+// RestoreProductCommand 恢复商品命令
+type RestoreProductCommand struct {
+	Id string
+}
+
+// RestoreProduct 恢复已删除的商品
+func (s *ProductApplicationService) RestoreProduct(ctx context.Context, cmd RestoreProductCommand) (*entity.Product, error) {
+	product, err := s.productService.RestoreProduct(ctx, cmd.Id)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to restore product")
+	}
+	return product, nil
 }
 
 // GetProductQuery 获取商品查询
@@ -124,8 +294,16 @@ type ListProductsQuery struct {
 	Status valueobject.ProductStatus
 }
 
-// ListProducts 列出商品
+// ListProducts 列出商品，未指定状态时不包括已删除的商品
 func (s *ProductApplicationService) ListProducts(ctx context.Context, query ListProductsQuery) ([]*entity.Product, error) {
+	if query.Status == valueobject.ProductStatusDeleted {
+		products, err := s.productService.ListDeletedProducts(ctx)
+		if err != nil {
+			return nil, gerror.Wrap(err, "failed to list deleted products")
+		}
+		return products, nil
+	}
+
 	products, err := s.productService.ListProducts(ctx)
 	if err != nil {
 		return nil, gerror.Wrap(err, "failed to list products")
@@ -145,15 +323,80 @@ func (s *ProductApplicationService) ListProducts(ctx context.Context, query List
 	return products, nil
 }
 
+// SearchProductsQuery 搜索商品查询
+type SearchProductsQuery struct {
+	Keyword       string                    // 关键词，匹配商品名称和描述
+	Status        valueobject.ProductStatus // 商品状态，为空表示不限
+	CategoryId    string                    // 类目，包含其所有后代类目
+	MinPrice      *float64                  // 最低价格，为空表示不限
+	MaxPrice      *float64                  // 最高价格，为空表示不限
+	Currency      string                    // 价格区间的货币，为空时使用默认货币
+	InStock       bool                      // 是否仅返回有库存的商品
+	SortBy        valueobject.ProductSortField
+	SortDirection valueobject.SortDirection
+	Cursor        string // 上一页返回的游标，为空表示第一页
+	Limit         int
+}
+
+// SearchProducts 搜索商品
+func (s *ProductApplicationService) SearchProducts(
+	ctx context.Context,
+	query SearchProductsQuery,
+) (*repository.ProductSearchResult, error) {
+	criteria := valueobject.ProductSearchCriteria{
+		Keyword:       query.Keyword,
+		Status:        query.Status,
+		InStock:       query.InStock,
+		SortBy:        query.SortBy,
+		SortDirection: query.SortDirection,
+		Cursor:        query.Cursor,
+		Limit:         query.Limit,
+	}
+
+	// 1. 类目条件展开为整个子树
+	if query.CategoryId != "" {
+		categoryIds, err := s.categoryService.SubtreeIds(ctx, query.CategoryId)
+		if err != nil {
+			return nil, gerror.Wrap(err, "failed to get category")
+		}
+		criteria.CategoryIds = categoryIds
+	}
+
+	// 2. 转换价格区间
+	currency := currencyOrDefault(query.Currency)
+	if query.MinPrice != nil {
+		price, err := sharedvo.NewMoney(*query.MinPrice, currency)
+		if err != nil {
+			return nil, gerror.Wrap(err, "invalid min price")
+		}
+		criteria.MinPrice = price
+	}
+	if query.MaxPrice != nil {
+		price, err := sharedvo.NewMoney(*query.MaxPrice, currency)
+		if err != nil {
+			return nil, gerror.Wrap(err, "invalid max price")
+		}
+		criteria.MaxPrice = price
+	}
+
+	// 3. 调用领域服务搜索商品
+	result, err := s.productService.SearchProducts(ctx, criteria)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to search products")
+	}
+	return result, nil
+}
+
 // ReserveStockCommand 预留库存命令
 type ReserveStockCommand struct {
-	ProductId string
+	SkuId     string
 	Quantity  int
+	Reference string // 关联单据，记录在库存流水中
 }
 
 // ReserveStock 预留库存
 func (s *ProductApplicationService) ReserveStock(ctx context.Context, cmd ReserveStockCommand) error {
-	if err := s.productService.ReserveStock(ctx, cmd.ProductId, cmd.Quantity); err != nil {
+	if err := s.productService.ReserveStock(ctx, cmd.SkuId, cmd.Quantity, valueobject.SystemSource(cmd.Reference)); err != nil {
 		return gerror.Wrap(err, "failed to reserve stock")
 	}
 	return nil
@@ -161,14 +404,49 @@ func (s *ProductApplicationService) ReserveStock(ctx context.Context, cmd Reserv
 
 // ReleaseStockCommand 释放库存命令
 type ReleaseStockCommand struct {
-	ProductId string
+	SkuId     string
 	Quantity  int
+	Reference string // 关联单据，记录在库存流水中
 }
 
 // ReleaseStock 释放库存
 func (s *ProductApplicationService) ReleaseStock(ctx context.Context, cmd ReleaseStockCommand) error {
-	if err := s.productService.ReleaseStock(ctx, cmd.ProductId, cmd.Quantity); err != nil {
+	if err := s.productService.ReleaseStock(ctx, cmd.SkuId, cmd.Quantity, valueobject.SystemSource(cmd.Reference)); err != nil {
 		return gerror.Wrap(err, "failed to release stock")
 	}
 	return nil
 }
+
+// checkCategory 检查类目是否存在，类目 Id 为空表示未分类
+func (s *ProductApplicationService) checkCategory(ctx context.Context, categoryId string) error {
+	if categoryId == "" {
+		return nil
+	}
+	if _, err := s.categoryService.GetCategory(ctx, categoryId); err != nil {
+		return gerror.Wrap(err, "invalid product category")
+	}
+	return nil
+}
+
+// newSKU 将 SKU 命令转换为 SKU 实体
+func newSKU(currency string, cmd SKUCommand) (*entity.SKU, error) {
+	price, err := sharedvo.NewMoney(cmd.Price, currencyOrDefault(currency))
+	if err != nil {
+		return nil, gerror.Wrap(err, "invalid sku price")
+	}
+	return entity.NewSKU(
+		"", // Id 由商品服务在保存前分配
+		cmd.Options,
+		price,
+		cmd.Stock,
+		cmd.Barcode,
+	), nil
+}
+
+// currencyOrDefault 未指定货币时使用默认货币
+func currencyOrDefault(currency string) string {
+	if currency == "" {
+		return sharedvo.DefaultCurrency
+	}
+	return currency
+}
@@ -0,0 +1,81 @@
+package product
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"main/internal/domain/product/entity"
+	"main/internal/domain/product/valueobject"
+)
+
+// AdjustStockCommand 登记库存变动命令
+type AdjustStockCommand struct {
+	ProductId string
+	SkuId     string
+	Delta     int                        // 在库数量的增量，入库和退货必须为正
+	Reason    valueobject.MovementReason // 变动原因，仅限入库、人工调整和退货
+	Actor     string
+	Reference string // 关联单据，如入库单号、退货单号
+	Note      string
+}
+
+// AdjustStock 登记 SKU 的库存变动，同时记录库存流水
+// 已分仓管理的商品应通过库存应用服务按仓库调整，以保持仓库库存与商品汇总库存一致
+func (s *ProductApplicationService) AdjustStock(ctx context.Context, cmd AdjustStockCommand) (*entity.Product, error) {
+	product, err := s.productService.GetProduct(ctx, cmd.ProductId)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to get product")
+	}
+	if _, err = product.FindSKU(cmd.SkuId); err != nil {
+		return nil, gerror.Wrap(err, "failed to get sku")
+	}
+
+	product, err = s.productService.AdjustStock(
+		ctx,
+		valueobject.StockItem{SkuId: cmd.SkuId, Quantity: cmd.Delta},
+		cmd.Reason,
+		valueobject.MovementSource{Actor: cmd.Actor, Reference: cmd.Reference, Note: cmd.Note},
+	)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to adjust stock")
+	}
+	return product, nil
+}
+
+// ListStockMovementsQuery 获取库存流水查询
+type ListStockMovementsQuery struct {
+	ProductId string
+	SkuId     string // 为空时返回所有 SKU 的流水
+	Limit     int
+}
+
+// ListStockMovements 获取商品的库存流水，按时间倒序
+func (s *ProductApplicationService) ListStockMovements(
+	ctx context.Context,
+	query ListStockMovementsQuery,
+) ([]*entity.StockMovement, error) {
+	movements, err := s.productService.ListStockMovements(ctx, query.ProductId, query.SkuId, query.Limit)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to list stock movements")
+	}
+	return movements, nil
+}
+
+// ReconcileStockCommand 核对库存命令
+type ReconcileStockCommand struct {
+	ProductId string
+	Actor     string // 操作人，补记的期初流水以其名义记录
+}
+
+// ReconcileStock 核对商品库存与库存流水，返回不一致的 SKU
+func (s *ProductApplicationService) ReconcileStock(
+	ctx context.Context,
+	cmd ReconcileStockCommand,
+) ([]valueobject.StockDiscrepancy, error) {
+	discrepancies, err := s.productService.ReconcileStock(ctx, cmd.ProductId, valueobject.MovementSource{Actor: cmd.Actor})
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to reconcile stock")
+	}
+	return discrepancies, nil
+}
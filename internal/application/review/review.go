@@ -0,0 +1,177 @@
+package review
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	productservice "main/internal/domain/product/service"
+	productvo "main/internal/domain/product/valueobject"
+	"main/internal/domain/review/entity"
+	"main/internal/domain/review/service"
+	"main/internal/domain/review/valueobject"
+)
+
+// ReviewApplication 评价应用服务
+// 负责评价提交、审核以及审核后同步商品评分的用例编排
+type ReviewApplication struct {
+	reviewService  *service.ReviewService         // 评价领域服务
+	productService *productservice.ProductService // 商品领域服务，维护商品的评分汇总
+}
+
+// NewReviewApplication 创建评价应用服务实例
+func NewReviewApplication(
+	reviewService *service.ReviewService,
+	productService *productservice.ProductService,
+) *ReviewApplication {
+	return &ReviewApplication{
+		reviewService:  reviewService,
+		productService: productService,
+	}
+}
+
+// ReviewPage 评价分页结果
+type ReviewPage struct {
+	Reviews []*entity.Review
+	Total   int // 符合条件的评价总数
+	Page    int
+	PerPage int
+}
+
+// CreateReviewCommand 评价订单项命令
+type CreateReviewCommand struct {
+	UserId      string
+	OrderId     string
+	OrderItemId string // 被评价的订单项
+	Rating      int
+	Content     string
+}
+
+// CreateReview 评价已送达订单中的订单项，评价审核通过后才对外展示
+func (s *ReviewApplication) CreateReview(ctx context.Context, cmd CreateReviewCommand) (*entity.Review, error) {
+	review, err := s.reviewService.CreateReview(ctx, cmd.UserId, cmd.OrderId, cmd.OrderItemId, cmd.Rating, cmd.Content)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to create review")
+	}
+	return review, nil
+}
+
+// ModerateReviewCommand 审核评价命令
+type ModerateReviewCommand struct {
+	Id        string
+	Status    valueobject.ReviewStatus // 审核结果，通过或拒绝
+	Moderator string
+	Note      string
+}
+
+// ModerateReview 审核评价
+// 评价通过审核或撤销通过时重新汇总商品的评分
+func (s *ReviewApplication) ModerateReview(ctx context.Context, cmd ModerateReviewCommand) (*entity.Review, error) {
+	// 1. 审核评价
+	review, from, err := s.reviewService.Moderate(ctx, cmd.Id, cmd.Status, cmd.Moderator, cmd.Note)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to moderate review")
+	}
+
+	// 2. 同步商品评分
+	if from == valueobject.ReviewStatusApproved || review.IsApproved() {
+		if err = s.refreshRating(ctx, review.ProductId); err != nil {
+			return nil, gerror.Wrap(err, "failed to update product rating")
+		}
+	}
+	return review, nil
+}
+
+// refreshRating 根据审核通过的评价重新汇总商品评分
+// 每次都从评价重新汇总而不是增量累加，并发审核或中途失败后下一次审核即可恢复正确的评分
+func (s *ReviewApplication) refreshRating(ctx context.Context, productId string) error {
+	count, total, err := s.reviewService.SummarizeRating(ctx, productId)
+	if err != nil {
+		return err
+	}
+	return s.productService.UpdateRating(ctx, productId, productvo.NewProductRating(count, total))
+}
+
+// GetReviewQuery 获取评价查询
+type GetReviewQuery struct {
+	Id string
+}
+
+// GetReview 获取评价
+func (s *ReviewApplication) GetReview(ctx context.Context, query GetReviewQuery) (*entity.Review, error) {
+	review, err := s.reviewService.GetReview(ctx, query.Id)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to get review")
+	}
+	return review, nil
+}
+
+// ListProductReviewsQuery 获取商品评价查询
+type ListProductReviewsQuery struct {
+	ProductId string
+	Rating    int // 评分，为 0 时不过滤
+	Page      int
+	PerPage   int
+}
+
+// ListProductReviews 分页获取商品审核通过的评价，按评价时间倒序
+func (s *ReviewApplication) ListProductReviews(ctx context.Context, query ListProductReviewsQuery) (*ReviewPage, error) {
+	return s.listReviews(ctx, valueobject.ReviewCriteria{
+		ProductId: query.ProductId,
+		Status:    valueobject.ReviewStatusApproved,
+		Rating:    query.Rating,
+		Page:      query.Page,
+		PerPage:   query.PerPage,
+	})
+}
+
+// ListUserReviewsQuery 获取用户评价查询
+type ListUserReviewsQuery struct {
+	UserId  string
+	Page    int
+	PerPage int
+}
+
+// ListUserReviews 分页获取用户提交的所有评价，包括待审核和被拒绝的评价
+func (s *ReviewApplication) ListUserReviews(ctx context.Context, query ListUserReviewsQuery) (*ReviewPage, error) {
+	return s.listReviews(ctx, valueobject.ReviewCriteria{
+		UserId:  query.UserId,
+		Page:    query.Page,
+		PerPage: query.PerPage,
+	})
+}
+
+// ListReviewsQuery 评价管理查询
+type ListReviewsQuery struct {
+	ProductId string                   // 商品，为空时不过滤
+	Status    valueobject.ReviewStatus // 审核状态，为空时不过滤
+	Page      int
+	PerPage   int
+}
+
+// ListReviews 分页获取评价，用于评价审核
+func (s *ReviewApplication) ListReviews(ctx context.Context, query ListReviewsQuery) (*ReviewPage, error) {
+	return s.listReviews(ctx, valueobject.ReviewCriteria{
+		ProductId: query.ProductId,
+		Status:    query.Status,
+		Page:      query.Page,
+		PerPage:   query.PerPage,
+	})
+}
+
+// listReviews 按条件分页查找评价
+func (s *ReviewApplication) listReviews(ctx context.Context, criteria valueobject.ReviewCriteria) (*ReviewPage, error) {
+	if err := criteria.Normalize(); err != nil {
+		return nil, err
+	}
+	reviews, total, err := s.reviewService.ListReviews(ctx, criteria)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to list reviews")
+	}
+	return &ReviewPage{
+		Reviews: reviews,
+		Total:   total,
+		Page:    criteria.Page,
+		PerPage: criteria.PerPage,
+	}, nil
+}
@@ -0,0 +1,142 @@
+package entity
+
+import (
+	"strings"
+	"time"
+
+	"main/internal/domain/category/valueobject"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// PathSeparator 类目路径分隔符
+const PathSeparator = "/"
+
+// Category 类目聚合根
+// 类目以树形结构组织，Path 保存从根类目到父类目的 Id 路径（物化路径），
+// 如 "/a/b/" 表示该类目的父类目是 b，b 的父类目是 a；根类目的 Path 为 "/"。
+// 物化路径使得查询整棵子树只需一次前缀匹配。
+type Category struct {
+	Id        string
+	Name      string
+	ParentId  string // 父类目 Id，根类目为空
+	Path      string // 祖先类目的 Id 路径，以分隔符开头和结尾
+	SortOrder int    // 同级类目中的排序，数值越小越靠前
+	CreatedAt int64
+	UpdatedAt int64
+}
+
+// NewCategory 创建类目，parent 为 nil 时创建根类目
+func NewCategory(id string, name string, parent *Category, sortOrder int) *Category {
+	now := time.Now().UnixMilli()
+	category := &Category{
+		Id:        id,
+		Name:      name,
+		Path:      PathSeparator,
+		SortOrder: sortOrder,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if parent != nil {
+		category.ParentId = parent.Id
+		category.Path = parent.SubtreePath()
+	}
+	return category
+}
+
+// IsRoot 判断是否为根类目
+func (c *Category) IsRoot() bool {
+	return c.ParentId == ""
+}
+
+// Level 获取类目层级，根类目为 1
+func (c *Category) Level() int {
+	return strings.Count(c.Path, PathSeparator)
+}
+
+// AncestorIds 获取从根类目到父类目的 Id 列表
+func (c *Category) AncestorIds() []string {
+	trimmed := strings.Trim(c.Path, PathSeparator)
+	if trimmed == "" {
+		return nil
+	}
+	return strings.Split(trimmed, PathSeparator)
+}
+
+// SubtreePath 获取子类目的路径，所有后代类目的 Path 都以此为前缀
+func (c *Category) SubtreePath() string {
+	return c.Path + c.Id + PathSeparator
+}
+
+// IsDescendantOf 判断是否为指定类目的后代类目
+func (c *Category) IsDescendantOf(ancestor *Category) bool {
+	return strings.HasPrefix(c.Path, ancestor.SubtreePath())
+}
+
+// Rename 重命名类目
+func (c *Category) Rename(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return valueobject.ErrInvalidCategoryName
+	}
+	c.Name = name
+	c.UpdatedAt = time.Now().UnixMilli()
+	return nil
+}
+
+// SetSortOrder 设置类目在同级中的排序
+func (c *Category) SetSortOrder(sortOrder int) {
+	c.SortOrder = sortOrder
+	c.UpdatedAt = time.Now().UnixMilli()
+}
+
+// MoveTo 将类目移动到新的父类目下，parent 为 nil 时移动为根类目
+// 不能移动到自身或自身的后代类目下。返回移动前后的子类目路径，
+// 调用方需使用 Rebase 更新所有后代类目的路径以保持一致。
+func (c *Category) MoveTo(parent *Category) (oldSubtreePath, newSubtreePath string, err error) {
+	oldSubtreePath = c.SubtreePath()
+	if parent == nil {
+		c.ParentId = ""
+		c.Path = PathSeparator
+	} else {
+		if parent.Id == c.Id || parent.IsDescendantOf(c) {
+			return "", "", gerror.Wrapf(valueobject.ErrInvalidCategoryMove,
+				"cannot move category %s under itself or its descendant %s", c.Id, parent.Id,
+			)
+		}
+		c.ParentId = parent.Id
+		c.Path = parent.SubtreePath()
+	}
+	c.UpdatedAt = time.Now().UnixMilli()
+	return oldSubtreePath, c.SubtreePath(), nil
+}
+
+// Rebase 祖先类目移动后，将路径中旧的前缀替换为新的前缀
+func (c *Category) Rebase(oldPrefix, newPrefix string) error {
+	if !strings.HasPrefix(c.Path, oldPrefix) {
+		return gerror.Wrapf(valueobject.ErrInvalidCategoryMove,
+			"category %s path %s does not start with %s", c.Id, c.Path, oldPrefix,
+		)
+	}
+	c.Path = newPrefix + strings.TrimPrefix(c.Path, oldPrefix)
+	c.UpdatedAt = time.Now().UnixMilli()
+	return nil
+}
+
+// Validate 验证类目
+func (c *Category) Validate() error {
+	if c.Id == "" {
+		return gerror.New("category id is required")
+	}
+	if strings.TrimSpace(c.Name) == "" {
+		return valueobject.ErrInvalidCategoryName
+	}
+	if !strings.HasPrefix(c.Path, PathSeparator) || !strings.HasSuffix(c.Path, PathSeparator) {
+		return gerror.Wrapf(valueobject.ErrInvalidCategoryMove, "invalid category path: %s", c.Path)
+	}
+	if c.IsRoot() != (c.Path == PathSeparator) {
+		return gerror.Wrapf(valueobject.ErrInvalidCategoryMove,
+			"category path %s does not match parent %q", c.Path, c.ParentId,
+		)
+	}
+	return nil
+}
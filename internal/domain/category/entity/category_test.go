@@ -0,0 +1,82 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+
+	"main/internal/domain/category/valueobject"
+)
+
+func TestCategoryMoveTo(t *testing.T) {
+	// 类目树：a -> b -> c，另有根类目 x
+	a := NewCategory("a", "A", nil, 0)
+	b := NewCategory("b", "B", a, 0)
+	c := NewCategory("c", "C", b, 0)
+	x := NewCategory("x", "X", nil, 0)
+
+	tests := []struct {
+		name       string
+		category   *Category
+		parent     *Category
+		wantOld    string
+		wantNew    string
+		wantPath   string
+		wantParent string
+		wantErr    error
+	}{
+		{"move under another root", b, x, "/a/b/", "/x/b/", "/x/", "x", nil},
+		{"move to root", c, nil, "/a/b/c/", "/c/", "/", "", nil},
+		{"move under itself", a, a, "", "", "/", "", valueobject.ErrInvalidCategoryMove},
+		{"move under descendant", a, c, "", "", "/", "", valueobject.ErrInvalidCategoryMove},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			category := *tt.category
+			oldPath, newPath, err := category.MoveTo(tt.parent)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("MoveTo() error = %v, want %v", err, tt.wantErr)
+			}
+			if oldPath != tt.wantOld || newPath != tt.wantNew {
+				t.Errorf("MoveTo() = (%q, %q), want (%q, %q)", oldPath, newPath, tt.wantOld, tt.wantNew)
+			}
+			if category.Path != tt.wantPath || category.ParentId != tt.wantParent {
+				t.Errorf("path = %q, parent = %q, want %q, %q", category.Path, category.ParentId, tt.wantPath, tt.wantParent)
+			}
+			if err == nil {
+				if err := category.Validate(); err != nil {
+					t.Errorf("Validate() error = %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestCategoryRebase(t *testing.T) {
+	tests := []struct {
+		name      string
+		path      string
+		oldPrefix string
+		newPrefix string
+		wantPath  string
+		wantErr   error
+	}{
+		{"child of moved category", "/a/b/", "/a/b/", "/x/b/", "/x/b/", nil},
+		{"deep descendant", "/a/b/c/d/", "/a/b/", "/x/b/", "/x/b/c/d/", nil},
+		{"subtree moved to root", "/a/b/c/", "/a/b/", "/b/", "/b/c/", nil},
+		{"subtree moved deeper", "/b/c/", "/b/", "/x/y/b/", "/x/y/b/c/", nil},
+		{"sibling id sharing a prefix is not descendant", "/a/bb/", "/a/b/", "/x/b/", "/a/bb/", valueobject.ErrInvalidCategoryMove},
+		{"unrelated path", "/y/", "/a/b/", "/x/b/", "/y/", valueobject.ErrInvalidCategoryMove},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			category := &Category{Id: "n", Name: "N", ParentId: "p", Path: tt.path}
+			err := category.Rebase(tt.oldPrefix, tt.newPrefix)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Rebase() error = %v, want %v", err, tt.wantErr)
+			}
+			if category.Path != tt.wantPath {
+				t.Errorf("path = %q, want %q", category.Path, tt.wantPath)
+			}
+		})
+	}
+}
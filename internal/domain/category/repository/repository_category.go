@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+
+	"main/internal/domain/category/entity"
+)
+
+// CategoryRepository 类目仓储接口
+type CategoryRepository interface {
+	// Save 保存类目
+	Save(ctx context.Context, category *entity.Category) error
+	// FindById 根据Id查找类目
+	FindById(ctx context.Context, id string) (*entity.Category, error)
+	// FindAll 查找所有类目
+	FindAll(ctx context.Context) ([]*entity.Category, error)
+	// FindByParentId 查找父类目下的直接子类目，父类目 Id 为空时查找根类目
+	FindByParentId(ctx context.Context, parentId string) ([]*entity.Category, error)
+	// FindByPathPrefix 查找路径以指定前缀开头的类目，用于查找整棵子树的后代类目
+	FindByPathPrefix(ctx context.Context, prefix string) ([]*entity.Category, error)
+	// Delete 删除类目
+	Delete(ctx context.Context, id string) error
+}
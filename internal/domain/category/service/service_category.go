@@ -0,0 +1,239 @@
+package service
+
+import (
+	"context"
+	"sort"
+
+	"main/internal/domain/category/entity"
+	"main/internal/domain/category/repository"
+	"main/internal/domain/category/valueobject"
+	sharedservice "main/internal/domain/shared/service"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// CategoryService 类目领域服务
+// 负责维护类目树，保证移动和排序后各类目的路径与层级关系一致
+type CategoryService struct {
+	categoryRepo repository.CategoryRepository
+	idGenerator  sharedservice.IdGenerator // 类目 Id 生成器
+}
+
+// NewCategoryService 创建类目领域服务实例
+func NewCategoryService(
+	categoryRepo repository.CategoryRepository,
+	idGenerator sharedservice.IdGenerator,
+) *CategoryService {
+	return &CategoryService{
+		categoryRepo: categoryRepo,
+		idGenerator:  idGenerator,
+	}
+}
+
+// CreateCategory 创建类目，父类目 Id 为空时创建根类目
+func (s *CategoryService) CreateCategory(
+	ctx context.Context,
+	name string,
+	parentId string,
+	sortOrder int,
+) (*entity.Category, error) {
+	parent, err := s.findParent(ctx, parentId)
+	if err != nil {
+		return nil, err
+	}
+
+	category := entity.NewCategory(s.idGenerator.NewId(), name, parent, sortOrder)
+	if err = category.Validate(); err != nil {
+		return nil, err
+	}
+	if err = s.categoryRepo.Save(ctx, category); err != nil {
+		return nil, gerror.Wrap(err, "failed to save category")
+	}
+	return category, nil
+}
+
+// RenameCategory 重命名类目
+func (s *CategoryService) RenameCategory(ctx context.Context, id string, name string) (*entity.Category, error) {
+	category, err := s.categoryRepo.FindById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err = category.Rename(name); err != nil {
+		return nil, err
+	}
+	if err = s.categoryRepo.Save(ctx, category); err != nil {
+		return nil, gerror.Wrap(err, "failed to save category")
+	}
+	return category, nil
+}
+
+// MoveCategory 将类目连同其子树移动到新的父类目下，父类目 Id 为空时移动为根类目
+func (s *CategoryService) MoveCategory(
+	ctx context.Context,
+	id string,
+	parentId string,
+	sortOrder int,
+) (*entity.Category, error) {
+	category, err := s.categoryRepo.FindById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	parent, err := s.findParent(ctx, parentId)
+	if err != nil {
+		return nil, err
+	}
+
+	// 1. 查找移动前的所有后代类目
+	descendants, err := s.categoryRepo.FindByPathPrefix(ctx, category.SubtreePath())
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to find descendant categories")
+	}
+
+	// 2. 移动类目并更新所有后代类目的路径
+	oldPrefix, newPrefix, err := category.MoveTo(parent)
+	if err != nil {
+		return nil, err
+	}
+	category.SetSortOrder(sortOrder)
+	for _, descendant := range descendants {
+		if err = descendant.Rebase(oldPrefix, newPrefix); err != nil {
+			return nil, err
+		}
+	}
+
+	// 3. 保存类目及其后代类目
+	if err = s.categoryRepo.Save(ctx, category); err != nil {
+		return nil, gerror.Wrap(err, "failed to save category")
+	}
+	for _, descendant := range descendants {
+		if err = s.categoryRepo.Save(ctx, descendant); err != nil {
+			return nil, gerror.Wrapf(err, "failed to save descendant category %s", descendant.Id)
+		}
+	}
+	return category, nil
+}
+
+// ReorderCategories 按给定顺序重排父类目下的直接子类目
+// categoryIds 必须恰好包含该父类目下的所有直接子类目
+func (s *CategoryService) ReorderCategories(
+	ctx context.Context,
+	parentId string,
+	categoryIds []string,
+) ([]*entity.Category, error) {
+	children, err := s.categoryRepo.FindByParentId(ctx, parentId)
+	if err != nil {
+		return nil, err
+	}
+	if len(children) != len(categoryIds) {
+		return nil, gerror.Wrapf(valueobject.ErrInvalidCategoryOrder,
+			"expected %d categories, got %d", len(children), len(categoryIds),
+		)
+	}
+
+	childById := make(map[string]*entity.Category, len(children))
+	for _, child := range children {
+		childById[child.Id] = child
+	}
+	ordered := make([]*entity.Category, len(categoryIds))
+	for i, id := range categoryIds {
+		child, ok := childById[id]
+		if !ok {
+			return nil, gerror.Wrapf(valueobject.ErrInvalidCategoryOrder,
+				"category %s is not a child of %q or is duplicated", id, parentId,
+			)
+		}
+		delete(childById, id)
+		child.SetSortOrder(i)
+		ordered[i] = child
+	}
+
+	for _, child := range ordered {
+		if err = s.categoryRepo.Save(ctx, child); err != nil {
+			return nil, gerror.Wrap(err, "failed to save category")
+		}
+	}
+	return ordered, nil
+}
+
+// GetCategory 获取类目
+func (s *CategoryService) GetCategory(ctx context.Context, id string) (*entity.Category, error) {
+	return s.categoryRepo.FindById(ctx, id)
+}
+
+// ListCategories 获取所有类目，按层级和同级排序排列
+func (s *CategoryService) ListCategories(ctx context.Context) ([]*entity.Category, error) {
+	categories, err := s.categoryRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	SortCategories(categories)
+	return categories, nil
+}
+
+// ListChildren 获取父类目下的直接子类目，按同级排序排列
+func (s *CategoryService) ListChildren(ctx context.Context, parentId string) ([]*entity.Category, error) {
+	children, err := s.categoryRepo.FindByParentId(ctx, parentId)
+	if err != nil {
+		return nil, err
+	}
+	SortCategories(children)
+	return children, nil
+}
+
+// SubtreeIds 获取类目及其所有后代类目的 Id
+func (s *CategoryService) SubtreeIds(ctx context.Context, id string) ([]string, error) {
+	category, err := s.categoryRepo.FindById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	descendants, err := s.categoryRepo.FindByPathPrefix(ctx, category.SubtreePath())
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to find descendant categories")
+	}
+
+	ids := make([]string, 0, len(descendants)+1)
+	ids = append(ids, category.Id)
+	for _, descendant := range descendants {
+		ids = append(ids, descendant.Id)
+	}
+	return ids, nil
+}
+
+// DeleteCategory 删除类目，只能删除没有子类目的类目
+// 类目下是否还有商品由调用方检查
+func (s *CategoryService) DeleteCategory(ctx context.Context, id string) error {
+	children, err := s.categoryRepo.FindByParentId(ctx, id)
+	if err != nil {
+		return err
+	}
+	if len(children) > 0 {
+		return gerror.Wrapf(valueobject.ErrCategoryNotEmpty, "category %s has %d sub categories", id, len(children))
+	}
+	return s.categoryRepo.Delete(ctx, id)
+}
+
+// findParent 查找父类目，Id 为空时返回 nil 表示根
+func (s *CategoryService) findParent(ctx context.Context, parentId string) (*entity.Category, error) {
+	if parentId == "" {
+		return nil, nil
+	}
+	parent, err := s.categoryRepo.FindById(ctx, parentId)
+	if err != nil {
+		return nil, gerror.Wrapf(err, "failed to find parent category %s", parentId)
+	}
+	return parent, nil
+}
+
+// SortCategories 按层级、同级排序和名称排列类目
+func SortCategories(categories []*entity.Category) {
+	sort.SliceStable(categories, func(i, j int) bool {
+		a, b := categories[i], categories[j]
+		if a.Level() != b.Level() {
+			return a.Level() < b.Level()
+		}
+		if a.SortOrder != b.SortOrder {
+			return a.SortOrder < b.SortOrder
+		}
+		return a.Name < b.Name
+	})
+}
@@ -0,0 +1,12 @@
+package valueobject
+
+import "github.com/gogf/gf/v2/errors/gerror"
+
+// 类目领域错误定义
+var (
+	ErrCategoryNotFound     = gerror.New("category not found")
+	ErrInvalidCategoryName  = gerror.New("invalid category name")
+	ErrInvalidCategoryMove  = gerror.New("invalid category move")
+	ErrInvalidCategoryOrder = gerror.New("invalid category order")
+	ErrCategoryNotEmpty     = gerror.New("category is not empty")
+)
@@ -0,0 +1,112 @@
+package entity
+
+import (
+	"time"
+
+	"main/internal/domain/flashsale/valueobject"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// FlashSale 秒杀活动聚合根
+// 在活动时间内以秒杀价出售选定的 SKU，每个 SKU 有独立的活动库存和每个用户的限购数量
+type FlashSale struct {
+	Id        string
+	Name      string
+	StartAt   int64 // 开始时间（毫秒）
+	EndAt     int64 // 结束时间（毫秒）
+	Status    valueobject.FlashSaleStatus
+	Items     []*FlashSaleItem
+	CreatedAt int64
+	UpdatedAt int64
+}
+
+// NewFlashSale 创建秒杀活动
+func NewFlashSale(id string, name string, startAt int64, endAt int64, items []*FlashSaleItem) *FlashSale {
+	now := time.Now().UnixMilli()
+	return &FlashSale{
+		Id:        id,
+		Name:      name,
+		StartAt:   startAt,
+		EndAt:     endAt,
+		Status:    valueobject.FlashSaleStatusActive,
+		Items:     items,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// ItemOf 获取 SKU 的活动商品，不存在时返回 nil
+func (s *FlashSale) ItemOf(skuId string) *FlashSaleItem {
+	for _, item := range s.Items {
+		if item.SkuId == skuId {
+			return item
+		}
+	}
+	return nil
+}
+
+// IsEffectiveAt 判断活动在指定时间（毫秒）是否进行中
+func (s *FlashSale) IsEffectiveAt(at int64) bool {
+	return s.Status == valueobject.FlashSaleStatusActive && at >= s.StartAt && at < s.EndAt
+}
+
+// Overlaps 判断两个未取消的活动是否在重叠的时间段内出售同一 SKU
+func (s *FlashSale) Overlaps(other *FlashSale) bool {
+	if s.Status != valueobject.FlashSaleStatusActive || other.Status != valueobject.FlashSaleStatusActive {
+		return false
+	}
+	if s.StartAt >= other.EndAt || other.StartAt >= s.EndAt {
+		return false
+	}
+	for _, item := range s.Items {
+		if other.ItemOf(item.SkuId) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Cancel 取消活动，已下单的购买记录不受影响
+func (s *FlashSale) Cancel() error {
+	if s.Status == valueobject.FlashSaleStatusCancelled {
+		return gerror.Wrapf(valueobject.ErrFlashSaleNotActive, "flash sale %s is already cancelled", s.Id)
+	}
+	if time.Now().UnixMilli() >= s.EndAt {
+		return gerror.Wrapf(valueobject.ErrFlashSaleNotActive, "flash sale %s has already ended", s.Id)
+	}
+	s.Status = valueobject.FlashSaleStatusCancelled
+	s.UpdatedAt = time.Now().UnixMilli()
+	return nil
+}
+
+// Validate 验证秒杀活动
+func (s *FlashSale) Validate() error {
+	if s.Id == "" {
+		return gerror.Wrap(valueobject.ErrInvalidFlashSale, "flash sale id is required")
+	}
+	if s.Name == "" {
+		return valueobject.ErrInvalidFlashSaleName
+	}
+	if !s.Status.IsValid() {
+		return gerror.Wrapf(valueobject.ErrInvalidFlashSale, "invalid status: %s", s.Status)
+	}
+	if s.StartAt <= 0 || s.EndAt <= s.StartAt {
+		return gerror.Wrapf(valueobject.ErrInvalidFlashSale, "invalid period from %d to %d", s.StartAt, s.EndAt)
+	}
+	if len(s.Items) == 0 {
+		return gerror.Wrap(valueobject.ErrInvalidFlashSale, "flash sale has no item")
+	}
+
+	seen := make(map[string]bool, len(s.Items))
+	for _, item := range s.Items {
+		if err := item.Validate(); err != nil {
+			return err
+		}
+		if seen[item.SkuId] {
+			return gerror.Wrapf(valueobject.ErrInvalidFlashSaleItem, "duplicate sku %s", item.SkuId)
+		}
+		seen[item.SkuId] = true
+	}
+	return nil
+}
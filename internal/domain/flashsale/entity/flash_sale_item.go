@@ -0,0 +1,65 @@
+package entity
+
+import (
+	"main/internal/domain/flashsale/valueobject"
+	sharedvo "main/internal/domain/shared/valueobject"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// FlashSaleItem 秒杀活动中的一个 SKU
+// 活动库存独立于商品库存，只限制以秒杀价售出的数量，下单时仍需预占商品库存
+type FlashSaleItem struct {
+	ProductId    string
+	SkuId        string
+	Price        *sharedvo.Money // 秒杀价
+	Stock        int             // 活动库存
+	Sold         int             // 已被订单占用的活动库存，包括未支付的订单
+	PerUserLimit int             // 每个用户在本活动中可购买的数量，0 表示不限购
+}
+
+// NewFlashSaleItem 创建秒杀活动商品
+func NewFlashSaleItem(productId, skuId string, price *sharedvo.Money, stock int, perUserLimit int) *FlashSaleItem {
+	return &FlashSaleItem{
+		ProductId:    productId,
+		SkuId:        skuId,
+		Price:        price,
+		Stock:        stock,
+		PerUserLimit: perUserLimit,
+	}
+}
+
+// Remaining 剩余的活动库存
+func (i *FlashSaleItem) Remaining() int {
+	return i.Stock - i.Sold
+}
+
+// IsLimited 是否限购
+func (i *FlashSaleItem) IsLimited() bool {
+	return i.PerUserLimit > 0
+}
+
+// Validate 验证秒杀活动商品
+func (i *FlashSaleItem) Validate() error {
+	if i.ProductId == "" || i.SkuId == "" {
+		return gerror.Wrap(valueobject.ErrInvalidFlashSaleItem, "product and sku are required")
+	}
+	if i.Price == nil {
+		return gerror.Wrapf(valueobject.ErrInvalidFlashSaleItem, "price of sku %s is required", i.SkuId)
+	}
+	if err := i.Price.Validate(); err != nil {
+		return gerror.Wrapf(err, "invalid price of sku %s", i.SkuId)
+	}
+	if i.Stock <= 0 {
+		return gerror.Wrapf(valueobject.ErrInvalidFlashSaleItem, "stock of sku %s must be positive: %d", i.SkuId, i.Stock)
+	}
+	if i.Sold < 0 || i.Sold > i.Stock {
+		return gerror.Wrapf(valueobject.ErrInvalidFlashSaleItem, "sold of sku %s out of range: %d", i.SkuId, i.Sold)
+	}
+	if i.PerUserLimit < 0 {
+		return gerror.Wrapf(valueobject.ErrInvalidFlashSaleItem,
+			"per user limit of sku %s must not be negative: %d", i.SkuId, i.PerUserLimit,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"main/internal/domain/flashsale/valueobject"
+)
+
+// Purchase 秒杀购买记录
+// 订单以秒杀价购买时占用活动库存和用户的限购名额，支付后确认，取消或超时未支付时归还
+type Purchase struct {
+	Id          string
+	FlashSaleId string
+	OrderId     string
+	UserId      string
+	ProductId   string
+	SkuId       string
+	Quantity    int
+	State       valueobject.PurchaseState
+	ExpiresAt   int64 // 过期时间，过期未支付的购买记录将被自动释放
+	CreatedAt   int64
+	UpdatedAt   int64
+}
+
+// NewPurchase 创建处于占用中状态的秒杀购买记录
+func NewPurchase(id, orderId, userId string, line valueobject.PurchaseLine, expiresAt int64) *Purchase {
+	now := time.Now().UnixMilli()
+	return &Purchase{
+		Id:          id,
+		FlashSaleId: line.FlashSaleId,
+		OrderId:     orderId,
+		UserId:      userId,
+		ProductId:   line.ProductId,
+		SkuId:       line.SkuId,
+		Quantity:    line.Quantity,
+		State:       valueobject.PurchaseStateHeld,
+		ExpiresAt:   expiresAt,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+// IsHeld 检查是否处于占用中
+func (p *Purchase) IsHeld() bool {
+	return p.State == valueobject.PurchaseStateHeld
+}
+
+// IsExpired 检查购买记录是否已过期
+func (p *Purchase) IsExpired() bool {
+	return p.IsHeld() && time.Now().UnixMilli() >= p.ExpiresAt
+}
+
+// Commit 确认购买，过期的购买记录不能确认
+func (p *Purchase) Commit() error {
+	if !p.IsHeld() {
+		return gerror.Wrapf(valueobject.ErrInvalidPurchase,
+			"cannot commit flash sale purchase %s in state %s", p.Id, p.State,
+		)
+	}
+	if p.IsExpired() {
+		return gerror.Wrapf(valueobject.ErrPurchaseExpired, "flash sale purchase %s has expired", p.Id)
+	}
+	p.State = valueobject.PurchaseStateCommitted
+	p.UpdatedAt = time.Now().UnixMilli()
+	return nil
+}
+
+// Revert 撤销确认，使购买记录回到占用中，用于确认后订单支付失败
+func (p *Purchase) Revert() error {
+	if p.State != valueobject.PurchaseStateCommitted {
+		return gerror.Wrapf(valueobject.ErrInvalidPurchase,
+			"cannot revert flash sale purchase %s in state %s", p.Id, p.State,
+		)
+	}
+	p.State = valueobject.PurchaseStateHeld
+	p.UpdatedAt = time.Now().UnixMilli()
+	return nil
+}
+
+// Release 释放购买记录
+func (p *Purchase) Release() error {
+	if !p.IsHeld() {
+		return gerror.Wrapf(valueobject.ErrInvalidPurchase,
+			"cannot release flash sale purchase %s in state %s", p.Id, p.State,
+		)
+	}
+	p.State = valueobject.PurchaseStateReleased
+	p.UpdatedAt = time.Now().UnixMilli()
+	return nil
+}
+
+// Validate 验证秒杀购买记录
+func (p *Purchase) Validate() error {
+	if p.Id == "" || p.FlashSaleId == "" || p.OrderId == "" || p.UserId == "" || p.SkuId == "" {
+		return gerror.Wrap(valueobject.ErrInvalidPurchase, "id, flash sale, order, user and sku are required")
+	}
+	if p.Quantity <= 0 {
+		return gerror.Wrapf(valueobject.ErrInvalidPurchase, "quantity must be positive: %d", p.Quantity)
+	}
+	if !p.State.IsValid() {
+		return gerror.Wrapf(valueobject.ErrInvalidPurchase, "invalid state: %s", p.State)
+	}
+	return nil
+}
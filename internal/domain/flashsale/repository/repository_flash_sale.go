@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+
+	"main/internal/domain/flashsale/entity"
+)
+
+// FlashSaleRepository 秒杀活动仓储接口
+// 活动库存的变更均为带条件的原子更新，高并发下单不会超卖
+type FlashSaleRepository interface {
+	// Save 保存新的秒杀活动
+	Save(ctx context.Context, flashSale *entity.FlashSale) error
+	// FindById 根据Id查找秒杀活动
+	FindById(ctx context.Context, id string) (*entity.FlashSale, error)
+	// FindAll 查找所有秒杀活动，按开始时间倒序
+	FindAll(ctx context.Context) ([]*entity.FlashSale, error)
+	// FindBySkuIds 查找包含任一 SKU 且尚未结束的未取消活动
+	FindBySkuIds(ctx context.Context, skuIds []string, now int64) ([]*entity.FlashSale, error)
+	// FindEffective 查找在 at 时进行中且包含该 SKU 的活动，不存在时返回 nil
+	FindEffective(ctx context.Context, skuId string, at int64) (*entity.FlashSale, error)
+	// UpdateStatus 保存活动的状态，不影响并发变更的活动库存
+	UpdateStatus(ctx context.Context, flashSale *entity.FlashSale) error
+	// ReserveStock 仅当活动在 at 时进行中且 SKU 剩余的活动库存充足时占用活动库存，
+	// 否则返回 valueobject.ErrFlashSaleNotActive 或 valueobject.ErrFlashSaleSoldOut
+	ReserveStock(ctx context.Context, id string, skuId string, quantity int, at int64) error
+	// ReleaseStock 归还 SKU 占用的活动库存
+	ReleaseStock(ctx context.Context, id string, skuId string, quantity int) error
+}
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+
+	"main/internal/domain/flashsale/entity"
+	"main/internal/domain/flashsale/valueobject"
+)
+
+// PurchaseRepository 秒杀购买记录仓储接口
+// 同时维护每个用户在活动中对每个 SKU 已占用的限购名额
+type PurchaseRepository interface {
+	// Save 保存新的购买记录
+	Save(ctx context.Context, purchase *entity.Purchase) error
+	// FindByOrderId 查找订单的购买记录
+	FindByOrderId(ctx context.Context, orderId string) ([]*entity.Purchase, error)
+	// FindByFlashSaleId 查找活动的购买记录，按创建时间倒序
+	FindByFlashSaleId(ctx context.Context, flashSaleId string) ([]*entity.Purchase, error)
+	// FindExpired 查找在 now 之前已过期但仍处于占用中的记录，最多返回 limit 条
+	FindExpired(ctx context.Context, now int64, limit int) ([]*entity.Purchase, error)
+	// UpdateState 仅当购买记录当前处于 from 状态时保存其新状态，
+	// 否则返回 valueobject.ErrPurchaseConflict，用于避免支付、取消与过期释放并发处理同一记录
+	UpdateState(ctx context.Context, purchase *entity.Purchase, from valueobject.PurchaseState) error
+	// Commit 仅当购买记录处于占用中且在 now 时尚未过期时将其保存为已确认，
+	// 否则返回 valueobject.ErrPurchaseConflict，检查与确认在同一次条件更新中完成
+	Commit(ctx context.Context, purchase *entity.Purchase, now int64) error
+	// ClaimQuota 仅当用户已占用的名额加上 quantity 不超过 limit 时原子地占用名额，
+	// 否则返回 valueobject.ErrPurchaseLimitExceeded
+	ClaimQuota(ctx context.Context, flashSaleId, skuId, userId string, quantity int, limit int) error
+	// ReturnQuota 归还用户占用的名额
+	ReturnQuota(ctx context.Context, flashSaleId, skuId, userId string, quantity int) error
+}
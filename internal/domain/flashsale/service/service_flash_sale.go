@@ -0,0 +1,348 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"main/internal/domain/flashsale/entity"
+	"main/internal/domain/flashsale/repository"
+	"main/internal/domain/flashsale/valueobject"
+	sharedservice "main/internal/domain/shared/service"
+)
+
+// expiredBatchSize 每批释放的过期购买记录数量
+const expiredBatchSize = 100
+
+// FlashSaleService 秒杀领域服务
+// 负责秒杀活动的维护，以及下单时原子地占用活动库存和用户的限购名额，
+// 订单取消或超时未支付时归还
+type FlashSaleService struct {
+	flashSaleRepo repository.FlashSaleRepository
+	purchaseRepo  repository.PurchaseRepository
+	holdDuration  time.Duration             // 购买记录的保留时长
+	idGenerator   sharedservice.IdGenerator // 秒杀活动和购买记录的 Id 生成器
+}
+
+// NewFlashSaleService 创建秒杀领域服务实例
+// holdDuration 应与库存预占的保留时长一致，不大于 0 时使用默认的保留时长
+func NewFlashSaleService(
+	flashSaleRepo repository.FlashSaleRepository,
+	purchaseRepo repository.PurchaseRepository,
+	holdDuration time.Duration,
+	idGenerator sharedservice.IdGenerator,
+) *FlashSaleService {
+	if holdDuration <= 0 {
+		holdDuration = valueobject.DefaultPurchaseHold
+	}
+	return &FlashSaleService{
+		flashSaleRepo: flashSaleRepo,
+		purchaseRepo:  purchaseRepo,
+		holdDuration:  holdDuration,
+		idGenerator:   idGenerator,
+	}
+}
+
+// CreateFlashSale 创建秒杀活动
+// 同一 SKU 不能同时参加时间段重叠的多个活动
+func (s *FlashSaleService) CreateFlashSale(
+	ctx context.Context,
+	name string,
+	startAt int64,
+	endAt int64,
+	items []*entity.FlashSaleItem,
+) (*entity.FlashSale, error) {
+	flashSale := entity.NewFlashSale(s.idGenerator.NewId(), name, startAt, endAt, items)
+	if err := flashSale.Validate(); err != nil {
+		return nil, err
+	}
+	now := time.Now().UnixMilli()
+	if endAt <= now {
+		return nil, gerror.Wrapf(valueobject.ErrInvalidFlashSale, "flash sale already ended at %d", endAt)
+	}
+
+	// 检查是否与其他活动重叠
+	skuIds := make([]string, len(items))
+	for i, item := range items {
+		skuIds[i] = item.SkuId
+	}
+	existing, err := s.flashSaleRepo.FindBySkuIds(ctx, skuIds, now)
+	if err != nil {
+		return nil, err
+	}
+	for _, other := range existing {
+		if flashSale.Overlaps(other) {
+			return nil, gerror.Wrapf(valueobject.ErrFlashSaleOverlap, "overlaps flash sale %s", other.Id)
+		}
+	}
+
+	if err = s.flashSaleRepo.Save(ctx, flashSale); err != nil {
+		return nil, gerror.Wrap(err, "failed to save flash sale")
+	}
+	return flashSale, nil
+}
+
+// CancelFlashSale 取消秒杀活动，已下单的订单不受影响
+func (s *FlashSaleService) CancelFlashSale(ctx context.Context, id string) (*entity.FlashSale, error) {
+	flashSale, err := s.flashSaleRepo.FindById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err = flashSale.Cancel(); err != nil {
+		return nil, err
+	}
+	if err = s.flashSaleRepo.UpdateStatus(ctx, flashSale); err != nil {
+		return nil, gerror.Wrap(err, "failed to update flash sale")
+	}
+	return flashSale, nil
+}
+
+// GetFlashSale 获取秒杀活动
+func (s *FlashSaleService) GetFlashSale(ctx context.Context, id string) (*entity.FlashSale, error) {
+	return s.flashSaleRepo.FindById(ctx, id)
+}
+
+// ListFlashSales 获取秒杀活动列表
+func (s *FlashSaleService) ListFlashSales(ctx context.Context) ([]*entity.FlashSale, error) {
+	return s.flashSaleRepo.FindAll(ctx)
+}
+
+// ListPurchases 获取秒杀活动的购买记录
+func (s *FlashSaleService) ListPurchases(ctx context.Context, flashSaleId string) ([]*entity.Purchase, error) {
+	return s.purchaseRepo.FindByFlashSaleId(ctx, flashSaleId)
+}
+
+// FindEffective 查找 SKU 在指定时间（毫秒）进行中的秒杀活动及其活动商品，没有时返回 nil
+func (s *FlashSaleService) FindEffective(
+	ctx context.Context,
+	skuId string,
+	at int64,
+) (*entity.FlashSale, *entity.FlashSaleItem, error) {
+	flashSale, err := s.flashSaleRepo.FindEffective(ctx, skuId, at)
+	if err != nil {
+		return nil, nil, gerror.Wrap(err, "failed to find flash sale")
+	}
+	if flashSale == nil {
+		return nil, nil, nil
+	}
+	return flashSale, flashSale.ItemOf(skuId), nil
+}
+
+// Hold 为订单占用秒杀活动库存和用户的限购名额并记录购买
+// 活动已结束、活动库存不足或超出限购时返回错误，且不占用任何活动库存和名额
+func (s *FlashSaleService) Hold(
+	ctx context.Context,
+	orderId string,
+	userId string,
+	lines []valueobject.PurchaseLine,
+) ([]*entity.Purchase, error) {
+	expiresAt := time.Now().Add(s.holdDuration).UnixMilli()
+	flashSales := make(map[string]*entity.FlashSale, len(lines))
+	purchases := make([]*entity.Purchase, 0, len(lines))
+	for _, line := range lines {
+		flashSale, ok := flashSales[line.FlashSaleId]
+		if !ok {
+			var err error
+			if flashSale, err = s.flashSaleRepo.FindById(ctx, line.FlashSaleId); err != nil {
+				s.rollback(ctx, purchases)
+				return nil, err
+			}
+			flashSales[line.FlashSaleId] = flashSale
+		}
+
+		purchase := entity.NewPurchase(s.idGenerator.NewId(), orderId, userId, line, expiresAt)
+		if err := s.hold(ctx, flashSale, purchase); err != nil {
+			s.rollback(ctx, purchases)
+			return nil, err
+		}
+		purchases = append(purchases, purchase)
+	}
+	return purchases, nil
+}
+
+// hold 占用单个购买记录的限购名额和活动库存并保存，任一步失败时归还已占用的部分
+func (s *FlashSaleService) hold(ctx context.Context, flashSale *entity.FlashSale, purchase *entity.Purchase) error {
+	if err := purchase.Validate(); err != nil {
+		return err
+	}
+	item := flashSale.ItemOf(purchase.SkuId)
+	if item == nil {
+		return gerror.Wrapf(valueobject.ErrInvalidFlashSaleItem,
+			"sku %s is not in flash sale %s", purchase.SkuId, flashSale.Id,
+		)
+	}
+
+	// 1. 占用限购名额，单次购买即超出限购时无需访问存储
+	if item.IsLimited() {
+		if purchase.Quantity > item.PerUserLimit {
+			return gerror.Wrapf(valueobject.ErrPurchaseLimitExceeded,
+				"sku %s is limited to %d per user in flash sale %s", purchase.SkuId, item.PerUserLimit, flashSale.Id,
+			)
+		}
+		err := s.purchaseRepo.ClaimQuota(
+			ctx, flashSale.Id, purchase.SkuId, purchase.UserId, purchase.Quantity, item.PerUserLimit,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	// 2. 占用活动库存
+	err := s.flashSaleRepo.ReserveStock(ctx, flashSale.Id, purchase.SkuId, purchase.Quantity, time.Now().UnixMilli())
+	if err == nil {
+		// 3. 保存购买记录
+		if err = s.purchaseRepo.Save(ctx, purchase); err == nil {
+			return nil
+		}
+		err = gerror.Wrap(err, "failed to save flash sale purchase")
+		_ = s.flashSaleRepo.ReleaseStock(ctx, flashSale.Id, purchase.SkuId, purchase.Quantity)
+	}
+	if item.IsLimited() {
+		_ = s.purchaseRepo.ReturnQuota(ctx, flashSale.Id, purchase.SkuId, purchase.UserId, purchase.Quantity)
+	}
+	return err
+}
+
+// rollback 释放占用到一半的购买记录
+func (s *FlashSaleService) rollback(ctx context.Context, purchases []*entity.Purchase) {
+	for _, purchase := range purchases {
+		_ = s.release(ctx, purchase)
+	}
+}
+
+// Commit 在订单支付前确认其所有购买记录，确认后的记录不再因过期被释放
+// 每个记录以占用中且尚未过期为条件在一次条件更新中确认，检查与确认之间没有过期窗口；
+// 任一记录已过期或已释放时撤销本次已确认的记录并返回 valueobject.ErrPurchaseExpired，已过期的记录将被释放。
+// 订单支付失败时由 Revert 撤销确认
+func (s *FlashSaleService) Commit(ctx context.Context, orderId string) error {
+	purchases, err := s.purchaseRepo.FindByOrderId(ctx, orderId)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UnixMilli()
+	committed := make([]*entity.Purchase, 0, len(purchases))
+	for _, purchase := range purchases {
+		if err = s.commit(ctx, purchase, now); err != nil {
+			if revertErr := s.revert(ctx, committed); revertErr != nil {
+				return gerror.Wrapf(err, "failed to commit flash sale purchases of order %s, and failed to revert: %v", orderId, revertErr)
+			}
+			return err
+		}
+		committed = append(committed, purchase)
+	}
+	return nil
+}
+
+// commit 以条件更新确认单个购买记录，已过期的记录将被释放
+func (s *FlashSaleService) commit(ctx context.Context, purchase *entity.Purchase, now int64) error {
+	switch {
+	case purchase.State == valueobject.PurchaseStateReleased:
+		return gerror.Wrapf(valueobject.ErrPurchaseExpired, "flash sale purchase %s has been released", purchase.Id)
+	case purchase.IsHeld() && now >= purchase.ExpiresAt:
+		if err := s.release(ctx, purchase); err != nil && !gerror.Is(err, valueobject.ErrPurchaseConflict) {
+			return err
+		}
+		return gerror.Wrapf(valueobject.ErrPurchaseExpired, "flash sale purchase %s has expired", purchase.Id)
+	}
+	if err := purchase.Commit(); err != nil {
+		return err
+	}
+	return s.purchaseRepo.Commit(ctx, purchase, now)
+}
+
+// Revert 撤销订单已确认的购买记录，用于确认后订单支付失败
+// 撤销后的记录回到占用中，仍按原过期时间自动释放
+func (s *FlashSaleService) Revert(ctx context.Context, orderId string) error {
+	purchases, err := s.purchaseRepo.FindByOrderId(ctx, orderId)
+	if err != nil {
+		return err
+	}
+	committed := make([]*entity.Purchase, 0, len(purchases))
+	for _, purchase := range purchases {
+		if purchase.State == valueobject.PurchaseStateCommitted {
+			committed = append(committed, purchase)
+		}
+	}
+	return s.revert(ctx, committed)
+}
+
+// revert 撤销已确认的购买记录，单个记录撤销失败时继续撤销其余记录并返回首个错误
+func (s *FlashSaleService) revert(ctx context.Context, purchases []*entity.Purchase) error {
+	var firstErr error
+	for _, purchase := range purchases {
+		err := purchase.Revert()
+		if err == nil {
+			err = s.purchaseRepo.UpdateState(ctx, purchase, valueobject.PurchaseStateCommitted)
+		}
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// Release 释放订单所有占用中的购买记录，归还活动库存和限购名额，用于订单取消
+func (s *FlashSaleService) Release(ctx context.Context, orderId string) error {
+	purchases, err := s.purchaseRepo.FindByOrderId(ctx, orderId)
+	if err != nil {
+		return err
+	}
+	for _, purchase := range purchases {
+		if !purchase.IsHeld() {
+			continue
+		}
+		if err = s.release(ctx, purchase); err != nil && !gerror.Is(err, valueobject.ErrPurchaseConflict) {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReleaseExpired 释放所有已过期的购买记录，返回释放的数量
+// 已被支付或取消并发处理的购买记录会被跳过
+func (s *FlashSaleService) ReleaseExpired(ctx context.Context) (int, error) {
+	released := 0
+	for {
+		purchases, err := s.purchaseRepo.FindExpired(ctx, time.Now().UnixMilli(), expiredBatchSize)
+		if err != nil {
+			return released, err
+		}
+		progressed := false
+		for _, purchase := range purchases {
+			err = s.release(ctx, purchase)
+			if gerror.Is(err, valueobject.ErrPurchaseConflict) {
+				continue
+			}
+			if err != nil {
+				return released, err
+			}
+			released++
+			progressed = true
+		}
+		if len(purchases) < expiredBatchSize || !progressed {
+			return released, nil
+		}
+	}
+}
+
+// release 释放单个购买记录，归还活动库存和限购名额
+// 先以条件更新抢占状态变更，只有成功变更状态的一方才会归还
+func (s *FlashSaleService) release(ctx context.Context, purchase *entity.Purchase) error {
+	if err := purchase.Release(); err != nil {
+		return err
+	}
+	if err := s.purchaseRepo.UpdateState(ctx, purchase, valueobject.PurchaseStateHeld); err != nil {
+		return err
+	}
+	if err := s.flashSaleRepo.ReleaseStock(ctx, purchase.FlashSaleId, purchase.SkuId, purchase.Quantity); err != nil {
+		return gerror.Wrapf(err, "failed to release stock of flash sale purchase %s", purchase.Id)
+	}
+	if err := s.purchaseRepo.ReturnQuota(
+		ctx, purchase.FlashSaleId, purchase.SkuId, purchase.UserId, purchase.Quantity,
+	); err != nil {
+		return gerror.Wrapf(err, "failed to return quota of flash sale purchase %s", purchase.Id)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"main/internal/domain/flashsale/entity"
+	"main/internal/domain/flashsale/repository"
+	"main/internal/domain/flashsale/valueobject"
+)
+
+// memoryPurchaseRepository 以内存保存购买记录的仓储，只实现确认与撤销所需的方法
+type memoryPurchaseRepository struct {
+	repository.PurchaseRepository
+	purchases []*entity.Purchase
+}
+
+func (r *memoryPurchaseRepository) FindByOrderId(_ context.Context, orderId string) ([]*entity.Purchase, error) {
+	var found []*entity.Purchase
+	for _, purchase := range r.purchases {
+		if purchase.OrderId == orderId {
+			copied := *purchase
+			found = append(found, &copied)
+		}
+	}
+	return found, nil
+}
+
+func (r *memoryPurchaseRepository) UpdateState(_ context.Context, purchase *entity.Purchase, from valueobject.PurchaseState) error {
+	stored := r.find(purchase.Id)
+	if stored.State != from {
+		return valueobject.ErrPurchaseConflict
+	}
+	stored.State = purchase.State
+	return nil
+}
+
+func (r *memoryPurchaseRepository) Commit(_ context.Context, purchase *entity.Purchase, now int64) error {
+	stored := r.find(purchase.Id)
+	if stored.State != valueobject.PurchaseStateHeld || stored.ExpiresAt <= now {
+		return valueobject.ErrPurchaseConflict
+	}
+	stored.State = valueobject.PurchaseStateCommitted
+	return nil
+}
+
+func (r *memoryPurchaseRepository) find(id string) *entity.Purchase {
+	for _, purchase := range r.purchases {
+		if purchase.Id == id {
+			return purchase
+		}
+	}
+	return nil
+}
+
+func TestFlashSaleServiceCommit(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour).UnixMilli()
+	held, committed, released := valueobject.PurchaseStateHeld, valueobject.PurchaseStateCommitted, valueobject.PurchaseStateReleased
+
+	tests := []struct {
+		name    string
+		states  []valueobject.PurchaseState
+		want    []valueobject.PurchaseState
+		wantErr error
+	}{
+		{"all held are committed", []valueobject.PurchaseState{held, held}, []valueobject.PurchaseState{committed, committed}, nil},
+		{"released reverts committed", []valueobject.PurchaseState{held, released}, []valueobject.PurchaseState{held, released}, valueobject.ErrPurchaseExpired},
+		{"committed concurrently reverts committed", []valueobject.PurchaseState{held, committed}, []valueobject.PurchaseState{held, committed}, valueobject.ErrInvalidPurchase},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &memoryPurchaseRepository{}
+			for i, state := range tt.states {
+				line := valueobject.PurchaseLine{FlashSaleId: "f1", ProductId: "p1", SkuId: "s1", Quantity: 1}
+				purchase := entity.NewPurchase(string(rune('a'+i)), "o1", "u1", line, expiresAt)
+				purchase.State = state
+				repo.purchases = append(repo.purchases, purchase)
+			}
+			s := &FlashSaleService{purchaseRepo: repo}
+			if err := s.Commit(context.Background(), "o1"); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Commit() error = %v, want %v", err, tt.wantErr)
+			}
+			for i, purchase := range repo.purchases {
+				if purchase.State != tt.want[i] {
+					t.Errorf("purchase %s state = %s, want %s", purchase.Id, purchase.State, tt.want[i])
+				}
+			}
+			if tt.wantErr == nil {
+				if err := s.Revert(context.Background(), "o1"); err != nil {
+					t.Fatalf("Revert() error = %v", err)
+				}
+				for _, purchase := range repo.purchases {
+					if purchase.State != held {
+						t.Errorf("reverted purchase %s state = %s, want %s", purchase.Id, purchase.State, held)
+					}
+				}
+			}
+		})
+	}
+}
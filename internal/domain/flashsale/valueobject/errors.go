@@ -0,0 +1,18 @@
+package valueobject
+
+import "github.com/gogf/gf/v2/errors/gerror"
+
+// 秒杀领域错误定义
+var (
+	ErrFlashSaleNotFound     = gerror.New("flash sale not found")
+	ErrInvalidFlashSale      = gerror.New("invalid flash sale")
+	ErrInvalidFlashSaleName  = gerror.New("invalid flash sale name")
+	ErrInvalidFlashSaleItem  = gerror.New("invalid flash sale item")
+	ErrFlashSaleOverlap      = gerror.New("flash sale overlaps another flash sale of the same sku")
+	ErrFlashSaleNotActive    = gerror.New("flash sale is not active")
+	ErrFlashSaleSoldOut      = gerror.New("flash sale stock is insufficient")
+	ErrPurchaseLimitExceeded = gerror.New("flash sale purchase limit exceeded")
+	ErrInvalidPurchase       = gerror.New("invalid flash sale purchase")
+	ErrPurchaseExpired       = gerror.New("flash sale purchase expired")
+	ErrPurchaseConflict      = gerror.New("flash sale purchase has been changed concurrently")
+)
@@ -0,0 +1,57 @@
+package valueobject
+
+import "time"
+
+// DefaultPurchaseHold 未配置时秒杀名额的默认保留时长，与库存预占的保留时长一致
+const DefaultPurchaseHold = 30 * time.Minute
+
+// FlashSaleStatus 秒杀活动状态
+// 活动在开始和结束时间之间进行，状态只区分是否已被取消
+type FlashSaleStatus string
+
+const (
+	FlashSaleStatusActive    FlashSaleStatus = "active"    // 有效，在活动时间内进行
+	FlashSaleStatusCancelled FlashSaleStatus = "cancelled" // 已取消，不再接受下单
+)
+
+// IsValid 检查状态是否有效
+func (s FlashSaleStatus) IsValid() bool {
+	switch s {
+	case FlashSaleStatusActive, FlashSaleStatusCancelled:
+		return true
+	default:
+		return false
+	}
+}
+
+// String 返回状态的字符串表示
+func (s FlashSaleStatus) String() string {
+	return string(s)
+}
+
+// PurchaseState 秒杀购买记录状态
+type PurchaseState string
+
+const (
+	PurchaseStateHeld      PurchaseState = "held"      // 已占用活动库存和限购名额，等待订单支付
+	PurchaseStateCommitted PurchaseState = "committed" // 已确认，订单已支付
+	PurchaseStateReleased  PurchaseState = "released"  // 已释放，活动库存和限购名额已归还
+)
+
+// IsValid 检查状态是否有效
+func (s PurchaseState) IsValid() bool {
+	switch s {
+	case PurchaseStateHeld, PurchaseStateCommitted, PurchaseStateReleased:
+		return true
+	default:
+		return false
+	}
+}
+
+// PurchaseLine 订单中以秒杀价购买的一项
+type PurchaseLine struct {
+	FlashSaleId string
+	ProductId   string
+	SkuId       string
+	Quantity    int
+}
@@ -0,0 +1,110 @@
+package entity
+
+import (
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"main/internal/domain/inventory/valueobject"
+)
+
+// Inventory 库存聚合
+// 每个商品在每个仓库有一条库存记录，记录该仓库中各 SKU 的在库和预占数量
+type Inventory struct {
+	Id          string
+	ProductId   string
+	WarehouseId string
+	Items       []*InventoryItem
+	UpdatedAt   int64
+}
+
+// InventoryItem SKU 在仓库中的库存
+type InventoryItem struct {
+	SkuId    string
+	OnHand   int // 在库数量
+	Reserved int // 被未支付订单预占的数量
+}
+
+// Available 获取可售数量
+func (i *InventoryItem) Available() int {
+	return i.OnHand - i.Reserved
+}
+
+// NewInventory 创建商品在仓库中的空库存
+func NewInventory(productId, warehouseId string) *Inventory {
+	return &Inventory{
+		ProductId:   productId,
+		WarehouseId: warehouseId,
+	}
+}
+
+// Item 获取 SKU 的库存，不存在时返回 nil
+func (inv *Inventory) Item(skuId string) *InventoryItem {
+	for _, item := range inv.Items {
+		if item.SkuId == skuId {
+			return item
+		}
+	}
+	return nil
+}
+
+// Available 获取 SKU 在该仓库的可售数量
+func (inv *Inventory) Available(skuId string) int {
+	if item := inv.Item(skuId); item != nil {
+		return item.Available()
+	}
+	return 0
+}
+
+// Adjust 调整 SKU 的在库数量，调整后不能少于已预占的数量
+func (inv *Inventory) Adjust(skuId string, delta int) error {
+	item := inv.Item(skuId)
+	if item == nil {
+		item = &InventoryItem{SkuId: skuId}
+		inv.Items = append(inv.Items, item)
+	}
+	if item.OnHand+delta < item.Reserved {
+		return gerror.Wrapf(valueobject.ErrInsufficientInventory,
+			"cannot adjust sku %s in warehouse %s by %d: %d on hand, %d reserved",
+			skuId, inv.WarehouseId, delta, item.OnHand, item.Reserved,
+		)
+	}
+	item.OnHand += delta
+	return nil
+}
+
+// Validate 验证库存
+func (inv *Inventory) Validate() error {
+	if inv.ProductId == "" || inv.WarehouseId == "" {
+		return gerror.Wrap(valueobject.ErrInvalidInventory, "product and warehouse are required")
+	}
+	for _, item := range inv.Items {
+		if item.OnHand < 0 || item.Reserved < 0 || item.Reserved > item.OnHand {
+			return gerror.Wrapf(valueobject.ErrInvalidInventory,
+				"invalid inventory of sku %s in warehouse %s: %d on hand, %d reserved",
+				item.SkuId, inv.WarehouseId, item.OnHand, item.Reserved,
+			)
+		}
+	}
+	return nil
+}
+
+// Aggregate 汇总商品在多个仓库的库存，得到各 SKU 的总库存
+// 结果按 SKU 首次出现的顺序排列
+func Aggregate(inventories []*Inventory) []*InventoryItem {
+	var (
+		totals []*InventoryItem
+		index  = make(map[string]*InventoryItem)
+	)
+	for _, inv := range inventories {
+		for _, item := range inv.Items {
+			total, ok := index[item.SkuId]
+			if !ok {
+				total = &InventoryItem{SkuId: item.SkuId}
+				index[item.SkuId] = total
+				totals = append(totals, total)
+			}
+			total.OnHand += item.OnHand
+			total.Reserved += item.Reserved
+		}
+	}
+	return totals
+}
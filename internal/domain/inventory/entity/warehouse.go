@@ -0,0 +1,62 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"main/internal/domain/inventory/valueobject"
+)
+
+// Warehouse 仓库实体
+type Warehouse struct {
+	Id        string
+	Code      string // 仓库编码，全局唯一
+	Name      string
+	Location  valueobject.Location
+	Priority  int  // 优先级，数值越小越优先，用于按优先级分配以及其他策略的平局
+	Active    bool // 停用的仓库不参与分配，但其库存仍可调整和调拨
+	CreatedAt int64
+	UpdatedAt int64
+}
+
+// NewWarehouse 创建启用状态的仓库
+func NewWarehouse(id, code, name string, location valueobject.Location, priority int) *Warehouse {
+	now := time.Now().UnixMilli()
+	return &Warehouse{
+		Id:        id,
+		Code:      code,
+		Name:      name,
+		Location:  location,
+		Priority:  priority,
+		Active:    true,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// Update 更新仓库信息
+func (w *Warehouse) Update(name string, location valueobject.Location, priority int, active bool) {
+	w.Name = name
+	w.Location = location
+	w.Priority = priority
+	w.Active = active
+	w.UpdatedAt = time.Now().UnixMilli()
+}
+
+// Validate 验证仓库
+func (w *Warehouse) Validate() error {
+	if w.Id == "" {
+		return gerror.Wrap(valueobject.ErrInvalidWarehouse, "warehouse id is required")
+	}
+	if w.Code == "" {
+		return gerror.Wrap(valueobject.ErrInvalidWarehouse, "warehouse code is required")
+	}
+	if w.Name == "" {
+		return gerror.Wrap(valueobject.ErrInvalidWarehouse, "warehouse name is required")
+	}
+	if !w.Location.IsValid() {
+		return gerror.Wrapf(valueobject.ErrInvalidWarehouse, "invalid location of warehouse %s", w.Code)
+	}
+	return nil
+}
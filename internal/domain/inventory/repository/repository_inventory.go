@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+
+	"main/internal/domain/inventory/entity"
+)
+
+// InventoryRepository 库存仓储接口
+// 库存数量的变更均为带条件的原子更新，并发下单和调拨不会使仓库库存为负
+type InventoryRepository interface {
+	// FindByProductId 查找商品在所有仓库的库存
+	FindByProductId(ctx context.Context, productId string) ([]*entity.Inventory, error)
+	// FindByProductIds 查找多个商品在所有仓库的库存
+	FindByProductIds(ctx context.Context, productIds []string) ([]*entity.Inventory, error)
+	// FindByWarehouseId 查找仓库中所有商品的库存
+	FindByWarehouseId(ctx context.Context, warehouseId string) ([]*entity.Inventory, error)
+	// Adjust 按增量调整 SKU 在仓库中的在库数量，库存记录不存在时创建，
+	// 调整后的在库数量少于预占数量时返回 valueobject.ErrInsufficientInventory
+	Adjust(ctx context.Context, productId, warehouseId, skuId string, delta int) (*entity.Inventory, error)
+	// Reserve 仅当 SKU 在仓库中的可售数量充足时预占
+	Reserve(ctx context.Context, productId, warehouseId, skuId string, quantity int) error
+	// Release 释放 SKU 在仓库中预占的库存
+	Release(ctx context.Context, productId, warehouseId, skuId string, quantity int) error
+	// Commit 确认 SKU 在仓库中预占的库存，在库数量和预占数量同时减少
+	Commit(ctx context.Context, productId, warehouseId, skuId string, quantity int) error
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+
+	"main/internal/domain/inventory/entity"
+)
+
+// WarehouseRepository 仓库仓储接口
+type WarehouseRepository interface {
+	// Save 保存新仓库，编码已存在时返回 valueobject.ErrWarehouseExists
+	Save(ctx context.Context, warehouse *entity.Warehouse) error
+	// FindById 根据Id查找仓库
+	FindById(ctx context.Context, id string) (*entity.Warehouse, error)
+	// FindAll 查找所有仓库，按优先级排序
+	FindAll(ctx context.Context) ([]*entity.Warehouse, error)
+	// Update 更新仓库
+	Update(ctx context.Context, warehouse *entity.Warehouse) error
+}
@@ -0,0 +1,229 @@
+package service
+
+import (
+	"context"
+	"sort"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"main/internal/domain/inventory/entity"
+	"main/internal/domain/inventory/valueobject"
+)
+
+// Allocate 按分配策略为订单行选择发货仓库
+// 1. 没有任何仓库库存记录的商品尚未分仓，按原有方式只使用商品的汇总库存，分配结果的仓库为空；
+// 2. 优先选择一个能发出订单全部商品的仓库，避免拆单；
+// 3. 否则逐行分配，优先由排序最靠前且库存充足的单个仓库发货，仍不足时按排序依次拆分到多个仓库。
+// 停用的仓库不参与分配。destination 为收货地址的位置，最近仓库策略下为空时按优先级分配
+func (s *InventoryService) Allocate(
+	ctx context.Context,
+	strategy valueobject.AllocationStrategy,
+	destination *valueobject.Location,
+	requests []valueobject.AllocationRequest,
+) ([]valueobject.Allocation, error) {
+	if strategy == "" {
+		strategy = valueobject.DefaultAllocationStrategy
+	}
+	if !strategy.IsValid() {
+		return nil, gerror.Wrapf(valueobject.ErrInvalidAllocationRule, "unknown strategy: %s", strategy)
+	}
+	if strategy == valueobject.AllocationNearest && destination == nil {
+		strategy = valueobject.AllocationPriority
+	}
+
+	// 1. 加载参与分配的仓库和库存
+	warehouses, err := s.warehouseRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	productIds := make([]string, 0, len(requests))
+	for _, request := range requests {
+		if request.ProductId == "" || request.SkuId == "" || request.Quantity <= 0 {
+			return nil, gerror.Wrapf(valueobject.ErrInvalidAllocation,
+				"invalid allocation request of sku %s: quantity %d", request.SkuId, request.Quantity,
+			)
+		}
+		productIds = append(productIds, request.ProductId)
+	}
+	inventories, err := s.inventoryRepo.FindByProductIds(ctx, productIds)
+	if err != nil {
+		return nil, err
+	}
+	stock := newStockTable(warehouses, inventories)
+
+	// 2. 尝试由单个仓库发出所有已分仓的商品
+	ranked := rankWarehouses(warehouses, strategy, destination, func(*entity.Warehouse) int { return 0 })
+	for _, warehouse := range ranked {
+		if stock.covers(warehouse.Id, requests) {
+			return stock.allocateAll(warehouse.Id, requests), nil
+		}
+	}
+
+	// 3. 逐行分配
+	allocations := make([]valueobject.Allocation, 0, len(requests))
+	for _, request := range requests {
+		if !stock.tracked(request.ProductId) {
+			allocations = append(allocations, valueobject.Allocation{
+				ProductId: request.ProductId,
+				SkuId:     request.SkuId,
+				Quantity:  request.Quantity,
+			})
+			continue
+		}
+		ranked = rankWarehouses(warehouses, strategy, destination, func(w *entity.Warehouse) int {
+			return stock.available(w.Id, request.SkuId)
+		})
+		lines, err := stock.allocate(ranked, request)
+		if err != nil {
+			return nil, err
+		}
+		allocations = append(allocations, lines...)
+	}
+	return allocations, nil
+}
+
+// rankWarehouses 按分配策略对启用的仓库排序，available 为仓库中待分配 SKU 的可售数量
+// 策略相同的仓库按优先级排序，优先级相同时按编码排序，保证分配结果稳定
+func rankWarehouses(
+	warehouses []*entity.Warehouse,
+	strategy valueobject.AllocationStrategy,
+	destination *valueobject.Location,
+	available func(*entity.Warehouse) int,
+) []*entity.Warehouse {
+	ranked := make([]*entity.Warehouse, 0, len(warehouses))
+	for _, warehouse := range warehouses {
+		if warehouse.Active {
+			ranked = append(ranked, warehouse)
+		}
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		a, b := ranked[i], ranked[j]
+		switch strategy {
+		case valueobject.AllocationNearest:
+			if da, db := a.Location.DistanceTo(*destination), b.Location.DistanceTo(*destination); da != db {
+				return da < db
+			}
+		case valueobject.AllocationMostStock:
+			if qa, qb := available(a), available(b); qa != qb {
+				return qa > qb
+			}
+		}
+		if a.Priority != b.Priority {
+			return a.Priority < b.Priority
+		}
+		return a.Code < b.Code
+	})
+	return ranked
+}
+
+// stockTable 分配过程中各仓库 SKU 的剩余可售数量
+type stockTable struct {
+	stock    map[string]map[string]int // 仓库 -> SKU -> 可售数量
+	products map[string]bool           // 有仓库库存记录的商品
+}
+
+// newStockTable 根据库存记录构造可售数量表
+func newStockTable(warehouses []*entity.Warehouse, inventories []*entity.Inventory) *stockTable {
+	t := &stockTable{
+		stock:    make(map[string]map[string]int, len(warehouses)),
+		products: make(map[string]bool),
+	}
+	for _, inv := range inventories {
+		t.products[inv.ProductId] = true
+		skus, ok := t.stock[inv.WarehouseId]
+		if !ok {
+			skus = make(map[string]int, len(inv.Items))
+			t.stock[inv.WarehouseId] = skus
+		}
+		for _, item := range inv.Items {
+			skus[item.SkuId] += item.Available()
+		}
+	}
+	return t
+}
+
+// tracked 商品是否已分仓
+func (t *stockTable) tracked(productId string) bool {
+	return t.products[productId]
+}
+
+// available 获取 SKU 在仓库中的剩余可售数量
+func (t *stockTable) available(warehouseId, skuId string) int {
+	return t.stock[warehouseId][skuId]
+}
+
+// covers 检查仓库能否发出所有已分仓商品的订单行，相同 SKU 的多行合并计算
+func (t *stockTable) covers(warehouseId string, requests []valueobject.AllocationRequest) bool {
+	needed := make(map[string]int, len(requests))
+	for _, request := range requests {
+		if t.tracked(request.ProductId) {
+			needed[request.SkuId] += request.Quantity
+		}
+	}
+	if len(needed) == 0 {
+		return false
+	}
+	for skuId, quantity := range needed {
+		if t.available(warehouseId, skuId) < quantity {
+			return false
+		}
+	}
+	return true
+}
+
+// allocateAll 由单个仓库发出所有已分仓商品的订单行
+func (t *stockTable) allocateAll(warehouseId string, requests []valueobject.AllocationRequest) []valueobject.Allocation {
+	allocations := make([]valueobject.Allocation, 0, len(requests))
+	for _, request := range requests {
+		allocation := valueobject.Allocation{
+			ProductId: request.ProductId,
+			SkuId:     request.SkuId,
+			Quantity:  request.Quantity,
+		}
+		if t.tracked(request.ProductId) {
+			allocation.WarehouseId = warehouseId
+		}
+		allocations = append(allocations, allocation)
+	}
+	return allocations
+}
+
+// allocate 按仓库排序分配一个订单行，优先由单个仓库发货，并扣减分配表中的可售数量
+func (t *stockTable) allocate(
+	ranked []*entity.Warehouse,
+	request valueobject.AllocationRequest,
+) ([]valueobject.Allocation, error) {
+	take := func(warehouseId string, quantity int) valueobject.Allocation {
+		t.stock[warehouseId][request.SkuId] -= quantity
+		return valueobject.Allocation{
+			ProductId:   request.ProductId,
+			SkuId:       request.SkuId,
+			WarehouseId: warehouseId,
+			Quantity:    quantity,
+		}
+	}
+
+	for _, warehouse := range ranked {
+		if t.available(warehouse.Id, request.SkuId) >= request.Quantity {
+			return []valueobject.Allocation{take(warehouse.Id, request.Quantity)}, nil
+		}
+	}
+
+	var (
+		allocations []valueobject.Allocation
+		remaining   = request.Quantity
+	)
+	for _, warehouse := range ranked {
+		if quantity := min(t.available(warehouse.Id, request.SkuId), remaining); quantity > 0 {
+			allocations = append(allocations, take(warehouse.Id, quantity))
+			remaining -= quantity
+		}
+		if remaining == 0 {
+			return allocations, nil
+		}
+	}
+	return nil, gerror.Wrapf(valueobject.ErrInsufficientInventory,
+		"insufficient inventory for sku %s across warehouses: %d requested, %d available",
+		request.SkuId, request.Quantity, request.Quantity-remaining,
+	)
+}
@@ -0,0 +1,258 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"main/internal/domain/inventory/entity"
+	"main/internal/domain/inventory/repository"
+	"main/internal/domain/inventory/valueobject"
+)
+
+// fakeWarehouseRepository 内存中的仓库仓储，仅实现分配用到的方法
+type fakeWarehouseRepository struct {
+	repository.WarehouseRepository
+	warehouses []*entity.Warehouse
+}
+
+func (r *fakeWarehouseRepository) FindAll(context.Context) ([]*entity.Warehouse, error) {
+	return r.warehouses, nil
+}
+
+// fakeInventoryRepository 内存中的库存仓储，仅实现分配用到的方法
+type fakeInventoryRepository struct {
+	repository.InventoryRepository
+	inventories []*entity.Inventory
+}
+
+func (r *fakeInventoryRepository) FindByProductIds(_ context.Context, productIds []string) ([]*entity.Inventory, error) {
+	var result []*entity.Inventory
+	for _, inv := range r.inventories {
+		for _, productId := range productIds {
+			if inv.ProductId == productId {
+				result = append(result, inv)
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+// allocationWarehouses 测试用的仓库：A、B、C 按优先级排列，D 优先级最高但已停用
+func allocationWarehouses() []*entity.Warehouse {
+	inactive := entity.NewWarehouse("D", "D", "Disabled", valueobject.Location{Latitude: 30.6, Longitude: 104.1}, 0)
+	inactive.Active = false
+	return []*entity.Warehouse{
+		entity.NewWarehouse("A", "A", "Beijing", valueobject.Location{Latitude: 39.9, Longitude: 116.4}, 1),
+		entity.NewWarehouse("B", "B", "Shanghai", valueobject.Location{Latitude: 31.2, Longitude: 121.5}, 2),
+		entity.NewWarehouse("C", "C", "Guangzhou", valueobject.Location{Latitude: 23.1, Longitude: 113.3}, 3),
+		inactive,
+	}
+}
+
+// allocationInventories 根据仓库 -> SKU -> 在库数量构造库存记录，SKU s1、s2 分别属于商品 p1、p2
+func allocationInventories(stock map[string]map[string]int) []*entity.Inventory {
+	products := map[string]string{"s1": "p1", "s2": "p2"}
+	var inventories []*entity.Inventory
+	for warehouseId, skus := range stock {
+		for skuId, onHand := range skus {
+			inv := entity.NewInventory(products[skuId], warehouseId)
+			inv.Items = []*entity.InventoryItem{{SkuId: skuId, OnHand: onHand}}
+			inventories = append(inventories, inv)
+		}
+	}
+	return inventories
+}
+
+// request 构造待分配的订单行
+func request(productId, skuId string, quantity int) valueobject.AllocationRequest {
+	return valueobject.AllocationRequest{ProductId: productId, SkuId: skuId, Quantity: quantity}
+}
+
+// allocation 构造期望的分配结果
+func allocation(productId, skuId, warehouseId string, quantity int) valueobject.Allocation {
+	return valueobject.Allocation{ProductId: productId, SkuId: skuId, WarehouseId: warehouseId, Quantity: quantity}
+}
+
+func TestInventoryServiceAllocate(t *testing.T) {
+	guangzhou := &valueobject.Location{Latitude: 22.5, Longitude: 114.1}
+
+	tests := []struct {
+		name        string
+		strategy    valueobject.AllocationStrategy
+		destination *valueobject.Location
+		stock       map[string]map[string]int
+		requests    []valueobject.AllocationRequest
+		want        []valueobject.Allocation
+		wantErr     error
+	}{
+		{
+			name:     "single warehouse by priority",
+			strategy: valueobject.AllocationPriority,
+			stock: map[string]map[string]int{
+				"A": {"s1": 5, "s2": 5},
+				"B": {"s1": 5, "s2": 5},
+			},
+			requests: []valueobject.AllocationRequest{request("p1", "s1", 2), request("p2", "s2", 1)},
+			want:     []valueobject.Allocation{allocation("p1", "s1", "A", 2), allocation("p2", "s2", "A", 1)},
+		},
+		{
+			name: "default strategy is priority",
+			stock: map[string]map[string]int{
+				"A": {"s1": 5},
+				"B": {"s1": 5},
+			},
+			requests: []valueobject.AllocationRequest{request("p1", "s1", 2)},
+			want:     []valueobject.Allocation{allocation("p1", "s1", "A", 2)},
+		},
+		{
+			name:     "prefer a warehouse that ships the whole order",
+			strategy: valueobject.AllocationPriority,
+			stock: map[string]map[string]int{
+				"A": {"s1": 5},
+				"B": {"s1": 5, "s2": 5},
+			},
+			requests: []valueobject.AllocationRequest{request("p1", "s1", 2), request("p2", "s2", 1)},
+			want:     []valueobject.Allocation{allocation("p1", "s1", "B", 2), allocation("p2", "s2", "B", 1)},
+		},
+		{
+			name:        "nearest warehouse",
+			strategy:    valueobject.AllocationNearest,
+			destination: guangzhou,
+			stock: map[string]map[string]int{
+				"A": {"s1": 5},
+				"B": {"s1": 5},
+				"C": {"s1": 5},
+			},
+			requests: []valueobject.AllocationRequest{request("p1", "s1", 2)},
+			want:     []valueobject.Allocation{allocation("p1", "s1", "C", 2)},
+		},
+		{
+			name:     "nearest without destination falls back to priority",
+			strategy: valueobject.AllocationNearest,
+			stock: map[string]map[string]int{
+				"A": {"s1": 5},
+				"C": {"s1": 5},
+			},
+			requests: []valueobject.AllocationRequest{request("p1", "s1", 2)},
+			want:     []valueobject.Allocation{allocation("p1", "s1", "A", 2)},
+		},
+		{
+			name:     "most stock allocates each line from the fullest warehouse",
+			strategy: valueobject.AllocationMostStock,
+			stock: map[string]map[string]int{
+				"A": {"s1": 4},
+				"B": {"s1": 10, "s2": 1},
+				"C": {"s2": 5},
+			},
+			requests: []valueobject.AllocationRequest{request("p1", "s1", 3), request("p2", "s2", 3)},
+			want:     []valueobject.Allocation{allocation("p1", "s1", "B", 3), allocation("p2", "s2", "C", 3)},
+		},
+		{
+			name:     "priority allocates each line from the first warehouse with enough stock",
+			strategy: valueobject.AllocationPriority,
+			stock: map[string]map[string]int{
+				"A": {"s1": 4},
+				"B": {"s1": 10, "s2": 1},
+				"C": {"s2": 5},
+			},
+			requests: []valueobject.AllocationRequest{request("p1", "s1", 3), request("p2", "s2", 3)},
+			want:     []valueobject.Allocation{allocation("p1", "s1", "A", 3), allocation("p2", "s2", "C", 3)},
+		},
+		{
+			name:     "split a line across warehouses",
+			strategy: valueobject.AllocationPriority,
+			stock: map[string]map[string]int{
+				"A": {"s1": 4},
+				"B": {"s1": 10},
+			},
+			requests: []valueobject.AllocationRequest{request("p1", "s1", 12)},
+			want:     []valueobject.Allocation{allocation("p1", "s1", "A", 4), allocation("p1", "s1", "B", 8)},
+		},
+		{
+			name:     "lines of the same sku are combined when shipping the whole order",
+			strategy: valueobject.AllocationPriority,
+			stock: map[string]map[string]int{
+				"A": {"s1": 4},
+				"B": {"s1": 10},
+			},
+			requests: []valueobject.AllocationRequest{request("p1", "s1", 3), request("p1", "s1", 3)},
+			want:     []valueobject.Allocation{allocation("p1", "s1", "B", 3), allocation("p1", "s1", "B", 3)},
+		},
+		{
+			name:     "lines of the same sku consume allocated stock",
+			strategy: valueobject.AllocationPriority,
+			stock: map[string]map[string]int{
+				"A": {"s1": 4},
+				"B": {"s1": 5},
+			},
+			requests: []valueobject.AllocationRequest{request("p1", "s1", 3), request("p1", "s1", 3)},
+			want:     []valueobject.Allocation{allocation("p1", "s1", "A", 3), allocation("p1", "s1", "B", 3)},
+		},
+		{
+			name:     "inactive warehouse is skipped",
+			strategy: valueobject.AllocationPriority,
+			stock: map[string]map[string]int{
+				"B": {"s1": 5},
+				"D": {"s1": 100},
+			},
+			requests: []valueobject.AllocationRequest{request("p1", "s1", 2)},
+			want:     []valueobject.Allocation{allocation("p1", "s1", "B", 2)},
+		},
+		{
+			name:     "product without warehouse stock is not assigned a warehouse",
+			strategy: valueobject.AllocationPriority,
+			stock: map[string]map[string]int{
+				"A": {"s1": 5},
+			},
+			requests: []valueobject.AllocationRequest{request("p1", "s1", 2), request("p3", "s3", 1)},
+			want:     []valueobject.Allocation{allocation("p1", "s1", "A", 2), allocation("p3", "s3", "", 1)},
+		},
+		{
+			name:     "insufficient stock across warehouses",
+			strategy: valueobject.AllocationPriority,
+			stock: map[string]map[string]int{
+				"A": {"s1": 4},
+				"B": {"s1": 5},
+				"D": {"s1": 100},
+			},
+			requests: []valueobject.AllocationRequest{request("p1", "s1", 10)},
+			wantErr:  valueobject.ErrInsufficientInventory,
+		},
+		{
+			name:     "unknown strategy",
+			strategy: "random",
+			requests: []valueobject.AllocationRequest{request("p1", "s1", 1)},
+			wantErr:  valueobject.ErrInvalidAllocationRule,
+		},
+		{
+			name:     "invalid quantity",
+			strategy: valueobject.AllocationPriority,
+			requests: []valueobject.AllocationRequest{request("p1", "s1", 0)},
+			wantErr:  valueobject.ErrInvalidAllocation,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewInventoryService(
+				&fakeInventoryRepository{inventories: allocationInventories(tt.stock)},
+				&fakeWarehouseRepository{warehouses: allocationWarehouses()},
+			)
+			got, err := s.Allocate(context.Background(), tt.strategy, tt.destination, tt.requests)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("Allocate() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Allocate() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Allocate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
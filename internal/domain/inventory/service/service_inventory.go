@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"main/internal/domain/inventory/entity"
+	"main/internal/domain/inventory/repository"
+	"main/internal/domain/inventory/valueobject"
+)
+
+// InventoryService 库存领域服务
+// 负责商品在各仓库的库存调整、调拨、预占以及订单的仓库分配
+type InventoryService struct {
+	inventoryRepo repository.InventoryRepository
+	warehouseRepo repository.WarehouseRepository
+}
+
+// NewInventoryService 创建库存领域服务实例
+func NewInventoryService(
+	inventoryRepo repository.InventoryRepository,
+	warehouseRepo repository.WarehouseRepository,
+) *InventoryService {
+	return &InventoryService{
+		inventoryRepo: inventoryRepo,
+		warehouseRepo: warehouseRepo,
+	}
+}
+
+// GetProductInventory 获取商品在所有仓库的库存
+func (s *InventoryService) GetProductInventory(ctx context.Context, productId string) ([]*entity.Inventory, error) {
+	return s.inventoryRepo.FindByProductId(ctx, productId)
+}
+
+// GetWarehouseInventory 获取仓库中所有商品的库存
+func (s *InventoryService) GetWarehouseInventory(ctx context.Context, warehouseId string) ([]*entity.Inventory, error) {
+	if _, err := s.warehouseRepo.FindById(ctx, warehouseId); err != nil {
+		return nil, err
+	}
+	return s.inventoryRepo.FindByWarehouseId(ctx, warehouseId)
+}
+
+// Adjust 按增量调整 SKU 在仓库中的在库数量，如入库、盘点或损耗
+func (s *InventoryService) Adjust(
+	ctx context.Context,
+	productId string,
+	warehouseId string,
+	skuId string,
+	delta int,
+) (*entity.Inventory, error) {
+	if productId == "" || skuId == "" {
+		return nil, gerror.Wrap(valueobject.ErrInvalidInventory, "product and sku are required")
+	}
+	if _, err := s.warehouseRepo.FindById(ctx, warehouseId); err != nil {
+		return nil, err
+	}
+	return s.inventoryRepo.Adjust(ctx, productId, warehouseId, skuId, delta)
+}
+
+// Transfer 将 SKU 的可售库存从一个仓库调拨到另一个仓库
+// 先原子地扣减调出仓库的库存，再增加调入仓库的库存，调入失败时退回调出仓库。
+// 调拨不改变商品的汇总库存
+func (s *InventoryService) Transfer(
+	ctx context.Context,
+	productId string,
+	skuId string,
+	fromWarehouseId string,
+	toWarehouseId string,
+	quantity int,
+) (from *entity.Inventory, to *entity.Inventory, err error) {
+	if quantity <= 0 {
+		return nil, nil, gerror.Wrapf(valueobject.ErrInvalidTransfer, "quantity must be positive: %d", quantity)
+	}
+	if fromWarehouseId == toWarehouseId {
+		return nil, nil, gerror.Wrap(valueobject.ErrInvalidTransfer, "source and destination warehouses are the same")
+	}
+	if _, err = s.warehouseRepo.FindById(ctx, toWarehouseId); err != nil {
+		return nil, nil, err
+	}
+
+	if from, err = s.Adjust(ctx, productId, fromWarehouseId, skuId, -quantity); err != nil {
+		return nil, nil, err
+	}
+	if to, err = s.inventoryRepo.Adjust(ctx, productId, toWarehouseId, skuId, quantity); err != nil {
+		if _, rollbackErr := s.inventoryRepo.Adjust(ctx, productId, fromWarehouseId, skuId, quantity); rollbackErr != nil {
+			return nil, nil, gerror.Wrapf(err, "failed to transfer stock, and failed to roll back: %v", rollbackErr)
+		}
+		return nil, nil, err
+	}
+	return from, to, nil
+}
+
+// Reserve 预占 SKU 在仓库中的库存
+func (s *InventoryService) Reserve(ctx context.Context, productId, warehouseId, skuId string, quantity int) error {
+	if err := validateQuantity(quantity); err != nil {
+		return err
+	}
+	return s.inventoryRepo.Reserve(ctx, productId, warehouseId, skuId, quantity)
+}
+
+// Release 释放 SKU 在仓库中预占的库存
+func (s *InventoryService) Release(ctx context.Context, productId, warehouseId, skuId string, quantity int) error {
+	if err := validateQuantity(quantity); err != nil {
+		return err
+	}
+	return s.inventoryRepo.Release(ctx, productId, warehouseId, skuId, quantity)
+}
+
+// Commit 确认 SKU 在仓库中预占的库存
+func (s *InventoryService) Commit(ctx context.Context, productId, warehouseId, skuId string, quantity int) error {
+	if err := validateQuantity(quantity); err != nil {
+		return err
+	}
+	return s.inventoryRepo.Commit(ctx, productId, warehouseId, skuId, quantity)
+}
+
+// validateQuantity 检查预占、释放和确认的数量
+func validateQuantity(quantity int) error {
+	if quantity <= 0 {
+		return gerror.Wrapf(valueobject.ErrInvalidInventory, "quantity must be positive: %d", quantity)
+	}
+	return nil
+}
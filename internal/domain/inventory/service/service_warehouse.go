@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"main/internal/domain/inventory/entity"
+	"main/internal/domain/inventory/repository"
+	"main/internal/domain/inventory/valueobject"
+	sharedservice "main/internal/domain/shared/service"
+)
+
+// WarehouseService 仓库领域服务
+type WarehouseService struct {
+	warehouseRepo repository.WarehouseRepository
+	idGenerator   sharedservice.IdGenerator // 仓库 Id 生成器
+}
+
+// NewWarehouseService 创建仓库领域服务实例
+func NewWarehouseService(
+	warehouseRepo repository.WarehouseRepository,
+	idGenerator sharedservice.IdGenerator,
+) *WarehouseService {
+	return &WarehouseService{warehouseRepo: warehouseRepo, idGenerator: idGenerator}
+}
+
+// CreateWarehouse 创建仓库
+func (s *WarehouseService) CreateWarehouse(
+	ctx context.Context,
+	code string,
+	name string,
+	location valueobject.Location,
+	priority int,
+) (*entity.Warehouse, error) {
+	warehouse := entity.NewWarehouse(s.idGenerator.NewId(), code, name, location, priority)
+	if err := warehouse.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.warehouseRepo.Save(ctx, warehouse); err != nil {
+		return nil, err
+	}
+	return warehouse, nil
+}
+
+// UpdateWarehouse 更新仓库信息，仓库编码不可修改
+func (s *WarehouseService) UpdateWarehouse(
+	ctx context.Context,
+	id string,
+	name string,
+	location valueobject.Location,
+	priority int,
+	active bool,
+) (*entity.Warehouse, error) {
+	warehouse, err := s.warehouseRepo.FindById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	warehouse.Update(name, location, priority, active)
+	if err = warehouse.Validate(); err != nil {
+		return nil, err
+	}
+	if err = s.warehouseRepo.Update(ctx, warehouse); err != nil {
+		return nil, gerror.Wrap(err, "failed to update warehouse")
+	}
+	return warehouse, nil
+}
+
+// GetWarehouse 获取仓库
+func (s *WarehouseService) GetWarehouse(ctx context.Context, id string) (*entity.Warehouse, error) {
+	return s.warehouseRepo.FindById(ctx, id)
+}
+
+// ListWarehouses 获取所有仓库，按优先级排序
+func (s *WarehouseService) ListWarehouses(ctx context.Context) ([]*entity.Warehouse, error) {
+	return s.warehouseRepo.FindAll(ctx)
+}
@@ -0,0 +1,38 @@
+package valueobject
+
+// AllocationStrategy 订单的仓库分配策略
+type AllocationStrategy string
+
+const (
+	AllocationNearest   AllocationStrategy = "nearest"    // 优先距收货地址最近的仓库
+	AllocationMostStock AllocationStrategy = "most_stock" // 优先可售数量最多的仓库
+	AllocationPriority  AllocationStrategy = "priority"   // 按仓库优先级
+
+	// DefaultAllocationStrategy 未指定时使用的分配策略
+	DefaultAllocationStrategy = AllocationPriority
+)
+
+// IsValid 检查分配策略是否有效
+func (s AllocationStrategy) IsValid() bool {
+	switch s {
+	case AllocationNearest, AllocationMostStock, AllocationPriority:
+		return true
+	default:
+		return false
+	}
+}
+
+// Allocation 仓库分配结果，表示由某个仓库发出某个 SKU 的数量
+type Allocation struct {
+	ProductId   string `json:"productId"`
+	SkuId       string `json:"skuId"`
+	WarehouseId string `json:"warehouseId"`
+	Quantity    int    `json:"quantity"`
+}
+
+// AllocationRequest 待分配的订单行
+type AllocationRequest struct {
+	ProductId string
+	SkuId     string
+	Quantity  int
+}
@@ -0,0 +1,16 @@
+package valueobject
+
+import "github.com/gogf/gf/v2/errors/gerror"
+
+// 库存领域错误定义
+var (
+	ErrWarehouseNotFound     = gerror.New("warehouse not found")
+	ErrInvalidWarehouse      = gerror.New("invalid warehouse")
+	ErrWarehouseExists       = gerror.New("warehouse already exists")
+	ErrInventoryNotFound     = gerror.New("inventory not found")
+	ErrInsufficientInventory = gerror.New("insufficient inventory")
+	ErrInvalidInventory      = gerror.New("invalid inventory")
+	ErrInvalidTransfer       = gerror.New("invalid inventory transfer")
+	ErrInvalidAllocation     = gerror.New("invalid inventory allocation")
+	ErrInvalidAllocationRule = gerror.New("invalid allocation strategy")
+)
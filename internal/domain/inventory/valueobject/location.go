@@ -0,0 +1,33 @@
+package valueobject
+
+import "math"
+
+// earthRadiusKm 地球平均半径（千米）
+const earthRadiusKm = 6371.0
+
+// Location 地理位置，用于按距离选择发货仓库
+type Location struct {
+	Latitude  float64 `json:"latitude"`  // 纬度
+	Longitude float64 `json:"longitude"` // 经度
+}
+
+// IsValid 检查经纬度是否在有效范围内
+func (l Location) IsValid() bool {
+	return l.Latitude >= -90 && l.Latitude <= 90 && l.Longitude >= -180 && l.Longitude <= 180
+}
+
+// DistanceTo 计算到另一位置的球面距离（千米）
+// 采用 Haversine 公式，对仓库选择而言精度足够
+func (l Location) DistanceTo(other Location) float64 {
+	lat1, lat2 := radians(l.Latitude), radians(other.Latitude)
+	dLat := lat2 - lat1
+	dLon := radians(other.Longitude - l.Longitude)
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// radians 角度转弧度
+func radians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
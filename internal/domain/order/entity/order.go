@@ -11,26 +11,28 @@ import (
 
 // Order represents the order aggregate root
 type Order struct {
-	Id           string
-	UserId       string
-	Items        []*OrderItem
-	TotalAmount  *sharedvo.Money
-	Status       valueobject.OrderStatus
-	PaymentInfo  *valueobject.PaymentInfo // 支付信息
-	Remark       string
-	CreatedAt    int64
-	UpdatedAt    int64
-	PaidAt       int64 // 支付时间
-}
-
-// NewOrder creates a new order instance
-func NewOrder(userId string) *Order {
+	Id             string
+	UserId         string
+	Items          []*OrderItem
+	TotalAmount    *sharedvo.Money
+	DiscountAmount *sharedvo.Money // 订单级优惠金额，按订单项金额比例分摊到各订单项
+	Status         valueobject.OrderStatus
+	PaymentInfo    *valueobject.PaymentInfo // 支付信息
+	Remark         string
+	CreatedAt      int64
+	UpdatedAt      int64
+	PaidAt         int64 // 支付时间
+}
+
+// NewOrder creates a new order instance with an id assigned by the domain
+// 订单货币由第一个订单项的价格货币决定，添加订单项前总金额以默认货币表示
+func NewOrder(id string, userId string) *Order {
 	return &Order{
-		Id:          "", // ID will be assigned by the infrastructure layer
+		Id:          id,
 		UserId:      userId,
 		Status:      valueobject.OrderStatusCreated,
 		Items:       make([]*OrderItem, 0),
-		TotalAmount: sharedvo.NewMoney(0, "CNY"),
+		TotalAmount: sharedvo.MustNewMoney(0, sharedvo.DefaultCurrency),
 		CreatedAt:   time.Now().UnixMilli(),
 		UpdatedAt:   time.Now().UnixMilli(),
 		PaidAt:      0,
@@ -43,38 +45,59 @@ func (o *Order) AddItem(item *OrderItem) error {
 		return gerror.New("cannot add items to non-created order")
 	}
 
-	// Check if product already exists in order
+	// 订单内所有订单项须使用同一货币
+	if len(o.Items) > 0 && item.Price.Currency() != o.Currency() {
+		return gerror.Wrapf(sharedvo.ErrCurrencyMismatch,
+			"item %s is priced in %s, order currency is %s", item.SkuId, item.Price.Currency(), o.Currency(),
+		)
+	}
+
+	// Check if sku already exists in order
+	// 单价可能随数量变化（阶梯价），合并后的单价须由调用方按合计数量重新解析，单价不同时不能合并
 	for _, existingItem := range o.Items {
-		if existingItem.ProductId == item.ProductId {
+		if existingItem.SkuId == item.SkuId {
+			if !existingItem.Price.Equals(item.Price) {
+				return gerror.Newf("sku %s is already in the order at a different unit price", item.SkuId)
+			}
 			existingItem.Quantity += item.Quantity
-			o.recalculateTotal()
+			if err := o.recalculateTotal(); err != nil {
+				existingItem.Quantity -= item.Quantity
+				return err
+			}
 			o.UpdatedAt = time.Now().UnixMilli()
 			return nil
 		}
 	}
 
 	o.Items = append(o.Items, item)
-	o.recalculateTotal()
+	if err := o.recalculateTotal(); err != nil {
+		o.Items = o.Items[:len(o.Items)-1]
+		return err
+	}
 	o.UpdatedAt = time.Now().UnixMilli()
 	return nil
 }
 
-// RemoveItem removes an item from the order
-func (o *Order) RemoveItem(productId string) error {
+// RemoveItem removes the item of the given sku from the order
+func (o *Order) RemoveItem(skuId string) error {
 	if o.Status != valueobject.OrderStatusCreated {
 		return gerror.New("cannot remove items from non-created order")
 	}
 
 	for i, item := range o.Items {
-		if item.ProductId == productId {
-			o.Items = append(o.Items[:i], o.Items[i+1:]...)
-			o.recalculateTotal()
+		if item.SkuId == skuId {
+			items := o.Items
+			o.Items = append(append(make([]*OrderItem, 0, len(items)-1), items[:i]...), items[i+1:]...)
+			if err := o.recalculateTotal(); err != nil {
+				o.Items = items
+				return gerror.Wrap(err, "cannot remove item")
+			}
 			o.UpdatedAt = time.Now().UnixMilli()
 			return nil
 		}
 	}
 
-	return gerror.Newf("product %s not found in order", productId)
+	return gerror.Newf("sku %s not found in order", skuId)
 }
 
 // UpdateStatus updates the order status
@@ -95,17 +118,12 @@ func (o *Order) UpdateStatus(newStatus valueobject.OrderStatus) error {
 // ProcessPayment 处理订单支付
 // 这是一个领域行为，包含了支付相关的业务规则
 func (o *Order) ProcessPayment(paymentInfo *valueobject.PaymentInfo) error {
-	// 1. 验证订单状态
-	if o.Status != valueobject.OrderStatusCreated {
-		return gerror.Newf("cannot pay order in status: %s", o.Status)
+	// 1. 验证订单状态和支付金额
+	if err := o.CheckPayment(paymentInfo); err != nil {
+		return err
 	}
 
-	// 2. 验证支付金额
-	if !o.TotalAmount.Equals(paymentInfo.Amount) {
-		return gerror.New("payment amount does not match order amount")
-	}
-
-	// 3. 更新订单状态和支付信息
+	// 2. 更新订单状态和支付信息
 	if err := o.UpdateStatus(valueobject.OrderStatusPaid); err != nil {
 		return gerror.Wrap(err, "failed to update order status")
 	}
@@ -117,6 +135,18 @@ func (o *Order) ProcessPayment(paymentInfo *valueobject.PaymentInfo) error {
 	return nil
 }
 
+// CheckPayment 检查订单能否接受该笔支付，不改变订单
+// 订单必须处于待支付状态，且支付金额与订单金额一致
+func (o *Order) CheckPayment(paymentInfo *valueobject.PaymentInfo) error {
+	if o.Status != valueobject.OrderStatusCreated {
+		return gerror.Newf("cannot pay order in status: %s", o.Status)
+	}
+	if !o.TotalAmount.Equals(paymentInfo.Amount) {
+		return gerror.New("payment amount does not match order amount")
+	}
+	return nil
+}
+
 // GetPaymentInfo 获取支付信息
 func (o *Order) GetPaymentInfo() *valueobject.PaymentInfo {
 	return o.PaymentInfo
@@ -151,15 +181,112 @@ func (o *Order) IsCancelled() bool {
 	return o.Status == valueobject.OrderStatusCancelled
 }
 
+// ApplyDiscount 应用订单级优惠
+// 优惠金额按各订单项金额的比例分摊到订单项，分摊结果之和严格等于优惠金额
+func (o *Order) ApplyDiscount(discount *sharedvo.Money) error {
+	if o.Status != valueobject.OrderStatusCreated {
+		return gerror.Newf("cannot apply discount to order in status: %s", o.Status)
+	}
+	if err := discount.Validate(); err != nil {
+		return gerror.Wrap(err, "invalid discount")
+	}
+
+	previous := o.DiscountAmount
+	o.DiscountAmount = discount
+	if err := o.recalculateTotal(); err != nil {
+		o.DiscountAmount = previous
+		return err
+	}
+	o.UpdatedAt = time.Now().UnixMilli()
+	return nil
+}
+
+// AllocateRefund 将部分退款金额分摊到订单项
+// 按各订单项实付金额的比例分摊，分摊结果之和严格等于退款金额，返回结果与 Items 一一对应
+func (o *Order) AllocateRefund(amount *sharedvo.Money) ([]*sharedvo.Money, error) {
+	if !o.IsPaid() && o.Status != valueobject.OrderStatusShipping && o.Status != valueobject.OrderStatusDelivered {
+		return nil, gerror.Newf("cannot refund order in status: %s", o.Status)
+	}
+	if err := amount.Validate(); err != nil {
+		return nil, gerror.Wrap(err, "invalid refund amount")
+	}
+	cmp, err := amount.Compare(o.TotalAmount)
+	if err != nil {
+		return nil, err
+	}
+	if cmp > 0 {
+		return nil, gerror.New("refund amount exceeds order amount")
+	}
+
+	ratios := make([]int64, len(o.Items))
+	for i, item := range o.Items {
+		payable, err := item.GetPayableAmount()
+		if err != nil {
+			return nil, err
+		}
+		ratios[i] = payable.MinorAmount()
+	}
+	return amount.Allocate(ratios...)
+}
+
 // recalculateTotal recalculates the total amount of the order
-func (o *Order) recalculateTotal() {
-	total := sharedvo.NewMoney(0, "CNY")
-	for _, item := range o.Items {
-		itemTotal := item.Price.Multiply(float64(item.Quantity))
-		newTotal, _ := total.Add(itemTotal)
-		total = newTotal
+// and re-distributes the order-level discount to the items
+func (o *Order) recalculateTotal() error {
+	// 1. 以订单货币计算订单项金额合计
+	gross, err := sharedvo.NewMoneyFromMinor(0, o.Currency())
+	if err != nil {
+		return err
+	}
+	ratios := make([]int64, len(o.Items))
+	for i, item := range o.Items {
+		subtotal := item.GetSubtotal()
+		if subtotal.Currency() != gross.Currency() {
+			return gerror.Wrapf(sharedvo.ErrCurrencyMismatch,
+				"item %s is priced in %s, order currency is %s", item.SkuId, subtotal.Currency(), gross.Currency(),
+			)
+		}
+		newGross, err := gross.Add(subtotal)
+		if err != nil {
+			return gerror.Wrap(err, "failed to sum order items")
+		}
+		gross = newGross
+		ratios[i] = subtotal.MinorAmount()
+	}
+
+	// 2. 分摊订单级优惠
+	if o.DiscountAmount == nil || o.DiscountAmount.IsZero() {
+		for _, item := range o.Items {
+			item.Discount = nil
+		}
+		o.TotalAmount = gross
+		return nil
+	}
+
+	total, err := gross.Subtract(o.DiscountAmount)
+	if err != nil {
+		return gerror.Wrap(err, "failed to apply discount")
+	}
+	if total.IsNegative() {
+		return gerror.New("discount exceeds order amount")
+	}
+	discounts, err := o.DiscountAmount.Allocate(ratios...)
+	if err != nil {
+		return gerror.Wrap(err, "failed to allocate discount")
+	}
+	for i, item := range o.Items {
+		item.Discount = discounts[i]
 	}
 	o.TotalAmount = total
+	return nil
+}
+
+// Currency returns the currency of the order, which is the currency of its first item
+// 订单尚无订单项时返回总金额的货币
+func (o *Order) Currency() string {
+	if len(o.Items) > 0 {
+		return o.Items[0].Price.Currency()
+	}
+	return o.TotalAmount.Currency()
 }
 
 // UpdateRemark 更新订单备注
@@ -171,6 +298,10 @@ func (o *Order) UpdateRemark(remark string) {
 // Validate 验证订单
 func (o *Order) Validate() error {
 	// 1. 基本字段验证
+	if o.Id == "" {
+		return gerror.New("order id is required")
+	}
+
 	if o.UserId == "" {
 		return gerror.New("user id is required")
 	}
@@ -8,19 +8,34 @@ import (
 
 // OrderItem represents an item in an order
 type OrderItem struct {
+	Id          string          // 订单项ID，创建订单时分配
 	ProductId   string          // 商品ID
 	ProductName string          // 商品名称
+	SkuId       string          // SKU ID
+	SkuName     string          // SKU 规格名称，如 "红色 / M"，单规格商品为空
 	Quantity    int             // 数量
 	Price       *sharedvo.Money // 单价
+	Discount    *sharedvo.Money // 分摊到该订单项的订单级优惠，可为空
+	// Components 套装订单项的组件及每套价格的分摊，普通订单项为空
+	Components []*OrderItemComponent
 }
 
 // NewOrderItem creates a new order item
-func NewOrderItem(productId string, productName string, quantity int, price float64) *OrderItem {
+func NewOrderItem(
+	productId string,
+	productName string,
+	skuId string,
+	skuName string,
+	quantity int,
+	price *sharedvo.Money,
+) *OrderItem {
 	return &OrderItem{
 		ProductId:   productId,
 		ProductName: productName,
+		SkuId:       skuId,
+		SkuName:     skuName,
 		Quantity:    quantity,
-		Price:       sharedvo.NewMoney(price, "CNY"),
+		Price:       price,
 	}
 }
 
@@ -29,6 +44,15 @@ func (i *OrderItem) GetSubtotal() *sharedvo.Money {
 	return i.Price.Multiply(float64(i.Quantity))
 }
 
+// GetPayableAmount calculates the amount payable for this item
+// after the allocated order-level discount
+func (i *OrderItem) GetPayableAmount() (*sharedvo.Money, error) {
+	if i.Discount == nil {
+		return i.GetSubtotal(), nil
+	}
+	return i.GetSubtotal().Subtract(i.Discount)
+}
+
 // UpdateQuantity updates the quantity of the item
 func (i *OrderItem) UpdateQuantity(quantity int) error {
 	if quantity <= 0 {
@@ -48,6 +72,10 @@ func (i *OrderItem) Validate() error {
 		return gerror.New("product name is required")
 	}
 
+	if i.SkuId == "" {
+		return gerror.New("sku id is required")
+	}
+
 	if i.Quantity <= 0 {
 		return gerror.New("quantity must be positive")
 	}
@@ -60,5 +88,47 @@ func (i *OrderItem) Validate() error {
 		return gerror.New("price must be positive")
 	}
 
+	if i.IsBundle() {
+		return i.validateComponents(i.Components)
+	}
+
+	return nil
+}
+
+// IsBundle checks if the item is a bundle composed of components
+func (i *OrderItem) IsBundle() bool {
+	return len(i.Components) > 0
+}
+
+// SetComponents records the components of a bundle item
+// 各组件分摊的金额之和必须等于订单项单价
+func (i *OrderItem) SetComponents(components []*OrderItemComponent) error {
+	if err := i.validateComponents(components); err != nil {
+		return err
+	}
+	i.Components = components
+	return nil
+}
+
+// validateComponents validates the components of a bundle item
+func (i *OrderItem) validateComponents(components []*OrderItemComponent) error {
+	if len(components) == 0 {
+		return gerror.New("bundle item must have at least one component")
+	}
+	total, err := sharedvo.NewMoney(0, i.Price.Currency())
+	if err != nil {
+		return err
+	}
+	for _, component := range components {
+		if err = component.Validate(); err != nil {
+			return err
+		}
+		if total, err = total.Add(component.Price); err != nil {
+			return gerror.Wrapf(err, "invalid price of component %s", component.SkuId)
+		}
+	}
+	if !total.Equals(i.Price) {
+		return gerror.Newf("component prices %v do not add up to bundle price %v", total.Amount(), i.Price.Amount())
+	}
 	return nil
 }
@@ -0,0 +1,54 @@
+package entity
+
+import (
+	sharedvo "main/internal/domain/shared/valueobject"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// OrderItemComponent represents a component of a bundle order item
+// 记录下单时套装包含的商品 SKU 及套装价格分摊到该组件的金额，用于按组件退款
+type OrderItemComponent struct {
+	ProductId   string          // 组件商品ID
+	ProductName string          // 组件商品名称
+	SkuId       string          // 组件 SKU ID
+	SkuName     string          // 组件 SKU 规格名称
+	Quantity    int             // 每套包含的数量
+	Price       *sharedvo.Money // 每套套装价格分摊到该组件（含全部数量）的金额
+}
+
+// NewOrderItemComponent creates a new bundle item component
+func NewOrderItemComponent(
+	productId string,
+	productName string,
+	skuId string,
+	skuName string,
+	quantity int,
+	price *sharedvo.Money,
+) *OrderItemComponent {
+	return &OrderItemComponent{
+		ProductId:   productId,
+		ProductName: productName,
+		SkuId:       skuId,
+		SkuName:     skuName,
+		Quantity:    quantity,
+		Price:       price,
+	}
+}
+
+// Validate validates the component
+func (c *OrderItemComponent) Validate() error {
+	if c.ProductId == "" || c.SkuId == "" {
+		return gerror.New("component product id and sku id are required")
+	}
+	if c.Quantity <= 0 {
+		return gerror.Newf("quantity of component %s must be positive", c.SkuId)
+	}
+	if c.Price == nil {
+		return gerror.Newf("price of component %s is required", c.SkuId)
+	}
+	if c.Price.IsNegative() {
+		return gerror.Newf("price of component %s cannot be negative", c.SkuId)
+	}
+	return nil
+}
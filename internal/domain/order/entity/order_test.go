@@ -0,0 +1,111 @@
+package entity
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"main/internal/domain/order/valueobject"
+	sharedvo "main/internal/domain/shared/valueobject"
+)
+
+// newTestOrder 创建包含三个订单项的订单，金额合计 60 元，discount 为订单级优惠
+func newTestOrder(t *testing.T, discount float64, paid bool) *Order {
+	t.Helper()
+	order := NewOrder("o1", "u1")
+	items := []*OrderItem{
+		NewOrderItem("p1", "P1", "s1", "", 1, sharedvo.MustNewMoney(30, "CNY")),
+		NewOrderItem("p2", "P2", "s2", "", 2, sharedvo.MustNewMoney(10, "CNY")),
+		NewOrderItem("p3", "P3", "s3", "", 1, sharedvo.MustNewMoney(10, "CNY")),
+	}
+	for _, item := range items {
+		if err := order.AddItem(item); err != nil {
+			t.Fatalf("AddItem() error = %v", err)
+		}
+	}
+	if discount > 0 {
+		if err := order.ApplyDiscount(sharedvo.MustNewMoney(discount, "CNY")); err != nil {
+			t.Fatalf("ApplyDiscount() error = %v", err)
+		}
+	}
+	if paid {
+		payment := valueobject.NewPaymentInfo(order.TotalAmount, valueobject.PaymentMethodAlipay, valueobject.PaymentChannelApp, "t1", nil)
+		if err := order.ProcessPayment(payment); err != nil {
+			t.Fatalf("ProcessPayment() error = %v", err)
+		}
+	}
+	return order
+}
+
+func TestOrderAllocateRefund(t *testing.T) {
+	tests := []struct {
+		name     string
+		discount float64
+		unpaid   bool
+		amount   *sharedvo.Money
+		want     []int64
+		wantErr  bool
+		errIs    error // 非空时错误须包装该错误
+	}{
+		{"remainder goes to largest remainder", 0, false, sharedvo.MustNewMoney(10, "CNY"), []int64{500, 333, 167}, false, nil},
+		{"full refund", 0, false, sharedvo.MustNewMoney(60, "CNY"), []int64{3000, 2000, 1000}, false, nil},
+		{"split by payable amount after discount", 6, false, sharedvo.MustNewMoney(54, "CNY"), []int64{2700, 1800, 900}, false, nil},
+		{"single cent", 0, false, sharedvo.MustNewMoney(0.01, "CNY"), []int64{1, 0, 0}, false, nil},
+		{"exceeds paid amount", 6, false, sharedvo.MustNewMoney(55, "CNY"), nil, true, nil},
+		{"currency mismatch", 0, false, sharedvo.MustNewMoney(10, "USD"), nil, true, sharedvo.ErrCurrencyMismatch},
+		{"unpaid order", 0, true, sharedvo.MustNewMoney(10, "CNY"), nil, true, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := newTestOrder(t, tt.discount, !tt.unpaid)
+			refunds, err := order.AllocateRefund(tt.amount)
+			if tt.wantErr {
+				if err == nil || (tt.errIs != nil && !errors.Is(err, tt.errIs)) {
+					t.Errorf("AllocateRefund() error = %v, want %v", err, tt.errIs)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("AllocateRefund() error = %v", err)
+			}
+			got := make([]int64, len(refunds))
+			for i, refund := range refunds {
+				got[i] = refund.MinorAmount()
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AllocateRefund() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrderAddItemSameSku(t *testing.T) {
+	tests := []struct {
+		name         string
+		price        float64
+		wantErr      bool
+		wantQuantity int
+		wantTotal    int64
+	}{
+		{"same unit price is merged", 30, false, 3, 9000},
+		{"different unit price is rejected", 25, true, 1, 3000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := NewOrder("o1", "u1")
+			if err := order.AddItem(NewOrderItem("p1", "P1", "s1", "", 1, sharedvo.MustNewMoney(30, "CNY"))); err != nil {
+				t.Fatalf("AddItem() error = %v", err)
+			}
+			err := order.AddItem(NewOrderItem("p1", "P1", "s1", "", 2, sharedvo.MustNewMoney(tt.price, "CNY")))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("AddItem() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(order.Items) != 1 || order.Items[0].Quantity != tt.wantQuantity {
+				t.Errorf("items = %d, quantity = %d, want 1 item of %d", len(order.Items), order.Items[0].Quantity, tt.wantQuantity)
+			}
+			if order.TotalAmount.MinorAmount() != tt.wantTotal {
+				t.Errorf("total = %d, want %d", order.TotalAmount.MinorAmount(), tt.wantTotal)
+			}
+		})
+	}
+}
@@ -18,6 +18,9 @@ type OrderRepository interface {
 	// FindByUserIdAndStatus 根据用户ID和状态查找订单列表
 	FindByUserIdAndStatus(ctx context.Context, userId string, status valueobject.OrderStatus) ([]*entity.Order, error)
 
+	// ExistsOpenByProductId 检查是否存在包含该商品（包括作为套装组件）且尚未送达或取消的订单
+	ExistsOpenByProductId(ctx context.Context, productId string) (bool, error)
+
 	// Delete 删除订单
 	Delete(ctx context.Context, id string) error
 
@@ -7,6 +7,7 @@ import (
 	"main/internal/domain/order/event"
 	"main/internal/domain/order/repository"
 	"main/internal/domain/order/valueobject"
+	sharedservice "main/internal/domain/shared/service"
 	"main/internal/infrastructure/eventbus"
 
 	"github.com/gogf/gf/v2/errors/gerror"
@@ -14,29 +15,33 @@ import (
 
 // OrderService 领域服务，处理订单相关的核心业务逻辑
 type OrderService struct {
-	orderRepo repository.OrderRepository
-	eventBus  eventbus.EventBus // 事件总线
+	orderRepo   repository.OrderRepository
+	eventBus    eventbus.EventBus         // 事件总线
+	idGenerator sharedservice.IdGenerator // 订单 Id 生成器
 }
 
 // NewOrderService 创建订单领域服务实例
 func NewOrderService(
 	orderRepo repository.OrderRepository,
 	eventBus eventbus.EventBus,
+	idGenerator sharedservice.IdGenerator,
 ) *OrderService {
 	return &OrderService{
-		orderRepo: orderRepo,
-		eventBus:  eventBus,
+		orderRepo:   orderRepo,
+		eventBus:    eventBus,
+		idGenerator: idGenerator,
 	}
 }
 
 // CreateOrder 创建订单
 // 这是一个领域服务方法，专注于订单领域的业务规则
 func (s *OrderService) CreateOrder(ctx context.Context, userId string, items []*entity.OrderItem) (*entity.Order, error) {
-	// 1. 创建订单实体
-	order := entity.NewOrder(userId)
+	// 1. 创建订单实体，订单 Id 由 Id 生成器分配
+	order := entity.NewOrder(s.idGenerator.NewId(), userId)
 
-	// 2. 添加订单项
+	// 2. 添加订单项，订单项 Id 同样由 Id 生成器分配
 	for _, item := range items {
+		item.Id = s.idGenerator.NewId()
 		if err := order.AddItem(item); err != nil {
 			return nil, gerror.Wrap(err, "failed to add order item")
 		}
@@ -139,6 +144,9 @@ func (s *OrderService) GetOrder(ctx context.Context, orderId string) (*entity.Or
 	if err != nil {
 		return nil, gerror.Wrap(err, "failed to find order")
 	}
+	if order == nil {
+		return nil, gerror.Wrapf(valueobject.ErrOrderNotFound, "order %s not found", orderId)
+	}
 	return order, nil
 }
 
@@ -27,6 +27,9 @@ func (s OrderStatus) IsValid() bool {
 	}
 }
 
+// OpenOrderStatuses lists the statuses of orders that are neither delivered nor cancelled
+var OpenOrderStatuses = []OrderStatus{OrderStatusCreated, OrderStatusPaid, OrderStatusShipping}
+
 // CanTransitionTo checks if the current status can transition to the target status
 func (s OrderStatus) CanTransitionTo(target OrderStatus) bool {
 	switch s {
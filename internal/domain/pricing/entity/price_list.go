@@ -0,0 +1,159 @@
+package entity
+
+import (
+	"time"
+
+	"main/internal/domain/pricing/valueobject"
+	sharedvo "main/internal/domain/shared/valueobject"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// PriceList 价目表聚合根
+// 为某个客户分组（为空表示所有客户）在有效期内提供商品的协议价或阶梯价
+type PriceList struct {
+	Id            string
+	Name          string
+	CustomerGroup string // 客户分组，为空表示适用于所有客户
+	Currency      string // 价目表货币，所有阶梯价格必须使用该货币
+	Priority      int    // 优先级，数值越大越优先
+	ValidFrom     int64  // 生效时间（毫秒），0 表示立即生效
+	ValidTo       int64  // 失效时间（毫秒），0 表示长期有效
+	Status        valueobject.PriceListStatus
+	Entries       []*PriceListEntry
+	CreatedAt     int64
+	UpdatedAt     int64
+}
+
+// NewPriceList 创建价目表
+func NewPriceList(
+	id string,
+	name string,
+	customerGroup string,
+	currency string,
+	priority int,
+	validFrom int64,
+	validTo int64,
+) *PriceList {
+	now := time.Now().UnixMilli()
+	return &PriceList{
+		Id:            id,
+		Name:          name,
+		CustomerGroup: customerGroup,
+		Currency:      currency,
+		Priority:      priority,
+		ValidFrom:     validFrom,
+		ValidTo:       validTo,
+		Status:        valueobject.PriceListStatusActive,
+		Entries:       make([]*PriceListEntry, 0),
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+}
+
+// Update 更新价目表基本信息
+func (l *PriceList) Update(
+	name string,
+	customerGroup string,
+	priority int,
+	validFrom int64,
+	validTo int64,
+	status valueobject.PriceListStatus,
+) error {
+	if !status.IsValid() {
+		return gerror.Wrapf(valueobject.ErrInvalidPriceList, "invalid status: %s", status)
+	}
+	l.Name = name
+	l.CustomerGroup = customerGroup
+	l.Priority = priority
+	l.ValidFrom = validFrom
+	l.ValidTo = validTo
+	l.Status = status
+	l.UpdatedAt = time.Now().UnixMilli()
+	return nil
+}
+
+// SetEntry 设置商品的阶梯价格，已存在的条目将被替换
+func (l *PriceList) SetEntry(entry *PriceListEntry) error {
+	if err := entry.Validate(l.Currency); err != nil {
+		return err
+	}
+
+	for i, existing := range l.Entries {
+		if existing.ProductId == entry.ProductId {
+			l.Entries[i] = entry
+			l.UpdatedAt = time.Now().UnixMilli()
+			return nil
+		}
+	}
+
+	l.Entries = append(l.Entries, entry)
+	l.UpdatedAt = time.Now().UnixMilli()
+	return nil
+}
+
+// RemoveEntry 移除商品的价格条目
+func (l *PriceList) RemoveEntry(productId string) error {
+	for i, entry := range l.Entries {
+		if entry.ProductId == productId {
+			l.Entries = append(l.Entries[:i], l.Entries[i+1:]...)
+			l.UpdatedAt = time.Now().UnixMilli()
+			return nil
+		}
+	}
+	return gerror.Wrapf(valueobject.ErrProductNotInPriceList, "product %s", productId)
+}
+
+// EntryOf 获取商品的价格条目，不存在时返回 nil
+func (l *PriceList) EntryOf(productId string) *PriceListEntry {
+	for _, entry := range l.Entries {
+		if entry.ProductId == productId {
+			return entry
+		}
+	}
+	return nil
+}
+
+// IsEffectiveAt 判断价目表在指定时间（毫秒）是否生效
+func (l *PriceList) IsEffectiveAt(at int64) bool {
+	if l.Status != valueobject.PriceListStatusActive {
+		return false
+	}
+	if l.ValidFrom > 0 && at < l.ValidFrom {
+		return false
+	}
+	if l.ValidTo > 0 && at >= l.ValidTo {
+		return false
+	}
+	return true
+}
+
+// AppliesTo 判断价目表是否适用于指定客户分组
+func (l *PriceList) AppliesTo(customerGroup string) bool {
+	return l.CustomerGroup == "" || l.CustomerGroup == customerGroup
+}
+
+// Validate 验证价目表
+func (l *PriceList) Validate() error {
+	if l.Id == "" {
+		return gerror.Wrap(valueobject.ErrInvalidPriceList, "price list id is required")
+	}
+	if l.Name == "" {
+		return valueobject.ErrInvalidPriceListName
+	}
+	if !sharedvo.IsValidCurrency(l.Currency) {
+		return gerror.Wrapf(sharedvo.ErrInvalidCurrency, "unknown currency: %s", l.Currency)
+	}
+	if !l.Status.IsValid() {
+		return gerror.Wrapf(valueobject.ErrInvalidPriceList, "invalid status: %s", l.Status)
+	}
+	if l.ValidFrom < 0 || l.ValidTo < 0 || (l.ValidTo > 0 && l.ValidTo <= l.ValidFrom) {
+		return gerror.Wrapf(valueobject.ErrInvalidValidityPeriod, "valid from %d to %d", l.ValidFrom, l.ValidTo)
+	}
+	for _, entry := range l.Entries {
+		if err := entry.Validate(l.Currency); err != nil {
+			return err
+		}
+	}
+	return nil
+}
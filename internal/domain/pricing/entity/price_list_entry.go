@@ -0,0 +1,71 @@
+package entity
+
+import (
+	"sort"
+
+	"main/internal/domain/pricing/valueobject"
+	sharedvo "main/internal/domain/shared/valueobject"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// PriceListEntry 价目表中某个商品的价格条目
+type PriceListEntry struct {
+	ProductId string                   // 商品ID
+	Tiers     []*valueobject.PriceTier // 阶梯价格，按起订数量升序排列
+}
+
+// NewPriceListEntry 创建价目表条目
+// 阶梯价格会按起订数量升序排列
+func NewPriceListEntry(productId string, tiers []*valueobject.PriceTier) *PriceListEntry {
+	sorted := append([]*valueobject.PriceTier(nil), tiers...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].MinQuantity < sorted[j].MinQuantity
+	})
+	return &PriceListEntry{
+		ProductId: productId,
+		Tiers:     sorted,
+	}
+}
+
+// PriceFor 获取指定购买数量适用的阶梯价格
+// 返回起订数量不超过购买数量的最高阶梯，没有适用阶梯时返回 nil
+func (e *PriceListEntry) PriceFor(quantity int) *valueobject.PriceTier {
+	var matched *valueobject.PriceTier
+	for _, tier := range e.Tiers {
+		if tier.MinQuantity > quantity {
+			break
+		}
+		matched = tier
+	}
+	return matched
+}
+
+// Validate 验证价目表条目
+func (e *PriceListEntry) Validate(currency string) error {
+	if e.ProductId == "" {
+		return gerror.New("product id is required")
+	}
+	if len(e.Tiers) == 0 {
+		return gerror.Wrapf(valueobject.ErrInvalidPriceTier, "product %s has no price tier", e.ProductId)
+	}
+
+	for i, tier := range e.Tiers {
+		if err := tier.Validate(); err != nil {
+			return err
+		}
+		if tier.Price.Currency() != currency {
+			return gerror.Wrapf(sharedvo.ErrCurrencyMismatch,
+				"tier price currency %s does not match price list currency %s",
+				tier.Price.Currency(), currency,
+			)
+		}
+		if i > 0 && tier.MinQuantity == e.Tiers[i-1].MinQuantity {
+			return gerror.Wrapf(valueobject.ErrInvalidPriceTier,
+				"duplicate min quantity %d for product %s",
+				tier.MinQuantity, e.ProductId,
+			)
+		}
+	}
+	return nil
+}
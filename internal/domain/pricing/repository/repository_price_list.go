@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+
+	"main/internal/domain/pricing/entity"
+)
+
+// PriceListRepository 价目表仓储接口
+type PriceListRepository interface {
+	// Save 保存价目表
+	Save(ctx context.Context, priceList *entity.PriceList) error
+	// FindById 根据Id查找价目表
+	FindById(ctx context.Context, id string) (*entity.PriceList, error)
+	// FindAll 查找所有价目表
+	FindAll(ctx context.Context) ([]*entity.PriceList, error)
+	// FindByCustomerGroup 查找适用于客户分组的价目表，包括适用于所有客户的价目表
+	FindByCustomerGroup(ctx context.Context, customerGroup string) ([]*entity.PriceList, error)
+	// Delete 删除价目表
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"sort"
+
+	"main/internal/domain/pricing/entity"
+	"main/internal/domain/pricing/repository"
+	"main/internal/domain/pricing/valueobject"
+	sharedservice "main/internal/domain/shared/service"
+	sharedvo "main/internal/domain/shared/valueobject"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// PricingService 定价领域服务
+// 负责价目表的维护，以及根据客户分组、购买数量和时间解析商品的最终单价
+type PricingService struct {
+	priceListRepo repository.PriceListRepository
+	idGenerator   sharedservice.IdGenerator // 价目表 Id 生成器
+}
+
+// NewPricingService 创建定价领域服务实例
+func NewPricingService(
+	priceListRepo repository.PriceListRepository,
+	idGenerator sharedservice.IdGenerator,
+) *PricingService {
+	return &PricingService{
+		priceListRepo: priceListRepo,
+		idGenerator:   idGenerator,
+	}
+}
+
+// CreatePriceList 创建价目表
+func (s *PricingService) CreatePriceList(
+	ctx context.Context,
+	name string,
+	customerGroup string,
+	currency string,
+	priority int,
+	validFrom int64,
+	validTo int64,
+) (*entity.PriceList, error) {
+	priceList := entity.NewPriceList(s.idGenerator.NewId(), name, customerGroup, currency, priority, validFrom, validTo)
+	if err := priceList.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.priceListRepo.Save(ctx, priceList); err != nil {
+		return nil, gerror.Wrap(err, "failed to save price list")
+	}
+	return priceList, nil
+}
+
+// UpdatePriceList 更新价目表基本信息
+func (s *PricingService) UpdatePriceList(
+	ctx context.Context,
+	id string,
+	name string,
+	customerGroup string,
+	priority int,
+	validFrom int64,
+	validTo int64,
+	status valueobject.PriceListStatus,
+) (*entity.PriceList, error) {
+	priceList, err := s.priceListRepo.FindById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err = priceList.Update(name, customerGroup, priority, validFrom, validTo, status); err != nil {
+		return nil, err
+	}
+	if err = priceList.Validate(); err != nil {
+		return nil, err
+	}
+	if err = s.priceListRepo.Save(ctx, priceList); err != nil {
+		return nil, gerror.Wrap(err, "failed to save price list")
+	}
+	return priceList, nil
+}
+
+// SetEntry 设置价目表中商品的阶梯价格
+func (s *PricingService) SetEntry(
+	ctx context.Context,
+	priceListId string,
+	productId string,
+	tiers []*valueobject.PriceTier,
+) (*entity.PriceList, error) {
+	priceList, err := s.priceListRepo.FindById(ctx, priceListId)
+	if err != nil {
+		return nil, err
+	}
+	if err = priceList.SetEntry(entity.NewPriceListEntry(productId, tiers)); err != nil {
+		return nil, err
+	}
+	if err = s.priceListRepo.Save(ctx, priceList); err != nil {
+		return nil, gerror.Wrap(err, "failed to save price list")
+	}
+	return priceList, nil
+}
+
+// RemoveEntry 移除价目表中商品的价格条目
+func (s *PricingService) RemoveEntry(ctx context.Context, priceListId string, productId string) (*entity.PriceList, error) {
+	priceList, err := s.priceListRepo.FindById(ctx, priceListId)
+	if err != nil {
+		return nil, err
+	}
+	if err = priceList.RemoveEntry(productId); err != nil {
+		return nil, err
+	}
+	if err = s.priceListRepo.Save(ctx, priceList); err != nil {
+		return nil, gerror.Wrap(err, "failed to save price list")
+	}
+	return priceList, nil
+}
+
+// GetPriceList 获取价目表
+func (s *PricingService) GetPriceList(ctx context.Context, id string) (*entity.PriceList, error) {
+	return s.priceListRepo.FindById(ctx, id)
+}
+
+// ListPriceLists 获取价目表列表，客户分组为空时返回所有价目表
+func (s *PricingService) ListPriceLists(ctx context.Context, customerGroup string) ([]*entity.PriceList, error) {
+	if customerGroup == "" {
+		return s.priceListRepo.FindAll(ctx)
+	}
+	return s.priceListRepo.FindByCustomerGroup(ctx, customerGroup)
+}
+
+// DeletePriceList 删除价目表
+func (s *PricingService) DeletePriceList(ctx context.Context, id string) error {
+	return s.priceListRepo.Delete(ctx, id)
+}
+
+// ResolvePrice 解析商品的最终单价
+// 规则：
+// 1. 只考虑在指定时间生效、适用于该客户分组且货币与基础价格一致的价目表
+// 2. 客户分组专属的价目表优先于通用价目表，同类中优先级高者优先
+// 3. 取第一个包含该商品且有适用阶梯的价目表的阶梯单价
+// 4. 没有任何适用的价目表时使用商品基础价格
+func (s *PricingService) ResolvePrice(
+	ctx context.Context,
+	productId string,
+	basePrice *sharedvo.Money,
+	customerGroup string,
+	quantity int,
+	at int64,
+) (*valueobject.ResolvedPrice, error) {
+	// 1. 查找候选价目表
+	priceLists, err := s.priceListRepo.FindByCustomerGroup(ctx, customerGroup)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to find price lists")
+	}
+	candidates := make([]*entity.PriceList, 0, len(priceLists))
+	for _, priceList := range priceLists {
+		if priceList.AppliesTo(customerGroup) &&
+			priceList.IsEffectiveAt(at) &&
+			priceList.Currency == basePrice.Currency() {
+			candidates = append(candidates, priceList)
+		}
+	}
+
+	// 2. 按专属优先、优先级降序排序
+	sort.SliceStable(candidates, func(i, j int) bool {
+		iGroup, jGroup := candidates[i].CustomerGroup != "", candidates[j].CustomerGroup != ""
+		if iGroup != jGroup {
+			return iGroup
+		}
+		return candidates[i].Priority > candidates[j].Priority
+	})
+
+	// 3. 匹配阶梯价格
+	for _, priceList := range candidates {
+		entry := priceList.EntryOf(productId)
+		if entry == nil {
+			continue
+		}
+		if tier := entry.PriceFor(quantity); tier != nil {
+			return &valueobject.ResolvedPrice{
+				UnitPrice:   tier.Price,
+				PriceListId: priceList.Id,
+				MinQuantity: tier.MinQuantity,
+			}, nil
+		}
+	}
+
+	// 4. 回退到基础价格
+	return &valueobject.ResolvedPrice{
+		UnitPrice:   basePrice,
+		MinQuantity: 1,
+	}, nil
+}
@@ -0,0 +1,13 @@
+package valueobject
+
+import "github.com/gogf/gf/v2/errors/gerror"
+
+// 定价领域错误定义
+var (
+	ErrPriceListNotFound     = gerror.New("price list not found")
+	ErrInvalidPriceList      = gerror.New("invalid price list")
+	ErrInvalidPriceListName  = gerror.New("invalid price list name")
+	ErrInvalidValidityPeriod = gerror.New("invalid validity period")
+	ErrInvalidPriceTier      = gerror.New("invalid price tier")
+	ErrProductNotInPriceList = gerror.New("product not in price list")
+)
@@ -0,0 +1,49 @@
+package valueobject
+
+import (
+	sharedvo "main/internal/domain/shared/valueobject"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// PriceTier 阶梯价格值对象
+// 单个订单项的购买数量达到 MinQuantity 时适用该单价
+type PriceTier struct {
+	MinQuantity int             // 起订数量
+	Price       *sharedvo.Money // 单价
+}
+
+// NewPriceTier 创建阶梯价格
+func NewPriceTier(minQuantity int, price *sharedvo.Money) *PriceTier {
+	return &PriceTier{
+		MinQuantity: minQuantity,
+		Price:       price,
+	}
+}
+
+// Validate 验证阶梯价格
+func (t *PriceTier) Validate() error {
+	if t.MinQuantity < 1 {
+		return gerror.Wrapf(ErrInvalidPriceTier, "min quantity must be at least 1: %d", t.MinQuantity)
+	}
+	if t.Price == nil {
+		return gerror.Wrap(ErrInvalidPriceTier, "price is required")
+	}
+	if err := t.Price.Validate(); err != nil {
+		return gerror.Wrap(err, "invalid tier price")
+	}
+	return nil
+}
+
+// ResolvedPrice 定价结果值对象
+// 记录最终单价及其来源，便于订单追溯价格依据
+type ResolvedPrice struct {
+	UnitPrice   *sharedvo.Money // 最终单价
+	PriceListId string          // 来源价目表，为空表示使用商品基础价格
+	MinQuantity int             // 命中的阶梯起订数量
+}
+
+// IsBasePrice 是否为商品基础价格
+func (p *ResolvedPrice) IsBasePrice() bool {
+	return p.PriceListId == ""
+}
@@ -0,0 +1,24 @@
+package valueobject
+
+// PriceListStatus 价目表状态
+type PriceListStatus string
+
+const (
+	PriceListStatusActive   PriceListStatus = "active"   // 启用
+	PriceListStatusInactive PriceListStatus = "inactive" // 停用
+)
+
+// IsValid 检查状态是否有效
+func (s PriceListStatus) IsValid() bool {
+	switch s {
+	case PriceListStatusActive, PriceListStatusInactive:
+		return true
+	default:
+		return false
+	}
+}
+
+// String 返回状态的字符串表示
+func (s PriceListStatus) String() string {
+	return string(s)
+}
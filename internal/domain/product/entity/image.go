@@ -0,0 +1,57 @@
+package entity
+
+import (
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"main/internal/domain/product/valueobject"
+)
+
+// ProductImage 商品图片实体
+// 每张图片包含原图和按 valueobject.ThumbnailSizes 生成的各尺寸缩略图
+type ProductImage struct {
+	Id         string
+	Original   valueobject.ImageFile
+	Thumbnails map[string]valueobject.ImageFile // 尺寸名称 -> 缩略图
+	CreatedAt  int64
+}
+
+// NewProductImage 创建商品图片实体
+func NewProductImage(
+	id string,
+	original valueobject.ImageFile,
+	thumbnails map[string]valueobject.ImageFile,
+	createdAt int64,
+) *ProductImage {
+	return &ProductImage{
+		Id:         id,
+		Original:   original,
+		Thumbnails: thumbnails,
+		CreatedAt:  createdAt,
+	}
+}
+
+// Keys 获取图片在对象存储中的所有文件键，包括原图和缩略图
+func (i *ProductImage) Keys() []string {
+	keys := make([]string, 0, len(i.Thumbnails)+1)
+	keys = append(keys, i.Original.Key)
+	for _, thumbnail := range i.Thumbnails {
+		keys = append(keys, thumbnail.Key)
+	}
+	return keys
+}
+
+// Validate 验证商品图片
+func (i *ProductImage) Validate() error {
+	if i.Id == "" {
+		return gerror.Wrap(valueobject.ErrInvalidImage, "image id is required")
+	}
+	if i.Original.Key == "" {
+		return gerror.Wrapf(valueobject.ErrInvalidImage, "image %s has no file", i.Id)
+	}
+	if !valueobject.IsSupportedImageType(i.Original.ContentType) {
+		return gerror.Wrapf(valueobject.ErrInvalidImage,
+			"unsupported image type %s of image %s", i.Original.ContentType, i.Id,
+		)
+	}
+	return nil
+}
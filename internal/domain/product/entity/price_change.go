@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"time"
+
+	"main/internal/domain/product/valueobject"
+	sharedvo "main/internal/domain/shared/valueobject"
+)
+
+// PriceChange 价格历史记录
+// SKU 价格的每一次变更都记录调整前后的价格和操作人，记录不可修改
+type PriceChange struct {
+	Id        string
+	ProductId string
+	SkuId     string
+	OldPrice  *sharedvo.Money
+	NewPrice  *sharedvo.Money
+	Actor     string
+	Reference string // 关联单据，如定时调价的 Id
+	Note      string
+	CreatedAt int64
+}
+
+// NewPriceChange 创建价格历史记录，未指定操作人时记为系统
+func NewPriceChange(
+	id string,
+	productId string,
+	skuId string,
+	oldPrice *sharedvo.Money,
+	newPrice *sharedvo.Money,
+	source valueobject.MovementSource,
+) *PriceChange {
+	actor := source.Actor
+	if actor == "" {
+		actor = valueobject.SystemActor
+	}
+	return &PriceChange{
+		Id:        id,
+		ProductId: productId,
+		SkuId:     skuId,
+		OldPrice:  oldPrice,
+		NewPrice:  newPrice,
+		Actor:     actor,
+		Reference: source.Reference,
+		Note:      source.Note,
+		CreatedAt: time.Now().UnixMilli(),
+	}
+}
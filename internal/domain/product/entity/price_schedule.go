@@ -0,0 +1,140 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"main/internal/domain/product/valueobject"
+	sharedvo "main/internal/domain/shared/valueobject"
+)
+
+// PriceSchedule 定时调价
+// 在开始时间将 SKU 调整为指定价格，设置了结束时间的在结束时恢复为开始时的价格，用于促销活动
+type PriceSchedule struct {
+	Id        string
+	ProductId string
+	SkuId     string
+	Price     *sharedvo.Money // 调整后的价格
+	// OriginalPrice 开始时 SKU 的价格，结束时恢复为该价格，开始前为空
+	OriginalPrice *sharedvo.Money
+	StartAt       int64 // 开始时间（毫秒）
+	EndAt         int64 // 结束时间（毫秒），0 表示永久调价，不恢复原价
+	Status        valueobject.PriceScheduleStatus
+	Actor         string // 创建定时调价的操作人
+	Note          string
+	CreatedAt     int64
+	UpdatedAt     int64
+}
+
+// NewPriceSchedule 创建等待开始的定时调价
+func NewPriceSchedule(
+	id string,
+	productId string,
+	skuId string,
+	price *sharedvo.Money,
+	startAt int64,
+	endAt int64,
+	actor string,
+	note string,
+) *PriceSchedule {
+	now := time.Now().UnixMilli()
+	return &PriceSchedule{
+		Id:        id,
+		ProductId: productId,
+		SkuId:     skuId,
+		Price:     price,
+		StartAt:   startAt,
+		EndAt:     endAt,
+		Status:    valueobject.PriceSchedulePending,
+		Actor:     actor,
+		Note:      note,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// IsEffectiveAt 判断定时调价在指定时间（毫秒）是否生效，不论调度器是否已应用
+func (s *PriceSchedule) IsEffectiveAt(at int64) bool {
+	return s.Status.IsOpen() && s.StartAt <= at && (s.EndAt == 0 || at < s.EndAt)
+}
+
+// IsDueToStart 判断是否已到开始时间而尚未应用
+func (s *PriceSchedule) IsDueToStart(at int64) bool {
+	return s.Status == valueobject.PriceSchedulePending && s.StartAt <= at
+}
+
+// IsDueToEnd 判断是否已到结束时间而尚未恢复原价
+func (s *PriceSchedule) IsDueToEnd(at int64) bool {
+	return s.Status == valueobject.PriceScheduleActive && s.EndAt > 0 && s.EndAt <= at
+}
+
+// Overlaps 判断与另一个定时调价的时间段是否重叠
+func (s *PriceSchedule) Overlaps(other *PriceSchedule) bool {
+	startsBeforeOtherEnds := other.EndAt == 0 || s.StartAt < other.EndAt
+	endsAfterOtherStarts := s.EndAt == 0 || other.StartAt < s.EndAt
+	return startsBeforeOtherEnds && endsAfterOtherStarts
+}
+
+// Start 开始定时调价，记录开始时的价格，永久调价开始后即结束
+func (s *PriceSchedule) Start(originalPrice *sharedvo.Money) error {
+	if s.Status != valueobject.PriceSchedulePending {
+		return gerror.Wrapf(valueobject.ErrInvalidPriceSchedule,
+			"cannot start price schedule %s in status %s", s.Id, s.Status,
+		)
+	}
+	s.OriginalPrice = originalPrice
+	s.Status = valueobject.PriceScheduleActive
+	if s.EndAt == 0 {
+		s.Status = valueobject.PriceScheduleCompleted
+	}
+	s.UpdatedAt = time.Now().UnixMilli()
+	return nil
+}
+
+// End 结束定时调价
+func (s *PriceSchedule) End() error {
+	if s.Status != valueobject.PriceScheduleActive {
+		return gerror.Wrapf(valueobject.ErrInvalidPriceSchedule,
+			"cannot end price schedule %s in status %s", s.Id, s.Status,
+		)
+	}
+	s.Status = valueobject.PriceScheduleCompleted
+	s.UpdatedAt = time.Now().UnixMilli()
+	return nil
+}
+
+// Cancel 取消定时调价，已开始的定时调价由调用方先恢复原价
+func (s *PriceSchedule) Cancel() error {
+	if !s.Status.IsOpen() {
+		return gerror.Wrapf(valueobject.ErrInvalidPriceSchedule,
+			"cannot cancel price schedule %s in status %s", s.Id, s.Status,
+		)
+	}
+	s.Status = valueobject.PriceScheduleCancelled
+	s.UpdatedAt = time.Now().UnixMilli()
+	return nil
+}
+
+// Validate 验证定时调价
+func (s *PriceSchedule) Validate() error {
+	if s.Id == "" || s.ProductId == "" || s.SkuId == "" {
+		return gerror.Wrap(valueobject.ErrInvalidPriceSchedule, "id, product and sku are required")
+	}
+	if s.Price == nil {
+		return gerror.Wrap(valueobject.ErrInvalidPriceSchedule, "price is required")
+	}
+	if err := s.Price.Validate(); err != nil {
+		return gerror.Wrap(valueobject.ErrInvalidPriceSchedule, err.Error())
+	}
+	if s.StartAt <= 0 || s.EndAt < 0 || (s.EndAt > 0 && s.EndAt <= s.StartAt) {
+		return gerror.Wrapf(valueobject.ErrInvalidPriceSchedule, "invalid period from %d to %d", s.StartAt, s.EndAt)
+	}
+	if s.Actor == "" {
+		return gerror.Wrap(valueobject.ErrInvalidPriceSchedule, "actor is required")
+	}
+	if !s.Status.IsValid() {
+		return gerror.Wrapf(valueobject.ErrInvalidPriceSchedule, "invalid status: %s", s.Status)
+	}
+	return nil
+}
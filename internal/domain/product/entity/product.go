@@ -1,20 +1,40 @@
 package entity
 
 import (
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+
 	"main/internal/domain/product/valueobject"
 	sharedvo "main/internal/domain/shared/valueobject"
 )
 
 // Product 商品实体
+// 商品是聚合根，价格和库存由其下的 SKU 持有
 type Product struct {
 	Id          string
 	Name        string
 	Description string
-	Price       *sharedvo.Money
-	Stock       int
+	CategoryId  string // 所属类目，为空表示未分类
+	SKUs        []*SKU
+	Images      []*ProductImage // 商品图片，按展示顺序排列，第一张为主图
 	Status      valueobject.ProductStatus
-	CreatedAt   int64
-	UpdatedAt   int64
+	// Type 商品类型，普通商品或套装
+	Type valueobject.ProductType
+	// Components 套装包含的组件，仅套装商品有值
+	// 套装只有一个不持有库存的 SKU，其价格即套装价，可售数量由组件的库存推算
+	Components valueobject.BundleComponents
+	// BundleAvailable 套装由组件的可售数量推算的可售数量，由商品服务在组件库存或状态变化时刷新
+	BundleAvailable int
+	// LowStockThreshold 低库存阈值，可售数量总和不高于该值时需要补货，为 0 表示不预警
+	LowStockThreshold int
+	// Rating 审核通过的评价的评分汇总，由评价子系统维护
+	Rating    valueobject.ProductRating
+	CreatedAt int64
+	UpdatedAt int64
+	DeletedAt int64 // 删除时间（毫秒），未删除时为 0
+	// Version 乐观锁版本号，由仓储在每次写入时递增，保存时版本号不一致说明商品已被并发修改
+	Version int64
 }
 
 // NewProduct 创建商品实体
@@ -22,8 +42,8 @@ func NewProduct(
 	id string,
 	name string,
 	description string,
-	price *sharedvo.Money,
-	stock int,
+	categoryId string,
+	skus []*SKU,
 	status valueobject.ProductStatus,
 	createdAt int64,
 	updatedAt int64,
@@ -32,41 +52,508 @@ func NewProduct(
 		Id:          id,
 		Name:        name,
 		Description: description,
-		Price:       price,
-		Stock:       stock,
+		CategoryId:  categoryId,
+		Type:        valueobject.ProductTypeStandard,
+		SKUs:        skus,
 		Status:      status,
 		CreatedAt:   createdAt,
 		UpdatedAt:   updatedAt,
 	}
 }
 
-// UpdateStock 更新库存
-func (p *Product) UpdateStock(stock int) error {
-	if stock < 0 {
-		return valueobject.ErrInvalidStock
+// NewBundle 创建草稿状态的套装商品，套装价格由唯一的 SKU 持有
+func NewBundle(
+	id string,
+	name string,
+	description string,
+	categoryId string,
+	price *sharedvo.Money,
+	components valueobject.BundleComponents,
+) *Product {
+	now := time.Now().UnixMilli()
+	product := NewProduct(
+		id,
+		name,
+		description,
+		categoryId,
+		[]*SKU{NewSKU("", nil, price, 0, "")},
+		valueobject.ProductStatusDraft,
+		now,
+		now,
+	)
+	product.Type = valueobject.ProductTypeBundle
+	product.Components = components
+	return product
+}
+
+// IsBundle 检查商品是否为套装
+func (p *Product) IsBundle() bool {
+	return p.Type == valueobject.ProductTypeBundle
+}
+
+// SetComponents 替换套装的组件，组件商品是否存在由调用方检查
+func (p *Product) SetComponents(components valueobject.BundleComponents) error {
+	if !p.IsBundle() {
+		return gerror.Wrapf(valueobject.ErrInvalidProductOp, "product %s is not a bundle", p.Id)
+	}
+	if err := components.Validate(); err != nil {
+		return err
 	}
-	p.Stock = stock
+	p.Components = components
 	return nil
 }
 
-// UpdatePrice 更新价格
-func (p *Product) UpdatePrice(price *sharedvo.Money) error {
-	if price == nil {
-		return valueobject.ErrInvalidPrice
+// AssignCategory 将商品归入类目，类目 Id 为空时取消分类
+// 类目是否存在由调用方检查
+func (p *Product) AssignCategory(categoryId string) {
+	p.CategoryId = categoryId
+}
+
+// FindSKU 根据 Id 查找 SKU
+func (p *Product) FindSKU(skuId string) (*SKU, error) {
+	for _, sku := range p.SKUs {
+		if sku.Id == skuId {
+			return sku, nil
+		}
 	}
-	p.Price = price
+	return nil, gerror.Wrapf(valueobject.ErrSKUNotFound, "sku %s not found in product %s", skuId, p.Id)
+}
+
+// AddSKU 添加 SKU
+// 同一商品下 SKU 的规格组合和条码不能重复
+func (p *Product) AddSKU(sku *SKU) error {
+	if err := sku.Validate(); err != nil {
+		return err
+	}
+	if err := p.checkDuplicate(sku); err != nil {
+		return err
+	}
+	p.SKUs = append(p.SKUs, sku)
 	return nil
 }
 
+// UpdateSKU 更新 SKU 的价格和条码，库存通过 AdjustStock 等库存操作变更
+func (p *Product) UpdateSKU(skuId string, price *sharedvo.Money, barcode string) error {
+	sku, err := p.FindSKU(skuId)
+	if err != nil {
+		return err
+	}
+	updated := NewSKU(sku.Id, sku.Options, price, sku.Stock, barcode)
+	updated.Reserved = sku.Reserved
+	updated.ExternalId = sku.ExternalId
+	if err = updated.Validate(); err != nil {
+		return err
+	}
+	if err = p.checkDuplicate(updated); err != nil {
+		return err
+	}
+	*sku = *updated
+	return nil
+}
+
+// UpdatePrice 更新 SKU 的价格
+func (p *Product) UpdatePrice(skuId string, price *sharedvo.Money) error {
+	sku, err := p.FindSKU(skuId)
+	if err != nil {
+		return err
+	}
+	if err = sku.UpdatePrice(price); err != nil {
+		return err
+	}
+	return sku.Validate()
+}
+
+// UpsertSKU 按外部编码导入 SKU，返回是否新增了 SKU
+// 优先匹配外部编码相同的 SKU，其次匹配规格组合相同且尚无外部编码的 SKU；
+// 匹配到时更新其价格、条码和在库数量，规格组合不可修改，未匹配时作为新 SKU 添加
+func (p *Product) UpsertSKU(sku *SKU) (bool, error) {
+	if sku.ExternalId == "" {
+		return false, gerror.Wrap(valueobject.ErrInvalidSKU, "sku external id is required")
+	}
+	existing := p.matchSKU(sku)
+	if existing == nil {
+		if err := p.AddSKU(sku); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+	if existing.Options.Key() != sku.Options.Key() {
+		return false, gerror.Wrapf(valueobject.ErrInvalidSKU,
+			"sku options of %s cannot be changed from %q to %q", sku.ExternalId, existing.Name(), sku.Name(),
+		)
+	}
+
+	original := *existing
+	existing.Price = sku.Price
+	existing.Barcode = sku.Barcode
+	existing.ExternalId = sku.ExternalId
+	err := existing.UpdateStock(sku.Stock)
+	if err == nil {
+		err = existing.Validate()
+	}
+	if err == nil {
+		err = p.checkDuplicate(existing)
+	}
+	if err != nil {
+		*existing = original
+		return false, err
+	}
+	return false, nil
+}
+
+// matchSKU 查找与导入的 SKU 对应的已有 SKU
+func (p *Product) matchSKU(sku *SKU) *SKU {
+	for _, existing := range p.SKUs {
+		if existing.ExternalId == sku.ExternalId {
+			return existing
+		}
+	}
+	for _, existing := range p.SKUs {
+		if existing.ExternalId == "" && existing.Options.Key() == sku.Options.Key() {
+			return existing
+		}
+	}
+	return nil
+}
+
+// RemoveSKU 移除 SKU
+// 商品至少需要保留一个 SKU，有未支付订单预占库存的 SKU 不能移除
+func (p *Product) RemoveSKU(skuId string) error {
+	for i, sku := range p.SKUs {
+		if sku.Id != skuId {
+			continue
+		}
+		if len(p.SKUs) == 1 {
+			return gerror.Wrapf(valueobject.ErrInvalidSKU, "cannot remove the only sku of product %s", p.Id)
+		}
+		if sku.Reserved > 0 {
+			return gerror.Wrapf(valueobject.ErrInvalidSKU, "cannot remove sku %s with %d reserved", skuId, sku.Reserved)
+		}
+		p.SKUs = append(p.SKUs[:i:i], p.SKUs[i+1:]...)
+		return nil
+	}
+	return gerror.Wrapf(valueobject.ErrSKUNotFound, "sku %s not found in product %s", skuId, p.Id)
+}
+
+// checkDuplicate 检查 SKU 是否与其他 SKU 的规格组合或条码重复
+func (p *Product) checkDuplicate(sku *SKU) error {
+	for _, existing := range p.SKUs {
+		if existing == sku || (sku.Id != "" && existing.Id == sku.Id) {
+			continue
+		}
+		if existing.Options.Key() == sku.Options.Key() {
+			return gerror.Wrapf(valueobject.ErrDuplicateSKU, "sku options %q already exist", sku.Name())
+		}
+		if sku.Barcode != "" && existing.Barcode == sku.Barcode {
+			return gerror.Wrapf(valueobject.ErrDuplicateSKU, "sku barcode %s already exists", sku.Barcode)
+		}
+		if sku.ExternalId != "" && existing.ExternalId == sku.ExternalId {
+			return gerror.Wrapf(valueobject.ErrDuplicateSKU, "sku external id %s already exists", sku.ExternalId)
+		}
+	}
+	return nil
+}
+
+// Clone 复制商品，SKU 为深拷贝，修改副本的 SKU 不影响原商品
+func (p *Product) Clone() *Product {
+	clone := *p
+	clone.SKUs = make([]*SKU, len(p.SKUs))
+	for i, sku := range p.SKUs {
+		copied := *sku
+		clone.SKUs[i] = &copied
+	}
+	clone.Images = append([]*ProductImage(nil), p.Images...)
+	clone.Components = append(valueobject.BundleComponents(nil), p.Components...)
+	return &clone
+}
+
+// FindImage 根据 Id 查找商品图片
+func (p *Product) FindImage(imageId string) (*ProductImage, error) {
+	for _, image := range p.Images {
+		if image.Id == imageId {
+			return image, nil
+		}
+	}
+	return nil, gerror.Wrapf(valueobject.ErrImageNotFound, "image %s not found in product %s", imageId, p.Id)
+}
+
+// AddImage 在图片列表末尾添加图片
+func (p *Product) AddImage(image *ProductImage) error {
+	if err := image.Validate(); err != nil {
+		return err
+	}
+	if len(p.Images) >= valueobject.MaxProductImages {
+		return gerror.Wrapf(valueobject.ErrTooManyImages,
+			"product %s already has %d images", p.Id, len(p.Images),
+		)
+	}
+	if _, err := p.FindImage(image.Id); err == nil {
+		return gerror.Wrapf(valueobject.ErrInvalidImage, "duplicate image %s", image.Id)
+	}
+	p.Images = append(p.Images, image)
+	return nil
+}
+
+// RemoveImage 移除图片，返回被移除的图片以便清理其文件
+func (p *Product) RemoveImage(imageId string) (*ProductImage, error) {
+	for i, image := range p.Images {
+		if image.Id == imageId {
+			p.Images = append(p.Images[:i], p.Images[i+1:]...)
+			return image, nil
+		}
+	}
+	return nil, gerror.Wrapf(valueobject.ErrImageNotFound, "image %s not found in product %s", imageId, p.Id)
+}
+
+// ReorderImages 按给定的图片 Id 顺序重新排列图片
+// 必须包含商品的全部图片且不能重复
+func (p *Product) ReorderImages(imageIds []string) error {
+	if len(imageIds) != len(p.Images) {
+		return gerror.Wrapf(valueobject.ErrInvalidImageOrder,
+			"expected %d images, got %d", len(p.Images), len(imageIds),
+		)
+	}
+	images := make([]*ProductImage, 0, len(imageIds))
+	seen := make(map[string]bool, len(imageIds))
+	for _, id := range imageIds {
+		if seen[id] {
+			return gerror.Wrapf(valueobject.ErrInvalidImageOrder, "duplicate image %s", id)
+		}
+		seen[id] = true
+		image, err := p.FindImage(id)
+		if err != nil {
+			return err
+		}
+		images = append(images, image)
+	}
+	p.Images = images
+	return nil
+}
+
+// TotalStock 获取所有 SKU 的在库数量总和
+func (p *Product) TotalStock() int {
+	total := 0
+	for _, sku := range p.SKUs {
+		total += sku.Stock
+	}
+	return total
+}
+
+// TotalAvailable 获取所有 SKU 的可售数量总和
+// 套装的 SKU 不持有库存，返回由组件推算的可售数量
+func (p *Product) TotalAvailable() int {
+	if p.IsBundle() {
+		return p.BundleAvailable
+	}
+	total := 0
+	for _, sku := range p.SKUs {
+		total += sku.Available()
+	}
+	return total
+}
+
+// MinPrice 获取 SKU 中的最低价格，用于列表展示和按价格排序
+// 只比较与第一个 SKU 货币相同的价格，商品没有 SKU 时返回 nil
+func (p *Product) MinPrice() *sharedvo.Money {
+	var min *sharedvo.Money
+	for _, sku := range p.SKUs {
+		if min == nil {
+			min = sku.Price
+			continue
+		}
+		if cmp, err := sku.Price.Compare(min); err == nil && cmp < 0 {
+			min = sku.Price
+		}
+	}
+	return min
+}
+
+// SetLowStockThreshold 设置低库存阈值，为 0 表示不预警
+func (p *Product) SetLowStockThreshold(threshold int) error {
+	if threshold < 0 {
+		return gerror.Wrapf(valueobject.ErrInvalidThreshold, "low stock threshold cannot be negative: %d", threshold)
+	}
+	if p.IsBundle() && threshold > 0 {
+		return gerror.Wrapf(valueobject.ErrInvalidProductOp, "bundle %s does not hold stock", p.Id)
+	}
+	p.LowStockThreshold = threshold
+	return nil
+}
+
+// IsLowStock 检查可售数量总和是否已不高于低库存阈值
+func (p *Product) IsLowStock() bool {
+	return p.LowStockThreshold > 0 && p.TotalAvailable() <= p.LowStockThreshold
+}
+
+// HasSufficientStock 检查 SKU 是否有足够的可售库存
+func (p *Product) HasSufficientStock(skuId string, quantity int) bool {
+	sku, err := p.FindSKU(skuId)
+	if err != nil {
+		return false
+	}
+	return sku.Available() >= quantity && p.Status == valueobject.ProductStatusOnSale
+}
+
+// ReserveStock 为未支付的订单预占 SKU 库存
+// 预占不改变在库数量，只减少可售数量；所有 SKU 均无可售库存时商品标记为售罄。
+// 套装不持有库存，须预占其组件的库存
+func (p *Product) ReserveStock(skuId string, quantity int) error {
+	if quantity <= 0 {
+		return gerror.Wrapf(valueobject.ErrInvalidStock, "quantity must be positive: %d", quantity)
+	}
+	if p.IsBundle() {
+		return gerror.Wrapf(valueobject.ErrInvalidBundle, "bundle %s cannot hold stock", p.Id)
+	}
+	sku, err := p.FindSKU(skuId)
+	if err != nil {
+		return err
+	}
+	if p.Status != valueobject.ProductStatusOnSale {
+		return gerror.Wrapf(valueobject.ErrProductUnavailable, "product %s is %s", p.Id, p.Status)
+	}
+	if sku.Available() < quantity {
+		return gerror.Wrapf(valueobject.ErrInsufficientStock,
+			"insufficient stock for sku %s: %d available, %d requested", skuId, sku.Available(), quantity,
+		)
+	}
+
+	sku.Reserved += quantity
+	if p.TotalAvailable() == 0 {
+		return p.MarkSoldOut()
+	}
+	return nil
+}
+
+// ReleaseStock 释放预占的 SKU 库存
+// 售罄的商品重新有可售库存后恢复在售
+func (p *Product) ReleaseStock(skuId string, quantity int) error {
+	sku, err := p.reserved(skuId, quantity)
+	if err != nil {
+		return err
+	}
+
+	sku.Reserved -= quantity
+	if p.Status == valueobject.ProductStatusSoldOut {
+		return p.Publish()
+	}
+	return nil
+}
+
+// CommitStock 确认预占的 SKU 库存，在库数量和预占数量同时减少
+// 用于订单支付后将预占转为实际出库，可售数量不变
+func (p *Product) CommitStock(skuId string, quantity int) error {
+	sku, err := p.reserved(skuId, quantity)
+	if err != nil {
+		return err
+	}
+
+	sku.Stock -= quantity
+	sku.Reserved -= quantity
+	return nil
+}
+
+// AdjustStock 按增量调整 SKU 的在库数量，如入库、盘点或损耗
+// 调整后的在库数量不能少于已预占的数量；售罄的商品重新有可售库存后恢复在售，
+// 在售的商品可售库存调整为 0 后标记为售罄；套装不持有库存，不能调整
+func (p *Product) AdjustStock(skuId string, delta int) error {
+	if p.IsBundle() {
+		return gerror.Wrapf(valueobject.ErrInvalidBundle, "bundle %s cannot hold stock", p.Id)
+	}
+	sku, err := p.FindSKU(skuId)
+	if err != nil {
+		return err
+	}
+	if err = sku.UpdateStock(sku.Stock + delta); err != nil {
+		return err
+	}
+
+	switch {
+	case p.Status == valueobject.ProductStatusSoldOut && p.TotalAvailable() > 0:
+		return p.Publish()
+	case p.Status == valueobject.ProductStatusOnSale && p.TotalAvailable() == 0:
+		return p.MarkSoldOut()
+	}
+	return nil
+}
+
+// reserved 获取预占数量不少于 quantity 的 SKU
+func (p *Product) reserved(skuId string, quantity int) (*SKU, error) {
+	if quantity <= 0 {
+		return nil, gerror.Wrapf(valueobject.ErrInvalidStock, "quantity must be positive: %d", quantity)
+	}
+	sku, err := p.FindSKU(skuId)
+	if err != nil {
+		return nil, err
+	}
+	if sku.Reserved < quantity {
+		return nil, gerror.Wrapf(valueobject.ErrInvalidStock,
+			"sku %s has only %d reserved, cannot settle %d", skuId, sku.Reserved, quantity,
+		)
+	}
+	return sku, nil
+}
+
 // UpdateStatus 更新商品状态
+// 状态必须按照商品生命周期流转，优先使用 Publish、TakeOffSale 等具体的生命周期方法
 func (p *Product) UpdateStatus(status valueobject.ProductStatus) error {
 	if !status.IsValid() {
 		return valueobject.ErrInvalidStatus
 	}
+	if !p.Status.CanTransitionTo(status) {
+		return gerror.Wrapf(valueobject.ErrInvalidTransition,
+			"cannot change product %s from %s to %s", p.Id, p.Status, status,
+		)
+	}
 	p.Status = status
 	return nil
 }
 
+// Publish 发布商品（上架）
+// 草稿、下架或补货后的售罄商品可以发布
+func (p *Product) Publish() error {
+	return p.UpdateStatus(valueobject.ProductStatusOnSale)
+}
+
+// TakeOffSale 下架商品
+func (p *Product) TakeOffSale() error {
+	return p.UpdateStatus(valueobject.ProductStatusOffSale)
+}
+
+// MarkSoldOut 标记商品售罄
+func (p *Product) MarkSoldOut() error {
+	return p.UpdateStatus(valueobject.ProductStatusSoldOut)
+}
+
+// Delete 删除商品
+// 在售商品需先下架才能删除；删除后的商品可以恢复，超过保留期后被永久清除
+func (p *Product) Delete() error {
+	if err := p.UpdateStatus(valueobject.ProductStatusDeleted); err != nil {
+		return err
+	}
+	p.DeletedAt = time.Now().UnixMilli()
+	return nil
+}
+
+// Restore 恢复已删除的商品
+// 恢复后的商品处于下架状态，需重新发布才能销售
+func (p *Product) Restore() error {
+	if p.Status != valueobject.ProductStatusDeleted {
+		return gerror.Wrapf(valueobject.ErrInvalidTransition,
+			"cannot restore product %s in status %s", p.Id, p.Status,
+		)
+	}
+	if err := p.UpdateStatus(valueobject.ProductStatusOffSale); err != nil {
+		return err
+	}
+	p.DeletedAt = 0
+	return nil
+}
+
+// IsDeleted 检查商品是否已删除
+func (p *Product) IsDeleted() bool {
+	return p.Status == valueobject.ProductStatusDeleted
+}
+
 // Validate 验证商品
 func (p *Product) Validate() error {
 	if p.Id == "" {
@@ -75,14 +562,56 @@ func (p *Product) Validate() error {
 	if p.Name == "" {
 		return valueobject.ErrInvalidName
 	}
-	if p.Price == nil {
-		return valueobject.ErrInvalidPrice
+	if len(p.SKUs) == 0 {
+		return gerror.Wrapf(valueobject.ErrInvalidSKU, "product %s must have at least one sku", p.Id)
+	}
+	for _, sku := range p.SKUs {
+		if err := sku.Validate(); err != nil {
+			return err
+		}
+		if err := p.checkDuplicate(sku); err != nil {
+			return err
+		}
+	}
+	if len(p.Images) > valueobject.MaxProductImages {
+		return gerror.Wrapf(valueobject.ErrTooManyImages, "product %s has %d images", p.Id, len(p.Images))
 	}
-	if p.Stock < 0 {
-		return valueobject.ErrInvalidStock
+	for _, image := range p.Images {
+		if err := image.Validate(); err != nil {
+			return err
+		}
 	}
 	if !p.Status.IsValid() {
 		return valueobject.ErrInvalidStatus
 	}
+	return p.validateType()
+}
+
+// validateType 验证商品类型相关的约束
+func (p *Product) validateType() error {
+	if !p.Type.IsValid() {
+		return gerror.Wrapf(valueobject.ErrInvalidProductOp, "invalid product type: %s", p.Type)
+	}
+	if !p.IsBundle() {
+		if len(p.Components) > 0 {
+			return gerror.Wrapf(valueobject.ErrInvalidBundle, "product %s is not a bundle", p.Id)
+		}
+		return nil
+	}
+
+	if err := p.Components.Validate(); err != nil {
+		return err
+	}
+	for _, component := range p.Components {
+		if component.ProductId == p.Id {
+			return gerror.Wrapf(valueobject.ErrInvalidBundle, "bundle %s cannot contain itself", p.Id)
+		}
+	}
+	if len(p.SKUs) != 1 {
+		return gerror.Wrapf(valueobject.ErrInvalidBundle, "bundle %s must have exactly one sku", p.Id)
+	}
+	if p.SKUs[0].Stock != 0 || p.SKUs[0].Reserved != 0 {
+		return gerror.Wrapf(valueobject.ErrInvalidBundle, "bundle %s cannot hold stock", p.Id)
+	}
 	return nil
 }
@@ -0,0 +1,41 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+
+	"main/internal/domain/product/valueobject"
+	sharedvo "main/internal/domain/shared/valueobject"
+)
+
+func TestProductStockOfBundle(t *testing.T) {
+	// newOnSale 创建有库存的在售商品，bundle 为真时标记为套装
+	newOnSale := func(bundle bool) *Product {
+		product := NewProduct("p1", "P1", "", "", []*SKU{NewSKU("s1", nil, sharedvo.MustNewMoney(10, "CNY"), 5, "")},
+			valueobject.ProductStatusOnSale, 0, 0,
+		)
+		if bundle {
+			product.Type = valueobject.ProductTypeBundle
+		}
+		return product
+	}
+
+	tests := []struct {
+		name    string
+		bundle  bool
+		change  func(product *Product) error
+		wantErr error
+	}{
+		{"reserve standard", false, func(p *Product) error { return p.ReserveStock("s1", 1) }, nil},
+		{"reserve bundle", true, func(p *Product) error { return p.ReserveStock("s1", 1) }, valueobject.ErrInvalidBundle},
+		{"adjust standard", false, func(p *Product) error { return p.AdjustStock("s1", 1) }, nil},
+		{"adjust bundle", true, func(p *Product) error { return p.AdjustStock("s1", 1) }, valueobject.ErrInvalidBundle},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.change(newOnSale(tt.bundle)); !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
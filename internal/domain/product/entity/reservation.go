@@ -0,0 +1,105 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"main/internal/domain/product/valueobject"
+)
+
+// Reservation 库存预占记录
+// 订单创建时为每个 SKU 预占库存，支付后确认，取消或超时未支付时释放
+type Reservation struct {
+	Id        string
+	OrderId   string
+	ProductId string
+	SkuId     string
+	// WarehouseId 发货仓库，为空表示预占时商品尚未分仓
+	WarehouseId string
+	Quantity    int
+	State       valueobject.ReservationState
+	ExpiresAt   int64 // 预占的过期时间，过期未支付的预占将被自动释放
+	CreatedAt   int64
+	UpdatedAt   int64
+}
+
+// NewReservation 创建处于预占中状态的库存预占记录
+func NewReservation(id, orderId, productId, skuId, warehouseId string, quantity int, expiresAt int64) *Reservation {
+	now := time.Now().UnixMilli()
+	return &Reservation{
+		Id:          id,
+		OrderId:     orderId,
+		ProductId:   productId,
+		SkuId:       skuId,
+		WarehouseId: warehouseId,
+		Quantity:    quantity,
+		State:       valueobject.ReservationStateHeld,
+		ExpiresAt:   expiresAt,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+// IsHeld 检查是否处于预占中
+func (r *Reservation) IsHeld() bool {
+	return r.State == valueobject.ReservationStateHeld
+}
+
+// IsExpired 检查预占是否已过期
+func (r *Reservation) IsExpired() bool {
+	return r.IsHeld() && time.Now().UnixMilli() >= r.ExpiresAt
+}
+
+// Commit 确认预占，过期的预占不能确认
+func (r *Reservation) Commit() error {
+	if !r.IsHeld() {
+		return gerror.Wrapf(valueobject.ErrInvalidReservation,
+			"cannot commit reservation %s in state %s", r.Id, r.State,
+		)
+	}
+	if r.IsExpired() {
+		return gerror.Wrapf(valueobject.ErrReservationExpired, "reservation %s has expired", r.Id)
+	}
+	r.State = valueobject.ReservationStateCommitted
+	r.UpdatedAt = time.Now().UnixMilli()
+	return nil
+}
+
+// Revert 撤销确认，使预占回到预占中，用于确认后订单支付失败
+func (r *Reservation) Revert() error {
+	if r.State != valueobject.ReservationStateCommitted {
+		return gerror.Wrapf(valueobject.ErrInvalidReservation,
+			"cannot revert reservation %s in state %s", r.Id, r.State,
+		)
+	}
+	r.State = valueobject.ReservationStateHeld
+	r.UpdatedAt = time.Now().UnixMilli()
+	return nil
+}
+
+// Release 释放预占
+func (r *Reservation) Release() error {
+	if !r.IsHeld() {
+		return gerror.Wrapf(valueobject.ErrInvalidReservation,
+			"cannot release reservation %s in state %s", r.Id, r.State,
+		)
+	}
+	r.State = valueobject.ReservationStateReleased
+	r.UpdatedAt = time.Now().UnixMilli()
+	return nil
+}
+
+// Validate 验证库存预占记录
+func (r *Reservation) Validate() error {
+	if r.Id == "" || r.OrderId == "" || r.ProductId == "" || r.SkuId == "" {
+		return gerror.Wrap(valueobject.ErrInvalidReservation, "id, order, product and sku are required")
+	}
+	if r.Quantity <= 0 {
+		return gerror.Wrapf(valueobject.ErrInvalidReservation, "quantity must be positive: %d", r.Quantity)
+	}
+	if !r.State.IsValid() {
+		return gerror.Wrapf(valueobject.ErrInvalidReservation, "invalid state: %s", r.State)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package entity
+
+import (
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"main/internal/domain/product/valueobject"
+	sharedvo "main/internal/domain/shared/valueobject"
+)
+
+// SKU 库存单位实体
+// 商品的每个规格组合（如 红色 / M）对应一个 SKU，拥有独立的价格、库存和条码
+type SKU struct {
+	Id       string
+	Options  valueobject.SKUOptions // 规格选项组合，单规格商品为空
+	Price    *sharedvo.Money
+	Stock    int    // 在库数量，包含已被预占的部分
+	Reserved int    // 被未支付订单预占的数量
+	Barcode  string // 条码，可为空
+	// ExternalId 外部编码（商家编码），批量导入时用于匹配 SKU，可为空
+	ExternalId string
+}
+
+// NewSKU 创建 SKU 实体
+func NewSKU(
+	id string,
+	options valueobject.SKUOptions,
+	price *sharedvo.Money,
+	stock int,
+	barcode string,
+) *SKU {
+	return &SKU{
+		Id:      id,
+		Options: options,
+		Price:   price,
+		Stock:   stock,
+		Barcode: barcode,
+	}
+}
+
+// Name 获取 SKU 的规格名称，如 "红色 / M"
+func (s *SKU) Name() string {
+	return s.Options.String()
+}
+
+// Available 获取可售数量，即在库数量减去已预占的数量
+func (s *SKU) Available() int {
+	return s.Stock - s.Reserved
+}
+
+// Level 获取库存水平
+func (s *SKU) Level() valueobject.StockLevel {
+	return valueobject.StockLevel{
+		OnHand:   s.Stock,
+		Reserved: s.Reserved,
+	}
+}
+
+// UpdateStock 更新在库数量，不能少于已预占的数量
+func (s *SKU) UpdateStock(stock int) error {
+	if stock < 0 {
+		return valueobject.ErrInvalidStock
+	}
+	if stock < s.Reserved {
+		return gerror.Wrapf(valueobject.ErrInvalidStock,
+			"stock of sku %s cannot be less than reserved quantity %d", s.Id, s.Reserved,
+		)
+	}
+	s.Stock = stock
+	return nil
+}
+
+// UpdatePrice 更新价格
+func (s *SKU) UpdatePrice(price *sharedvo.Money) error {
+	if price == nil {
+		return valueobject.ErrInvalidPrice
+	}
+	s.Price = price
+	return nil
+}
+
+// Validate 验证 SKU
+// SKU 的 Id 由商品服务在保存前通过 Id 生成器分配，新建的 SKU 此时可能还没有 Id，因此不做校验
+func (s *SKU) Validate() error {
+	if err := s.Options.Validate(); err != nil {
+		return err
+	}
+	if s.Price == nil {
+		return valueobject.ErrInvalidPrice
+	}
+	if err := s.Price.Validate(); err != nil {
+		return gerror.Wrap(valueobject.ErrInvalidPrice, err.Error())
+	}
+	if s.Stock < 0 {
+		return valueobject.ErrInvalidStock
+	}
+	if s.Reserved < 0 || s.Reserved > s.Stock {
+		return gerror.Wrapf(valueobject.ErrInvalidStock,
+			"reserved quantity %d of sku %s must be between 0 and stock %d", s.Reserved, s.Id, s.Stock,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"main/internal/domain/product/valueobject"
+)
+
+// StockMovement 库存流水
+// 每一次库存变更都记录为一条不可修改的流水，SKU 的所有流水之和即为其当前库存
+type StockMovement struct {
+	Id            string
+	ProductId     string
+	SkuId         string
+	WarehouseId   string // 发生变动的仓库，为空表示不区分仓库
+	Reason        valueobject.MovementReason
+	OnHandDelta   int                    // 在库数量的变动
+	ReservedDelta int                    // 预占数量的变动
+	Balance       valueobject.StockLevel // 变动后 SKU 的库存
+	Actor         string
+	Reference     string
+	Note          string
+	CreatedAt     int64
+}
+
+// NewStockMovement 根据 SKU 变动前后的库存创建库存流水
+func NewStockMovement(
+	id string,
+	productId string,
+	skuId string,
+	warehouseId string,
+	reason valueobject.MovementReason,
+	before valueobject.StockLevel,
+	after valueobject.StockLevel,
+	source valueobject.MovementSource,
+) *StockMovement {
+	return &StockMovement{
+		Id:            id,
+		ProductId:     productId,
+		SkuId:         skuId,
+		WarehouseId:   warehouseId,
+		Reason:        reason,
+		OnHandDelta:   after.OnHand - before.OnHand,
+		ReservedDelta: after.Reserved - before.Reserved,
+		Balance:       after,
+		Actor:         source.Actor,
+		Reference:     source.Reference,
+		Note:          source.Note,
+		CreatedAt:     time.Now().UnixMilli(),
+	}
+}
+
+// Validate 验证库存流水
+func (m *StockMovement) Validate() error {
+	if m.Id == "" || m.ProductId == "" || m.SkuId == "" {
+		return gerror.Wrap(valueobject.ErrInvalidMovement, "id, product and sku are required")
+	}
+	if !m.Reason.IsValid() {
+		return gerror.Wrapf(valueobject.ErrInvalidMovement, "invalid reason: %s", m.Reason)
+	}
+	if m.Actor == "" {
+		return gerror.Wrap(valueobject.ErrInvalidMovement, "actor is required")
+	}
+	if m.OnHandDelta == 0 && m.ReservedDelta == 0 {
+		return gerror.Wrapf(valueobject.ErrInvalidMovement, "%s movement of sku %s changes nothing", m.Reason, m.SkuId)
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package event
+
+import (
+	"main/internal/domain/product/entity"
+	"main/internal/domain/product/valueobject"
+	sharedvo "main/internal/domain/shared/valueobject"
+	"main/internal/infrastructure/eventbus"
+)
+
+// 商品事件名称，订阅方通过事件名称订阅事件
+const (
+	ProductCreatedEventName       = "product.created"
+	ProductPriceChangedEventName  = "product.price_changed"
+	ProductStockChangedEventName  = "product.stock_changed"
+	ProductStatusChangedEventName = "product.status_changed"
+	ProductDeletedEventName       = "product.deleted"
+	ProductLowStockEventName      = "product.low_stock"
+	ProductSKUAddedEventName      = "product.sku_added"
+)
+
+// ProductCreatedEvent 商品创建事件
+type ProductCreatedEvent struct {
+	eventbus.BaseEvent
+	Product *entity.Product `json:"product"`
+}
+
+// NewProductCreatedEvent 创建商品创建事件
+func NewProductCreatedEvent(product *entity.Product) *ProductCreatedEvent {
+	return &ProductCreatedEvent{
+		BaseEvent: eventbus.NewBaseEvent(ProductCreatedEventName),
+		Product:   product,
+	}
+}
+
+// ProductPriceChangedEvent 商品价格变更事件
+// 价格由 SKU 持有，每个价格变动的已有 SKU 发布一个事件，新增的 SKU 发布 ProductSKUAddedEvent
+type ProductPriceChangedEvent struct {
+	eventbus.BaseEvent
+	ProductId string          `json:"productId"`
+	SkuId     string          `json:"skuId"`
+	OldPrice  *sharedvo.Money `json:"oldPrice"`
+	NewPrice  *sharedvo.Money `json:"newPrice"`
+}
+
+// NewProductPriceChangedEvent 创建商品价格变更事件
+func NewProductPriceChangedEvent(productId, skuId string, oldPrice, newPrice *sharedvo.Money) *ProductPriceChangedEvent {
+	return &ProductPriceChangedEvent{
+		BaseEvent: eventbus.NewBaseEvent(ProductPriceChangedEventName),
+		ProductId: productId,
+		SkuId:     skuId,
+		OldPrice:  oldPrice,
+		NewPrice:  newPrice,
+	}
+}
+
+// ProductSKUAddedEvent 商品新增 SKU 事件
+// 更新商品时每个新增的 SKU 发布一个事件，新增 SKU 的初始库存另以库存变更事件发布
+type ProductSKUAddedEvent struct {
+	eventbus.BaseEvent
+	ProductId string          `json:"productId"`
+	SkuId     string          `json:"skuId"`
+	Price     *sharedvo.Money `json:"price"`
+}
+
+// NewProductSKUAddedEvent 创建商品新增 SKU 事件
+func NewProductSKUAddedEvent(productId, skuId string, price *sharedvo.Money) *ProductSKUAddedEvent {
+	return &ProductSKUAddedEvent{
+		BaseEvent: eventbus.NewBaseEvent(ProductSKUAddedEventName),
+		ProductId: productId,
+		SkuId:     skuId,
+		Price:     price,
+	}
+}
+
+// ProductStockChangedEvent 商品库存变更事件
+// 库存由 SKU 持有，每个在库数量或预占数量变动的 SKU 发布一个事件，新增或移除的 SKU 视为从 0 变动或变动为 0
+type ProductStockChangedEvent struct {
+	eventbus.BaseEvent
+	ProductId   string `json:"productId"`
+	SkuId       string `json:"skuId"`
+	OldStock    int    `json:"oldStock"`
+	NewStock    int    `json:"newStock"`
+	OldReserved int    `json:"oldReserved"`
+	NewReserved int    `json:"newReserved"`
+}
+
+// NewProductStockChangedEvent 创建商品库存变更事件
+func NewProductStockChangedEvent(productId, skuId string, before, after valueobject.StockLevel) *ProductStockChangedEvent {
+	return &ProductStockChangedEvent{
+		BaseEvent:   eventbus.NewBaseEvent(ProductStockChangedEventName),
+		ProductId:   productId,
+		SkuId:       skuId,
+		OldStock:    before.OnHand,
+		NewStock:    after.OnHand,
+		OldReserved: before.Reserved,
+		NewReserved: after.Reserved,
+	}
+}
+
+// ProductStatusChangedEvent 商品状态变更事件
+// 所有生命周期操作（包括删除和恢复）都会发布该事件
+type ProductStatusChangedEvent struct {
+	eventbus.BaseEvent
+	ProductId string                    `json:"productId"`
+	OldStatus valueobject.ProductStatus `json:"oldStatus"`
+	NewStatus valueobject.ProductStatus `json:"newStatus"`
+}
+
+// NewProductStatusChangedEvent 创建商品状态变更事件
+func NewProductStatusChangedEvent(productId string, oldStatus, newStatus valueobject.ProductStatus) *ProductStatusChangedEvent {
+	return &ProductStatusChangedEvent{
+		BaseEvent: eventbus.NewBaseEvent(ProductStatusChangedEventName),
+		ProductId: productId,
+		OldStatus: oldStatus,
+		NewStatus: newStatus,
+	}
+}
+
+// ProductDeletedEvent 商品删除事件
+type ProductDeletedEvent struct {
+	eventbus.BaseEvent
+	ProductId string                    `json:"productId"`
+	OldStatus valueobject.ProductStatus `json:"oldStatus"` // 删除前的状态
+}
+
+// NewProductDeletedEvent 创建商品删除事件
+func NewProductDeletedEvent(productId string, oldStatus valueobject.ProductStatus) *ProductDeletedEvent {
+	return &ProductDeletedEvent{
+		BaseEvent: eventbus.NewBaseEvent(ProductDeletedEventName),
+		ProductId: productId,
+		OldStatus: oldStatus,
+	}
+}
+
+// ProductLowStockEvent 商品低库存事件
+// 商品的可售数量总和从高于低库存阈值降到不高于阈值时发布，回升到阈值以上后再次降低时会再次发布
+type ProductLowStockEvent struct {
+	eventbus.BaseEvent
+	ProductId string `json:"productId"`
+	Available int    `json:"available"` // 当前可售数量总和
+	Threshold int    `json:"threshold"`
+}
+
+// NewProductLowStockEvent 创建商品低库存事件
+func NewProductLowStockEvent(productId string, available, threshold int) *ProductLowStockEvent {
+	return &ProductLowStockEvent{
+		BaseEvent: eventbus.NewBaseEvent(ProductLowStockEventName),
+		ProductId: productId,
+		Available: available,
+		Threshold: threshold,
+	}
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"main/internal/domain/product/entity"
+)
+
+// PriceHistoryRepository 价格历史仓储接口
+// 价格历史只追加，不修改也不删除
+type PriceHistoryRepository interface {
+	// Append 追加价格历史
+	Append(ctx context.Context, changes ...*entity.PriceChange) error
+	// FindByProductId 查找商品的价格历史，按时间倒序，skuId 为空时返回所有 SKU 的记录，最多返回 limit 条
+	FindByProductId(ctx context.Context, productId string, skuId string, limit int) ([]*entity.PriceChange, error)
+}
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+
+	"main/internal/domain/product/entity"
+	"main/internal/domain/product/valueobject"
+)
+
+// PriceScheduleRepository 定时调价仓储接口
+type PriceScheduleRepository interface {
+	// Save 保存新的定时调价
+	Save(ctx context.Context, schedule *entity.PriceSchedule) error
+	// FindById 根据Id查找定时调价
+	FindById(ctx context.Context, id string) (*entity.PriceSchedule, error)
+	// FindByProductId 查找商品的定时调价，按开始时间排序
+	FindByProductId(ctx context.Context, productId string) ([]*entity.PriceSchedule, error)
+	// FindOpenBySkuIds 查找 SKU 尚未结束的定时调价
+	FindOpenBySkuIds(ctx context.Context, skuIds []string) ([]*entity.PriceSchedule, error)
+	// FindDue 查找到开始时间未开始或到结束时间未结束的定时调价，按到期时间排序，最多返回 limit 条
+	FindDue(ctx context.Context, at int64, limit int) ([]*entity.PriceSchedule, error)
+	// UpdateStatus 仅当定时调价当前处于 from 状态时保存其新状态和原价，
+	// 否则返回 valueobject.ErrPriceScheduleConflict，用于避免多个调度器或取消操作并发处理同一定时调价
+	UpdateStatus(ctx context.Context, schedule *entity.PriceSchedule, from valueobject.PriceScheduleStatus) error
+}
@@ -4,18 +4,62 @@ import (
 	"context"
 
 	"main/internal/domain/product/entity"
+	"main/internal/domain/product/valueobject"
 )
 
+// ProductSearchResult 商品搜索结果
+type ProductSearchResult struct {
+	Products   []*entity.Product
+	NextCursor string // 下一页的分页游标，为空表示没有更多结果
+}
+
 // ProductRepository 商品仓储接口
 type ProductRepository interface {
 	// Save 保存商品
 	Save(ctx context.Context, product *entity.Product) error
 	// FindById 根据Id查找商品
 	FindById(ctx context.Context, id string) (*entity.Product, error)
-	// FindAll 查找所有商品
+	// FindBySKUId 根据 SKU Id 查找所属商品
+	FindBySKUId(ctx context.Context, skuId string) (*entity.Product, error)
+	// FindByCategoryIds 查找属于指定类目的商品，不包括已删除的商品
+	FindByCategoryIds(ctx context.Context, categoryIds []string) ([]*entity.Product, error)
+	// Search 按条件搜索商品，条件已经过 Normalize 处理，未指定状态时不包括已删除的商品
+	Search(ctx context.Context, criteria valueobject.ProductSearchCriteria) (*ProductSearchResult, error)
+	// FindByExternalIds 查找包含指定外部编码 SKU 的商品，包括已删除的商品
+	FindByExternalIds(ctx context.Context, externalIds []string) ([]*entity.Product, error)
+	// FindLowStock 查找设置了低库存阈值且可售数量总和不高于阈值的商品，不包括已删除的商品
+	FindLowStock(ctx context.Context) ([]*entity.Product, error)
+	// FindBundlesByComponents 查找以指定商品为组件的未删除套装
+	FindBundlesByComponents(ctx context.Context, productIds []string) ([]*entity.Product, error)
+	// FindAll 查找所有未删除的商品
 	FindAll(ctx context.Context) ([]*entity.Product, error)
-	// Update 更新商品
+	// FindDeleted 查找已删除的商品，按删除时间排序；deletedBefore 不为 0 时只返回在该时间（毫秒）之前删除的商品
+	FindDeleted(ctx context.Context, deletedBefore int64) ([]*entity.Product, error)
+	// ReserveStock 原子地预占库存
+	// 仅当商品在售且 SKU 可售数量不少于预占数量时预占，多个 SKU 要么全部预占，要么全部不预占。
+	// items 中的 SKU 不重复，返回各项变更前的商品，与 items 一一对应
+	ReserveStock(ctx context.Context, items valueobject.StockItems) ([]*entity.Product, error)
+	// ReleaseStock 原子地释放预占的库存，返回各项变更前的商品，与 items 一一对应
+	ReleaseStock(ctx context.Context, items valueobject.StockItems) ([]*entity.Product, error)
+	// CommitStock 原子地确认预占的库存，返回各项变更前的商品，与 items 一一对应
+	CommitStock(ctx context.Context, items valueobject.StockItems) ([]*entity.Product, error)
+	// AdjustStock 原子地按增量调整 SKU 的在库数量，仅当调整后不少于预占数量时生效，返回变更前的商品
+	AdjustStock(ctx context.Context, skuId string, delta int) (*entity.Product, error)
+	// AddImage 原子地将图片追加到商品图片列表末尾
+	// 商品图片已达 valueobject.MaxProductImages 张时返回 ErrTooManyImages
+	AddImage(ctx context.Context, productId string, image *entity.ProductImage) error
+	// RemoveImage 原子地从商品图片列表中移除图片，图片不存在时返回 ErrImageNotFound
+	RemoveImage(ctx context.Context, productId string, imageId string) error
+	// ReorderImages 原子地按给定顺序重新排列商品图片
+	// imageIds 须恰好包含商品当前的全部图片，否则返回 ErrInvalidImageOrder
+	ReorderImages(ctx context.Context, productId string, imageIds []string) error
+	// UpdateBundleAvailable 只更新套装由组件推算的可售数量
+	UpdateBundleAvailable(ctx context.Context, id string, available int) error
+	// UpdateRating 只更新商品的评分汇总
+	UpdateRating(ctx context.Context, id string, rating valueobject.ProductRating) error
+	// Update 以读取时的版本号为条件更新商品，成功后递增 product.Version
+	// 商品在读取后已被其他写入修改时返回 ErrConcurrentModification
 	Update(ctx context.Context, product *entity.Product) error
-	// Delete 删除商品
+	// Delete 永久删除已删除状态的商品，商品不存在或未处于删除状态时返回 ErrProductNotFound
 	Delete(ctx context.Context, id string) error
 }
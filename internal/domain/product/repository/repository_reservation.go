@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+
+	"main/internal/domain/product/entity"
+	"main/internal/domain/product/valueobject"
+)
+
+// ReservationRepository 库存预占仓储接口
+type ReservationRepository interface {
+	// Save 保存新的预占记录
+	Save(ctx context.Context, reservation *entity.Reservation) error
+	// FindByOrderId 查找订单的预占记录
+	FindByOrderId(ctx context.Context, orderId string) ([]*entity.Reservation, error)
+	// FindByProductId 查找商品的预占记录，state 为空时返回所有状态
+	FindByProductId(ctx context.Context, productId string, state valueobject.ReservationState) ([]*entity.Reservation, error)
+	// FindExpired 查找在 now 之前已过期但仍处于预占中的记录，最多返回 limit 条
+	FindExpired(ctx context.Context, now int64, limit int) ([]*entity.Reservation, error)
+	// UpdateState 仅当预占记录当前处于 from 状态时保存其新状态，
+	// 否则返回 valueobject.ErrReservationConflict，用于避免支付与过期释放并发处理同一预占
+	UpdateState(ctx context.Context, reservation *entity.Reservation, from valueobject.ReservationState) error
+	// Commit 仅当预占记录处于预占中且在 now 时尚未过期时将其保存为已确认，
+	// 否则返回 valueobject.ErrReservationConflict，检查与确认在同一次条件更新中完成
+	Commit(ctx context.Context, reservation *entity.Reservation, now int64) error
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+
+	"main/internal/domain/product/entity"
+	"main/internal/domain/product/valueobject"
+)
+
+// StockMovementRepository 库存流水仓储接口
+// 流水只追加，不修改也不删除
+type StockMovementRepository interface {
+	// Append 追加库存流水
+	Append(ctx context.Context, movements ...*entity.StockMovement) error
+	// FindByProductId 查找商品的库存流水，按时间倒序，skuId 为空时返回所有 SKU 的流水，最多返回 limit 条
+	FindByProductId(ctx context.Context, productId string, skuId string, limit int) ([]*entity.StockMovement, error)
+	// SumByProductId 按 SKU 汇总商品的库存流水，得到流水记录的各 SKU 库存
+	SumByProductId(ctx context.Context, productId string) (map[string]valueobject.StockLevel, error)
+	// SumSales 按商品汇总自 since 起订单确认出库的数量，没有销售的商品不在结果中
+	SumSales(ctx context.Context, productIds []string, since int64) (map[string]int, error)
+}
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+
+	"main/internal/domain/product/entity"
+	"main/internal/domain/product/valueobject"
+	sharedvo "main/internal/domain/shared/valueobject"
+)
+
+// BundleLine 解析后的套装组件
+type BundleLine struct {
+	Product  *entity.Product // 组件所属的普通商品
+	SKU      *entity.SKU
+	Quantity int // 每套包含的数量
+}
+
+// BundleLines 解析后的套装组件列表，与套装的 Components 一一对应
+type BundleLines []BundleLine
+
+// Available 根据组件的可售数量推算套装的可售数量
+// 任一组件商品不在售时套装不可售
+func (lines BundleLines) Available() int {
+	available := -1
+	for _, line := range lines {
+		if line.Product.Status != valueobject.ProductStatusOnSale {
+			return 0
+		}
+		sets := line.SKU.Available() / line.Quantity
+		if available < 0 || sets < available {
+			available = sets
+		}
+	}
+	if available < 0 {
+		return 0
+	}
+	return available
+}
+
+// Apportion 将一套套装的价格按组件原价（SKU 价格乘以每套数量）的比例分摊到各组件，
+// 分摊结果与组件一一对应且之和严格等于套装价格；组件原价均为 0 时按数量分摊
+func (lines BundleLines) Apportion(price *sharedvo.Money) ([]*sharedvo.Money, error) {
+	ratios := make([]int64, len(lines))
+	sum := int64(0)
+	for i, line := range lines {
+		ratios[i] = line.SKU.Price.MinorAmount() * int64(line.Quantity)
+		sum += ratios[i]
+	}
+	if sum == 0 {
+		for i, line := range lines {
+			ratios[i] = int64(line.Quantity)
+		}
+	}
+	return price.Allocate(ratios...)
+}
+
+// CreateBundle 创建草稿状态的套装商品
+// 组件必须是未删除的普通商品，其 SKU 价格货币与套装价格一致
+func (s *ProductService) CreateBundle(
+	ctx context.Context,
+	name string,
+	description string,
+	categoryId string,
+	price *sharedvo.Money,
+	components valueobject.BundleComponents,
+) (*entity.Product, error) {
+	bundle := entity.NewBundle(s.idGenerator.NewId(), name, description, categoryId, price, components)
+	lines, err := s.ResolveBundle(ctx, bundle)
+	if err != nil {
+		return nil, err
+	}
+	bundle.BundleAvailable = lines.Available()
+	return s.create(ctx, bundle, valueobject.MovementSource{})
+}
+
+// SetBundleComponents 替换套装的组件
+func (s *ProductService) SetBundleComponents(
+	ctx context.Context,
+	id string,
+	components valueobject.BundleComponents,
+) (*entity.Product, error) {
+	return s.modify(ctx, id, func(product *entity.Product) error {
+		if err := product.SetComponents(components); err != nil {
+			return err
+		}
+		_, err := s.ResolveBundle(ctx, product)
+		return err
+	})
+}
+
+// ResolveBundle 查找套装各组件当前的商品和 SKU
+// 组件商品或 SKU 不存在、已删除、本身是套装，或价格货币与套装价格不一致时返回错误
+func (s *ProductService) ResolveBundle(ctx context.Context, bundle *entity.Product) (BundleLines, error) {
+	if !bundle.IsBundle() {
+		return nil, gerror.Wrapf(valueobject.ErrInvalidProductOp, "product %s is not a bundle", bundle.Id)
+	}
+	if err := bundle.Components.Validate(); err != nil {
+		return nil, err
+	}
+	currency := bundle.SKUs[0].Price.Currency()
+
+	lines := make(BundleLines, len(bundle.Components))
+	products := make(map[string]*entity.Product, len(bundle.Components))
+	for i, component := range bundle.Components {
+		product, ok := products[component.ProductId]
+		if !ok {
+			var err error
+			if product, err = s.productRepo.FindById(ctx, component.ProductId); err != nil {
+				return nil, gerror.Wrapf(err, "failed to find component product %s", component.ProductId)
+			}
+			products[component.ProductId] = product
+		}
+		if product.IsDeleted() || product.IsBundle() {
+			return nil, gerror.Wrapf(valueobject.ErrInvalidBundle,
+				"component product %s must be an undeleted standard product", product.Id,
+			)
+		}
+		sku, err := product.FindSKU(component.SkuId)
+		if err != nil {
+			return nil, err
+		}
+		if sku.Price.Currency() != currency {
+			return nil, gerror.Wrapf(valueobject.ErrInvalidBundle,
+				"currency %s of component %s does not match bundle currency %s", sku.Price.Currency(), sku.Id, currency,
+			)
+		}
+		lines[i] = BundleLine{Product: product, SKU: sku, Quantity: component.Quantity}
+	}
+	return lines, nil
+}
+
+// BundleAvailability 根据组件的可售数量推算套装的可售数量
+func (s *ProductService) BundleAvailability(ctx context.Context, bundle *entity.Product) (int, error) {
+	lines, err := s.ResolveBundle(ctx, bundle)
+	if err != nil {
+		return 0, err
+	}
+	return lines.Available(), nil
+}
+
+// bundleAvailable 推算套装的可售数量，组件已不存在等无法解析的套装视为不可售
+func (s *ProductService) bundleAvailable(ctx context.Context, bundle *entity.Product) int {
+	lines, err := s.ResolveBundle(ctx, bundle)
+	if err != nil {
+		return 0
+	}
+	return lines.Available()
+}
+
+// refreshBundles 重新推算以指定商品为组件的套装的可售数量并保存，使套装可以按库存搜索和展示
+// 套装的可售数量只是组件库存的派生值，组件的变更此时已经保存，刷新失败只记录日志
+func (s *ProductService) refreshBundles(ctx context.Context, productIds []string) {
+	bundles, err := s.productRepo.FindBundlesByComponents(ctx, productIds)
+	if err != nil {
+		g.Log().Errorf(ctx, "failed to find bundles of products %v: %+v", productIds, err)
+		return
+	}
+	for _, bundle := range bundles {
+		available := s.bundleAvailable(ctx, bundle)
+		if available == bundle.BundleAvailable {
+			continue
+		}
+		if err = s.productRepo.UpdateBundleAvailable(ctx, bundle.Id, available); err != nil {
+			g.Log().Errorf(ctx, "failed to refresh availability of bundle %s: %+v", bundle.Id, err)
+		}
+	}
+}
+
+// checkComponentInUse 检查商品是否为未删除套装的组件
+func (s *ProductService) checkComponentInUse(ctx context.Context, productId string) error {
+	bundles, err := s.productRepo.FindBundlesByComponents(ctx, []string{productId})
+	if err != nil {
+		return gerror.Wrapf(err, "failed to find bundles of product %s", productId)
+	}
+	if len(bundles) > 0 {
+		return gerror.Wrapf(valueobject.ErrComponentInUse,
+			"product %s is a component of bundle %s", productId, bundles[0].Id,
+		)
+	}
+	return nil
+}
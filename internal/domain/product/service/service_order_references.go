@@ -0,0 +1,10 @@
+package service
+
+import "context"
+
+// OrderReferences 订单引用端口
+// 商品被未完结的订单引用时不能永久清除，由订单上下文提供实现
+type OrderReferences interface {
+	// HasOpenOrders 检查是否存在引用该商品且尚未送达或取消的订单
+	HasOpenOrders(ctx context.Context, productId string) (bool, error)
+}
@@ -0,0 +1,292 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"main/internal/domain/product/entity"
+	"main/internal/domain/product/repository"
+	"main/internal/domain/product/valueobject"
+	sharedservice "main/internal/domain/shared/service"
+	sharedvo "main/internal/domain/shared/valueobject"
+)
+
+// dueScheduleBatchSize 每批处理的到期定时调价数量
+const dueScheduleBatchSize = 100
+
+// PriceScheduleService 定时调价服务
+// 调度器定期调用 RunDue 在开始时应用调价、在结束时恢复原价；
+// 调度器执行之前，下单时通过 EffectivePrice 解析当时生效的价格
+type PriceScheduleService struct {
+	productService *ProductService
+	scheduleRepo   repository.PriceScheduleRepository
+	idGenerator    sharedservice.IdGenerator // 定时调价 Id 生成器
+}
+
+// NewPriceScheduleService 创建定时调价服务实例
+func NewPriceScheduleService(
+	productService *ProductService,
+	scheduleRepo repository.PriceScheduleRepository,
+	idGenerator sharedservice.IdGenerator,
+) *PriceScheduleService {
+	return &PriceScheduleService{
+		productService: productService,
+		scheduleRepo:   scheduleRepo,
+		idGenerator:    idGenerator,
+	}
+}
+
+// Schedule 创建定时调价
+// 价格货币必须与 SKU 当前价格一致，同一 SKU 未结束的定时调价时间段不能重叠
+func (s *PriceScheduleService) Schedule(
+	ctx context.Context,
+	productId string,
+	skuId string,
+	price *sharedvo.Money,
+	startAt int64,
+	endAt int64,
+	actor string,
+	note string,
+) (*entity.PriceSchedule, error) {
+	product, err := s.productService.GetProduct(ctx, productId)
+	if err != nil {
+		return nil, err
+	}
+	if product.IsDeleted() {
+		return nil, gerror.Wrapf(valueobject.ErrProductUnavailable, "product %s is deleted", productId)
+	}
+	sku, err := product.FindSKU(skuId)
+	if err != nil {
+		return nil, err
+	}
+	if price != nil && price.Currency() != sku.Price.Currency() {
+		return nil, gerror.Wrapf(valueobject.ErrInvalidPriceSchedule,
+			"price currency %s does not match sku currency %s", price.Currency(), sku.Price.Currency(),
+		)
+	}
+
+	schedule := entity.NewPriceSchedule(s.idGenerator.NewId(), productId, skuId, price, startAt, endAt, actor, note)
+	if err = schedule.Validate(); err != nil {
+		return nil, err
+	}
+	if endAt > 0 && endAt <= time.Now().UnixMilli() {
+		return nil, gerror.Wrapf(valueobject.ErrInvalidPriceSchedule, "price schedule already ended at %d", endAt)
+	}
+
+	// 检查时间段是否与未结束的定时调价重叠
+	existing, err := s.scheduleRepo.FindOpenBySkuIds(ctx, []string{skuId})
+	if err != nil {
+		return nil, err
+	}
+	for _, other := range existing {
+		if schedule.Overlaps(other) {
+			return nil, gerror.Wrapf(valueobject.ErrPriceScheduleOverlap,
+				"overlaps price schedule %s of sku %s", other.Id, skuId,
+			)
+		}
+	}
+
+	if err = s.scheduleRepo.Save(ctx, schedule); err != nil {
+		return nil, gerror.Wrap(err, "failed to save price schedule")
+	}
+	return schedule, nil
+}
+
+// Cancel 取消定时调价，已开始的定时调价先恢复原价
+func (s *PriceScheduleService) Cancel(ctx context.Context, id string, actor string) (*entity.PriceSchedule, error) {
+	schedule, err := s.scheduleRepo.FindById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	from := schedule.Status
+	if err = schedule.Cancel(); err != nil {
+		return nil, err
+	}
+	if err = s.scheduleRepo.UpdateStatus(ctx, schedule, from); err != nil {
+		return nil, err
+	}
+	if from == valueobject.PriceScheduleActive {
+		source := valueobject.MovementSource{Actor: actor, Reference: schedule.Id, Note: "price schedule cancelled"}
+		if err = s.revert(ctx, schedule, source); err != nil {
+			return nil, s.reactivate(ctx, schedule, err)
+		}
+	}
+	return schedule, nil
+}
+
+// GetSchedule 获取定时调价
+func (s *PriceScheduleService) GetSchedule(ctx context.Context, id string) (*entity.PriceSchedule, error) {
+	return s.scheduleRepo.FindById(ctx, id)
+}
+
+// ListProductSchedules 获取商品的定时调价，按开始时间排序
+func (s *PriceScheduleService) ListProductSchedules(ctx context.Context, productId string) ([]*entity.PriceSchedule, error) {
+	return s.scheduleRepo.FindByProductId(ctx, productId)
+}
+
+// RunDue 应用所有到开始时间的定时调价，恢复所有到结束时间的定时调价，返回处理的数量
+// 已被并发处理的定时调价会被跳过；商品或 SKU 已不存在的定时调价将被取消
+func (s *PriceScheduleService) RunDue(ctx context.Context) (int, error) {
+	processed := 0
+	for {
+		now := time.Now().UnixMilli()
+		schedules, err := s.scheduleRepo.FindDue(ctx, now, dueScheduleBatchSize)
+		if err != nil {
+			return processed, err
+		}
+		progressed := false
+		for _, schedule := range schedules {
+			err = s.run(ctx, schedule, now)
+			if gerror.Is(err, valueobject.ErrPriceScheduleConflict) {
+				continue
+			}
+			if gerror.Is(err, valueobject.ErrProductNotFound) || gerror.Is(err, valueobject.ErrSKUNotFound) {
+				err = s.discard(ctx, schedule)
+			}
+			if err != nil {
+				return processed, err
+			}
+			processed++
+			progressed = true
+		}
+		if len(schedules) < dueScheduleBatchSize || !progressed {
+			return processed, nil
+		}
+	}
+}
+
+// EffectivePrice 解析 SKU 在指定时间（毫秒）生效的价格
+// 调度器尚未执行时，已到开始时间的调价价格生效，已到结束时间的调价恢复为原价；
+// 其余情况下 SKU 的当前价格即为生效价格，包括进行中的调价期间人工修改的价格
+func (s *PriceScheduleService) EffectivePrice(ctx context.Context, sku *entity.SKU, at int64) (*sharedvo.Money, error) {
+	schedules, err := s.scheduleRepo.FindOpenBySkuIds(ctx, []string{sku.Id})
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to find price schedules")
+	}
+	for _, schedule := range schedules {
+		switch {
+		case schedule.IsDueToStart(at) && schedule.IsEffectiveAt(at):
+			return schedule.Price, nil
+		case schedule.IsDueToEnd(at) && schedule.OriginalPrice != nil && sku.Price.Equals(schedule.Price):
+			return schedule.OriginalPrice, nil
+		}
+	}
+	return sku.Price, nil
+}
+
+// run 处理单个到期的定时调价
+func (s *PriceScheduleService) run(ctx context.Context, schedule *entity.PriceSchedule, now int64) error {
+	switch {
+	case schedule.IsDueToStart(now):
+		return s.start(ctx, schedule, now)
+	case schedule.IsDueToEnd(now):
+		return s.end(ctx, schedule)
+	default:
+		return nil
+	}
+}
+
+// start 开始定时调价
+// 先以条件更新抢占状态变更，只有成功变更状态的一方才会修改价格，修改失败时恢复状态；
+// 调度器停止期间已错过整个时间段的定时调价直接结束，不修改价格
+func (s *PriceScheduleService) start(ctx context.Context, schedule *entity.PriceSchedule, now int64) error {
+	if schedule.EndAt > 0 && schedule.EndAt <= now {
+		if err := schedule.Start(nil); err != nil {
+			return err
+		}
+		if err := schedule.End(); err != nil {
+			return err
+		}
+		return s.scheduleRepo.UpdateStatus(ctx, schedule, valueobject.PriceSchedulePending)
+	}
+
+	product, err := s.productService.GetProduct(ctx, schedule.ProductId)
+	if err != nil {
+		return err
+	}
+	sku, err := product.FindSKU(schedule.SkuId)
+	if err != nil {
+		return err
+	}
+
+	if err = schedule.Start(sku.Price); err != nil {
+		return err
+	}
+	if err = s.scheduleRepo.UpdateStatus(ctx, schedule, valueobject.PriceSchedulePending); err != nil {
+		return err
+	}
+
+	source := valueobject.MovementSource{Actor: schedule.Actor, Reference: schedule.Id, Note: "price schedule started"}
+	if _, err = s.productService.ChangePrice(ctx, schedule.ProductId, schedule.SkuId, schedule.Price, nil, source); err != nil {
+		from := schedule.Status
+		schedule.Status = valueobject.PriceSchedulePending
+		schedule.OriginalPrice = nil
+		if rollbackErr := s.scheduleRepo.UpdateStatus(ctx, schedule, from); rollbackErr != nil {
+			return gerror.Wrapf(err, "failed to roll back price schedule %s: %v", schedule.Id, rollbackErr)
+		}
+		return err
+	}
+	return nil
+}
+
+// end 结束定时调价并恢复原价
+// 先以条件更新抢占状态变更，只有成功变更状态的一方才会恢复原价，恢复失败时改回进行中
+func (s *PriceScheduleService) end(ctx context.Context, schedule *entity.PriceSchedule) error {
+	if err := schedule.End(); err != nil {
+		return err
+	}
+	if err := s.scheduleRepo.UpdateStatus(ctx, schedule, valueobject.PriceScheduleActive); err != nil {
+		return err
+	}
+	source := valueobject.MovementSource{Actor: schedule.Actor, Reference: schedule.Id, Note: "price schedule ended"}
+	if err := s.revert(ctx, schedule, source); err != nil {
+		return s.reactivate(ctx, schedule, err)
+	}
+	return nil
+}
+
+// reactivate 恢复原价失败时将已结束或已取消的定时调价改回进行中，
+// 避免活动价格在定时调价结束后一直生效，到期的定时调价由下次执行重新结束
+func (s *PriceScheduleService) reactivate(ctx context.Context, schedule *entity.PriceSchedule, err error) error {
+	from := schedule.Status
+	schedule.Status = valueobject.PriceScheduleActive
+	if rollbackErr := s.scheduleRepo.UpdateStatus(ctx, schedule, from); rollbackErr != nil {
+		return gerror.Wrapf(err, "failed to roll back price schedule %s: %v", schedule.Id, rollbackErr)
+	}
+	return err
+}
+
+// revert 将 SKU 恢复为定时调价开始时的价格，期间价格已被人工修改时保留人工修改的价格
+func (s *PriceScheduleService) revert(
+	ctx context.Context,
+	schedule *entity.PriceSchedule,
+	source valueobject.MovementSource,
+) error {
+	if schedule.OriginalPrice == nil {
+		return nil
+	}
+	_, err := s.productService.ChangePrice(
+		ctx, schedule.ProductId, schedule.SkuId, schedule.OriginalPrice, schedule.Price, source,
+	)
+	if gerror.Is(err, valueobject.ErrProductNotFound) || gerror.Is(err, valueobject.ErrSKUNotFound) {
+		return nil
+	}
+	return err
+}
+
+// discard 取消商品或 SKU 已不存在而无法开始的定时调价
+func (s *PriceScheduleService) discard(ctx context.Context, schedule *entity.PriceSchedule) error {
+	if schedule.Status != valueobject.PriceSchedulePending {
+		return nil
+	}
+	if err := schedule.Cancel(); err != nil {
+		return err
+	}
+	err := s.scheduleRepo.UpdateStatus(ctx, schedule, valueobject.PriceSchedulePending)
+	if gerror.Is(err, valueobject.ErrPriceScheduleConflict) {
+		return nil
+	}
+	return err
+}
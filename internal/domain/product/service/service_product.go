@@ -2,39 +2,84 @@ package service
 
 import (
 	"context"
+	"sort"
+	"time"
 
 	"main/internal/domain/product/valueobject"
 
 	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
 
 	"main/internal/domain/product/entity"
+	"main/internal/domain/product/event"
 	"main/internal/domain/product/repository"
+	sharedservice "main/internal/domain/shared/service"
 	sharedvo "main/internal/domain/shared/valueobject"
+	"main/internal/infrastructure/eventbus"
 )
 
+// maxModifyRetries 保存商品时因并发修改导致版本冲突的最大重试次数
+const maxModifyRetries = 3
+
 // ProductService 商品服务
+// 商品库存的每一次变更都记录为库存流水，SKU 价格的每一次变更都记录为价格历史
 type ProductService struct {
-	productRepo repository.ProductRepository
+	productRepo      repository.ProductRepository
+	movementRepo     repository.StockMovementRepository // 库存流水仓储
+	priceHistoryRepo repository.PriceHistoryRepository  // 价格历史仓储
+	eventBus         eventbus.EventBus                  // 事件总线
+	idGenerator      sharedservice.IdGenerator          // 商品和 SKU 的 Id 生成器
 }
 
 // NewProductService 创建商品服务实例
-func NewProductService(productRepo repository.ProductRepository) *ProductService {
+func NewProductService(
+	productRepo repository.ProductRepository,
+	movementRepo repository.StockMovementRepository,
+	priceHistoryRepo repository.PriceHistoryRepository,
+	eventBus eventbus.EventBus,
+	idGenerator sharedservice.IdGenerator,
+) *ProductService {
 	return &ProductService{
-		productRepo: productRepo,
+		productRepo:      productRepo,
+		movementRepo:     movementRepo,
+		priceHistoryRepo: priceHistoryRepo,
+		eventBus:         eventBus,
+		idGenerator:      idGenerator,
 	}
 }
 
-// CreateProduct 创建商品
+// CreateProduct 创建商品，商品 Id 由 Id 生成器分配，SKU 的初始库存记录为入库流水
 func (s *ProductService) CreateProduct(
 	ctx context.Context,
-	id string,
 	name string,
 	description string,
-	price *sharedvo.Money,
-	stock int,
+	categoryId string,
+	skus []*entity.SKU,
+	source valueobject.MovementSource,
+) (*entity.Product, error) {
+	// 创建新商品
+	now := time.Now().UnixMilli()
+	product := entity.NewProduct(
+		s.idGenerator.NewId(),
+		name,
+		description,
+		categoryId,
+		skus,
+		valueobject.ProductStatusDraft,
+		now,
+		now,
+	)
+	return s.create(ctx, product, source)
+}
+
+// create 验证并保存新商品，SKU 的初始库存记录为入库流水，并发布商品创建事件
+func (s *ProductService) create(
+	ctx context.Context,
+	product *entity.Product,
+	source valueobject.MovementSource,
 ) (*entity.Product, error) {
 	// 检查商品是否已存在
-	existing, err := s.productRepo.FindById(ctx, id)
+	existing, err := s.productRepo.FindById(ctx, product.Id)
 	if err != nil && !gerror.Is(err, valueobject.ErrProductNotFound) {
 		return nil, err
 	}
@@ -42,19 +87,8 @@ func (s *ProductService) CreateProduct(
 		return nil, valueobject.ErrProductExists
 	}
 
-	// 创建新商品
-	product := entity.NewProduct(
-		id,
-		name,
-		description,
-		price,
-		stock,
-		valueobject.ProductStatusDraft,
-		0, // createdAt will be set by repository
-		0, // updatedAt will be set by repository
-	)
-
-	// 验证商品
+	// 为新 SKU 分配 Id 并验证商品
+	s.assignSKUIds(product)
 	if err = product.Validate(); err != nil {
 		return nil, err
 	}
@@ -64,49 +98,180 @@ func (s *ProductService) CreateProduct(
 		return nil, err
 	}
 
+	// 商品已经保存，初始库存流水和创建事件失败时只记录日志，缺失的流水可以通过 ReconcileStock 核对
+	if err = s.recordMovements(ctx, productSnapshot{}, product, "", valueobject.MovementInbound, source); err != nil {
+		g.Log().Errorf(ctx, "failed to record initial stock of product %s: %+v", product.Id, err)
+	}
+	if err = s.eventBus.Publish(ctx, event.NewProductCreatedEvent(product)); err != nil {
+		g.Log().Errorf(ctx, "failed to publish created event of product %s: %+v", product.Id, err)
+	}
+
 	return product, nil
 }
 
 // UpdateProduct 更新商品
+// 仅更新商品信息，SKU 通过 AddSKU、UpdateSKU、RemoveSKU 维护，
+// 商品状态需通过 PublishProduct、TakeProductOffSale 等生命周期方法变更
 func (s *ProductService) UpdateProduct(
 	ctx context.Context,
 	id string,
 	name string,
 	description string,
-	price *sharedvo.Money,
-	stock int,
-	status valueobject.ProductStatus,
 ) (*entity.Product, error) {
-	// 获取现有商品
-	product, err := s.productRepo.FindById(ctx, id)
-	if err != nil {
-		return nil, err
-	}
+	return s.modify(ctx, id, func(product *entity.Product) error {
+		product.Name = name
+		product.Description = description
+		return nil
+	})
+}
 
-	// 更新商品信息
-	product.Name = name
-	product.Description = description
-	if err = product.UpdatePrice(price); err != nil {
-		return nil, err
-	}
-	if err = product.UpdateStock(stock); err != nil {
-		return nil, err
+// ImportProduct 按外部编码导入商品
+// id 为空时创建商品，SKU 的初始库存记录为入库流水；
+// 否则更新商品信息并逐个导入 SKU，在库数量的差异记录为调整流水
+func (s *ProductService) ImportProduct(
+	ctx context.Context,
+	id string,
+	name string,
+	description string,
+	categoryId string,
+	skus []*entity.SKU,
+	source valueobject.MovementSource,
+) (*entity.Product, error) {
+	if id == "" {
+		return s.CreateProduct(ctx, name, description, categoryId, skus, source)
 	}
-	if err = product.UpdateStatus(status); err != nil {
-		return nil, err
+	return s.modifyStock(ctx, id, valueobject.MovementAdjustment, source, func(product *entity.Product) error {
+		product.Name = name
+		product.Description = description
+		product.AssignCategory(categoryId)
+		for _, sku := range skus {
+			if _, err := product.UpsertSKU(sku); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// FindProductsByExternalIds 获取包含指定外部编码 SKU 的商品
+func (s *ProductService) FindProductsByExternalIds(ctx context.Context, externalIds []string) ([]*entity.Product, error) {
+	if len(externalIds) == 0 {
+		return []*entity.Product{}, nil
 	}
+	return s.productRepo.FindByExternalIds(ctx, externalIds)
+}
 
-	// 验证商品
-	if err = product.Validate(); err != nil {
-		return nil, err
+// SetLowStockThreshold 设置商品的低库存阈值，为 0 表示不预警
+// 设置后商品已处于低库存时立即发布低库存事件
+func (s *ProductService) SetLowStockThreshold(ctx context.Context, id string, threshold int) (*entity.Product, error) {
+	return s.modify(ctx, id, func(product *entity.Product) error {
+		return product.SetLowStockThreshold(threshold)
+	})
+}
+
+// ListLowStockProducts 获取可售数量不高于低库存阈值的商品，不包括已删除的商品
+func (s *ProductService) ListLowStockProducts(ctx context.Context) ([]*entity.Product, error) {
+	return s.productRepo.FindLowStock(ctx)
+}
+
+// UpdateRating 更新商品的评分汇总，由评价子系统在评价审核后调用
+func (s *ProductService) UpdateRating(ctx context.Context, id string, rating valueobject.ProductRating) error {
+	return s.productRepo.UpdateRating(ctx, id, rating)
+}
+
+// SalesSince 统计商品自 since 起通过订单售出的数量
+func (s *ProductService) SalesSince(ctx context.Context, productIds []string, since int64) (map[string]int, error) {
+	if len(productIds) == 0 {
+		return map[string]int{}, nil
 	}
+	return s.movementRepo.SumSales(ctx, productIds, since)
+}
 
-	// 保存更新
-	if err = s.productRepo.Update(ctx, product); err != nil {
-		return nil, err
+// AssignCategory 将商品归入类目，类目 Id 为空时取消分类
+func (s *ProductService) AssignCategory(ctx context.Context, id string, categoryId string) (*entity.Product, error) {
+	return s.modify(ctx, id, func(product *entity.Product) error {
+		product.AssignCategory(categoryId)
+		return nil
+	})
+}
+
+// AddSKU 为商品添加 SKU，初始库存记录为入库流水
+func (s *ProductService) AddSKU(
+	ctx context.Context,
+	productId string,
+	sku *entity.SKU,
+	source valueobject.MovementSource,
+) (*entity.Product, error) {
+	return s.modifyStock(ctx, productId, valueobject.MovementInbound, source, func(product *entity.Product) error {
+		return product.AddSKU(sku)
+	})
+}
+
+// UpdateSKU 更新 SKU 的价格和条码，价格变更以 source 的名义记录价格历史
+// 库存不能直接修改，需通过 AdjustStock 登记变动原因
+func (s *ProductService) UpdateSKU(
+	ctx context.Context,
+	productId string,
+	skuId string,
+	price *sharedvo.Money,
+	barcode string,
+	source valueobject.MovementSource,
+) (*entity.Product, error) {
+	return s.modifyStock(ctx, productId, "", source, func(product *entity.Product) error {
+		return product.UpdateSKU(skuId, price, barcode)
+	})
+}
+
+// ChangePrice 修改 SKU 的价格并记录价格历史
+// expected 不为空时仅当 SKU 的当前价格与之相同才修改，用于恢复原价时不覆盖期间的人工调价
+func (s *ProductService) ChangePrice(
+	ctx context.Context,
+	productId string,
+	skuId string,
+	price *sharedvo.Money,
+	expected *sharedvo.Money,
+	source valueobject.MovementSource,
+) (*entity.Product, error) {
+	return s.modifyStock(ctx, productId, "", source, func(product *entity.Product) error {
+		if expected != nil {
+			sku, err := product.FindSKU(skuId)
+			if err != nil {
+				return err
+			}
+			if !sku.Price.Equals(expected) {
+				return nil
+			}
+		}
+		return product.UpdatePrice(skuId, price)
+	})
+}
+
+// ListPriceHistory 获取商品的价格历史，按时间倒序
+func (s *ProductService) ListPriceHistory(
+	ctx context.Context,
+	productId string,
+	skuId string,
+	limit int,
+) ([]*entity.PriceChange, error) {
+	switch {
+	case limit <= 0:
+		limit = valueobject.DefaultPriceHistoryLimit
+	case limit > valueobject.MaxPriceHistoryLimit:
+		limit = valueobject.MaxPriceHistoryLimit
 	}
+	return s.priceHistoryRepo.FindByProductId(ctx, productId, skuId, limit)
+}
 
-	return product, nil
+// RemoveSKU 移除商品的 SKU，剩余库存记录为调整流水
+func (s *ProductService) RemoveSKU(
+	ctx context.Context,
+	productId string,
+	skuId string,
+	source valueobject.MovementSource,
+) (*entity.Product, error) {
+	return s.modifyStock(ctx, productId, valueobject.MovementAdjustment, source, func(product *entity.Product) error {
+		return product.RemoveSKU(skuId)
+	})
 }
 
 // GetProduct 获取商品
@@ -114,85 +279,574 @@ func (s *ProductService) GetProduct(ctx context.Context, id string) (*entity.Pro
 	return s.productRepo.FindById(ctx, id)
 }
 
-// ListProducts 获取所有商品
+// GetProductBySKU 根据 SKU Id 获取所属商品
+func (s *ProductService) GetProductBySKU(ctx context.Context, skuId string) (*entity.Product, error) {
+	return s.productRepo.FindBySKUId(ctx, skuId)
+}
+
+// ListProductsByCategories 获取属于指定类目的商品，不包括已删除的商品
+func (s *ProductService) ListProductsByCategories(ctx context.Context, categoryIds []string) ([]*entity.Product, error) {
+	if len(categoryIds) == 0 {
+		return []*entity.Product{}, nil
+	}
+	return s.productRepo.FindByCategoryIds(ctx, categoryIds)
+}
+
+// SearchProducts 按条件搜索商品
+func (s *ProductService) SearchProducts(
+	ctx context.Context,
+	criteria valueobject.ProductSearchCriteria,
+) (*repository.ProductSearchResult, error) {
+	if err := criteria.Normalize(); err != nil {
+		return nil, err
+	}
+	return s.productRepo.Search(ctx, criteria)
+}
+
+// ListProducts 获取所有未删除的商品
 func (s *ProductService) ListProducts(ctx context.Context) ([]*entity.Product, error) {
 	return s.productRepo.FindAll(ctx)
 }
 
+// ListDeletedProducts 获取所有已删除、尚未被清除的商品
+func (s *ProductService) ListDeletedProducts(ctx context.Context) ([]*entity.Product, error) {
+	return s.productRepo.FindDeleted(ctx, 0)
+}
+
+// PublishProduct 发布商品
+func (s *ProductService) PublishProduct(ctx context.Context, id string) (*entity.Product, error) {
+	return s.modify(ctx, id, (*entity.Product).Publish)
+}
+
+// TakeProductOffSale 下架商品
+func (s *ProductService) TakeProductOffSale(ctx context.Context, id string) (*entity.Product, error) {
+	return s.modify(ctx, id, (*entity.Product).TakeOffSale)
+}
+
+// MarkProductSoldOut 标记商品售罄
+func (s *ProductService) MarkProductSoldOut(ctx context.Context, id string) (*entity.Product, error) {
+	return s.modify(ctx, id, (*entity.Product).MarkSoldOut)
+}
+
 // DeleteProduct 删除商品
-func (s *ProductService) DeleteProduct(ctx context.Context, id string) error {
-	// 检查商品是否存在
-	product, err := s.productRepo.FindById(ctx, id)
+// 商品仍是未删除套装的组件时不能删除，需先删除套装或替换其组件
+func (s *ProductService) DeleteProduct(ctx context.Context, id string) (*entity.Product, error) {
+	if err := s.checkComponentInUse(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.modify(ctx, id, (*entity.Product).Delete)
+}
+
+// RestoreProduct 恢复已删除的商品
+func (s *ProductService) RestoreProduct(ctx context.Context, id string) (*entity.Product, error) {
+	return s.modify(ctx, id, (*entity.Product).Restore)
+}
+
+// modify 对商品执行不涉及库存的修改操作，验证并保存后发布相应的领域事件
+func (s *ProductService) modify(
+	ctx context.Context,
+	id string,
+	change func(*entity.Product) error,
+) (*entity.Product, error) {
+	return s.modifyStock(ctx, id, "", valueobject.MovementSource{}, change)
+}
+
+// assignSKUIds 为商品中尚无 Id 的 SKU 分配 Id
+func (s *ProductService) assignSKUIds(product *entity.Product) {
+	for _, sku := range product.SKUs {
+		if sku.Id == "" {
+			sku.Id = s.idGenerator.NewId()
+		}
+	}
+}
+
+// modifyStock 对商品执行修改操作，验证并保存后按 reason 记录库存流水，
+// 以 source 的名义记录价格历史，并发布相应的领域事件
+// reason 为空表示修改不涉及库存。商品保存后修改即已生效，流水、价格历史和事件只是其派生记录，
+// 失败时只记录日志而不返回错误，避免调用方误以为修改未生效而重试
+func (s *ProductService) modifyStock(
+	ctx context.Context,
+	id string,
+	reason valueobject.MovementReason,
+	source valueobject.MovementSource,
+	change func(*entity.Product) error,
+) (*entity.Product, error) {
+	before, product, err := s.update(ctx, id, change)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	// 更新状态为已删除
-	if err := product.UpdateStatus(valueobject.ProductStatusDeleted); err != nil {
-		return err
+	// 记录库存流水
+	if reason != "" {
+		if err = s.recordMovements(ctx, before, product, "", reason, source); err != nil {
+			g.Log().Errorf(ctx, "failed to record stock movements of product %s: %+v", product.Id, err)
+		}
+	}
+
+	// 记录价格历史
+	if err = s.recordPriceChanges(ctx, before, product, source); err != nil {
+		g.Log().Errorf(ctx, "failed to record price changes of product %s: %+v", product.Id, err)
+	}
+
+	// 发布商品变更事件
+	if err = s.publishChanges(ctx, before, product); err != nil {
+		g.Log().Errorf(ctx, "failed to publish changes of product %s: %+v", product.Id, err)
 	}
 
-	// 保存更新
-	return s.productRepo.Update(ctx, product)
+	// 组件的库存或状态变化影响套装的可售数量
+	if !product.IsBundle() {
+		s.refreshBundles(ctx, []string{product.Id})
+	}
+
+	return product, nil
 }
 
-// HasSufficientStock 检查商品是否有足够的库存
-func (s *ProductService) HasSufficientStock(ctx context.Context, productId string, quantity int) bool {
-	product, err := s.productRepo.FindById(ctx, productId)
+// update 读取商品并执行修改操作，验证后保存，返回修改前的快照和修改后的商品
+// 商品在读取后被预占库存等原子操作并发修改时，重新读取并重新执行修改操作，
+// 避免整体保存覆盖这些修改
+func (s *ProductService) update(
+	ctx context.Context,
+	id string,
+	change func(*entity.Product) error,
+) (productSnapshot, *entity.Product, error) {
+	for attempt := 0; ; attempt++ {
+		product, err := s.productRepo.FindById(ctx, id)
+		if err != nil {
+			return productSnapshot{}, nil, err
+		}
+
+		// 执行修改操作
+		before := snapshotOf(product)
+		if err = change(product); err != nil {
+			return productSnapshot{}, nil, err
+		}
+
+		// 为新 SKU 分配 Id 并验证商品，套装重新推算可售数量
+		s.assignSKUIds(product)
+		if err = product.Validate(); err != nil {
+			return productSnapshot{}, nil, err
+		}
+		if product.IsBundle() {
+			product.BundleAvailable = s.bundleAvailable(ctx, product)
+		}
+		product.UpdatedAt = time.Now().UnixMilli()
+
+		// 保存更新
+		err = s.productRepo.Update(ctx, product)
+		if err == nil {
+			return before, product, nil
+		}
+		if !gerror.Is(err, valueobject.ErrConcurrentModification) || attempt >= maxModifyRetries {
+			return productSnapshot{}, nil, err
+		}
+	}
+}
+
+// HasSufficientStock 检查 SKU 是否有足够的可售库存
+func (s *ProductService) HasSufficientStock(ctx context.Context, skuId string, quantity int) bool {
+	product, err := s.productRepo.FindBySKUId(ctx, skuId)
 	if err != nil {
 		return false
 	}
 
-	return product.Stock >= quantity && product.Status == valueobject.ProductStatusOnSale
+	return product.HasSufficientStock(skuId, quantity)
+}
+
+// ReserveStock 预占 SKU 库存
+func (s *ProductService) ReserveStock(ctx context.Context, skuId string, quantity int, source valueobject.MovementSource) error {
+	_, err := s.ReserveStockItems(ctx, valueobject.StockItems{{SkuId: skuId, Quantity: quantity}}, source)
+	return err
+}
+
+// ReleaseStock 释放预占的 SKU 库存
+func (s *ProductService) ReleaseStock(ctx context.Context, skuId string, quantity int, source valueobject.MovementSource) error {
+	_, err := s.ReleaseStockItems(ctx, valueobject.StockItems{{SkuId: skuId, Quantity: quantity}}, source)
+	return err
+}
+
+// ReserveStockItems 原子地为多个 SKU 预占库存
+// 预占以可售数量充足为条件在仓储中完成，并发下单不会超卖；
+// 任一 SKU 不可售或库存不足时所有 SKU 都不预占。
+// 返回预占后的商品，与按 SKU 合并后的 items 一一对应
+func (s *ProductService) ReserveStockItems(
+	ctx context.Context,
+	items valueobject.StockItems,
+	source valueobject.MovementSource,
+) ([]*entity.Product, error) {
+	return s.changeStockItems(ctx, items, valueobject.MovementReservation, source,
+		s.productRepo.ReserveStock, (*entity.Product).ReserveStock,
+	)
+}
+
+// ReleaseStockItems 原子地释放多个 SKU 预占的库存
+func (s *ProductService) ReleaseStockItems(
+	ctx context.Context,
+	items valueobject.StockItems,
+	source valueobject.MovementSource,
+) ([]*entity.Product, error) {
+	return s.changeStockItems(ctx, items, valueobject.MovementRelease, source,
+		s.productRepo.ReleaseStock, (*entity.Product).ReleaseStock,
+	)
 }
 
-// ReserveStock 预扣商品库存
-func (s *ProductService) ReserveStock(ctx context.Context, productId string, quantity int) error {
+// CommitStockItems 原子地确认多个 SKU 预占的库存，在库数量和预占数量同时减少
+func (s *ProductService) CommitStockItems(
+	ctx context.Context,
+	items valueobject.StockItems,
+	source valueobject.MovementSource,
+) ([]*entity.Product, error) {
+	return s.changeStockItems(ctx, items, valueobject.MovementSale, source,
+		s.productRepo.CommitStock, (*entity.Product).CommitStock,
+	)
+}
+
+// AdjustStock 原子地按增量调整 SKU 的在库数量并登记变动原因，返回调整后的商品
+// item.Quantity 为在库数量的增量，入库和退货的增量必须为正；调整后的在库数量不能少于已预占的数量
+func (s *ProductService) AdjustStock(
+	ctx context.Context,
+	item valueobject.StockItem,
+	reason valueobject.MovementReason,
+	source valueobject.MovementSource,
+) (*entity.Product, error) {
+	if !reason.IsManual() {
+		return nil, gerror.Wrapf(valueobject.ErrInvalidMovement, "stock cannot be adjusted for reason %s", reason)
+	}
+	if item.Quantity == 0 || (reason != valueobject.MovementAdjustment && item.Quantity < 0) {
+		return nil, gerror.Wrapf(valueobject.ErrInvalidStock, "invalid %s quantity: %d", reason, item.Quantity)
+	}
+	product, err := s.productRepo.AdjustStock(ctx, item.SkuId, item.Quantity)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to adjust stock")
+	}
+	items := valueobject.StockItems{item}
+	s.publishStockChanges(ctx, items, items, []*entity.Product{product}, reason, source, (*entity.Product).AdjustStock)
+	return product, nil
+}
+
+// RecordTransfer 记录 SKU 在仓库间调拨的库存流水
+// 调拨只改变库存在仓库间的分布，商品的汇总库存不变，因此调出和调入两条流水相互抵消
+func (s *ProductService) RecordTransfer(
+	ctx context.Context,
+	productId string,
+	skuId string,
+	fromWarehouseId string,
+	toWarehouseId string,
+	quantity int,
+	source valueobject.MovementSource,
+) error {
 	product, err := s.productRepo.FindById(ctx, productId)
 	if err != nil {
-		return gerror.Wrap(err, "failed to find product")
+		return err
 	}
+	sku, err := product.FindSKU(skuId)
+	if err != nil {
+		return err
+	}
+
+	out := entity.NewStockMovement(
+		s.idGenerator.NewId(),
+		productId, skuId, fromWarehouseId, valueobject.MovementTransfer, sku.Level(), sku.Level(), source,
+	)
+	out.OnHandDelta = -quantity
+	in := entity.NewStockMovement(
+		s.idGenerator.NewId(),
+		productId, skuId, toWarehouseId, valueobject.MovementTransfer, sku.Level(), sku.Level(), source,
+	)
+	in.OnHandDelta = quantity
+	movements := []*entity.StockMovement{out, in}
+	return s.appendMovements(ctx, movements)
+}
 
-	// 检查库存是否充足
-	if !s.HasSufficientStock(ctx, productId, quantity) {
-		return gerror.Newf("insufficient stock for product %s", productId)
+// ListStockMovements 获取商品的库存流水，按时间倒序，skuId 为空时返回所有 SKU 的流水
+func (s *ProductService) ListStockMovements(
+	ctx context.Context,
+	productId string,
+	skuId string,
+	limit int,
+) ([]*entity.StockMovement, error) {
+	switch {
+	case limit <= 0:
+		limit = valueobject.DefaultMovementLimit
+	case limit > valueobject.MaxMovementLimit:
+		limit = valueobject.MaxMovementLimit
 	}
+	return s.movementRepo.FindByProductId(ctx, productId, skuId, limit)
+}
 
-	// 更新库存
-	if err := product.UpdateStock(product.Stock - quantity); err != nil {
-		return gerror.Wrap(err, "failed to update stock")
+// ReconcileStock 核对商品各 SKU 的库存与库存流水的汇总，返回不一致的 SKU
+// 启用库存流水之前已有库存、尚无任何流水的 SKU，以当前库存补记一条期初流水
+func (s *ProductService) ReconcileStock(
+	ctx context.Context,
+	productId string,
+	source valueobject.MovementSource,
+) ([]valueobject.StockDiscrepancy, error) {
+	product, err := s.productRepo.FindById(ctx, productId)
+	if err != nil {
+		return nil, err
+	}
+	ledger, err := s.movementRepo.SumByProductId(ctx, productId)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to sum stock movements")
 	}
 
-	// 如果库存为0，更新状态为售罄
-	if product.Stock == 0 {
-		if err := product.UpdateStatus(valueobject.ProductStatusSoldOut); err != nil {
-			return gerror.Wrap(err, "failed to update product status")
+	var (
+		openings      []*entity.StockMovement
+		discrepancies = make([]valueobject.StockDiscrepancy, 0)
+	)
+	for _, sku := range product.SKUs {
+		level, ok := ledger[sku.Id]
+		delete(ledger, sku.Id)
+		switch {
+		case !ok && sku.Level() != (valueobject.StockLevel{}):
+			openings = append(openings, entity.NewStockMovement(
+				s.idGenerator.NewId(),
+				product.Id, sku.Id, "", valueobject.MovementOpening, valueobject.StockLevel{}, sku.Level(), source,
+			))
+		case ok && level != sku.Level():
+			discrepancies = append(discrepancies, valueobject.StockDiscrepancy{
+				SkuId:  sku.Id,
+				Ledger: level,
+				Actual: sku.Level(),
+			})
+		}
+	}
+	// 已移除的 SKU 的流水应当归零
+	for skuId, level := range ledger {
+		if level != (valueobject.StockLevel{}) {
+			discrepancies = append(discrepancies, valueobject.StockDiscrepancy{SkuId: skuId, Ledger: level})
 		}
 	}
+	sort.Slice(discrepancies, func(i, j int) bool { return discrepancies[i].SkuId < discrepancies[j].SkuId })
 
-	// 保存更新
-	return s.productRepo.Update(ctx, product)
+	if err = s.appendMovements(ctx, openings); err != nil {
+		return nil, err
+	}
+	return discrepancies, nil
 }
 
-// ReleaseStock 释放商品库存
-func (s *ProductService) ReleaseStock(ctx context.Context, productId string, quantity int) error {
-	product, err := s.productRepo.FindById(ctx, productId)
+// changeStockItems 通过仓储的原子操作变更库存，并在变更前的商品上重放领域操作以记录库存流水、发布领域事件
+// 商品库存按 SKU 汇总变更，库存流水按 SKU 和仓库分别记录
+func (s *ProductService) changeStockItems(
+	ctx context.Context,
+	items valueobject.StockItems,
+	reason valueobject.MovementReason,
+	source valueobject.MovementSource,
+	change func(ctx context.Context, items valueobject.StockItems) ([]*entity.Product, error),
+	apply func(product *entity.Product, skuId string, quantity int) error,
+) ([]*entity.Product, error) {
+	items = items.Merge()
+	if err := items.Validate(); err != nil {
+		return nil, err
+	}
+	merged := items.BySKU()
+	products, err := change(ctx, merged)
 	if err != nil {
-		return gerror.Wrap(err, "failed to find product")
+		return nil, gerror.Wrap(err, "failed to update stock")
+	}
+	s.publishStockChanges(ctx, items, merged, products, reason, source, apply)
+	return products, nil
+}
+
+// publishStockChanges 在变更前的商品上重放库存操作，记录库存流水并发布相应的领域事件
+// befores 与按 SKU 合并后的 merged 一一对应，items 为按 SKU 和仓库合并的操作项。
+// 库存此时已经原子地变更，流水和事件只是其派生记录，失败时只记录日志而不返回错误，
+// 避免调用方误以为库存未变更而遗留无人释放的预占；缺失的流水可以通过 ReconcileStock 核对
+func (s *ProductService) publishStockChanges(
+	ctx context.Context,
+	items valueobject.StockItems,
+	merged valueobject.StockItems,
+	befores []*entity.Product,
+	reason valueobject.MovementReason,
+	source valueobject.MovementSource,
+	apply func(product *entity.Product, skuId string, quantity int) error,
+) {
+	var (
+		movements = make([]*entity.StockMovement, 0, len(items))
+		snapshots = make([]productSnapshot, 0, len(befores))
+		products  = make([]*entity.Product, 0, len(befores))
+	)
+	for i, product := range befores {
+		before := snapshotOf(product)
+		sku, err := product.FindSKU(merged[i].SkuId)
+		if err != nil {
+			g.Log().Errorf(ctx, "failed to replay %s of sku %s: %+v", reason, merged[i].SkuId, err)
+			continue
+		}
+		replayed := true
+		for _, item := range items {
+			if item.SkuId != sku.Id {
+				continue
+			}
+			level := sku.Level()
+			if err = apply(product, item.SkuId, item.Quantity); err != nil {
+				g.Log().Errorf(ctx, "failed to replay %s of sku %s: %+v", reason, item.SkuId, err)
+				replayed = false
+				break
+			}
+			movements = append(movements, entity.NewStockMovement(
+				s.idGenerator.NewId(),
+				product.Id, sku.Id, item.WarehouseId, reason, level, sku.Level(), source,
+			))
+		}
+		if replayed {
+			snapshots = append(snapshots, before)
+			products = append(products, product)
+		}
+	}
+
+	// 先记录流水再发布事件，事件的订阅者可以查到对应的流水
+	if err := s.appendMovements(ctx, movements); err != nil {
+		g.Log().Errorf(ctx, "failed to record stock movements of %s: %+v", reason, err)
+	}
+	productIds := make([]string, len(products))
+	for i, product := range products {
+		productIds[i] = product.Id
+		if err := s.publishChanges(ctx, snapshots[i], product); err != nil {
+			g.Log().Errorf(ctx, "failed to publish stock changes of product %s: %+v", product.Id, err)
+		}
+	}
+	s.refreshBundles(ctx, productIds)
+}
+
+// recordMovements 对比商品变更前后各 SKU 的库存，记录库存流水
+func (s *ProductService) recordMovements(
+	ctx context.Context,
+	before productSnapshot,
+	product *entity.Product,
+	warehouseId string,
+	reason valueobject.MovementReason,
+	source valueobject.MovementSource,
+) error {
+	var movements []*entity.StockMovement
+	seen := make(map[string]bool, len(product.SKUs))
+	for _, sku := range product.SKUs {
+		seen[sku.Id] = true
+		if old := before.skus[sku.Id].stock; old != sku.Level() {
+			movements = append(movements, entity.NewStockMovement(
+				s.idGenerator.NewId(), product.Id, sku.Id, warehouseId, reason, old, sku.Level(), source,
+			))
+		}
+	}
+	// 已移除的 SKU
+	for skuId, old := range before.skus {
+		if !seen[skuId] && old.stock != (valueobject.StockLevel{}) {
+			movements = append(movements, entity.NewStockMovement(
+				s.idGenerator.NewId(),
+				product.Id, skuId, warehouseId, reason, old.stock, valueobject.StockLevel{}, source,
+			))
+		}
 	}
+	return s.appendMovements(ctx, movements)
+}
 
-	// 更新库存
-	if err := product.UpdateStock(product.Stock + quantity); err != nil {
-		return gerror.Wrap(err, "failed to update stock")
+// appendMovements 验证并追加库存流水
+func (s *ProductService) appendMovements(ctx context.Context, movements []*entity.StockMovement) error {
+	if len(movements) == 0 {
+		return nil
+	}
+	for _, movement := range movements {
+		if err := movement.Validate(); err != nil {
+			return err
+		}
+	}
+	if err := s.movementRepo.Append(ctx, movements...); err != nil {
+		return gerror.Wrap(err, "failed to record stock movements")
 	}
+	return nil
+}
 
-	// 如果商品之前是售罄状态，且现在有库存了，更新状态为在售
-	if product.Status == valueobject.ProductStatusSoldOut && product.Stock > 0 {
-		if err := product.UpdateStatus(valueobject.ProductStatusOnSale); err != nil {
-			return gerror.Wrap(err, "failed to update product status")
+// recordPriceChanges 对比商品变更前后的快照，为价格变动的已有 SKU 记录价格历史
+// 新增 SKU 的初始价格不记录
+func (s *ProductService) recordPriceChanges(
+	ctx context.Context,
+	before productSnapshot,
+	product *entity.Product,
+	source valueobject.MovementSource,
+) error {
+	var changes []*entity.PriceChange
+	for _, sku := range product.SKUs {
+		old := before.skus[sku.Id].price
+		if old != nil && !old.Equals(sku.Price) {
+			changes = append(changes, entity.NewPriceChange(
+				s.idGenerator.NewId(), product.Id, sku.Id, old, sku.Price, source,
+			))
 		}
 	}
+	if len(changes) == 0 {
+		return nil
+	}
+	if err := s.priceHistoryRepo.Append(ctx, changes...); err != nil {
+		return gerror.Wrap(err, "failed to record price history")
+	}
+	return nil
+}
+
+// productSnapshot 商品变更前的快照，用于生成携带变更前后值的领域事件
+type productSnapshot struct {
+	skus     map[string]skuSnapshot
+	status   valueobject.ProductStatus
+	lowStock bool // 是否已处于低库存
+}
 
-	// 保存更新
-	return s.productRepo.Update(ctx, product)
+// skuSnapshot SKU 变更前的价格和库存
+type skuSnapshot struct {
+	price *sharedvo.Money
+	stock valueobject.StockLevel
+}
+
+// snapshotOf 记录商品当前各 SKU 的价格、库存和商品状态
+func snapshotOf(product *entity.Product) productSnapshot {
+	skus := make(map[string]skuSnapshot, len(product.SKUs))
+	for _, sku := range product.SKUs {
+		skus[sku.Id] = skuSnapshot{
+			price: sku.Price,
+			stock: sku.Level(),
+		}
+	}
+	return productSnapshot{
+		skus:     skus,
+		status:   product.Status,
+		lowStock: product.IsLowStock(),
+	}
+}
+
+// publishChanges 对比商品变更前后的快照，发布相应的领域事件
+func (s *ProductService) publishChanges(ctx context.Context, before productSnapshot, product *entity.Product) error {
+	var events []eventbus.Event
+	for _, sku := range product.SKUs {
+		old, existed := before.skus[sku.Id]
+		switch {
+		case !existed:
+			events = append(events, event.NewProductSKUAddedEvent(product.Id, sku.Id, sku.Price))
+		case old.price != nil && !old.price.Equals(sku.Price):
+			events = append(events, event.NewProductPriceChangedEvent(product.Id, sku.Id, old.price, sku.Price))
+		}
+		if old.stock != sku.Level() {
+			events = append(events, event.NewProductStockChangedEvent(product.Id, sku.Id, old.stock, sku.Level()))
+		}
+		delete(before.skus, sku.Id)
+	}
+	// 已移除的 SKU
+	for skuId, old := range before.skus {
+		if old.stock != (valueobject.StockLevel{}) {
+			events = append(events, event.NewProductStockChangedEvent(product.Id, skuId, old.stock, valueobject.StockLevel{}))
+		}
+	}
+	// 可售数量降到低库存阈值以下，或者调低阈值使商品进入低库存
+	if !before.lowStock && product.IsLowStock() && !product.IsDeleted() {
+		events = append(events, event.NewProductLowStockEvent(product.Id, product.TotalAvailable(), product.LowStockThreshold))
+	}
+	if before.status != product.Status {
+		events = append(events, event.NewProductStatusChangedEvent(product.Id, before.status, product.Status))
+		if product.IsDeleted() {
+			events = append(events, event.NewProductDeletedEvent(product.Id, before.status))
+		}
+	}
+
+	for _, e := range events {
+		if err := s.eventBus.Publish(ctx, e); err != nil {
+			return gerror.Wrapf(err, "failed to publish %s event", e.EventName())
+		}
+	}
+	return nil
 }
@@ -0,0 +1,206 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/util/guid"
+
+	"main/internal/domain/product/entity"
+	"main/internal/domain/product/repository"
+	"main/internal/domain/product/valueobject"
+	sharedservice "main/internal/domain/shared/service"
+)
+
+// Image 图片数据
+type Image struct {
+	Data        []byte
+	ContentType string
+	Width       int
+	Height      int
+}
+
+// ImageProcessor 图片处理接口
+// 由基础设施层实现，负责图片的解码和缩放
+type ImageProcessor interface {
+	// Decode 识别图片格式和尺寸，只读取图片头部，不解码像素
+	// 不支持的格式或像素数超过 valueobject.MaxImagePixels 时返回 valueobject.ErrInvalidImage
+	Decode(data []byte) (*Image, error)
+	// Thumbnails 按比例将图片缩放到不超过各指定尺寸，原图更小时不放大，结果与 sizes 一一对应
+	// 原图只解码一次
+	Thumbnails(image *Image, sizes []valueobject.ImageSize) ([]*Image, error)
+}
+
+// ProductImageService 商品图片服务
+// 负责图片文件的存储、缩略图生成以及商品图片列表的维护，
+// 图片从商品中移除时同步删除其在对象存储中的文件，避免产生孤立文件
+type ProductImageService struct {
+	productRepo repository.ProductRepository
+	blobStore   sharedservice.BlobStore
+	processor   ImageProcessor
+}
+
+// NewProductImageService 创建商品图片服务实例
+func NewProductImageService(
+	productRepo repository.ProductRepository,
+	blobStore sharedservice.BlobStore,
+	processor ImageProcessor,
+) *ProductImageService {
+	return &ProductImageService{
+		productRepo: productRepo,
+		blobStore:   blobStore,
+		processor:   processor,
+	}
+}
+
+// UploadImage 上传商品图片，添加到图片列表末尾
+func (s *ProductImageService) UploadImage(ctx context.Context, productId string, data []byte) (*entity.Product, error) {
+	if len(data) > valueobject.MaxImageBytes {
+		return nil, gerror.Wrapf(valueobject.ErrInvalidImage,
+			"image size %d exceeds the limit of %d bytes", len(data), valueobject.MaxImageBytes,
+		)
+	}
+
+	product, err := s.productRepo.FindById(ctx, productId)
+	if err != nil {
+		return nil, err
+	}
+	// 先检查数量限制，避免写入注定无用的文件
+	if len(product.Images) >= valueobject.MaxProductImages {
+		return nil, gerror.Wrapf(valueobject.ErrTooManyImages,
+			"product %s already has %d images", product.Id, len(product.Images),
+		)
+	}
+
+	// 1. 识别图片
+	original, err := s.processor.Decode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. 存储原图和各尺寸缩略图
+	image, err := s.storeImage(ctx, product.Id, original)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. 添加到商品，失败时清理已写入的文件
+	// 仓储以原子操作追加图片，并发上传的图片不会相互覆盖，数量限制也在同一操作中检查
+	if err = product.AddImage(image); err != nil {
+		s.deleteFiles(ctx, image.Keys())
+		return nil, err
+	}
+	if err = s.productRepo.AddImage(ctx, product.Id, image); err != nil {
+		s.deleteFiles(ctx, image.Keys())
+		return nil, err
+	}
+
+	return s.productRepo.FindById(ctx, product.Id)
+}
+
+// RemoveImage 移除商品图片并删除其文件
+func (s *ProductImageService) RemoveImage(ctx context.Context, productId string, imageId string) (*entity.Product, error) {
+	product, err := s.productRepo.FindById(ctx, productId)
+	if err != nil {
+		return nil, err
+	}
+
+	image, err := product.RemoveImage(imageId)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.productRepo.RemoveImage(ctx, product.Id, imageId); err != nil {
+		return nil, err
+	}
+
+	// 商品已不再引用这些文件，删除失败时返回错误以便调用方感知孤立文件
+	if err = s.deleteFiles(ctx, image.Keys()); err != nil {
+		return nil, gerror.Wrapf(err, "image %s removed but its files could not be deleted", imageId)
+	}
+	return product, nil
+}
+
+// ReorderImages 调整商品图片顺序，第一张为主图
+func (s *ProductImageService) ReorderImages(ctx context.Context, productId string, imageIds []string) (*entity.Product, error) {
+	product, err := s.productRepo.FindById(ctx, productId)
+	if err != nil {
+		return nil, err
+	}
+	if err = product.ReorderImages(imageIds); err != nil {
+		return nil, err
+	}
+	if err = s.productRepo.ReorderImages(ctx, product.Id, imageIds); err != nil {
+		return nil, err
+	}
+	return product, nil
+}
+
+// storeImage 存储原图并生成缩略图
+// 任一文件写入失败时删除已写入的文件
+func (s *ProductImageService) storeImage(
+	ctx context.Context,
+	productId string,
+	original *Image,
+) (*entity.ProductImage, error) {
+	imageId := guid.S()
+	prefix := fmt.Sprintf("products/%s/images/%s", productId, imageId)
+
+	var written []string
+	put := func(name string, img *Image) (valueobject.ImageFile, error) {
+		key := prefix + "/" + name + valueobject.ImageExtension(img.ContentType)
+		if err := s.blobStore.Put(ctx, key, bytes.NewReader(img.Data), img.ContentType); err != nil {
+			return valueobject.ImageFile{}, gerror.Wrapf(err, "failed to store image file %s", key)
+		}
+		written = append(written, key)
+		return valueobject.ImageFile{
+			Key:         key,
+			URL:         s.blobStore.URL(key),
+			ContentType: img.ContentType,
+			Width:       img.Width,
+			Height:      img.Height,
+		}, nil
+	}
+
+	originalFile, err := put("original", original)
+	if err != nil {
+		return nil, err
+	}
+	resized, err := s.processor.Thumbnails(original, valueobject.ThumbnailSizes)
+	if err != nil {
+		s.deleteFiles(ctx, written)
+		return nil, gerror.Wrap(err, "failed to create thumbnails")
+	}
+	thumbnails := make(map[string]valueobject.ImageFile, len(valueobject.ThumbnailSizes))
+	for i, size := range valueobject.ThumbnailSizes {
+		if thumbnails[size.Name], err = put(size.Name, resized[i]); err != nil {
+			s.deleteFiles(ctx, written)
+			return nil, gerror.Wrapf(err, "failed to store %s thumbnail", size.Name)
+		}
+	}
+
+	return entity.NewProductImage(imageId, originalFile, thumbnails, time.Now().UnixMilli()), nil
+}
+
+// deleteProductImages 删除商品全部图片在对象存储中的文件，不修改商品
+func (s *ProductImageService) deleteProductImages(ctx context.Context, product *entity.Product) error {
+	keys := make([]string, 0)
+	for _, image := range product.Images {
+		keys = append(keys, image.Keys()...)
+	}
+	return s.deleteFiles(ctx, keys)
+}
+
+// deleteFiles 删除对象存储中的文件
+// 尽量删除全部文件，返回遇到的第一个错误
+func (s *ProductImageService) deleteFiles(ctx context.Context, keys []string) error {
+	var firstErr error
+	for _, key := range keys {
+		if err := s.blobStore.Delete(ctx, key); err != nil && firstErr == nil {
+			firstErr = gerror.Wrapf(err, "failed to delete image file %s", key)
+		}
+	}
+	return firstErr
+}
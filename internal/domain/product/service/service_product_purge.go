@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"main/internal/domain/product/entity"
+	"main/internal/domain/product/repository"
+	"main/internal/domain/product/valueobject"
+)
+
+// DefaultPurgeRetention 已删除商品的默认保留期，保留期内的商品可以恢复
+const DefaultPurgeRetention = 30 * 24 * time.Hour
+
+// ProductPurgeService 商品清除服务
+// 定期永久清除删除时间超过保留期的商品及其图片文件，仍被未完结订单或未删除套装引用的商品暂不清除
+type ProductPurgeService struct {
+	productRepo     repository.ProductRepository
+	imageService    *ProductImageService // 删除商品图片在对象存储中的文件
+	orderReferences OrderReferences
+	retention       time.Duration
+}
+
+// NewProductPurgeService 创建商品清除服务实例，retention 不大于 0 时使用默认保留期
+func NewProductPurgeService(
+	productRepo repository.ProductRepository,
+	imageService *ProductImageService,
+	orderReferences OrderReferences,
+	retention time.Duration,
+) *ProductPurgeService {
+	if retention <= 0 {
+		retention = DefaultPurgeRetention
+	}
+	return &ProductPurgeService{
+		productRepo:     productRepo,
+		imageService:    imageService,
+		orderReferences: orderReferences,
+		retention:       retention,
+	}
+}
+
+// PurgeExpired 永久清除所有超过保留期的已删除商品，返回清除的数量
+// 单个商品的图片文件删除失败时继续清除其余商品，返回遇到的第一个错误
+func (s *ProductPurgeService) PurgeExpired(ctx context.Context) (int, error) {
+	deletedBefore := time.Now().Add(-s.retention).UnixMilli()
+	products, err := s.productRepo.FindDeleted(ctx, deletedBefore)
+	if err != nil {
+		return 0, gerror.Wrap(err, "failed to find deleted products")
+	}
+
+	purged := 0
+	var firstErr error
+	for _, product := range products {
+		ok, err := s.purge(ctx, product)
+		if ok {
+			purged++
+		}
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return purged, firstErr
+}
+
+// purge 永久清除单个商品，返回商品是否已被清除
+// 先删除商品再删除图片文件，删除商品时以删除状态为条件，并发恢复的商品不会被清除
+func (s *ProductPurgeService) purge(ctx context.Context, product *entity.Product) (bool, error) {
+	referenced, err := s.orderReferences.HasOpenOrders(ctx, product.Id)
+	if err != nil {
+		return false, gerror.Wrapf(err, "failed to check open orders of product %s", product.Id)
+	}
+	if referenced {
+		return false, nil
+	}
+	bundles, err := s.productRepo.FindBundlesByComponents(ctx, []string{product.Id})
+	if err != nil {
+		return false, gerror.Wrapf(err, "failed to find bundles of product %s", product.Id)
+	}
+	if len(bundles) > 0 {
+		return false, nil
+	}
+
+	err = s.productRepo.Delete(ctx, product.Id)
+	if gerror.Is(err, valueobject.ErrProductNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, gerror.Wrapf(err, "failed to purge product %s", product.Id)
+	}
+	return true, s.imageService.deleteProductImages(ctx, product)
+}
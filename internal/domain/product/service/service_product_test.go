@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"main/internal/domain/product/entity"
+	"main/internal/domain/product/event"
+	"main/internal/domain/product/valueobject"
+	sharedvo "main/internal/domain/shared/valueobject"
+	"main/internal/infrastructure/eventbus"
+)
+
+// recordingEventBus 记录已发布事件的事件总线
+type recordingEventBus struct {
+	events []eventbus.Event
+}
+
+func (b *recordingEventBus) Publish(_ context.Context, e interface{}) error {
+	b.events = append(b.events, e.(eventbus.Event))
+	return nil
+}
+
+func (b *recordingEventBus) Subscribe(string, func(event interface{}) error) error {
+	return nil
+}
+
+// newTestProduct 创建包含给定 SKU 的在售商品
+func newTestProduct(skus ...*entity.SKU) *entity.Product {
+	return entity.NewProduct("p1", "P1", "", "", skus, valueobject.ProductStatusOnSale, 0, 0)
+}
+
+func TestProductServicePublishChanges(t *testing.T) {
+	price := func(amount float64) *sharedvo.Money { return sharedvo.MustNewMoney(amount, "CNY") }
+
+	tests := []struct {
+		name   string
+		before *entity.Product
+		after  *entity.Product
+		want   []string
+	}{
+		{
+			name:   "unchanged",
+			before: newTestProduct(entity.NewSKU("s1", nil, price(10), 5, "")),
+			after:  newTestProduct(entity.NewSKU("s1", nil, price(10), 5, "")),
+		},
+		{
+			name:   "price of existing sku changed",
+			before: newTestProduct(entity.NewSKU("s1", nil, price(10), 5, "")),
+			after:  newTestProduct(entity.NewSKU("s1", nil, price(12), 5, "")),
+			want:   []string{event.ProductPriceChangedEventName},
+		},
+		{
+			name:   "new sku with stock",
+			before: newTestProduct(entity.NewSKU("s1", nil, price(10), 5, "")),
+			after:  newTestProduct(entity.NewSKU("s1", nil, price(10), 5, ""), entity.NewSKU("s2", nil, price(20), 3, "")),
+			want:   []string{event.ProductSKUAddedEventName, event.ProductStockChangedEventName},
+		},
+		{
+			name:   "new sku without stock",
+			before: newTestProduct(entity.NewSKU("s1", nil, price(10), 5, "")),
+			after:  newTestProduct(entity.NewSKU("s1", nil, price(10), 5, ""), entity.NewSKU("s2", nil, price(20), 0, "")),
+			want:   []string{event.ProductSKUAddedEventName},
+		},
+		{
+			name:   "removed sku with stock",
+			before: newTestProduct(entity.NewSKU("s1", nil, price(10), 5, ""), entity.NewSKU("s2", nil, price(20), 3, "")),
+			after:  newTestProduct(entity.NewSKU("s1", nil, price(10), 5, "")),
+			want:   []string{event.ProductStockChangedEventName},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bus := &recordingEventBus{}
+			s := &ProductService{eventBus: bus}
+			if err := s.publishChanges(context.Background(), snapshotOf(tt.before), tt.after); err != nil {
+				t.Fatalf("publishChanges() error = %v", err)
+			}
+			var got []string
+			for _, e := range bus.events {
+				got = append(got, e.EventName())
+				if changed, ok := e.(*event.ProductPriceChangedEvent); ok && changed.OldPrice == nil {
+					t.Errorf("price changed event of sku %s has no old price", changed.SkuId)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("published %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,295 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"main/internal/domain/product/entity"
+	"main/internal/domain/product/repository"
+	"main/internal/domain/product/valueobject"
+	sharedservice "main/internal/domain/shared/service"
+)
+
+// expiredBatchSize 每批释放的过期预占数量
+const expiredBatchSize = 100
+
+// ReservationService 库存预占服务
+// 在商品库存之外记录每笔预占的来源订单和状态，
+// 使预占中的库存与已售出的库存可以区分，并支持超时未支付的预占自动释放
+type ReservationService struct {
+	productService  *ProductService
+	reservationRepo repository.ReservationRepository
+	warehouseStock  WarehouseStock            // 分仓库存，为空时不区分仓库
+	holdDuration    time.Duration             // 预占的保留时长
+	idGenerator     sharedservice.IdGenerator // 预占记录的 Id 生成器
+}
+
+// NewReservationService 创建库存预占服务实例
+// warehouseStock 为空时忽略预占项的发货仓库，holdDuration 不大于 0 时使用默认的保留时长
+func NewReservationService(
+	productService *ProductService,
+	reservationRepo repository.ReservationRepository,
+	warehouseStock WarehouseStock,
+	holdDuration time.Duration,
+	idGenerator sharedservice.IdGenerator,
+) *ReservationService {
+	if holdDuration <= 0 {
+		holdDuration = valueobject.DefaultReservationHold
+	}
+	return &ReservationService{
+		productService:  productService,
+		reservationRepo: reservationRepo,
+		warehouseStock:  warehouseStock,
+		holdDuration:    holdDuration,
+		idGenerator:     idGenerator,
+	}
+}
+
+// Hold 为订单预占库存并记录预占
+// 商品库存按 SKU 汇总预占，指定了发货仓库的预占项同时预占仓库库存；
+// 任一 SKU 或仓库库存不足时不预占任何库存
+func (s *ReservationService) Hold(
+	ctx context.Context,
+	orderId string,
+	items valueobject.StockItems,
+) ([]*entity.Reservation, error) {
+	// 1. 原子地预占商品库存
+	items = items.Merge()
+	if s.warehouseStock == nil {
+		items = items.BySKU()
+	}
+	products, err := s.productService.ReserveStockItems(ctx, items, valueobject.SystemSource(orderId))
+	if err != nil {
+		return nil, err
+	}
+	productIds := make(map[string]string, len(products))
+	for i, item := range items.BySKU() {
+		productIds[item.SkuId] = products[i].Id
+	}
+
+	// 2. 预占仓库库存并记录预占，失败时释放已预占的库存
+	expiresAt := time.Now().Add(s.holdDuration).UnixMilli()
+	reservations := make([]*entity.Reservation, 0, len(items))
+	for _, item := range items {
+		reservation := entity.NewReservation(
+			s.idGenerator.NewId(), orderId, productIds[item.SkuId], item.SkuId, item.WarehouseId, item.Quantity, expiresAt,
+		)
+		if err = s.hold(ctx, reservation); err != nil {
+			for _, saved := range reservations {
+				_ = s.release(ctx, saved)
+			}
+			if _, releaseErr := s.productService.ReleaseStockItems(ctx, items[len(reservations):], valueobject.SystemSource(orderId)); releaseErr != nil {
+				return nil, gerror.Wrapf(err, "failed to hold stock, and failed to release stock: %v", releaseErr)
+			}
+			return nil, err
+		}
+		reservations = append(reservations, reservation)
+	}
+	return reservations, nil
+}
+
+// hold 预占单个预占项的仓库库存并保存预占记录，保存失败时释放仓库库存
+func (s *ReservationService) hold(ctx context.Context, reservation *entity.Reservation) error {
+	if reservation.WarehouseId != "" {
+		if err := s.warehouseStock.Reserve(ctx, reservation.ProductId, stockItemOf(reservation)); err != nil {
+			return gerror.Wrapf(err, "failed to reserve stock of sku %s in warehouse %s",
+				reservation.SkuId, reservation.WarehouseId,
+			)
+		}
+	}
+	if err := s.reservationRepo.Save(ctx, reservation); err != nil {
+		if reservation.WarehouseId != "" {
+			_ = s.warehouseStock.Release(ctx, reservation.ProductId, stockItemOf(reservation))
+		}
+		return gerror.Wrap(err, "failed to save reservation")
+	}
+	return nil
+}
+
+// Commit 在订单支付前确认其所有预占，确认后的预占不再因过期被释放
+// 每个预占以预占中且尚未过期为条件在一次条件更新中确认，检查与确认之间没有过期窗口；
+// 任一预占已过期或已释放时撤销本次已确认的预占并返回 valueobject.ErrReservationExpired，已过期的预占将被释放。
+// 确认只变更预占状态，订单支付成功后由 Settle 结算库存，支付失败时由 Revert 撤销确认
+func (s *ReservationService) Commit(ctx context.Context, orderId string) error {
+	reservations, err := s.reservationRepo.FindByOrderId(ctx, orderId)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UnixMilli()
+	committed := make([]*entity.Reservation, 0, len(reservations))
+	for _, reservation := range reservations {
+		if err = s.commit(ctx, reservation, now); err != nil {
+			if revertErr := s.revert(ctx, committed); revertErr != nil {
+				return gerror.Wrapf(err, "failed to commit reservations of order %s, and failed to revert: %v", orderId, revertErr)
+			}
+			return err
+		}
+		committed = append(committed, reservation)
+	}
+	return nil
+}
+
+// commit 以条件更新确认单个预占，已过期的预占将被释放
+func (s *ReservationService) commit(ctx context.Context, reservation *entity.Reservation, now int64) error {
+	switch {
+	case reservation.State == valueobject.ReservationStateReleased:
+		return gerror.Wrapf(valueobject.ErrReservationExpired, "reservation %s has been released", reservation.Id)
+	case reservation.IsHeld() && now >= reservation.ExpiresAt:
+		if err := s.release(ctx, reservation); err != nil && !gerror.Is(err, valueobject.ErrReservationConflict) {
+			return err
+		}
+		return gerror.Wrapf(valueobject.ErrReservationExpired, "reservation %s has expired", reservation.Id)
+	}
+	if err := reservation.Commit(); err != nil {
+		return err
+	}
+	return s.reservationRepo.Commit(ctx, reservation, now)
+}
+
+// Revert 撤销订单已确认但尚未结算的预占，用于确认后订单支付失败
+// 撤销后的预占回到预占中，仍按原过期时间自动释放
+func (s *ReservationService) Revert(ctx context.Context, orderId string) error {
+	reservations, err := s.reservationRepo.FindByOrderId(ctx, orderId)
+	if err != nil {
+		return err
+	}
+	committed := make([]*entity.Reservation, 0, len(reservations))
+	for _, reservation := range reservations {
+		if reservation.State == valueobject.ReservationStateCommitted {
+			committed = append(committed, reservation)
+		}
+	}
+	return s.revert(ctx, committed)
+}
+
+// revert 撤销已确认的预占，单个预占撤销失败时继续撤销其余预占并返回首个错误
+func (s *ReservationService) revert(ctx context.Context, reservations []*entity.Reservation) error {
+	var firstErr error
+	for _, reservation := range reservations {
+		err := reservation.Revert()
+		if err == nil {
+			err = s.reservationRepo.UpdateState(ctx, reservation, valueobject.ReservationStateCommitted)
+		}
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// Settle 订单支付成功后结算其已确认的预占，在库数量随之减少
+// 须在订单支付成功后调用且只调用一次
+func (s *ReservationService) Settle(ctx context.Context, orderId string) error {
+	reservations, err := s.reservationRepo.FindByOrderId(ctx, orderId)
+	if err != nil {
+		return err
+	}
+	for _, reservation := range reservations {
+		if reservation.State != valueobject.ReservationStateCommitted {
+			continue
+		}
+		items := valueobject.StockItems{stockItemOf(reservation)}
+		if _, err = s.productService.CommitStockItems(ctx, items, valueobject.SystemSource(reservation.OrderId)); err != nil {
+			return gerror.Wrapf(err, "failed to settle reservation %s", reservation.Id)
+		}
+		if reservation.WarehouseId != "" && s.warehouseStock != nil {
+			if err = s.warehouseStock.Commit(ctx, reservation.ProductId, items[0]); err != nil {
+				return gerror.Wrapf(err, "failed to settle reservation %s in warehouse %s",
+					reservation.Id, reservation.WarehouseId,
+				)
+			}
+		}
+	}
+	return nil
+}
+
+// Release 释放订单所有预占中的库存，用于订单取消
+func (s *ReservationService) Release(ctx context.Context, orderId string) error {
+	reservations, err := s.reservationRepo.FindByOrderId(ctx, orderId)
+	if err != nil {
+		return err
+	}
+	for _, reservation := range reservations {
+		if !reservation.IsHeld() {
+			continue
+		}
+		if err = s.release(ctx, reservation); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReleaseExpired 释放所有已过期的预占，返回释放的数量
+// 已被支付或取消并发处理的预占会被跳过
+func (s *ReservationService) ReleaseExpired(ctx context.Context) (int, error) {
+	released := 0
+	for {
+		reservations, err := s.reservationRepo.FindExpired(ctx, time.Now().UnixMilli(), expiredBatchSize)
+		if err != nil {
+			return released, err
+		}
+		progressed := false
+		for _, reservation := range reservations {
+			err = s.release(ctx, reservation)
+			if gerror.Is(err, valueobject.ErrReservationConflict) {
+				continue
+			}
+			if err != nil {
+				return released, err
+			}
+			released++
+			progressed = true
+		}
+		if len(reservations) < expiredBatchSize || !progressed {
+			return released, nil
+		}
+	}
+}
+
+// ListProductReservations 获取商品的预占记录，state 为空时返回所有状态
+func (s *ReservationService) ListProductReservations(
+	ctx context.Context,
+	productId string,
+	state valueobject.ReservationState,
+) ([]*entity.Reservation, error) {
+	if state != "" && !state.IsValid() {
+		return nil, gerror.Wrapf(valueobject.ErrInvalidReservation, "invalid state: %s", state)
+	}
+	return s.reservationRepo.FindByProductId(ctx, productId, state)
+}
+
+// release 释放单个预占及其库存
+// 先以条件更新抢占状态变更，只有成功变更状态的一方才会释放库存
+func (s *ReservationService) release(ctx context.Context, reservation *entity.Reservation) error {
+	if err := reservation.Release(); err != nil {
+		return err
+	}
+	if err := s.reservationRepo.UpdateState(ctx, reservation, valueobject.ReservationStateHeld); err != nil {
+		return err
+	}
+	items := valueobject.StockItems{stockItemOf(reservation)}
+	if _, err := s.productService.ReleaseStockItems(ctx, items, valueobject.SystemSource(reservation.OrderId)); err != nil {
+		return gerror.Wrapf(err, "failed to release reservation %s", reservation.Id)
+	}
+	if reservation.WarehouseId != "" && s.warehouseStock != nil {
+		if err := s.warehouseStock.Release(ctx, reservation.ProductId, items[0]); err != nil {
+			return gerror.Wrapf(err, "failed to release reservation %s in warehouse %s",
+				reservation.Id, reservation.WarehouseId,
+			)
+		}
+	}
+	return nil
+}
+
+// stockItemOf 获取预占记录对应的库存操作项
+func stockItemOf(reservation *entity.Reservation) valueobject.StockItem {
+	return valueobject.StockItem{
+		SkuId:       reservation.SkuId,
+		WarehouseId: reservation.WarehouseId,
+		Quantity:    reservation.Quantity,
+	}
+}
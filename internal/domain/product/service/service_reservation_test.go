@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"main/internal/domain/product/entity"
+	"main/internal/domain/product/repository"
+	"main/internal/domain/product/valueobject"
+)
+
+// memoryReservationRepository 以内存保存预占记录的仓储，只实现确认与撤销所需的方法
+type memoryReservationRepository struct {
+	repository.ReservationRepository
+	reservations []*entity.Reservation
+}
+
+func (r *memoryReservationRepository) FindByOrderId(_ context.Context, orderId string) ([]*entity.Reservation, error) {
+	var found []*entity.Reservation
+	for _, reservation := range r.reservations {
+		if reservation.OrderId == orderId {
+			copied := *reservation
+			found = append(found, &copied)
+		}
+	}
+	return found, nil
+}
+
+func (r *memoryReservationRepository) UpdateState(_ context.Context, reservation *entity.Reservation, from valueobject.ReservationState) error {
+	stored := r.find(reservation.Id)
+	if stored.State != from {
+		return valueobject.ErrReservationConflict
+	}
+	stored.State = reservation.State
+	return nil
+}
+
+func (r *memoryReservationRepository) Commit(_ context.Context, reservation *entity.Reservation, now int64) error {
+	stored := r.find(reservation.Id)
+	if stored.State != valueobject.ReservationStateHeld || stored.ExpiresAt <= now {
+		return valueobject.ErrReservationConflict
+	}
+	stored.State = valueobject.ReservationStateCommitted
+	return nil
+}
+
+func (r *memoryReservationRepository) find(id string) *entity.Reservation {
+	for _, reservation := range r.reservations {
+		if reservation.Id == id {
+			return reservation
+		}
+	}
+	return nil
+}
+
+func TestReservationServiceCommit(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour).UnixMilli()
+	held, committed, released := valueobject.ReservationStateHeld, valueobject.ReservationStateCommitted, valueobject.ReservationStateReleased
+
+	tests := []struct {
+		name    string
+		states  []valueobject.ReservationState
+		want    []valueobject.ReservationState
+		wantErr error
+	}{
+		{"all held are committed", []valueobject.ReservationState{held, held}, []valueobject.ReservationState{committed, committed}, nil},
+		{"released reverts committed", []valueobject.ReservationState{held, released}, []valueobject.ReservationState{held, released}, valueobject.ErrReservationExpired},
+		{"committed concurrently reverts committed", []valueobject.ReservationState{held, committed}, []valueobject.ReservationState{held, committed}, valueobject.ErrInvalidReservation},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &memoryReservationRepository{}
+			for i, state := range tt.states {
+				reservation := entity.NewReservation(string(rune('a'+i)), "o1", "p1", "s1", "", 1, expiresAt)
+				reservation.State = state
+				repo.reservations = append(repo.reservations, reservation)
+			}
+			s := &ReservationService{reservationRepo: repo}
+			if err := s.Commit(context.Background(), "o1"); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Commit() error = %v, want %v", err, tt.wantErr)
+			}
+			for i, reservation := range repo.reservations {
+				if reservation.State != tt.want[i] {
+					t.Errorf("reservation %s state = %s, want %s", reservation.Id, reservation.State, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestReservationServiceCommitReleasedConcurrently(t *testing.T) {
+	// 读取后预占被过期释放并发处理，条件更新不能再确认该预占
+	repo := &memoryReservationRepository{reservations: []*entity.Reservation{
+		entity.NewReservation("a", "o1", "p1", "s1", "", 1, time.Now().Add(time.Hour).UnixMilli()),
+	}}
+	s := &ReservationService{reservationRepo: repo}
+	reservation := *repo.reservations[0]
+	repo.reservations[0].State = valueobject.ReservationStateReleased
+	if err := s.commit(context.Background(), &reservation, time.Now().UnixMilli()); !errors.Is(err, valueobject.ErrReservationConflict) {
+		t.Fatalf("commit() error = %v, want %v", err, valueobject.ErrReservationConflict)
+	}
+	if repo.reservations[0].State != valueobject.ReservationStateReleased {
+		t.Errorf("state = %s, want %s", repo.reservations[0].State, valueobject.ReservationStateReleased)
+	}
+}
+
+func TestReservationServiceRevert(t *testing.T) {
+	repo := &memoryReservationRepository{}
+	for _, id := range []string{"a", "b"} {
+		repo.reservations = append(repo.reservations,
+			entity.NewReservation(id, "o1", "p1", "s1", "", 1, time.Now().Add(time.Hour).UnixMilli()),
+		)
+	}
+	s := &ReservationService{reservationRepo: repo}
+	if err := s.Commit(context.Background(), "o1"); err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+	if err := s.Revert(context.Background(), "o1"); err != nil {
+		t.Fatalf("Revert() error = %v", err)
+	}
+	for _, reservation := range repo.reservations {
+		if reservation.State != valueobject.ReservationStateHeld {
+			t.Errorf("reservation %s state = %s, want %s", reservation.Id, reservation.State, valueobject.ReservationStateHeld)
+		}
+	}
+}
@@ -0,0 +1,19 @@
+package service
+
+import (
+	"context"
+
+	"main/internal/domain/product/valueobject"
+)
+
+// WarehouseStock 分仓库存端口
+// 商品聚合只记录各 SKU 的汇总库存，订单指定了发货仓库时，
+// 预占服务通过该端口同步变更对应仓库的库存，由库存上下文提供实现
+type WarehouseStock interface {
+	// Reserve 在仓库中预占 SKU 库存，item.WarehouseId 不为空
+	Reserve(ctx context.Context, productId string, item valueobject.StockItem) error
+	// Release 释放仓库中预占的 SKU 库存
+	Release(ctx context.Context, productId string, item valueobject.StockItem) error
+	// Commit 确认仓库中预占的 SKU 库存
+	Commit(ctx context.Context, productId string, item valueobject.StockItem) error
+}
@@ -0,0 +1,59 @@
+package valueobject
+
+import "github.com/gogf/gf/v2/errors/gerror"
+
+var (
+	ErrInvalidBundle    = gerror.New("invalid bundle")
+	ErrInvalidProductOp = gerror.New("operation not supported by product type")
+	ErrComponentInUse   = gerror.New("product is a component of a bundle")
+)
+
+// MaxBundleComponents 套装最多包含的组件数
+const MaxBundleComponents = 20
+
+// ProductType 商品类型
+type ProductType string
+
+const (
+	ProductTypeStandard ProductType = "standard" // 普通商品，由 SKU 持有库存
+	ProductTypeBundle   ProductType = "bundle"   // 套装，由组件商品组成，不持有库存
+)
+
+// IsValid 检查商品类型是否有效
+func (t ProductType) IsValid() bool {
+	return t == ProductTypeStandard || t == ProductTypeBundle
+}
+
+// BundleComponent 套装组件，即每套包含的普通商品 SKU 及数量
+type BundleComponent struct {
+	ProductId string `json:"productId"`
+	SkuId     string `json:"skuId"`
+	Quantity  int    `json:"quantity"` // 每套包含的数量
+}
+
+// BundleComponents 套装组件列表
+type BundleComponents []BundleComponent
+
+// Validate 验证组件列表，至少包含一个组件且 SKU 不重复
+func (c BundleComponents) Validate() error {
+	if len(c) == 0 {
+		return gerror.Wrap(ErrInvalidBundle, "bundle must have at least one component")
+	}
+	if len(c) > MaxBundleComponents {
+		return gerror.Wrapf(ErrInvalidBundle, "bundle cannot have more than %d components", MaxBundleComponents)
+	}
+	seen := make(map[string]bool, len(c))
+	for _, component := range c {
+		if component.ProductId == "" || component.SkuId == "" {
+			return gerror.Wrap(ErrInvalidBundle, "component product id and sku id are required")
+		}
+		if component.Quantity <= 0 {
+			return gerror.Wrapf(ErrInvalidBundle, "quantity of component %s must be positive", component.SkuId)
+		}
+		if seen[component.SkuId] {
+			return gerror.Wrapf(ErrInvalidBundle, "duplicate component %s", component.SkuId)
+		}
+		seen[component.SkuId] = true
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package valueobject
+
+import "github.com/gogf/gf/v2/errors/gerror"
+
+var (
+	ErrInvalidImage      = gerror.New("invalid product image")
+	ErrImageNotFound     = gerror.New("product image not found")
+	ErrTooManyImages     = gerror.New("too many product images")
+	ErrInvalidImageOrder = gerror.New("invalid product image order")
+)
+
+const (
+	MaxProductImages = 10       // 每个商品最多的图片数量
+	MaxImageBytes    = 10 << 20 // 单张图片的最大字节数
+	// MaxImagePixels 单张图片的最大像素数（宽 × 高）
+	// 压缩率很高的图片字节数很小，但解码后占用的内存与像素数成正比，须在解码前拒绝
+	MaxImagePixels = 40_000_000
+)
+
+// supportedImageTypes 支持上传的图片格式及其文件扩展名
+var supportedImageTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+}
+
+// IsSupportedImageType 判断是否支持该图片格式
+func IsSupportedImageType(contentType string) bool {
+	_, ok := supportedImageTypes[contentType]
+	return ok
+}
+
+// ImageExtension 获取图片格式对应的文件扩展名
+func ImageExtension(contentType string) string {
+	return supportedImageTypes[contentType]
+}
+
+// ImageSize 缩略图尺寸
+// 缩略图按比例缩放到不超过最大宽高，原图小于该尺寸时不放大
+type ImageSize struct {
+	Name      string
+	MaxWidth  int
+	MaxHeight int
+}
+
+// ThumbnailSizes 为每张商品图片生成的缩略图尺寸
+var ThumbnailSizes = []ImageSize{
+	{Name: "small", MaxWidth: 150, MaxHeight: 150},
+	{Name: "medium", MaxWidth: 400, MaxHeight: 400},
+	{Name: "large", MaxWidth: 800, MaxHeight: 800},
+}
+
+// ImageFile 存储在对象存储中的图片文件
+type ImageFile struct {
+	Key         string `json:"key"` // 对象存储中的键
+	URL         string `json:"url"` // 访问地址
+	ContentType string `json:"contentType"`
+	Width       int    `json:"width"`
+	Height      int    `json:"height"`
+}
@@ -0,0 +1,72 @@
+package valueobject
+
+import "github.com/gogf/gf/v2/errors/gerror"
+
+var (
+	ErrInvalidMovement = gerror.New("invalid stock movement")
+)
+
+// SystemActor 系统自动处理的库存变更的操作人，如下单预占和超时释放
+const SystemActor = "system"
+
+// MovementReason 库存变动原因
+type MovementReason string
+
+const (
+	MovementInbound     MovementReason = "inbound"     // 入库，包括新建 SKU 时的初始库存
+	MovementReservation MovementReason = "reservation" // 下单预占
+	MovementRelease     MovementReason = "release"     // 订单取消或预占过期释放
+	MovementSale        MovementReason = "sale"        // 订单支付后确认出库
+	MovementAdjustment  MovementReason = "adjustment"  // 人工调整，如盘点差异、损耗、移除 SKU
+	MovementReturn      MovementReason = "return"      // 退货入库
+	MovementTransfer    MovementReason = "transfer"    // 仓库间调拨，不改变商品的汇总库存
+	MovementOpening     MovementReason = "opening"     // 期初，为启用流水前已有库存的 SKU 补记
+)
+
+// IsValid 检查变动原因是否有效
+func (r MovementReason) IsValid() bool {
+	switch r {
+	case MovementInbound, MovementReservation, MovementRelease, MovementSale,
+		MovementAdjustment, MovementReturn, MovementTransfer, MovementOpening:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsManual 是否为可以人工登记的变动原因
+func (r MovementReason) IsManual() bool {
+	switch r {
+	case MovementInbound, MovementAdjustment, MovementReturn:
+		return true
+	default:
+		return false
+	}
+}
+
+// MovementSource 库存变动的来源
+type MovementSource struct {
+	Actor     string // 操作人，系统自动处理时为 SystemActor
+	Reference string // 关联单据，如订单号、入库单号
+	Note      string // 备注
+}
+
+// SystemSource 系统为关联单据自动处理的库存变动来源
+func SystemSource(reference string) MovementSource {
+	return MovementSource{
+		Actor:     SystemActor,
+		Reference: reference,
+	}
+}
+
+// StockDiscrepancy 库存与流水不一致的 SKU
+type StockDiscrepancy struct {
+	SkuId  string     `json:"skuId"`
+	Ledger StockLevel `json:"ledger"` // 由流水汇总得到的库存
+	Actual StockLevel `json:"actual"` // 商品当前记录的库存
+}
+
+const (
+	DefaultMovementLimit = 50  // 查询库存流水的默认条数
+	MaxMovementLimit     = 500 // 查询库存流水的最大条数
+)
@@ -0,0 +1,41 @@
+package valueobject
+
+import "github.com/gogf/gf/v2/errors/gerror"
+
+var (
+	ErrInvalidPriceSchedule  = gerror.New("invalid price schedule")
+	ErrPriceScheduleNotFound = gerror.New("price schedule not found")
+	ErrPriceScheduleOverlap  = gerror.New("price schedule overlaps another schedule")
+	ErrPriceScheduleConflict = gerror.New("price schedule has been changed concurrently")
+)
+
+// 价格历史的查询条数
+const (
+	DefaultPriceHistoryLimit = 50
+	MaxPriceHistoryLimit     = 500
+)
+
+// PriceScheduleStatus 定时调价状态
+type PriceScheduleStatus string
+
+const (
+	PriceSchedulePending   PriceScheduleStatus = "pending"   // 等待开始
+	PriceScheduleActive    PriceScheduleStatus = "active"    // 已应用调价，等待结束时恢复原价
+	PriceScheduleCompleted PriceScheduleStatus = "completed" // 已结束，永久调价应用后即结束
+	PriceScheduleCancelled PriceScheduleStatus = "cancelled" // 已取消
+)
+
+// IsValid 检查定时调价状态是否有效
+func (s PriceScheduleStatus) IsValid() bool {
+	switch s {
+	case PriceSchedulePending, PriceScheduleActive, PriceScheduleCompleted, PriceScheduleCancelled:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsOpen 是否尚未结束，未结束的定时调价之间时间段不能重叠
+func (s PriceScheduleStatus) IsOpen() bool {
+	return s == PriceSchedulePending || s == PriceScheduleActive
+}
@@ -0,0 +1,108 @@
+package valueobject
+
+import (
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	sharedvo "main/internal/domain/shared/valueobject"
+)
+
+// 商品搜索分页参数
+const (
+	DefaultSearchLimit = 20  // 默认每页数量
+	MaxSearchLimit     = 100 // 每页数量上限
+)
+
+var ErrInvalidSearchCriteria = gerror.New("invalid product search criteria")
+
+// ProductSortField 商品搜索排序字段
+type ProductSortField string
+
+const (
+	ProductSortRelevance ProductSortField = "relevance"  // 关键词相关度，仅在指定关键词时可用
+	ProductSortCreatedAt ProductSortField = "created_at" // 创建时间
+	ProductSortPrice     ProductSortField = "price"      // 最低 SKU 价格
+	ProductSortName      ProductSortField = "name"       // 商品名称
+)
+
+// IsValid 检查排序字段是否有效
+func (f ProductSortField) IsValid() bool {
+	switch f {
+	case ProductSortRelevance, ProductSortCreatedAt, ProductSortPrice, ProductSortName:
+		return true
+	default:
+		return false
+	}
+}
+
+// SortDirection 排序方向
+type SortDirection string
+
+const (
+	SortAsc  SortDirection = "asc"  // 升序
+	SortDesc SortDirection = "desc" // 降序
+)
+
+// ProductSearchCriteria 商品搜索条件
+type ProductSearchCriteria struct {
+	Keyword       string           // 关键词，匹配商品名称和描述
+	Status        ProductStatus    // 商品状态，为空时返回除已删除外的全部商品
+	CategoryIds   []string         // 所属类目，为空时不过滤
+	MinPrice      *sharedvo.Money  // 最低价格，任一 SKU 价格在区间内即匹配
+	MaxPrice      *sharedvo.Money  // 最高价格
+	InStock       bool             // 只返回有库存的商品
+	SortBy        ProductSortField // 排序字段，为空时有关键词按相关度排序，否则按创建时间排序
+	SortDirection SortDirection    // 排序方向，为空时相关度和创建时间降序，其余升序
+	Cursor        string           // 分页游标，取自上一页结果，为空时从第一页开始
+	Limit         int              // 每页数量，为 0 时使用默认值
+}
+
+// Normalize 填充默认值并验证搜索条件
+func (c *ProductSearchCriteria) Normalize() error {
+	if c.Status != "" && !c.Status.IsValid() {
+		return gerror.Wrapf(ErrInvalidSearchCriteria, "invalid status: %s", c.Status)
+	}
+
+	// 价格区间
+	if c.MinPrice != nil && c.MaxPrice != nil {
+		cmp, err := c.MinPrice.Compare(c.MaxPrice)
+		if err != nil {
+			return gerror.Wrap(ErrInvalidSearchCriteria, err.Error())
+		}
+		if cmp > 0 {
+			return gerror.Wrap(ErrInvalidSearchCriteria, "min price cannot be greater than max price")
+		}
+	}
+
+	// 排序
+	if c.SortBy == "" {
+		c.SortBy = ProductSortCreatedAt
+		if c.Keyword != "" {
+			c.SortBy = ProductSortRelevance
+		}
+	}
+	if !c.SortBy.IsValid() {
+		return gerror.Wrapf(ErrInvalidSearchCriteria, "invalid sort field: %s", c.SortBy)
+	}
+	if c.SortBy == ProductSortRelevance && c.Keyword == "" {
+		return gerror.Wrap(ErrInvalidSearchCriteria, "sorting by relevance requires a keyword")
+	}
+	switch c.SortDirection {
+	case "":
+		c.SortDirection = SortAsc
+		if c.SortBy == ProductSortRelevance || c.SortBy == ProductSortCreatedAt {
+			c.SortDirection = SortDesc
+		}
+	case SortAsc, SortDesc:
+	default:
+		return gerror.Wrapf(ErrInvalidSearchCriteria, "invalid sort direction: %s", c.SortDirection)
+	}
+
+	// 分页
+	switch {
+	case c.Limit == 0:
+		c.Limit = DefaultSearchLimit
+	case c.Limit < 0 || c.Limit > MaxSearchLimit:
+		return gerror.Wrapf(ErrInvalidSearchCriteria, "limit must be between 1 and %d", MaxSearchLimit)
+	}
+	return nil
+}
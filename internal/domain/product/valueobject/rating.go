@@ -0,0 +1,21 @@
+package valueobject
+
+import "math"
+
+// ProductRating 商品评分汇总
+// 由评价子系统根据审核通过的评价维护，是商品读模型上的冗余数据
+type ProductRating struct {
+	Count   int     `json:"count"`   // 审核通过的评价数
+	Average float64 `json:"average"` // 平均评分，保留两位小数，没有评价时为 0
+}
+
+// NewProductRating 根据评价数和评分总和创建评分汇总
+func NewProductRating(count int, total int) ProductRating {
+	if count <= 0 {
+		return ProductRating{}
+	}
+	return ProductRating{
+		Count:   count,
+		Average: math.Round(float64(total)/float64(count)*100) / 100,
+	}
+}
@@ -0,0 +1,36 @@
+package valueobject
+
+import (
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+var (
+	ErrInvalidReservation  = gerror.New("invalid stock reservation")
+	ErrReservationNotFound = gerror.New("stock reservation not found")
+	ErrReservationExpired  = gerror.New("stock reservation expired")
+	ErrReservationConflict = gerror.New("stock reservation has been changed concurrently")
+)
+
+// DefaultReservationHold 未配置时库存预占的默认保留时长
+const DefaultReservationHold = 30 * time.Minute
+
+// ReservationState 库存预占状态
+type ReservationState string
+
+const (
+	ReservationStateHeld      ReservationState = "held"      // 预占中，等待订单支付
+	ReservationStateCommitted ReservationState = "committed" // 已确认，订单已支付
+	ReservationStateReleased  ReservationState = "released"  // 已释放，订单取消或预占过期
+)
+
+// IsValid 检查预占状态是否有效
+func (s ReservationState) IsValid() bool {
+	switch s {
+	case ReservationStateHeld, ReservationStateCommitted, ReservationStateReleased:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,73 @@
+package valueobject
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// SKUOption SKU 规格选项，如 颜色=红色、尺码=M
+type SKUOption struct {
+	Name  string // 规格名称
+	Value string // 规格值
+}
+
+// SKUOptions SKU 的规格选项组合
+// 同一商品下各 SKU 的规格组合互不相同
+type SKUOptions []SKUOption
+
+// Validate 验证规格选项组合
+func (o SKUOptions) Validate() error {
+	names := make(map[string]struct{}, len(o))
+	for _, option := range o {
+		if option.Name == "" || option.Value == "" {
+			return gerror.Wrap(ErrInvalidSKU, "sku option name and value are required")
+		}
+		if _, ok := names[option.Name]; ok {
+			return gerror.Wrapf(ErrInvalidSKU, "duplicate sku option: %s", option.Name)
+		}
+		names[option.Name] = struct{}{}
+	}
+	return nil
+}
+
+// Key 获取规格组合的唯一标识，与选项的先后顺序无关
+func (o SKUOptions) Key() string {
+	pairs := make([]string, len(o))
+	for i, option := range o {
+		pairs[i] = option.Name + "=" + option.Value
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ";")
+}
+
+// ParseSKUOptions 解析 Key 格式的规格组合，如 "尺码=M;颜色=红色"，空字符串表示单规格
+func ParseSKUOptions(s string) (SKUOptions, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return SKUOptions{}, nil
+	}
+	pairs := strings.Split(s, ";")
+	options := make(SKUOptions, 0, len(pairs))
+	for _, pair := range pairs {
+		name, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, gerror.Wrapf(ErrInvalidSKU, "invalid sku option %q, expected name=value", pair)
+		}
+		options = append(options, SKUOption{Name: strings.TrimSpace(name), Value: strings.TrimSpace(value)})
+	}
+	if err := options.Validate(); err != nil {
+		return nil, err
+	}
+	return options, nil
+}
+
+// String 获取规格组合的展示名称，如 "红色 / M"
+func (o SKUOptions) String() string {
+	values := make([]string, len(o))
+	for i, option := range o {
+		values[i] = option.Value
+	}
+	return strings.Join(values, " / ")
+}
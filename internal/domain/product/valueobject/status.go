@@ -9,21 +9,28 @@ var (
 	ErrInvalidPrice       = errors.New("invalid product price")
 	ErrInvalidStock       = errors.New("invalid product stock")
 	ErrInvalidStatus      = errors.New("invalid product status")
+	ErrInvalidTransition  = errors.New("invalid product status transition")
 	ErrProductNotFound    = errors.New("product not found")
 	ErrProductExists      = errors.New("product already exists")
 	ErrInsufficientStock  = errors.New("insufficient stock")
 	ErrProductUnavailable = errors.New("product is unavailable")
+	ErrInvalidSKU         = errors.New("invalid sku")
+	ErrSKUNotFound        = errors.New("sku not found")
+	ErrDuplicateSKU       = errors.New("duplicate sku")
+	ErrInvalidThreshold   = errors.New("invalid low stock threshold")
+	// ErrConcurrentModification 商品在读取后被并发修改，保存时版本号不一致
+	ErrConcurrentModification = errors.New("product modified concurrently")
 )
 
 // ProductStatus 商品状态
 type ProductStatus string
 
 const (
-	ProductStatusDraft     ProductStatus = "draft"     // 草稿
-	ProductStatusOnSale    ProductStatus = "on_sale"   // 在售
-	ProductStatusOffSale   ProductStatus = "off_sale"  // 下架
-	ProductStatusSoldOut   ProductStatus = "sold_out"  // 售罄
-	ProductStatusDeleted   ProductStatus = "deleted"   // 删除
+	ProductStatusDraft   ProductStatus = "draft"    // 草稿
+	ProductStatusOnSale  ProductStatus = "on_sale"  // 在售
+	ProductStatusOffSale ProductStatus = "off_sale" // 下架
+	ProductStatusSoldOut ProductStatus = "sold_out" // 售罄
+	ProductStatusDeleted ProductStatus = "deleted"  // 删除
 )
 
 // IsValid 检查状态是否有效
@@ -38,6 +45,8 @@ func (s ProductStatus) IsValid() bool {
 }
 
 // CanTransitionTo 检查是否可以转换到目标状态
+// 商品生命周期：草稿 -> 在售 <-> 下架，在售 -> 售罄 -> 在售/下架；
+// 草稿、下架和售罄的商品可以删除，删除的商品恢复后处于下架状态，需重新发布才能销售
 func (s ProductStatus) CanTransitionTo(target ProductStatus) bool {
 	switch s {
 	case ProductStatusDraft:
@@ -47,9 +56,9 @@ func (s ProductStatus) CanTransitionTo(target ProductStatus) bool {
 	case ProductStatusOffSale:
 		return target == ProductStatusOnSale || target == ProductStatusDeleted
 	case ProductStatusSoldOut:
-		return target == ProductStatusOnSale || target == ProductStatusDeleted
+		return target == ProductStatusOnSale || target == ProductStatusOffSale || target == ProductStatusDeleted
 	case ProductStatusDeleted:
-		return false
+		return target == ProductStatusOffSale
 	default:
 		return false
 	}
@@ -0,0 +1,70 @@
+package valueobject
+
+import "github.com/gogf/gf/v2/errors/gerror"
+
+// StockLevel SKU 的库存水平
+type StockLevel struct {
+	OnHand   int `json:"onHand"`   // 在库数量
+	Reserved int `json:"reserved"` // 被未支付订单预占的数量
+}
+
+// Available 获取可售数量
+func (l StockLevel) Available() int {
+	return l.OnHand - l.Reserved
+}
+
+// StockItem 库存操作项
+type StockItem struct {
+	SkuId       string
+	WarehouseId string // 发货仓库，为空表示不区分仓库
+	Quantity    int
+}
+
+// StockItems 库存操作项列表
+type StockItems []StockItem
+
+// Merge 合并相同 SKU 和仓库的数量，保持首次出现的顺序
+func (items StockItems) Merge() StockItems {
+	return items.merge(func(item StockItem) StockItem { return item })
+}
+
+// BySKU 合并相同 SKU 的数量而不区分仓库，用于商品聚合上的库存操作
+func (items StockItems) BySKU() StockItems {
+	return items.merge(func(item StockItem) StockItem {
+		item.WarehouseId = ""
+		return item
+	})
+}
+
+// merge 按 key 转换后的 SKU 和仓库合并数量
+func (items StockItems) merge(key func(item StockItem) StockItem) StockItems {
+	merged := make(StockItems, 0, len(items))
+	index := make(map[StockItem]int, len(items))
+	for _, item := range items {
+		item = key(item)
+		k := StockItem{SkuId: item.SkuId, WarehouseId: item.WarehouseId}
+		if i, ok := index[k]; ok {
+			merged[i].Quantity += item.Quantity
+			continue
+		}
+		index[k] = len(merged)
+		merged = append(merged, item)
+	}
+	return merged
+}
+
+// Validate 验证库存操作项
+func (items StockItems) Validate() error {
+	if len(items) == 0 {
+		return gerror.Wrap(ErrInvalidStock, "at least one stock item is required")
+	}
+	for _, item := range items {
+		if item.SkuId == "" {
+			return gerror.Wrap(ErrInvalidSKU, "sku id is required")
+		}
+		if item.Quantity <= 0 {
+			return gerror.Wrapf(ErrInvalidStock, "quantity of sku %s must be positive: %d", item.SkuId, item.Quantity)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"main/internal/domain/review/valueobject"
+)
+
+// Review 商品评价聚合根
+// 客户只能评价自己已送达订单中的订单项，每个订单项只能评价一次，审核通过后才对外展示并计入商品评分
+type Review struct {
+	Id          string
+	ProductId   string
+	SkuId       string
+	OrderId     string // 评价的订单
+	OrderItemId string // 被评价的订单项，同一订单中同一 SKU 的多个订单项分别评价
+	UserId      string
+	Rating      int // 评分，1 到 5
+	Content     string
+	Status      valueobject.ReviewStatus
+	Moderator   string // 最近一次审核的审核人
+	// ModerationNote 审核备注，如拒绝原因
+	ModerationNote string
+	ModeratedAt    int64
+	CreatedAt      int64
+	UpdatedAt      int64
+}
+
+// NewReview 创建待审核的评价
+func NewReview(id, productId, skuId, orderId, orderItemId, userId string, rating int, content string) *Review {
+	now := time.Now().UnixMilli()
+	return &Review{
+		Id:          id,
+		ProductId:   productId,
+		SkuId:       skuId,
+		OrderId:     orderId,
+		OrderItemId: orderItemId,
+		UserId:      userId,
+		Rating:      rating,
+		Content:     content,
+		Status:      valueobject.ReviewStatusPending,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+// IsApproved 是否审核通过
+func (r *Review) IsApproved() bool {
+	return r.Status == valueobject.ReviewStatusApproved
+}
+
+// Moderate 审核评价
+// 评价可以审核通过或拒绝，已审核的评价可以改判，但不能回到待审核状态
+func (r *Review) Moderate(status valueobject.ReviewStatus, moderator string, note string) error {
+	if status != valueobject.ReviewStatusApproved && status != valueobject.ReviewStatusRejected {
+		return gerror.Wrapf(valueobject.ErrInvalidModeration, "cannot moderate review to status %s", status)
+	}
+	if moderator == "" {
+		return gerror.Wrap(valueobject.ErrInvalidModeration, "moderator is required")
+	}
+	if r.Status == status {
+		return gerror.Wrapf(valueobject.ErrInvalidModeration, "review %s is already %s", r.Id, status)
+	}
+	now := time.Now().UnixMilli()
+	r.Status = status
+	r.Moderator = moderator
+	r.ModerationNote = note
+	r.ModeratedAt = now
+	r.UpdatedAt = now
+	return nil
+}
+
+// Validate 验证评价
+func (r *Review) Validate() error {
+	if r.Id == "" || r.ProductId == "" || r.SkuId == "" || r.OrderId == "" || r.OrderItemId == "" || r.UserId == "" {
+		return gerror.Wrap(valueobject.ErrInvalidReview, "id, product, sku, order, order item and user are required")
+	}
+	if r.Rating < valueobject.MinRating || r.Rating > valueobject.MaxRating {
+		return gerror.Wrapf(valueobject.ErrInvalidRating,
+			"rating must be between %d and %d: %d", valueobject.MinRating, valueobject.MaxRating, r.Rating,
+		)
+	}
+	if !r.Status.IsValid() {
+		return gerror.Wrapf(valueobject.ErrInvalidReview, "invalid status: %s", r.Status)
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+
+	"main/internal/domain/review/entity"
+	"main/internal/domain/review/valueobject"
+)
+
+// ReviewRepository 评价仓储接口
+type ReviewRepository interface {
+	// Save 保存新的评价，同一订单项已有评价时返回 valueobject.ErrDuplicateReview
+	Save(ctx context.Context, review *entity.Review) error
+	// FindById 根据Id查找评价
+	FindById(ctx context.Context, id string) (*entity.Review, error)
+	// Find 按条件分页查找评价，条件已经过 Normalize 处理，返回当前页的评价和符合条件的总数
+	Find(ctx context.Context, criteria valueobject.ReviewCriteria) ([]*entity.Review, int, error)
+	// UpdateModeration 仅当评价当前处于 from 状态时保存其审核结果，
+	// 否则返回 valueobject.ErrReviewConflict，用于避免并发审核同一评价
+	UpdateModeration(ctx context.Context, review *entity.Review, from valueobject.ReviewStatus) error
+	// SummarizeApproved 汇总商品审核通过的评价，返回评价数和评分总和
+	SummarizeApproved(ctx context.Context, productId string) (count int, total int, err error)
+}
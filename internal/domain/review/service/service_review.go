@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	orderentity "main/internal/domain/order/entity"
+	orderrepository "main/internal/domain/order/repository"
+	ordervo "main/internal/domain/order/valueobject"
+	"main/internal/domain/review/entity"
+	"main/internal/domain/review/repository"
+	"main/internal/domain/review/valueobject"
+	sharedservice "main/internal/domain/shared/service"
+)
+
+// ReviewService 评价领域服务
+// 负责校验评价资格、评价审核以及商品评分的汇总
+type ReviewService struct {
+	reviewRepo  repository.ReviewRepository
+	orderRepo   orderrepository.OrderRepository // 订单仓储，用于校验评价的订单项
+	idGenerator sharedservice.IdGenerator       // 评价 Id 生成器
+}
+
+// NewReviewService 创建评价领域服务实例
+func NewReviewService(
+	reviewRepo repository.ReviewRepository,
+	orderRepo orderrepository.OrderRepository,
+	idGenerator sharedservice.IdGenerator,
+) *ReviewService {
+	return &ReviewService{
+		reviewRepo:  reviewRepo,
+		orderRepo:   orderRepo,
+		idGenerator: idGenerator,
+	}
+}
+
+// CreateReview 评价订单项
+// 订单必须属于评价人且已送达，订单中包含该订单项，且该订单项尚未评价
+func (s *ReviewService) CreateReview(
+	ctx context.Context,
+	userId string,
+	orderId string,
+	orderItemId string,
+	rating int,
+	content string,
+) (*entity.Review, error) {
+	item, err := s.reviewableItem(ctx, userId, orderId, orderItemId)
+	if err != nil {
+		return nil, err
+	}
+
+	review := entity.NewReview(s.idGenerator.NewId(), item.ProductId, item.SkuId, orderId, item.Id, userId, rating, content)
+	if err = review.Validate(); err != nil {
+		return nil, err
+	}
+	if err = s.reviewRepo.Save(ctx, review); err != nil {
+		if gerror.Is(err, valueobject.ErrDuplicateReview) {
+			return nil, err
+		}
+		return nil, gerror.Wrap(err, "failed to save review")
+	}
+	return review, nil
+}
+
+// reviewableItem 查找可以评价的订单项
+func (s *ReviewService) reviewableItem(
+	ctx context.Context,
+	userId string,
+	orderId string,
+	orderItemId string,
+) (*orderentity.OrderItem, error) {
+	order, err := s.orderRepo.FindById(ctx, orderId)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to get order")
+	}
+	if order == nil || order.UserId != userId {
+		return nil, gerror.Wrapf(valueobject.ErrNotReviewable, "order %s not found for user %s", orderId, userId)
+	}
+	if order.Status != ordervo.OrderStatusDelivered {
+		return nil, gerror.Wrapf(valueobject.ErrNotReviewable, "order %s is %s, not delivered", orderId, order.Status)
+	}
+	for _, item := range order.Items {
+		if item.Id == orderItemId {
+			return item, nil
+		}
+	}
+	return nil, gerror.Wrapf(valueobject.ErrNotReviewable, "order %s has no item %s", orderId, orderItemId)
+}
+
+// Moderate 审核评价，返回审核后的评价及其审核前的状态
+// 以条件更新保存审核结果，并发审核同一评价时只有一方成功
+func (s *ReviewService) Moderate(
+	ctx context.Context,
+	id string,
+	status valueobject.ReviewStatus,
+	moderator string,
+	note string,
+) (*entity.Review, valueobject.ReviewStatus, error) {
+	review, err := s.reviewRepo.FindById(ctx, id)
+	if err != nil {
+		return nil, "", err
+	}
+	from := review.Status
+	if err = review.Moderate(status, moderator, note); err != nil {
+		return nil, "", err
+	}
+	if err = s.reviewRepo.UpdateModeration(ctx, review, from); err != nil {
+		return nil, "", err
+	}
+	return review, from, nil
+}
+
+// GetReview 获取评价
+func (s *ReviewService) GetReview(ctx context.Context, id string) (*entity.Review, error) {
+	return s.reviewRepo.FindById(ctx, id)
+}
+
+// ListReviews 按条件分页查找评价，返回当前页的评价和符合条件的总数
+func (s *ReviewService) ListReviews(ctx context.Context, criteria valueobject.ReviewCriteria) ([]*entity.Review, int, error) {
+	if err := criteria.Normalize(); err != nil {
+		return nil, 0, err
+	}
+	return s.reviewRepo.Find(ctx, criteria)
+}
+
+// SummarizeRating 汇总商品审核通过的评价，返回评价数和评分总和
+func (s *ReviewService) SummarizeRating(ctx context.Context, productId string) (int, int, error) {
+	return s.reviewRepo.SummarizeApproved(ctx, productId)
+}
@@ -0,0 +1,15 @@
+package valueobject
+
+import "github.com/gogf/gf/v2/errors/gerror"
+
+// 评价领域错误定义
+var (
+	ErrReviewNotFound     = gerror.New("review not found")
+	ErrInvalidReview      = gerror.New("invalid review")
+	ErrInvalidRating      = gerror.New("invalid rating")
+	ErrDuplicateReview    = gerror.New("order item has already been reviewed")
+	ErrNotReviewable      = gerror.New("order item is not reviewable")
+	ErrInvalidModeration  = gerror.New("invalid review moderation")
+	ErrReviewConflict     = gerror.New("review has been moderated concurrently")
+	ErrInvalidReviewQuery = gerror.New("invalid review query")
+)
@@ -0,0 +1,47 @@
+package valueobject
+
+import "github.com/gogf/gf/v2/errors/gerror"
+
+// 评价列表分页参数
+const (
+	DefaultReviewPerPage = 20  // 默认每页数量
+	MaxReviewPerPage     = 100 // 每页数量上限
+)
+
+// ReviewCriteria 评价查询条件，按创建时间倒序分页
+type ReviewCriteria struct {
+	ProductId string       // 商品，为空时不过滤
+	UserId    string       // 评价人，为空时不过滤
+	Status    ReviewStatus // 审核状态，为空时不过滤
+	Rating    int          // 评分，为 0 时不过滤
+	Page      int          // 页码，从 1 开始，为 0 时取第一页
+	PerPage   int          // 每页数量，为 0 时使用默认值
+}
+
+// Normalize 填充默认值并验证查询条件
+func (c *ReviewCriteria) Normalize() error {
+	if c.Status != "" && !c.Status.IsValid() {
+		return gerror.Wrapf(ErrInvalidReviewQuery, "invalid status: %s", c.Status)
+	}
+	if c.Rating != 0 && (c.Rating < MinRating || c.Rating > MaxRating) {
+		return gerror.Wrapf(ErrInvalidReviewQuery, "rating must be between %d and %d", MinRating, MaxRating)
+	}
+	switch {
+	case c.Page == 0:
+		c.Page = 1
+	case c.Page < 0:
+		return gerror.Wrapf(ErrInvalidReviewQuery, "invalid page: %d", c.Page)
+	}
+	switch {
+	case c.PerPage == 0:
+		c.PerPage = DefaultReviewPerPage
+	case c.PerPage < 0 || c.PerPage > MaxReviewPerPage:
+		return gerror.Wrapf(ErrInvalidReviewQuery, "per page must be between 1 and %d", MaxReviewPerPage)
+	}
+	return nil
+}
+
+// Offset 当前页之前的记录数
+func (c *ReviewCriteria) Offset() int {
+	return (c.Page - 1) * c.PerPage
+}
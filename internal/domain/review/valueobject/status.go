@@ -0,0 +1,31 @@
+package valueobject
+
+// 评分范围
+const (
+	MinRating = 1
+	MaxRating = 5
+)
+
+// ReviewStatus 评价审核状态
+type ReviewStatus string
+
+const (
+	ReviewStatusPending  ReviewStatus = "pending"  // 待审核，不对外展示
+	ReviewStatusApproved ReviewStatus = "approved" // 审核通过，对外展示并计入商品评分
+	ReviewStatusRejected ReviewStatus = "rejected" // 审核拒绝，不对外展示
+)
+
+// IsValid 检查状态是否有效
+func (s ReviewStatus) IsValid() bool {
+	switch s {
+	case ReviewStatusPending, ReviewStatusApproved, ReviewStatusRejected:
+		return true
+	default:
+		return false
+	}
+}
+
+// String 返回状态的字符串表示
+func (s ReviewStatus) String() string {
+	return string(s)
+}
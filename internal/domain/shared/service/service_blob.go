@@ -0,0 +1,20 @@
+package service
+
+import (
+	"context"
+	"io"
+)
+
+// BlobStore 二进制对象存储接口
+// 由基础设施层实现，如本地文件系统、对象存储服务等。
+// 键为以 / 分隔的相对路径，如 products/123/456/original.jpg
+type BlobStore interface {
+	// Put 写入对象，键已存在时覆盖
+	Put(ctx context.Context, key string, data io.Reader, contentType string) error
+	// Get 读取对象，找不到时返回 valueobject.ErrBlobNotFound
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete 删除对象，对象不存在时不返回错误
+	Delete(ctx context.Context, key string) error
+	// URL 获取对象的访问地址
+	URL(key string) string
+}
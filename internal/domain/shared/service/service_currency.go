@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	sharedvo "main/internal/domain/shared/valueobject"
+)
+
+// ExchangeRateProvider 汇率提供者接口
+// 由基础设施层实现，如配置文件中的静态汇率表、按日更新的汇率文件等
+type ExchangeRateProvider interface {
+	// Rate 获取在指定时间生效的汇率，找不到时返回 valueobject.ErrExchangeRateNotFound
+	Rate(ctx context.Context, from, to string, at time.Time) (*sharedvo.ExchangeRate, error)
+}
+
+// Conversion 金额换算记录
+// 保留原始金额、换算结果以及所使用的汇率（含生效时间），便于报表追溯
+type Conversion struct {
+	Source *sharedvo.Money        // 原始金额
+	Result *sharedvo.Money        // 换算后的金额
+	Rate   *sharedvo.ExchangeRate // 使用的汇率
+}
+
+// CurrencyConverter 货币换算领域服务
+type CurrencyConverter struct {
+	provider ExchangeRateProvider
+}
+
+// NewCurrencyConverter 创建货币换算领域服务实例
+func NewCurrencyConverter(provider ExchangeRateProvider) *CurrencyConverter {
+	return &CurrencyConverter{
+		provider: provider,
+	}
+}
+
+// Convert 按当前汇率将金额换算为目标货币
+func (s *CurrencyConverter) Convert(ctx context.Context, money *sharedvo.Money, target string) (*Conversion, error) {
+	return s.ConvertAt(ctx, money, target, time.Now())
+}
+
+// ConvertAt 按指定时间生效的汇率将金额换算为目标货币
+func (s *CurrencyConverter) ConvertAt(
+	ctx context.Context,
+	money *sharedvo.Money,
+	target string,
+	at time.Time,
+) (*Conversion, error) {
+	// 1. 获取汇率，同一货币无需查询
+	var (
+		rate *sharedvo.ExchangeRate
+		err  error
+	)
+	if money.Currency() == target {
+		rate, err = sharedvo.IdentityExchangeRate(target, at)
+	} else {
+		rate, err = s.provider.Rate(ctx, money.Currency(), target, at)
+	}
+	if err != nil {
+		return nil, gerror.Wrapf(err, "failed to get exchange rate from %s to %s", money.Currency(), target)
+	}
+
+	// 2. 换算金额
+	result, err := money.ConvertTo(rate)
+	if err != nil {
+		return nil, gerror.Wrap(err, "failed to convert money")
+	}
+
+	return &Conversion{
+		Source: money,
+		Result: result,
+		Rate:   rate,
+	}, nil
+}
+
+// Sum 将多种货币的金额按当前汇率换算后汇总为目标货币
+// 返回汇总金额以及每一笔金额的换算记录
+func (s *CurrencyConverter) Sum(
+	ctx context.Context,
+	target string,
+	amounts []*sharedvo.Money,
+) (*sharedvo.Money, []*Conversion, error) {
+	total, err := sharedvo.NewMoneyFromMinor(0, target)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	conversions := make([]*Conversion, 0, len(amounts))
+	for _, amount := range amounts {
+		conversion, err := s.ConvertAt(ctx, amount, target, now)
+		if err != nil {
+			return nil, nil, err
+		}
+		if total, err = total.Add(conversion.Result); err != nil {
+			return nil, nil, err
+		}
+		conversions = append(conversions, conversion)
+	}
+
+	return total, conversions, nil
+}
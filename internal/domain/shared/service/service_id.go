@@ -0,0 +1,9 @@
+package service
+
+// IdGenerator 聚合标识生成器
+// 聚合在创建时即由领域层分配 Id，不依赖存储后端生成主键。
+// 由基础设施层实现，如 ULID、Snowflake，实现须支持并发调用，生成的 Id 全局唯一
+type IdGenerator interface {
+	// NewId 生成新的 Id
+	NewId() string
+}
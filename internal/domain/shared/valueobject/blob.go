@@ -0,0 +1,8 @@
+package valueobject
+
+import "github.com/gogf/gf/v2/errors/gerror"
+
+var (
+	ErrBlobNotFound   = gerror.New("blob not found")
+	ErrInvalidBlobKey = gerror.New("invalid blob key")
+)
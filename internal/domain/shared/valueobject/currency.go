@@ -0,0 +1,126 @@
+package valueobject
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// DefaultCurrency 系统默认货币
+const DefaultCurrency = "CNY"
+
+// maxCurrencyPrecision 货币精度上限，ISO 4217 中最大的小数位数为 4
+const maxCurrencyPrecision = 4
+
+// Currency 货币定义
+// 对应 ISO 4217 中的一条记录
+type Currency struct {
+	Code      string // 字母代码，如 CNY
+	Numeric   string // 数字代码，如 156
+	Name      string // 货币名称
+	Precision int    // 最小货币单位的小数位数
+}
+
+// Validate 验证货币定义
+func (c Currency) Validate() error {
+	if len(c.Code) != 3 || strings.ToUpper(c.Code) != c.Code {
+		return gerror.Wrapf(ErrInvalidCurrency, "currency code must be 3 uppercase letters: %s", c.Code)
+	}
+	if c.Precision < 0 || c.Precision > maxCurrencyPrecision {
+		return gerror.Wrapf(ErrInvalidCurrency, "invalid precision %d for currency %s", c.Precision, c.Code)
+	}
+	return nil
+}
+
+// CurrencyRegistry 货币注册表
+// 记录系统认可的货币及其精度，Money 在创建时会据此校验货币代码
+type CurrencyRegistry struct {
+	mu         sync.RWMutex
+	currencies map[string]Currency
+}
+
+// NewCurrencyRegistry 创建货币注册表
+func NewCurrencyRegistry(currencies ...Currency) *CurrencyRegistry {
+	r := &CurrencyRegistry{
+		currencies: make(map[string]Currency, len(currencies)),
+	}
+	for _, c := range currencies {
+		r.currencies[c.Code] = c
+	}
+	return r
+}
+
+// Register 注册货币，已存在的同代码货币将被覆盖
+// 已保存的金额以最小货币单位存储，因此不允许修改已注册货币的精度
+func (r *CurrencyRegistry) Register(currency Currency) error {
+	if err := currency.Validate(); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if existing, ok := r.currencies[currency.Code]; ok && existing.Precision != currency.Precision {
+		return gerror.Wrapf(ErrInvalidCurrency,
+			"cannot change precision of currency %s from %d to %d",
+			currency.Code, existing.Precision, currency.Precision,
+		)
+	}
+	r.currencies[currency.Code] = currency
+	return nil
+}
+
+// Lookup 根据货币代码查找货币
+func (r *CurrencyRegistry) Lookup(code string) (Currency, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	c, ok := r.currencies[code]
+	return c, ok
+}
+
+// IsValid 判断货币代码是否已注册
+func (r *CurrencyRegistry) IsValid(code string) bool {
+	_, ok := r.Lookup(code)
+	return ok
+}
+
+// Codes 返回所有已注册的货币代码，按字母顺序排列
+func (r *CurrencyRegistry) Codes() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	codes := make([]string, 0, len(r.currencies))
+	for code := range r.currencies {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// defaultCurrencyRegistry 默认货币注册表，包含 ISO 4217 中的现行货币
+var defaultCurrencyRegistry = NewCurrencyRegistry(iso4217Currencies...)
+
+// Currencies 获取默认货币注册表
+func Currencies() *CurrencyRegistry {
+	return defaultCurrencyRegistry
+}
+
+// LookupCurrency 在默认货币注册表中查找货币
+func LookupCurrency(code string) (Currency, bool) {
+	return defaultCurrencyRegistry.Lookup(code)
+}
+
+// IsValidCurrency 判断货币代码是否在默认货币注册表中
+func IsValidCurrency(code string) bool {
+	return defaultCurrencyRegistry.IsValid(code)
+}
+
+// validateCurrency 校验货币代码
+func validateCurrency(code string) error {
+	if code == "" {
+		return gerror.Wrap(ErrInvalidCurrency, "currency cannot be empty")
+	}
+	if !IsValidCurrency(code) {
+		return gerror.Wrapf(ErrInvalidCurrency, "unknown currency: %s", code)
+	}
+	return nil
+}
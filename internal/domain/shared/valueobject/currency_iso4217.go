@@ -0,0 +1,164 @@
+package valueobject
+
+// iso4217Currencies ISO 4217 现行货币列表
+var iso4217Currencies = []Currency{
+	{Code: "AED", Numeric: "784", Name: "UAE Dirham", Precision: 2},
+	{Code: "AFN", Numeric: "971", Name: "Afghani", Precision: 2},
+	{Code: "ALL", Numeric: "008", Name: "Lek", Precision: 2},
+	{Code: "AMD", Numeric: "051", Name: "Armenian Dram", Precision: 2},
+	{Code: "ANG", Numeric: "532", Name: "Netherlands Antillean Guilder", Precision: 2},
+	{Code: "AOA", Numeric: "973", Name: "Kwanza", Precision: 2},
+	{Code: "ARS", Numeric: "032", Name: "Argentine Peso", Precision: 2},
+	{Code: "AUD", Numeric: "036", Name: "Australian Dollar", Precision: 2},
+	{Code: "AWG", Numeric: "533", Name: "Aruban Florin", Precision: 2},
+	{Code: "AZN", Numeric: "944", Name: "Azerbaijan Manat", Precision: 2},
+	{Code: "BAM", Numeric: "977", Name: "Convertible Mark", Precision: 2},
+	{Code: "BBD", Numeric: "052", Name: "Barbados Dollar", Precision: 2},
+	{Code: "BDT", Numeric: "050", Name: "Taka", Precision: 2},
+	{Code: "BGN", Numeric: "975", Name: "Bulgarian Lev", Precision: 2},
+	{Code: "BHD", Numeric: "048", Name: "Bahraini Dinar", Precision: 3},
+	{Code: "BIF", Numeric: "108", Name: "Burundi Franc", Precision: 0},
+	{Code: "BMD", Numeric: "060", Name: "Bermudian Dollar", Precision: 2},
+	{Code: "BND", Numeric: "096", Name: "Brunei Dollar", Precision: 2},
+	{Code: "BOB", Numeric: "068", Name: "Boliviano", Precision: 2},
+	{Code: "BRL", Numeric: "986", Name: "Brazilian Real", Precision: 2},
+	{Code: "BSD", Numeric: "044", Name: "Bahamian Dollar", Precision: 2},
+	{Code: "BTN", Numeric: "064", Name: "Ngultrum", Precision: 2},
+	{Code: "BWP", Numeric: "072", Name: "Pula", Precision: 2},
+	{Code: "BYN", Numeric: "933", Name: "Belarusian Ruble", Precision: 2},
+	{Code: "BZD", Numeric: "084", Name: "Belize Dollar", Precision: 2},
+	{Code: "CAD", Numeric: "124", Name: "Canadian Dollar", Precision: 2},
+	{Code: "CDF", Numeric: "976", Name: "Congolese Franc", Precision: 2},
+	{Code: "CHF", Numeric: "756", Name: "Swiss Franc", Precision: 2},
+	{Code: "CLF", Numeric: "990", Name: "Unidad de Fomento", Precision: 4},
+	{Code: "CLP", Numeric: "152", Name: "Chilean Peso", Precision: 0},
+	{Code: "CNY", Numeric: "156", Name: "Yuan Renminbi", Precision: 2},
+	{Code: "COP", Numeric: "170", Name: "Colombian Peso", Precision: 2},
+	{Code: "CRC", Numeric: "188", Name: "Costa Rican Colon", Precision: 2},
+	{Code: "CUP", Numeric: "192", Name: "Cuban Peso", Precision: 2},
+	{Code: "CVE", Numeric: "132", Name: "Cabo Verde Escudo", Precision: 2},
+	{Code: "CZK", Numeric: "203", Name: "Czech Koruna", Precision: 2},
+	{Code: "DJF", Numeric: "262", Name: "Djibouti Franc", Precision: 0},
+	{Code: "DKK", Numeric: "208", Name: "Danish Krone", Precision: 2},
+	{Code: "DOP", Numeric: "214", Name: "Dominican Peso", Precision: 2},
+	{Code: "DZD", Numeric: "012", Name: "Algerian Dinar", Precision: 2},
+	{Code: "EGP", Numeric: "818", Name: "Egyptian Pound", Precision: 2},
+	{Code: "ERN", Numeric: "232", Name: "Nakfa", Precision: 2},
+	{Code: "ETB", Numeric: "230", Name: "Ethiopian Birr", Precision: 2},
+	{Code: "EUR", Numeric: "978", Name: "Euro", Precision: 2},
+	{Code: "FJD", Numeric: "242", Name: "Fiji Dollar", Precision: 2},
+	{Code: "FKP", Numeric: "238", Name: "Falkland Islands Pound", Precision: 2},
+	{Code: "GBP", Numeric: "826", Name: "Pound Sterling", Precision: 2},
+	{Code: "GEL", Numeric: "981", Name: "Lari", Precision: 2},
+	{Code: "GHS", Numeric: "936", Name: "Ghana Cedi", Precision: 2},
+	{Code: "GIP", Numeric: "292", Name: "Gibraltar Pound", Precision: 2},
+	{Code: "GMD", Numeric: "270", Name: "Dalasi", Precision: 2},
+	{Code: "GNF", Numeric: "324", Name: "Guinean Franc", Precision: 0},
+	{Code: "GTQ", Numeric: "320", Name: "Quetzal", Precision: 2},
+	{Code: "GYD", Numeric: "328", Name: "Guyana Dollar", Precision: 2},
+	{Code: "HKD", Numeric: "344", Name: "Hong Kong Dollar", Precision: 2},
+	{Code: "HNL", Numeric: "340", Name: "Lempira", Precision: 2},
+	{Code: "HTG", Numeric: "332", Name: "Gourde", Precision: 2},
+	{Code: "HUF", Numeric: "348", Name: "Forint", Precision: 2},
+	{Code: "IDR", Numeric: "360", Name: "Rupiah", Precision: 2},
+	{Code: "ILS", Numeric: "376", Name: "New Israeli Sheqel", Precision: 2},
+	{Code: "INR", Numeric: "356", Name: "Indian Rupee", Precision: 2},
+	{Code: "IQD", Numeric: "368", Name: "Iraqi Dinar", Precision: 3},
+	{Code: "IRR", Numeric: "364", Name: "Iranian Rial", Precision: 2},
+	{Code: "ISK", Numeric: "352", Name: "Iceland Krona", Precision: 0},
+	{Code: "JMD", Numeric: "388", Name: "Jamaican Dollar", Precision: 2},
+	{Code: "JOD", Numeric: "400", Name: "Jordanian Dinar", Precision: 3},
+	{Code: "JPY", Numeric: "392", Name: "Yen", Precision: 0},
+	{Code: "KES", Numeric: "404", Name: "Kenyan Shilling", Precision: 2},
+	{Code: "KGS", Numeric: "417", Name: "Som", Precision: 2},
+	{Code: "KHR", Numeric: "116", Name: "Riel", Precision: 2},
+	{Code: "KMF", Numeric: "174", Name: "Comorian Franc", Precision: 0},
+	{Code: "KPW", Numeric: "408", Name: "North Korean Won", Precision: 2},
+	{Code: "KRW", Numeric: "410", Name: "Won", Precision: 0},
+	{Code: "KWD", Numeric: "414", Name: "Kuwaiti Dinar", Precision: 3},
+	{Code: "KYD", Numeric: "136", Name: "Cayman Islands Dollar", Precision: 2},
+	{Code: "KZT", Numeric: "398", Name: "Tenge", Precision: 2},
+	{Code: "LAK", Numeric: "418", Name: "Lao Kip", Precision: 2},
+	{Code: "LBP", Numeric: "422", Name: "Lebanese Pound", Precision: 2},
+	{Code: "LKR", Numeric: "144", Name: "Sri Lanka Rupee", Precision: 2},
+	{Code: "LRD", Numeric: "430", Name: "Liberian Dollar", Precision: 2},
+	{Code: "LSL", Numeric: "426", Name: "Loti", Precision: 2},
+	{Code: "LYD", Numeric: "434", Name: "Libyan Dinar", Precision: 3},
+	{Code: "MAD", Numeric: "504", Name: "Moroccan Dirham", Precision: 2},
+	{Code: "MDL", Numeric: "498", Name: "Moldovan Leu", Precision: 2},
+	{Code: "MGA", Numeric: "969", Name: "Malagasy Ariary", Precision: 2},
+	{Code: "MKD", Numeric: "807", Name: "Denar", Precision: 2},
+	{Code: "MMK", Numeric: "104", Name: "Kyat", Precision: 2},
+	{Code: "MNT", Numeric: "496", Name: "Tugrik", Precision: 2},
+	{Code: "MOP", Numeric: "446", Name: "Pataca", Precision: 2},
+	{Code: "MRU", Numeric: "929", Name: "Ouguiya", Precision: 2},
+	{Code: "MUR", Numeric: "480", Name: "Mauritius Rupee", Precision: 2},
+	{Code: "MVR", Numeric: "462", Name: "Rufiyaa", Precision: 2},
+	{Code: "MWK", Numeric: "454", Name: "Malawi Kwacha", Precision: 2},
+	{Code: "MXN", Numeric: "484", Name: "Mexican Peso", Precision: 2},
+	{Code: "MYR", Numeric: "458", Name: "Malaysian Ringgit", Precision: 2},
+	{Code: "MZN", Numeric: "943", Name: "Mozambique Metical", Precision: 2},
+	{Code: "NAD", Numeric: "516", Name: "Namibia Dollar", Precision: 2},
+	{Code: "NGN", Numeric: "566", Name: "Naira", Precision: 2},
+	{Code: "NIO", Numeric: "558", Name: "Cordoba Oro", Precision: 2},
+	{Code: "NOK", Numeric: "578", Name: "Norwegian Krone", Precision: 2},
+	{Code: "NPR", Numeric: "524", Name: "Nepalese Rupee", Precision: 2},
+	{Code: "NZD", Numeric: "554", Name: "New Zealand Dollar", Precision: 2},
+	{Code: "OMR", Numeric: "512", Name: "Rial Omani", Precision: 3},
+	{Code: "PAB", Numeric: "590", Name: "Balboa", Precision: 2},
+	{Code: "PEN", Numeric: "604", Name: "Sol", Precision: 2},
+	{Code: "PGK", Numeric: "598", Name: "Kina", Precision: 2},
+	{Code: "PHP", Numeric: "608", Name: "Philippine Peso", Precision: 2},
+	{Code: "PKR", Numeric: "586", Name: "Pakistan Rupee", Precision: 2},
+	{Code: "PLN", Numeric: "985", Name: "Zloty", Precision: 2},
+	{Code: "PYG", Numeric: "600", Name: "Guarani", Precision: 0},
+	{Code: "QAR", Numeric: "634", Name: "Qatari Rial", Precision: 2},
+	{Code: "RON", Numeric: "946", Name: "Romanian Leu", Precision: 2},
+	{Code: "RSD", Numeric: "941", Name: "Serbian Dinar", Precision: 2},
+	{Code: "RUB", Numeric: "643", Name: "Russian Ruble", Precision: 2},
+	{Code: "RWF", Numeric: "646", Name: "Rwanda Franc", Precision: 0},
+	{Code: "SAR", Numeric: "682", Name: "Saudi Riyal", Precision: 2},
+	{Code: "SBD", Numeric: "090", Name: "Solomon Islands Dollar", Precision: 2},
+	{Code: "SCR", Numeric: "690", Name: "Seychelles Rupee", Precision: 2},
+	{Code: "SDG", Numeric: "938", Name: "Sudanese Pound", Precision: 2},
+	{Code: "SEK", Numeric: "752", Name: "Swedish Krona", Precision: 2},
+	{Code: "SGD", Numeric: "702", Name: "Singapore Dollar", Precision: 2},
+	{Code: "SHP", Numeric: "654", Name: "Saint Helena Pound", Precision: 2},
+	{Code: "SLE", Numeric: "925", Name: "Leone", Precision: 2},
+	{Code: "SOS", Numeric: "706", Name: "Somali Shilling", Precision: 2},
+	{Code: "SRD", Numeric: "968", Name: "Surinam Dollar", Precision: 2},
+	{Code: "SSP", Numeric: "728", Name: "South Sudanese Pound", Precision: 2},
+	{Code: "STN", Numeric: "930", Name: "Dobra", Precision: 2},
+	{Code: "SVC", Numeric: "222", Name: "El Salvador Colon", Precision: 2},
+	{Code: "SYP", Numeric: "760", Name: "Syrian Pound", Precision: 2},
+	{Code: "SZL", Numeric: "748", Name: "Lilangeni", Precision: 2},
+	{Code: "THB", Numeric: "764", Name: "Baht", Precision: 2},
+	{Code: "TJS", Numeric: "972", Name: "Somoni", Precision: 2},
+	{Code: "TMT", Numeric: "934", Name: "Turkmenistan New Manat", Precision: 2},
+	{Code: "TND", Numeric: "788", Name: "Tunisian Dinar", Precision: 3},
+	{Code: "TOP", Numeric: "776", Name: "Pa'anga", Precision: 2},
+	{Code: "TRY", Numeric: "949", Name: "Turkish Lira", Precision: 2},
+	{Code: "TTD", Numeric: "780", Name: "Trinidad and Tobago Dollar", Precision: 2},
+	{Code: "TWD", Numeric: "901", Name: "New Taiwan Dollar", Precision: 2},
+	{Code: "TZS", Numeric: "834", Name: "Tanzanian Shilling", Precision: 2},
+	{Code: "UAH", Numeric: "980", Name: "Hryvnia", Precision: 2},
+	{Code: "UGX", Numeric: "800", Name: "Uganda Shilling", Precision: 0},
+	{Code: "USD", Numeric: "840", Name: "US Dollar", Precision: 2},
+	{Code: "UYI", Numeric: "940", Name: "Uruguay Peso en Unidades Indexadas", Precision: 0},
+	{Code: "UYU", Numeric: "858", Name: "Peso Uruguayo", Precision: 2},
+	{Code: "UYW", Numeric: "927", Name: "Unidad Previsional", Precision: 4},
+	{Code: "UZS", Numeric: "860", Name: "Uzbekistan Sum", Precision: 2},
+	{Code: "VED", Numeric: "926", Name: "Bolivar Soberano", Precision: 2},
+	{Code: "VES", Numeric: "928", Name: "Bolivar Soberano", Precision: 2},
+	{Code: "VND", Numeric: "704", Name: "Dong", Precision: 0},
+	{Code: "VUV", Numeric: "548", Name: "Vatu", Precision: 0},
+	{Code: "WST", Numeric: "882", Name: "Tala", Precision: 2},
+	{Code: "XAF", Numeric: "950", Name: "CFA Franc BEAC", Precision: 0},
+	{Code: "XCD", Numeric: "951", Name: "East Caribbean Dollar", Precision: 2},
+	{Code: "XOF", Numeric: "952", Name: "CFA Franc BCEAO", Precision: 0},
+	{Code: "XPF", Numeric: "953", Name: "CFP Franc", Precision: 0},
+	{Code: "YER", Numeric: "886", Name: "Yemeni Rial", Precision: 2},
+	{Code: "ZAR", Numeric: "710", Name: "Rand", Precision: 2},
+	{Code: "ZMW", Numeric: "967", Name: "Zambian Kwacha", Precision: 2},
+	{Code: "ZWL", Numeric: "932", Name: "Zimbabwe Dollar", Precision: 2},
+}
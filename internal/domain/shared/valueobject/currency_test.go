@@ -0,0 +1,35 @@
+package valueobject
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCurrencyRegistryRegister(t *testing.T) {
+	tests := []struct {
+		name     string
+		currency Currency
+		wantErr  error
+	}{
+		{"new currency", Currency{Code: "XTS", Numeric: "963", Name: "Test", Precision: 3}, nil},
+		{"existing currency with same precision", Currency{Code: "CNY", Numeric: "156", Name: "Renminbi", Precision: 2}, nil},
+		{"existing currency with different precision", Currency{Code: "CNY", Numeric: "156", Name: "Renminbi", Precision: 3}, ErrInvalidCurrency},
+		{"lowercase code", Currency{Code: "xts", Precision: 2}, ErrInvalidCurrency},
+		{"precision too large", Currency{Code: "XTS", Precision: maxCurrencyPrecision + 1}, ErrInvalidCurrency},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewCurrencyRegistry(Currency{Code: "CNY", Numeric: "156", Name: "Yuan Renminbi", Precision: 2})
+			if err := r.Register(tt.currency); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Register() error = %v, want %v", err, tt.wantErr)
+			}
+			c, ok := r.Lookup(tt.currency.Code)
+			if tt.wantErr == nil && (!ok || c != tt.currency) {
+				t.Errorf("Lookup() = %v, %v, want %v", c, ok, tt.currency)
+			}
+			if tt.currency.Code == "CNY" && c.Precision != 2 {
+				t.Errorf("CNY precision = %d, want 2", c.Precision)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package valueobject
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+var (
+	ErrInvalidExchangeRate  = gerror.New("invalid exchange rate")
+	ErrExchangeRateNotFound = gerror.New("exchange rate not found")
+)
+
+// ExchangeRate 汇率值对象
+// 表示在某一时刻 1 单位源货币可兑换的目标货币数量
+type ExchangeRate struct {
+	from      string
+	to        string
+	rate      *big.Rat
+	timestamp time.Time // 汇率的生效时间
+}
+
+// NewExchangeRate 创建汇率值对象
+func NewExchangeRate(from, to string, rate float64, timestamp time.Time) (*ExchangeRate, error) {
+	r := ratFromFloat(rate)
+	if r == nil {
+		return nil, gerror.Wrapf(ErrInvalidExchangeRate, "rate is not a finite number: %v", rate)
+	}
+	return newExchangeRate(from, to, r, timestamp)
+}
+
+// newExchangeRate 使用精确的有理数创建汇率值对象
+func newExchangeRate(from, to string, rate *big.Rat, timestamp time.Time) (*ExchangeRate, error) {
+	if err := validateCurrency(from); err != nil {
+		return nil, err
+	}
+	if err := validateCurrency(to); err != nil {
+		return nil, err
+	}
+	if rate.Sign() <= 0 {
+		return nil, gerror.Wrapf(ErrInvalidExchangeRate, "rate from %s to %s must be positive", from, to)
+	}
+	return &ExchangeRate{
+		from:      from,
+		to:        to,
+		rate:      rate,
+		timestamp: timestamp,
+	}, nil
+}
+
+// IdentityExchangeRate 同一货币之间的汇率，恒为 1
+func IdentityExchangeRate(currency string, timestamp time.Time) (*ExchangeRate, error) {
+	return newExchangeRate(currency, currency, big.NewRat(1, 1), timestamp)
+}
+
+// From 获取源货币
+func (r *ExchangeRate) From() string {
+	return r.from
+}
+
+// To 获取目标货币
+func (r *ExchangeRate) To() string {
+	return r.to
+}
+
+// Rate 获取汇率
+// 返回值仅用于展示，换算应使用 Money.ConvertTo
+func (r *ExchangeRate) Rate() float64 {
+	f, _ := r.rate.Float64()
+	return f
+}
+
+// Timestamp 获取汇率的生效时间
+func (r *ExchangeRate) Timestamp() time.Time {
+	return r.timestamp
+}
+
+// Inverse 获取反向汇率
+func (r *ExchangeRate) Inverse() *ExchangeRate {
+	return &ExchangeRate{
+		from:      r.to,
+		to:        r.from,
+		rate:      new(big.Rat).Inv(r.rate),
+		timestamp: r.timestamp,
+	}
+}
+
+// Cross 通过公共货币计算交叉汇率
+// 例如 USD->CNY 与 CNY->JPY 可得到 USD->JPY，生效时间取两者中较早的一个
+func (r *ExchangeRate) Cross(other *ExchangeRate) (*ExchangeRate, error) {
+	if r.to != other.from {
+		return nil, gerror.Wrapf(ErrInvalidExchangeRate,
+			"cannot cross %s->%s with %s->%s",
+			r.from, r.to, other.from, other.to,
+		)
+	}
+	timestamp := r.timestamp
+	if other.timestamp.Before(timestamp) {
+		timestamp = other.timestamp
+	}
+	return newExchangeRate(r.from, other.to, new(big.Rat).Mul(r.rate, other.rate), timestamp)
+}
+
+// ConvertTo 按汇率将金额换算为目标货币
+// 结果按四舍五入舍入到目标货币的精度
+func (m *Money) ConvertTo(rate *ExchangeRate) (*Money, error) {
+	return m.ConvertToWithRounding(rate, RoundHalfUp)
+}
+
+// ConvertToWithRounding 使用指定的舍入模式按汇率换算金额
+func (m *Money) ConvertToWithRounding(rate *ExchangeRate, mode RoundingMode) (*Money, error) {
+	if rate == nil {
+		return nil, gerror.Wrap(ErrInvalidExchangeRate, "exchange rate is required")
+	}
+	if rate.from != m.currency {
+		return nil, gerror.Wrapf(ErrCurrencyMismatch,
+			"cannot convert %s money with %s->%s rate",
+			m.currency, rate.from, rate.to,
+		)
+	}
+
+	// 目标最小单位金额 = 源最小单位金额 * 汇率 * 10^(目标精度 - 源精度)
+	converted := new(big.Rat).Mul(ratFromInt(m.amount), rate.rate)
+	converted.Mul(converted, ratPow10(currencyPrecision(rate.to)))
+	converted.Quo(converted, ratPow10(m.Precision()))
+	return newMoney(mode.round(converted), rate.to), nil
+}
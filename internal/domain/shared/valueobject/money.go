@@ -1,6 +1,10 @@
 package valueobject
 
-import "github.com/gogf/gf/v2/errors/gerror"
+import (
+	"math"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+)
 
 var (
 	ErrInvalidCurrency   = gerror.New("invalid currency")
@@ -9,27 +13,99 @@ var (
 	ErrInvalidMultiplier = gerror.New("invalid multiplier")
 )
 
+// DefaultPrecision 默认的货币精度（小数位数），用于未注册的货币
+const DefaultPrecision = 2
+
 // Money 金额值对象
 // 在领域驱动设计中，Money 是一个典型的值对象：
 // 1. 不可变性：所有操作都返回新的实例
 // 2. 无副作用：不改变原有对象的状态
 // 3. 完整性：包含了金额和货币单位
 // 4. 自封含：包含了所有必要的业务规则
+//
+// 金额以最小货币单位（如人民币的"分"）的整数形式保存，
+// 避免浮点数运算带来的累积误差。
 type Money struct {
-	amount   float64
+	amount   int64 // 以最小货币单位表示的金额
 	currency string
 }
 
 // NewMoney 创建金额值对象
-func NewMoney(amount float64, currency string) *Money {
+// 货币必须是货币注册表中的有效货币，金额按照货币精度以四舍五入的方式转换为最小货币单位
+func NewMoney(amount float64, currency string) (*Money, error) {
+	return NewMoneyWithRounding(amount, currency, RoundHalfUp)
+}
+
+// NewMoneyWithRounding 使用指定的舍入模式创建金额值对象
+func NewMoneyWithRounding(amount float64, currency string, mode RoundingMode) (*Money, error) {
+	if err := validateCurrency(currency); err != nil {
+		return nil, err
+	}
+	r := ratFromFloat(amount)
+	if r == nil {
+		return nil, gerror.Wrapf(ErrInvalidAmount, "amount is not a finite number: %v", amount)
+	}
+	return newMoney(mode.round(r.Mul(r, ratPow10(currencyPrecision(currency)))), currency), nil
+}
+
+// NewMoneyFromMinor 根据最小货币单位的金额创建金额值对象
+func NewMoneyFromMinor(minorAmount int64, currency string) (*Money, error) {
+	if err := validateCurrency(currency); err != nil {
+		return nil, err
+	}
+	return newMoney(minorAmount, currency), nil
+}
+
+// RestoreMoney 根据已保存的最小货币单位金额重建金额值对象
+// 仅用于从存储中读取已有数据：不校验货币注册表，未注册的货币代码原样保留，
+// 空货币视为历史数据使用的默认货币。新的金额应通过 NewMoney 系列函数创建
+func RestoreMoney(minorAmount int64, currency string) *Money {
+	if currency == "" {
+		currency = DefaultCurrency
+	}
+	return newMoney(minorAmount, currency)
+}
+
+// RestoreMoneyFromAmount 根据历史数据中的浮点数金额重建金额值对象
+// 按货币精度四舍五入为最小货币单位，非法的金额（NaN、Inf）视为零，其余同 RestoreMoney
+func RestoreMoneyFromAmount(amount float64, currency string) *Money {
+	if currency == "" {
+		currency = DefaultCurrency
+	}
+	r := ratFromFloat(amount)
+	if r == nil {
+		return newMoney(0, currency)
+	}
+	return newMoney(RoundHalfUp.round(r.Mul(r, ratPow10(currencyPrecision(currency)))), currency)
+}
+
+// MustNewMoney 创建金额值对象，货币无效时 panic
+// 仅用于货币代码为常量的场景，如创建默认货币的零金额
+func MustNewMoney(amount float64, currency string) *Money {
+	m, err := NewMoney(amount, currency)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// newMoney 创建金额值对象，不做校验
+// 仅用于已知货币有效的内部运算
+func newMoney(minorAmount int64, currency string) *Money {
 	return &Money{
-		amount:   amount,
+		amount:   minorAmount,
 		currency: currency,
 	}
 }
 
 // Amount 获取金额
+// 返回值仅用于展示或与外部系统交互，业务计算应使用 Money 自身的运算方法
 func (m *Money) Amount() float64 {
+	return float64(m.amount) / math.Pow10(m.Precision())
+}
+
+// MinorAmount 获取以最小货币单位表示的金额
+func (m *Money) MinorAmount() int64 {
 	return m.amount
 }
 
@@ -38,6 +114,11 @@ func (m *Money) Currency() string {
 	return m.currency
 }
 
+// Precision 获取货币精度（小数位数）
+func (m *Money) Precision() int {
+	return currencyPrecision(m.currency)
+}
+
 // Add 金额相加
 // 确保两个金额的货币单位相同
 func (m *Money) Add(other *Money) (*Money, error) {
@@ -48,7 +129,7 @@ func (m *Money) Add(other *Money) (*Money, error) {
 			other.currency,
 		)
 	}
-	return NewMoney(m.amount+other.amount, m.currency), nil
+	return newMoney(m.amount+other.amount, m.currency), nil
 }
 
 // Subtract 金额相减
@@ -61,13 +142,23 @@ func (m *Money) Subtract(other *Money) (*Money, error) {
 			other.currency,
 		)
 	}
-	return NewMoney(m.amount-other.amount, m.currency), nil
+	return newMoney(m.amount-other.amount, m.currency), nil
 }
 
 // Multiply 金额乘以系数
-// 确保乘数为非负数
+// 结果按四舍五入舍入到最小货币单位
 func (m *Money) Multiply(multiplier float64) *Money {
-	return NewMoney(m.amount*multiplier, m.currency)
+	return m.MultiplyWithRounding(multiplier, RoundHalfUp)
+}
+
+// MultiplyWithRounding 使用指定的舍入模式将金额乘以系数
+// 非法的系数（NaN、Inf）将得到零金额
+func (m *Money) MultiplyWithRounding(multiplier float64, mode RoundingMode) *Money {
+	r := ratFromFloat(multiplier)
+	if r == nil {
+		return newMoney(0, m.currency)
+	}
+	return newMoney(mode.round(r.Mul(r, ratFromInt(m.amount))), m.currency)
 }
 
 // Equals 判断金额是否相等
@@ -79,6 +170,26 @@ func (m *Money) Equals(other *Money) bool {
 	return m.amount == other.amount && m.currency == other.currency
 }
 
+// Compare 比较两个金额的大小
+// 返回 -1、0、1 分别表示小于、等于、大于，货币不同时返回错误
+func (m *Money) Compare(other *Money) (int, error) {
+	if m.currency != other.currency {
+		return 0, gerror.Wrapf(ErrCurrencyMismatch,
+			"cannot compare money with different currencies: %s and %s",
+			m.currency,
+			other.currency,
+		)
+	}
+	switch {
+	case m.amount < other.amount:
+		return -1, nil
+	case m.amount > other.amount:
+		return 1, nil
+	default:
+		return 0, nil
+	}
+}
+
 // IsZero 判断金额是否为零
 func (m *Money) IsZero() bool {
 	return m.amount == 0
@@ -97,11 +208,20 @@ func (m *Money) IsPositive() bool {
 // Validate 验证金额
 // 确保金额和货币单位的有效性
 func (m *Money) Validate() error {
-	if m.currency == "" {
-		return gerror.Wrap(ErrInvalidCurrency, "currency cannot be empty")
+	if err := validateCurrency(m.currency); err != nil {
+		return err
 	}
 	if m.amount < 0 {
 		return gerror.Wrap(ErrInvalidAmount, "amount cannot be negative")
 	}
 	return nil
 }
+
+// currencyPrecision 获取货币精度
+// 未注册的货币使用默认精度
+func currencyPrecision(currency string) int {
+	if c, ok := LookupCurrency(currency); ok {
+		return c.Precision
+	}
+	return DefaultPrecision
+}
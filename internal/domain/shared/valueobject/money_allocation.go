@@ -0,0 +1,86 @@
+package valueobject
+
+import (
+	"math/big"
+	"sort"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+var ErrInvalidAllocation = gerror.New("invalid allocation")
+
+// Allocate 按比例分摊金额
+// 采用最大余数法：先按比例向下取整分配最小货币单位，
+// 剩余的最小货币单位依次分给余数最大的份额（余数相同时靠前的优先），
+// 保证各份额之和严格等于原金额，不会凭空多出或丢失一分钱。
+func (m *Money) Allocate(ratios ...int64) ([]*Money, error) {
+	if len(ratios) == 0 {
+		return nil, gerror.Wrap(ErrInvalidAllocation, "at least one ratio is required")
+	}
+
+	sum := new(big.Int)
+	for _, ratio := range ratios {
+		if ratio < 0 {
+			return nil, gerror.Wrapf(ErrInvalidAllocation, "ratio cannot be negative: %d", ratio)
+		}
+		sum.Add(sum, big.NewInt(ratio))
+	}
+	if sum.Sign() == 0 {
+		return nil, gerror.Wrap(ErrInvalidAllocation, "sum of ratios must be positive")
+	}
+
+	// 负数金额按绝对值分摊后再取反
+	sign := int64(1)
+	total := m.amount
+	if total < 0 {
+		sign, total = -1, -total
+	}
+
+	// 1. 按比例向下取整
+	type share struct {
+		index     int
+		remainder *big.Int
+	}
+	var (
+		amounts   = make([]int64, len(ratios))
+		shares    = make([]share, len(ratios))
+		allocated = int64(0)
+	)
+	for i, ratio := range ratios {
+		quo, rem := new(big.Int).QuoRem(
+			new(big.Int).Mul(big.NewInt(total), big.NewInt(ratio)),
+			sum,
+			new(big.Int),
+		)
+		amounts[i] = quo.Int64()
+		shares[i] = share{index: i, remainder: rem}
+		allocated += amounts[i]
+	}
+
+	// 2. 剩余部分按余数从大到小逐一分配
+	sort.SliceStable(shares, func(i, j int) bool {
+		return shares[i].remainder.Cmp(shares[j].remainder) > 0
+	})
+	for i := int64(0); i < total-allocated; i++ {
+		amounts[shares[i].index]++
+	}
+
+	result := make([]*Money, len(amounts))
+	for i, amount := range amounts {
+		result[i] = newMoney(sign*amount, m.currency)
+	}
+	return result, nil
+}
+
+// Split 将金额平均分成 n 份
+// 无法整除的最小货币单位分给靠前的份额
+func (m *Money) Split(n int) ([]*Money, error) {
+	if n <= 0 {
+		return nil, gerror.Wrapf(ErrInvalidAllocation, "number of parts must be positive: %d", n)
+	}
+	ratios := make([]int64, n)
+	for i := range ratios {
+		ratios[i] = 1
+	}
+	return m.Allocate(ratios...)
+}
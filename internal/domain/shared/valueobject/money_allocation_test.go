@@ -0,0 +1,105 @@
+package valueobject
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestMoneyAllocate(t *testing.T) {
+	tests := []struct {
+		name   string
+		minor  int64
+		ratios []int64
+		want   []int64
+	}{
+		{"even split", 100, []int64{1, 1}, []int64{50, 50}},
+		{"remainder goes to first on ties", 100, []int64{1, 1, 1}, []int64{34, 33, 33}},
+		{"remainder goes to largest remainder", 5, []int64{3, 7}, []int64{2, 3}},
+		{"remainder spread over several shares", 10, []int64{1, 1, 1, 1, 1, 1}, []int64{2, 2, 2, 2, 1, 1}},
+		{"weighted", 1000, []int64{70, 20, 10}, []int64{700, 200, 100}},
+		{"zero ratio receives nothing", 101, []int64{0, 1, 1}, []int64{0, 51, 50}},
+		{"single ratio", 99, []int64{5}, []int64{99}},
+		{"amount smaller than shares", 2, []int64{1, 1, 1}, []int64{1, 1, 0}},
+		{"negative amount", -100, []int64{1, 1, 1}, []int64{-34, -33, -33}},
+		{"zero amount", 0, []int64{1, 2}, []int64{0, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parts, err := newMoney(tt.minor, "CNY").Allocate(tt.ratios...)
+			if err != nil {
+				t.Fatalf("Allocate() error = %v", err)
+			}
+			got := make([]int64, len(parts))
+			sum := int64(0)
+			for i, part := range parts {
+				if part.Currency() != "CNY" {
+					t.Errorf("part %d currency = %s, want CNY", i, part.Currency())
+				}
+				got[i] = part.MinorAmount()
+				sum += got[i]
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Allocate() = %v, want %v", got, tt.want)
+			}
+			if sum != tt.minor {
+				t.Errorf("sum of parts = %d, want %d", sum, tt.minor)
+			}
+		})
+	}
+}
+
+func TestMoneyAllocateInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		ratios []int64
+	}{
+		{"no ratios", nil},
+		{"negative ratio", []int64{1, -1}},
+		{"all ratios zero", []int64{0, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := newMoney(100, "CNY").Allocate(tt.ratios...); !errors.Is(err, ErrInvalidAllocation) {
+				t.Errorf("Allocate() error = %v, want %v", err, ErrInvalidAllocation)
+			}
+		})
+	}
+}
+
+func TestMoneySplit(t *testing.T) {
+	tests := []struct {
+		name    string
+		minor   int64
+		n       int
+		want    []int64
+		wantErr bool
+	}{
+		{"divisible", 90, 3, []int64{30, 30, 30}, false},
+		{"remainder goes to leading parts", 100, 3, []int64{34, 33, 33}, false},
+		{"one part", 7, 1, []int64{7}, false},
+		{"zero parts", 100, 0, nil, true},
+		{"negative parts", 100, -1, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parts, err := newMoney(tt.minor, "CNY").Split(tt.n)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidAllocation) {
+					t.Errorf("Split() error = %v, want %v", err, ErrInvalidAllocation)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Split() error = %v", err)
+			}
+			got := make([]int64, len(parts))
+			for i, part := range parts {
+				got[i] = part.MinorAmount()
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Split() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package valueobject
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"strings"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// moneyJSON Money 的 JSON 表示
+// 金额以精确的十进制数字输出，如 {"amount": 12.34, "currency": "CNY"}
+type moneyJSON struct {
+	Amount   json.Number `json:"amount"`
+	Currency string      `json:"currency"`
+}
+
+// NewMoneyFromString 根据十进制字符串创建金额值对象
+// 字符串按精确的十进制数解析，超出货币精度的部分以四舍五入舍入
+func NewMoneyFromString(amount string, currency string) (*Money, error) {
+	if err := validateCurrency(currency); err != nil {
+		return nil, err
+	}
+	r, ok := new(big.Rat).SetString(strings.TrimSpace(amount))
+	if !ok {
+		return nil, gerror.Wrapf(ErrInvalidAmount, "invalid decimal amount: %q", amount)
+	}
+	return newMoney(RoundHalfUp.round(r.Mul(r, ratPow10(currencyPrecision(currency)))), currency), nil
+}
+
+// AmountString 获取金额的精确十进制字符串表示，如 12.34
+func (m *Money) AmountString() string {
+	precision := m.Precision()
+	digits := big.NewInt(m.amount).String()
+	if precision == 0 {
+		return digits
+	}
+
+	sign := ""
+	if strings.HasPrefix(digits, "-") {
+		sign, digits = "-", digits[1:]
+	}
+	if len(digits) <= precision {
+		digits = strings.Repeat("0", precision-len(digits)+1) + digits
+	}
+	return sign + digits[:len(digits)-precision] + "." + digits[len(digits)-precision:]
+}
+
+// String 获取金额的字符串表示，如 12.34 CNY
+func (m *Money) String() string {
+	return m.AmountString() + " " + m.currency
+}
+
+// MarshalJSON 实现 json.Marshaler 接口
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(moneyJSON{
+		Amount:   json.Number(m.AmountString()),
+		Currency: m.currency,
+	})
+}
+
+// UnmarshalJSON 实现 json.Unmarshaler 接口
+// 金额既可以是数字也可以是字符串
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var v struct {
+		Amount   json.RawMessage `json:"amount"`
+		Currency string          `json:"currency"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return gerror.Wrap(ErrInvalidAmount, err.Error())
+	}
+
+	amount := string(bytes.Trim(v.Amount, `"`))
+	if amount == "" {
+		return gerror.Wrap(ErrInvalidAmount, "amount is required")
+	}
+	money, err := NewMoneyFromString(amount, v.Currency)
+	if err != nil {
+		return err
+	}
+	*m = *money
+	return nil
+}
+
+// UnmarshalValue 实现 gconv.IUnmarshalValue 接口
+// 使 HTTP 请求参数可以直接绑定到 Money 类型的字段
+func (m *Money) UnmarshalValue(value interface{}) error {
+	switch v := value.(type) {
+	case *Money:
+		*m = *v
+		return nil
+	case Money:
+		*m = v
+		return nil
+	case []byte:
+		return m.UnmarshalJSON(v)
+	case string:
+		return m.UnmarshalJSON([]byte(v))
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return gerror.Wrap(ErrInvalidAmount, err.Error())
+		}
+		return m.UnmarshalJSON(data)
+	}
+}
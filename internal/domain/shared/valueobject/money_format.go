@@ -0,0 +1,194 @@
+package valueobject
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// Locale 地区，采用 BCP 47 语言标签
+type Locale string
+
+const (
+	LocaleZhCN Locale = "zh-CN" // 简体中文（中国）
+	LocaleEnUS Locale = "en-US" // 英语（美国）
+	LocaleJaJP Locale = "ja-JP" // 日语（日本）
+	LocaleDeDE Locale = "de-DE" // 德语（德国）
+
+	// DefaultLocale 未知地区使用的默认地区
+	DefaultLocale = LocaleZhCN
+)
+
+// CurrencyDisplay 货币的显示方式
+type CurrencyDisplay int
+
+const (
+	CurrencyDisplaySymbol CurrencyDisplay = iota // 显示货币符号，如 ¥1,234.56
+	CurrencyDisplayCode                          // 显示货币代码，如 CNY 1,234.56
+	CurrencyDisplayNone                          // 不显示货币，如 1,234.56，用于货币单独成列的表格
+)
+
+// localeFormat 地区的金额格式规则
+type localeFormat struct {
+	decimalSeparator string
+	groupSeparator   string
+	currencySuffix   bool              // 货币符号是否位于数字之后
+	symbols          map[string]string // 该地区特有的货币符号，覆盖 currencySymbols
+}
+
+// localeFormats 已支持地区的格式规则
+var localeFormats = map[Locale]localeFormat{
+	LocaleZhCN: {
+		decimalSeparator: ".",
+		groupSeparator:   ",",
+		symbols:          map[string]string{"USD": "US$", "JPY": "JP¥", "HKD": "HK$"},
+	},
+	LocaleEnUS: {
+		decimalSeparator: ".",
+		groupSeparator:   ",",
+		symbols:          map[string]string{"CNY": "CN¥"},
+	},
+	LocaleJaJP: {
+		decimalSeparator: ".",
+		groupSeparator:   ",",
+		symbols:          map[string]string{"JPY": "￥", "CNY": "元"},
+	},
+	LocaleDeDE: {
+		decimalSeparator: ",",
+		groupSeparator:   ".",
+		currencySuffix:   true,
+		symbols:          map[string]string{"CNY": "CN¥"},
+	},
+}
+
+// currencySymbols 通用的货币符号，未列出的货币使用货币代码
+var currencySymbols = map[string]string{
+	"CNY": "¥",
+	"USD": "$",
+	"EUR": "€",
+	"GBP": "£",
+	"JPY": "¥",
+	"HKD": "HK$",
+	"TWD": "NT$",
+	"KRW": "₩",
+	"INR": "₹",
+}
+
+// decimalPattern 去除符号和分隔符后的金额格式
+var decimalPattern = regexp.MustCompile(`^\d+(\.\d+)?$`)
+
+// IsSupportedLocale 判断是否支持该地区
+func IsSupportedLocale(locale Locale) bool {
+	_, ok := localeFormats[locale]
+	return ok
+}
+
+// formatOf 获取地区的格式规则，未知地区使用默认地区
+func formatOf(locale Locale) localeFormat {
+	if f, ok := localeFormats[locale]; ok {
+		return f
+	}
+	return localeFormats[DefaultLocale]
+}
+
+// symbol 获取货币在该地区的符号
+func (f localeFormat) symbol(currency string) string {
+	if s, ok := f.symbols[currency]; ok {
+		return s
+	}
+	if s, ok := currencySymbols[currency]; ok {
+		return s
+	}
+	return currency
+}
+
+// Format 按地区格式化金额，使用货币符号
+// 如 zh-CN 下 ¥1,234.56，ja-JP 下 ￥1,235，de-DE 下 1.234,56 €
+func (m *Money) Format(locale Locale) string {
+	return m.FormatWith(locale, CurrencyDisplaySymbol)
+}
+
+// FormatWith 按地区和货币显示方式格式化金额
+// 小数位数始终与货币精度一致
+func (m *Money) FormatWith(locale Locale, display CurrencyDisplay) string {
+	f := formatOf(locale)
+
+	// 1. 拆分整数部分与小数部分
+	amount := strings.TrimPrefix(m.AmountString(), "-")
+	integer, fraction, _ := strings.Cut(amount, ".")
+
+	// 2. 整数部分按三位分组
+	var grouped strings.Builder
+	for i, digit := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			grouped.WriteString(f.groupSeparator)
+		}
+		grouped.WriteRune(digit)
+	}
+	number := grouped.String()
+	if fraction != "" {
+		number += f.decimalSeparator + fraction
+	}
+
+	// 3. 组合货币符号或代码
+	sign := ""
+	if m.IsNegative() {
+		sign = "-"
+	}
+	switch {
+	case display == CurrencyDisplayNone:
+		return sign + number
+	case display == CurrencyDisplayCode && f.currencySuffix:
+		return sign + number + " " + m.currency
+	case display == CurrencyDisplayCode:
+		return sign + m.currency + " " + number
+	case f.currencySuffix:
+		return sign + number + " " + f.symbol(m.currency)
+	default:
+		return sign + f.symbol(m.currency) + number
+	}
+}
+
+// ParseMoney 按地区解析用户输入的金额
+// 支持带货币符号或代码、千位分隔符的输入，如 "¥1,234.56"、"1.234,56 €"、"CNY 1234.5"，
+// 小数位数超过货币精度时返回错误，而不是静默舍入
+func ParseMoney(input string, currency string, locale Locale) (*Money, error) {
+	if err := validateCurrency(currency); err != nil {
+		return nil, err
+	}
+	f := formatOf(locale)
+
+	// 1. 去除空白、货币符号和货币代码
+	text := strings.Join(strings.Fields(strings.ReplaceAll(input, " ", " ")), "")
+	for _, s := range []string{currency, f.symbol(currency), currencySymbols[currency]} {
+		if s != "" {
+			text = strings.ReplaceAll(text, s, "")
+		}
+	}
+
+	// 2. 识别负号
+	negative := false
+	if strings.HasPrefix(text, "-") || strings.HasPrefix(text, "−") {
+		negative = true
+		text = strings.TrimLeft(text, "-−")
+	}
+
+	// 3. 去除千位分隔符，统一小数点
+	text = strings.ReplaceAll(text, f.groupSeparator, "")
+	text = strings.ReplaceAll(text, f.decimalSeparator, ".")
+	if !decimalPattern.MatchString(text) {
+		return nil, gerror.Wrapf(ErrInvalidAmount, "cannot parse %q as %s amount in %s", input, currency, locale)
+	}
+	if _, fraction, ok := strings.Cut(text, "."); ok && len(fraction) > currencyPrecision(currency) {
+		return nil, gerror.Wrapf(ErrInvalidAmount,
+			"%s allows at most %d decimal places: %q",
+			currency, currencyPrecision(currency), input,
+		)
+	}
+
+	if negative {
+		text = "-" + text
+	}
+	return NewMoneyFromString(text, currency)
+}
@@ -0,0 +1,116 @@
+package valueobject
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestNewMoneyWithRounding(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   float64
+		currency string
+		mode     RoundingMode
+		want     int64
+	}{
+		{"half up rounds half away from zero", 1.005, "CNY", RoundHalfUp, 101},
+		{"half up negative", -1.005, "CNY", RoundHalfUp, -101},
+		{"half up below half", 1.004, "CNY", RoundHalfUp, 100},
+		{"half even rounds half to even down", 1.025, "CNY", RoundHalfEven, 102},
+		{"half even rounds half to even up", 1.035, "CNY", RoundHalfEven, 104},
+		{"half even above half", 1.0251, "CNY", RoundHalfEven, 103},
+		{"down truncates positive", 1.019, "CNY", RoundDown, 101},
+		{"down truncates negative", -1.019, "CNY", RoundDown, -101},
+		{"up moves away from zero", 1.011, "CNY", RoundUp, 102},
+		{"up moves away from zero negative", -1.011, "CNY", RoundUp, -102},
+		{"floor positive", 1.019, "CNY", RoundFloor, 101},
+		{"floor negative", -1.011, "CNY", RoundFloor, -102},
+		{"ceiling positive", 1.011, "CNY", RoundCeiling, 102},
+		{"ceiling negative", -1.019, "CNY", RoundCeiling, -101},
+		{"exact amount is not rounded", 0.1, "CNY", RoundUp, 10},
+		{"zero precision currency", 2.5, "JPY", RoundHalfUp, 3},
+		{"zero precision currency half even", 2.5, "JPY", RoundHalfEven, 2},
+		{"three digit precision currency", 1.0005, "KWD", RoundHalfUp, 1001},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := NewMoneyWithRounding(tt.amount, tt.currency, tt.mode)
+			if err != nil {
+				t.Fatalf("NewMoneyWithRounding() error = %v", err)
+			}
+			if got := m.MinorAmount(); got != tt.want {
+				t.Errorf("MinorAmount() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewMoneyInvalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   float64
+		currency string
+		wantErr  error
+	}{
+		{"unknown currency", 1, "XXY", ErrInvalidCurrency},
+		{"lowercase currency", 1, "cny", ErrInvalidCurrency},
+		{"not a number", math.NaN(), "CNY", ErrInvalidAmount},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewMoney(tt.amount, tt.currency); !errors.Is(err, tt.wantErr) {
+				t.Errorf("NewMoney() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMoneyMultiplyWithRounding(t *testing.T) {
+	tests := []struct {
+		name       string
+		minor      int64
+		multiplier float64
+		mode       RoundingMode
+		want       int64
+	}{
+		{"half up", 101, 0.5, RoundHalfUp, 51},
+		{"half even", 101, 0.5, RoundHalfEven, 50},
+		{"half even odd quotient", 103, 0.5, RoundHalfEven, 52},
+		{"down", 999, 0.333, RoundDown, 332},
+		{"up", 999, 0.333, RoundUp, 333},
+		{"floor negative", -101, 0.5, RoundFloor, -51},
+		{"ceiling negative", -101, 0.5, RoundCeiling, -50},
+		{"decimal multiplier is exact", 1000, 0.07, RoundUp, 70},
+		{"invalid multiplier yields zero", 100, math.NaN(), RoundHalfUp, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newMoney(tt.minor, "CNY")
+			if got := m.MultiplyWithRounding(tt.multiplier, tt.mode).MinorAmount(); got != tt.want {
+				t.Errorf("MultiplyWithRounding() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoneyArithmeticCurrencyMismatch(t *testing.T) {
+	cny := newMoney(100, "CNY")
+	usd := newMoney(100, "USD")
+
+	tests := []struct {
+		name string
+		op   func() error
+	}{
+		{"add", func() error { _, err := cny.Add(usd); return err }},
+		{"subtract", func() error { _, err := cny.Subtract(usd); return err }},
+		{"compare", func() error { _, err := cny.Compare(usd); return err }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.op(); !errors.Is(err, ErrCurrencyMismatch) {
+				t.Errorf("error = %v, want %v", err, ErrCurrencyMismatch)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package valueobject
+
+import (
+	"math/big"
+	"strconv"
+)
+
+// RoundingMode 舍入模式
+// 金额在换算为最小货币单位时需要显式指定舍入规则
+type RoundingMode int
+
+const (
+	RoundHalfUp   RoundingMode = iota // 四舍五入（默认）
+	RoundHalfEven                     // 银行家舍入，四舍六入五取偶
+	RoundDown                         // 向零截断
+	RoundUp                           // 远离零进位
+	RoundFloor                        // 向负无穷舍入
+	RoundCeiling                      // 向正无穷舍入
+)
+
+// round 将有理数按照舍入模式舍入为整数
+func (mode RoundingMode) round(r *big.Rat) int64 {
+	quo, rem := new(big.Int).QuoRem(r.Num(), r.Denom(), new(big.Int))
+	if rem.Sign() == 0 {
+		return quo.Int64()
+	}
+
+	// 比较余数的两倍与分母，判断是否超过一半
+	half := new(big.Int).Abs(rem)
+	half.Lsh(half, 1)
+	cmp := half.Cmp(r.Denom())
+
+	var awayFromZero bool
+	switch mode {
+	case RoundHalfEven:
+		awayFromZero = cmp > 0 || (cmp == 0 && quo.Bit(0) == 1)
+	case RoundDown:
+		awayFromZero = false
+	case RoundUp:
+		awayFromZero = true
+	case RoundFloor:
+		awayFromZero = r.Sign() < 0
+	case RoundCeiling:
+		awayFromZero = r.Sign() > 0
+	default:
+		awayFromZero = cmp >= 0
+	}
+
+	if awayFromZero {
+		quo.Add(quo, big.NewInt(int64(r.Sign())))
+	}
+	return quo.Int64()
+}
+
+// ratFromFloat 将浮点数转换为有理数
+// 使用浮点数的最短十进制表示，使 0.1 被视为精确的 1/10
+func ratFromFloat(f float64) *big.Rat {
+	r, ok := new(big.Rat).SetString(strconv.FormatFloat(f, 'f', -1, 64))
+	if !ok {
+		return nil
+	}
+	return r
+}
+
+// ratFromInt 将整数转换为有理数
+func ratFromInt(i int64) *big.Rat {
+	return new(big.Rat).SetInt64(i)
+}
+
+// ratPow10 返回 10 的 n 次方
+func ratPow10(n int) *big.Rat {
+	return new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil))
+}
@@ -27,6 +27,8 @@ func (b *SimpleEventBus) Publish(ctx context.Context, event interface{}) error {
 	// 获取事件类型
 	var eventType string
 	switch e := event.(type) {
+	case Event:
+		eventType = e.EventName()
 	case interface{ GetType() string }:
 		eventType = e.GetType()
 	default:
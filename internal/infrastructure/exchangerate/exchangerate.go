@@ -0,0 +1,97 @@
+package exchangerate
+
+import (
+	"context"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+
+	"main/internal/domain/shared/service"
+	sharedvo "main/internal/domain/shared/valueobject"
+)
+
+const (
+	ProviderStatic = "static" // 配置文件中的静态汇率表
+	ProviderFile   = "file"   // 按日更新的汇率文件
+)
+
+// Config 汇率配置
+type Config struct {
+	Provider string             `json:"provider"` // 汇率提供者类型：static、file
+	Base     string             `json:"base"`     // 静态汇率表的基准货币
+	Rates    map[string]float64 `json:"rates"`    // 静态汇率表：1 单位基准货币可兑换的各货币数量
+	Dir      string             `json:"dir"`      // 每日汇率文件所在目录
+}
+
+// NewProviderFromConfig 根据配置文件中的 exchangeRate 节点创建汇率提供者
+func NewProviderFromConfig(ctx context.Context) (service.ExchangeRateProvider, error) {
+	var cfg Config
+	if err := g.Cfg().MustGet(ctx, "exchangeRate").Scan(&cfg); err != nil {
+		return nil, gerror.Wrap(err, "failed to read exchange rate config")
+	}
+	return NewProvider(cfg)
+}
+
+// NewProvider 根据配置创建汇率提供者
+func NewProvider(cfg Config) (service.ExchangeRateProvider, error) {
+	switch cfg.Provider {
+	case ProviderStatic, "":
+		return NewStaticProvider(cfg.Base, cfg.Rates, time.Now())
+	case ProviderFile:
+		return NewFileProvider(cfg.Dir), nil
+	default:
+		return nil, gerror.Newf("unknown exchange rate provider: %s", cfg.Provider)
+	}
+}
+
+// rateTable 以基准货币表示的汇率表
+// 任意两种货币之间的汇率通过基准货币交叉计算得到
+type rateTable struct {
+	base  string
+	rates map[string]*sharedvo.ExchangeRate // 基准货币 -> 各货币的汇率
+}
+
+// newRateTable 创建汇率表
+func newRateTable(base string, rates map[string]float64, timestamp time.Time) (*rateTable, error) {
+	table := &rateTable{
+		base:  base,
+		rates: make(map[string]*sharedvo.ExchangeRate, len(rates)),
+	}
+	for code, value := range rates {
+		rate, err := sharedvo.NewExchangeRate(base, code, value, timestamp)
+		if err != nil {
+			return nil, gerror.Wrapf(err, "invalid rate for %s", code)
+		}
+		table.rates[code] = rate
+	}
+	return table, nil
+}
+
+// rate 计算两种货币之间的汇率
+func (t *rateTable) rate(from, to string) (*sharedvo.ExchangeRate, error) {
+	if from == t.base {
+		return t.lookup(to)
+	}
+	fromRate, err := t.lookup(from)
+	if err != nil {
+		return nil, err
+	}
+	if to == t.base {
+		return fromRate.Inverse(), nil
+	}
+	toRate, err := t.lookup(to)
+	if err != nil {
+		return nil, err
+	}
+	return fromRate.Inverse().Cross(toRate)
+}
+
+// lookup 查找基准货币到指定货币的汇率
+func (t *rateTable) lookup(code string) (*sharedvo.ExchangeRate, error) {
+	rate, ok := t.rates[code]
+	if !ok {
+		return nil, gerror.Wrapf(sharedvo.ErrExchangeRateNotFound, "no rate from %s to %s", t.base, code)
+	}
+	return rate, nil
+}
@@ -0,0 +1,114 @@
+package exchangerate
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	sharedvo "main/internal/domain/shared/valueobject"
+)
+
+// dateLayout 每日汇率文件名中的日期格式
+const dateLayout = "2006-01-02"
+
+// dailyRatesFile 每日汇率文件内容
+// 文件名为生效日期，如 2024-06-01.json：
+//
+//	{"base": "CNY", "rates": {"USD": 0.1380, "EUR": 0.1272}}
+type dailyRatesFile struct {
+	Base  string             `json:"base"`
+	Rates map[string]float64 `json:"rates"`
+}
+
+// FileProvider 基于文件的每日汇率提供者
+// 查询时使用不晚于查询日期的最近一份汇率文件，已加载的文件会被缓存
+type FileProvider struct {
+	dir    string
+	mu     sync.Mutex
+	tables map[string]*rateTable // 日期 -> 汇率表
+}
+
+// NewFileProvider 创建基于文件的每日汇率提供者
+func NewFileProvider(dir string) *FileProvider {
+	return &FileProvider{
+		dir:    dir,
+		tables: make(map[string]*rateTable),
+	}
+}
+
+// Rate 获取在指定时间生效的汇率
+func (p *FileProvider) Rate(ctx context.Context, from, to string, at time.Time) (*sharedvo.ExchangeRate, error) {
+	date, err := p.effectiveDate(at)
+	if err != nil {
+		return nil, err
+	}
+	table, err := p.load(date)
+	if err != nil {
+		return nil, err
+	}
+	return table.rate(from, to)
+}
+
+// effectiveDate 查找不晚于指定时间的最近一份汇率文件的日期
+func (p *FileProvider) effectiveDate(at time.Time) (string, error) {
+	entries, err := os.ReadDir(p.dir)
+	if err != nil {
+		return "", gerror.Wrapf(err, "failed to read exchange rate directory %s", p.dir)
+	}
+
+	target := at.Format(dateLayout)
+	dates := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || filepath.Ext(name) != ".json" {
+			continue
+		}
+		date := strings.TrimSuffix(name, ".json")
+		if _, err = time.Parse(dateLayout, date); err != nil {
+			continue
+		}
+		if date <= target {
+			dates = append(dates, date)
+		}
+	}
+	if len(dates) == 0 {
+		return "", gerror.Wrapf(sharedvo.ErrExchangeRateNotFound, "no exchange rate file on or before %s", target)
+	}
+
+	sort.Strings(dates)
+	return dates[len(dates)-1], nil
+}
+
+// load 加载指定日期的汇率文件
+func (p *FileProvider) load(date string) (*rateTable, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if table, ok := p.tables[date]; ok {
+		return table, nil
+	}
+
+	content, err := os.ReadFile(filepath.Join(p.dir, date+".json"))
+	if err != nil {
+		return nil, gerror.Wrapf(err, "failed to read exchange rate file of %s", date)
+	}
+	var file dailyRatesFile
+	if err = json.Unmarshal(content, &file); err != nil {
+		return nil, gerror.Wrapf(err, "failed to parse exchange rate file of %s", date)
+	}
+
+	timestamp, _ := time.Parse(dateLayout, date)
+	table, err := newRateTable(file.Base, file.Rates, timestamp)
+	if err != nil {
+		return nil, gerror.Wrapf(err, "invalid exchange rate file of %s", date)
+	}
+	p.tables[date] = table
+	return table, nil
+}
@@ -0,0 +1,31 @@
+package exchangerate
+
+import (
+	"context"
+	"time"
+
+	sharedvo "main/internal/domain/shared/valueobject"
+)
+
+// StaticProvider 静态汇率提供者
+// 汇率来自配置，不随时间变化，适用于开发环境或汇率固定的场景
+type StaticProvider struct {
+	table *rateTable
+}
+
+// NewStaticProvider 创建静态汇率提供者
+// rates 表示 1 单位基准货币可兑换的各货币数量，updatedAt 记录为汇率的生效时间
+func NewStaticProvider(base string, rates map[string]float64, updatedAt time.Time) (*StaticProvider, error) {
+	table, err := newRateTable(base, rates, updatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &StaticProvider{
+		table: table,
+	}, nil
+}
+
+// Rate 获取汇率，静态汇率忽略查询时间
+func (p *StaticProvider) Rate(ctx context.Context, from, to string, at time.Time) (*sharedvo.ExchangeRate, error) {
+	return p.table.rate(from, to)
+}
@@ -0,0 +1,46 @@
+package idgen
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+
+	"main/internal/domain/shared/service"
+)
+
+const (
+	GeneratorULID      = "ulid"      // 26 位 ULID，按时间有序，无需协调
+	GeneratorSnowflake = "snowflake" // 64 位整数，按时间有序，各节点须配置不同的节点号
+)
+
+// Config Id 生成器配置
+type Config struct {
+	Generator string `json:"generator"` // 生成器类型：ulid、snowflake
+	Node      int64  `json:"node"`      // Snowflake 节点号，取值 0 到 1023
+	// CatalogNode catalog 命令行工具使用的 Snowflake 节点号，须与所有服务实例的 Node 不同，
+	// 否则命令行工具与服务在同一毫秒内可能生成相同的 Id
+	CatalogNode int64 `json:"catalogNode"`
+}
+
+// LoadConfig 读取配置文件中的 idGenerator 节点
+func LoadConfig(ctx context.Context) (Config, error) {
+	var cfg Config
+	if err := g.Cfg().MustGet(ctx, "idGenerator").Scan(&cfg); err != nil {
+		return cfg, gerror.Wrap(err, "failed to read id generator config")
+	}
+	return cfg, nil
+}
+
+// NewIdGenerator 根据配置创建 Id 生成器
+// 同一进程内应共享一个生成器实例，Snowflake 依靠实例内的序列号避免同一毫秒内的重复
+func NewIdGenerator(cfg Config) (service.IdGenerator, error) {
+	switch cfg.Generator {
+	case GeneratorULID, "":
+		return NewULIDGenerator(), nil
+	case GeneratorSnowflake:
+		return NewSnowflakeGenerator(cfg.Node)
+	default:
+		return nil, gerror.Newf("unknown id generator: %s", cfg.Generator)
+	}
+}
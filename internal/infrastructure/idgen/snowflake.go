@@ -0,0 +1,63 @@
+package idgen
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// Snowflake Id 的组成：41 位自纪元起的毫秒数、10 位节点号、12 位序列号
+const (
+	snowflakeNodeBits     = 10
+	snowflakeSequenceBits = 12
+	snowflakeMaxNode      = 1<<snowflakeNodeBits - 1
+	snowflakeMaxSequence  = 1<<snowflakeSequenceBits - 1
+)
+
+// snowflakeEpoch Snowflake 时间戳的纪元（2024-01-01 00:00:00 UTC，毫秒）
+const snowflakeEpoch int64 = 1704067200000
+
+// SnowflakeGenerator Snowflake 生成器
+// 生成的 Id 为十进制的 64 位正整数，同一节点内按时间递增；
+// 多个进程同时运行时每个进程须配置不同的节点号，否则可能生成重复的 Id
+type SnowflakeGenerator struct {
+	mu       sync.Mutex
+	node     int64
+	lastTime int64
+	sequence int64
+}
+
+// NewSnowflakeGenerator 创建 Snowflake 生成器，节点号取值 0 到 1023
+func NewSnowflakeGenerator(node int64) (*SnowflakeGenerator, error) {
+	if node < 0 || node > snowflakeMaxNode {
+		return nil, gerror.Newf("snowflake node must be between 0 and %d: %d", snowflakeMaxNode, node)
+	}
+	return &SnowflakeGenerator{node: node}, nil
+}
+
+// NewId 生成新的 Snowflake Id
+// 系统时钟回拨时沿用上一个毫秒继续分配序列号，同一毫秒内序列号用尽时借用下一个毫秒，
+// 均不等待时钟，持有锁的时间不受回拨幅度影响；时钟追上后恢复使用系统时间
+func (g *SnowflakeGenerator) NewId() string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := time.Now().UnixMilli() - snowflakeEpoch
+	if now < g.lastTime {
+		now = g.lastTime
+	}
+	if now == g.lastTime {
+		g.sequence = (g.sequence + 1) & snowflakeMaxSequence
+		if g.sequence == 0 {
+			now++
+		}
+	} else {
+		g.sequence = 0
+	}
+	g.lastTime = now
+
+	id := now<<(snowflakeNodeBits+snowflakeSequenceBits) | g.node<<snowflakeSequenceBits | g.sequence
+	return strconv.FormatInt(id, 10)
+}
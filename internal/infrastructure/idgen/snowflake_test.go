@@ -0,0 +1,102 @@
+package idgen
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+// parseSnowflake 解析 Snowflake Id 的时间戳、节点号和序列号
+func parseSnowflake(t *testing.T, id string) (timestamp, node, sequence int64) {
+	t.Helper()
+	v, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		t.Fatalf("invalid snowflake id %q: %v", id, err)
+	}
+	return v >> (snowflakeNodeBits + snowflakeSequenceBits),
+		v >> snowflakeSequenceBits & snowflakeMaxNode,
+		v & snowflakeMaxSequence
+}
+
+func TestNewSnowflakeGenerator(t *testing.T) {
+	tests := []struct {
+		name    string
+		node    int64
+		wantErr bool
+	}{
+		{"min node", 0, false},
+		{"max node", snowflakeMaxNode, false},
+		{"negative node", -1, true},
+		{"node too large", snowflakeMaxNode + 1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewSnowflakeGenerator(tt.node); (err != nil) != tt.wantErr {
+				t.Errorf("NewSnowflakeGenerator() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSnowflakeGeneratorNewId(t *testing.T) {
+	tests := []struct {
+		name         string
+		lastOffset   int64 // 上次生成 Id 的毫秒相对当前时钟的偏移
+		sequence     int64 // 上次生成 Id 的序列号
+		wantOffset   int64 // 生成的时间戳相对上次毫秒的偏移，wantLater 为真时不检查
+		wantLater    bool  // 生成的时间戳须晚于上次的毫秒
+		wantSequence int64
+	}{
+		{"clock moves forward", -1000, 7, 0, true, 0},
+		{"clock rollback stays on last millisecond", 1000, 7, 0, false, 8},
+		{"sequence rollover borrows next millisecond", 1000, snowflakeMaxSequence, 1, false, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := NewSnowflakeGenerator(3)
+			if err != nil {
+				t.Fatal(err)
+			}
+			last := time.Now().UnixMilli() - snowflakeEpoch + tt.lastOffset
+			g.lastTime, g.sequence = last, tt.sequence
+
+			start := time.Now()
+			timestamp, node, sequence := parseSnowflake(t, g.NewId())
+			if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+				t.Errorf("NewId() took %v, want no waiting", elapsed)
+			}
+			if tt.wantLater && timestamp <= last || !tt.wantLater && timestamp != last+tt.wantOffset {
+				t.Errorf("timestamp = %d, last = %d", timestamp, last)
+			}
+			if node != 3 {
+				t.Errorf("node = %d, want 3", node)
+			}
+			if sequence != tt.wantSequence {
+				t.Errorf("sequence = %d, want %d", sequence, tt.wantSequence)
+			}
+		})
+	}
+}
+
+func TestSnowflakeGeneratorIncreasing(t *testing.T) {
+	g, err := NewSnowflakeGenerator(snowflakeMaxNode)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 生成的数量超过单个毫秒的序列号容量，覆盖序列号用尽的情况
+	var last int64
+	for i := 0; i < 3*(snowflakeMaxSequence+1); i++ {
+		id := g.NewId()
+		v, err := strconv.ParseInt(id, 10, 64)
+		if err != nil {
+			t.Fatalf("invalid snowflake id %q: %v", id, err)
+		}
+		if v <= last {
+			t.Fatalf("id %d is not greater than previous id %d", v, last)
+		}
+		if _, node, _ := parseSnowflake(t, id); node != snowflakeMaxNode {
+			t.Fatalf("node = %d, want %d", node, snowflakeMaxNode)
+		}
+		last = v
+	}
+}
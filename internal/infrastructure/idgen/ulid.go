@@ -0,0 +1,75 @@
+package idgen
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"sync"
+	"time"
+)
+
+// crockford ULID 使用的 Crockford Base32 字母表
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// ULIDGenerator ULID 生成器
+// ULID 由 48 位毫秒时间戳和 80 位随机数组成，编码为 26 个字符，按字典序即按时间排序。
+// 同一毫秒内生成的 ULID 在上一个的随机数上加一，保证单个实例生成的 Id 严格递增
+type ULIDGenerator struct {
+	mu       sync.Mutex
+	lastTime uint64
+	entropy  [10]byte // 80 位随机数，大端序
+}
+
+// NewULIDGenerator 创建 ULID 生成器
+func NewULIDGenerator() *ULIDGenerator {
+	return &ULIDGenerator{}
+}
+
+// NewId 生成新的 ULID
+func (g *ULIDGenerator) NewId() string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := uint64(time.Now().UnixMilli())
+	if now > g.lastTime || !g.increment() {
+		// 新的毫秒重新取随机数；时钟回拨期间沿用上一个毫秒，随机数用尽时借用下一个毫秒，不等待时钟
+		if now <= g.lastTime {
+			now = g.lastTime + 1
+		}
+		if _, err := rand.Read(g.entropy[:]); err != nil {
+			panic("idgen: failed to read random bytes: " + err.Error())
+		}
+		g.lastTime = now
+	}
+	return g.encode()
+}
+
+// increment 将随机数加一，溢出时返回 false
+func (g *ULIDGenerator) increment() bool {
+	for i := len(g.entropy) - 1; i >= 0; i-- {
+		g.entropy[i]++
+		if g.entropy[i] != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// encode 将时间戳和随机数编码为 26 个字符
+func (g *ULIDGenerator) encode() string {
+	// 128 位数据：高 48 位为时间戳，低 80 位为随机数
+	var data [16]byte
+	binary.BigEndian.PutUint16(data[0:2], uint16(g.lastTime>>32))
+	binary.BigEndian.PutUint32(data[2:6], uint32(g.lastTime))
+	copy(data[6:], g.entropy[:])
+
+	// 26 个字符共 130 位，最高 2 位补 0，每 5 位一个字符
+	hi := binary.BigEndian.Uint64(data[0:8])
+	lo := binary.BigEndian.Uint64(data[8:16])
+	var out [26]byte
+	for i := 25; i >= 0; i-- {
+		out[i] = crockford[lo&0x1f]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(out[:])
+}
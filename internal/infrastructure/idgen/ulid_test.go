@@ -0,0 +1,93 @@
+package idgen
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// parseULIDTime 解析 ULID 的毫秒时间戳，即前 10 个字符
+func parseULIDTime(t *testing.T, id string) uint64 {
+	t.Helper()
+	if len(id) != 26 {
+		t.Fatalf("ulid %q has length %d, want 26", id, len(id))
+	}
+	var ms uint64
+	for _, c := range id[:10] {
+		i := strings.IndexRune(crockford, c)
+		if i < 0 {
+			t.Fatalf("ulid %q contains invalid character %q", id, c)
+		}
+		ms = ms<<5 | uint64(i)
+	}
+	return ms
+}
+
+func TestULIDGeneratorNewId(t *testing.T) {
+	tests := []struct {
+		name string
+		// prepare 在生成 Id 前调整生成器的状态
+		prepare func(g *ULIDGenerator)
+		// wantSameTime 为 true 时 Id 沿用上一个 Id 的时间戳，否则必须晚于它
+		wantSameTime bool
+	}{
+		{
+			name: "same millisecond increments entropy",
+			prepare: func(g *ULIDGenerator) {
+				g.lastTime = uint64(time.Now().UnixMilli()) + 50
+				g.entropy = [10]byte{9: 0x10}
+			},
+			wantSameTime: true,
+		},
+		{
+			name: "entropy carry propagates",
+			prepare: func(g *ULIDGenerator) {
+				g.lastTime = uint64(time.Now().UnixMilli()) + 50
+				g.entropy = [10]byte{0x01, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+			},
+			wantSameTime: true,
+		},
+		{
+			name: "entropy overflow waits for next millisecond",
+			prepare: func(g *ULIDGenerator) {
+				g.lastTime = uint64(time.Now().UnixMilli()) + 5
+				g.entropy = [10]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewULIDGenerator()
+			tt.prepare(g)
+			previous, lastTime := g.encode(), g.lastTime
+
+			id := g.NewId()
+			if id <= previous {
+				t.Errorf("id %s is not greater than previous id %s", id, previous)
+			}
+			ms := parseULIDTime(t, id)
+			if tt.wantSameTime && ms != lastTime {
+				t.Errorf("timestamp = %d, want %d", ms, lastTime)
+			}
+			if !tt.wantSameTime && ms <= lastTime {
+				t.Errorf("timestamp = %d, want after %d", ms, lastTime)
+			}
+		})
+	}
+}
+
+func TestULIDGeneratorIncreasing(t *testing.T) {
+	g := NewULIDGenerator()
+	start := uint64(time.Now().UnixMilli())
+	var last string
+	for i := 0; i < 10000; i++ {
+		id := g.NewId()
+		if id <= last {
+			t.Fatalf("id %s is not greater than previous id %s", id, last)
+		}
+		if ms := parseULIDTime(t, id); ms < start {
+			t.Fatalf("timestamp = %d, want not before %d", ms, start)
+		}
+		last = id
+	}
+}
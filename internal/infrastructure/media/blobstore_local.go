@@ -0,0 +1,115 @@
+package media
+
+import (
+	"context"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	sharedvo "main/internal/domain/shared/valueobject"
+)
+
+// LocalBlobStore 基于本地文件系统的对象存储
+// 对象键映射为根目录下的相对路径，文件先写入临时文件再重命名，避免读到写了一半的文件
+type LocalBlobStore struct {
+	root    string
+	baseURL string
+}
+
+// NewLocalBlobStore 创建本地对象存储，根目录不存在时自动创建
+func NewLocalBlobStore(root string, baseURL string) (*LocalBlobStore, error) {
+	if root == "" {
+		return nil, gerror.New("media root directory is required")
+	}
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, gerror.Wrapf(err, "failed to create media root directory %s", root)
+	}
+	return &LocalBlobStore{
+		root:    root,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}, nil
+}
+
+// Put 写入对象
+func (s *LocalBlobStore) Put(ctx context.Context, key string, data io.Reader, contentType string) error {
+	filename, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(filename)
+	if err = os.MkdirAll(dir, 0o755); err != nil {
+		return gerror.Wrapf(err, "failed to create directory for %s", key)
+	}
+
+	tmp, err := os.CreateTemp(dir, ".upload-*")
+	if err != nil {
+		return gerror.Wrapf(err, "failed to create temp file for %s", key)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = io.Copy(tmp, data); err != nil {
+		tmp.Close()
+		return gerror.Wrapf(err, "failed to write %s", key)
+	}
+	if err = tmp.Close(); err != nil {
+		return gerror.Wrapf(err, "failed to write %s", key)
+	}
+	if err = os.Rename(tmp.Name(), filename); err != nil {
+		return gerror.Wrapf(err, "failed to write %s", key)
+	}
+	return nil
+}
+
+// Get 读取对象
+func (s *LocalBlobStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	filename, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, gerror.Wrapf(sharedvo.ErrBlobNotFound, "blob %s not found", key)
+		}
+		return nil, gerror.Wrapf(err, "failed to read %s", key)
+	}
+	return file, nil
+}
+
+// Delete 删除对象，并清理因此变空的目录
+func (s *LocalBlobStore) Delete(ctx context.Context, key string) error {
+	filename, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err = os.Remove(filename); err != nil && !os.IsNotExist(err) {
+		return gerror.Wrapf(err, "failed to delete %s", key)
+	}
+
+	// 逐级删除空的父目录，直到根目录或非空目录为止
+	root := filepath.Clean(s.root)
+	for dir := filepath.Dir(filename); dir != root && strings.HasPrefix(dir, root); dir = filepath.Dir(dir) {
+		if os.Remove(dir) != nil {
+			break
+		}
+	}
+	return nil
+}
+
+// URL 获取对象的访问地址
+func (s *LocalBlobStore) URL(key string) string {
+	return s.baseURL + "/" + strings.TrimPrefix(path.Clean("/"+key), "/")
+}
+
+// path 将对象键转换为本地文件路径，拒绝越出根目录的键
+func (s *LocalBlobStore) path(key string) (string, error) {
+	cleaned := path.Clean("/" + key)
+	if key == "" || cleaned == "/" || cleaned != "/"+key {
+		return "", gerror.Wrapf(sharedvo.ErrInvalidBlobKey, "invalid blob key: %q", key)
+	}
+	return filepath.Join(s.root, filepath.FromSlash(cleaned)), nil
+}
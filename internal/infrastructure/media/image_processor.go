@@ -0,0 +1,165 @@
+package media
+
+import (
+	"bytes"
+	"image"
+	"image/draw"
+	_ "image/gif" // 注册 GIF 解码器
+	"image/jpeg"
+	"image/png"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+
+	"main/internal/domain/product/service"
+	"main/internal/domain/product/valueobject"
+)
+
+// jpegQuality 缩略图的 JPEG 压缩质量
+const jpegQuality = 85
+
+// imageFormats 图片解码器识别的格式名称对应的 MIME 类型
+var imageFormats = map[string]string{
+	"jpeg": "image/jpeg",
+	"png":  "image/png",
+	"gif":  "image/gif",
+}
+
+// ImageProcessor 基于标准库的图片处理器
+// JPEG 图片的缩略图编码为 JPEG，PNG 和 GIF 图片的缩略图编码为 PNG 以保留透明度
+type ImageProcessor struct{}
+
+// NewImageProcessor 创建图片处理器
+func NewImageProcessor() *ImageProcessor {
+	return &ImageProcessor{}
+}
+
+// Decode 识别图片格式和尺寸
+func (p *ImageProcessor) Decode(data []byte) (*service.Image, error) {
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, gerror.Wrap(valueobject.ErrInvalidImage, "unrecognized image format")
+	}
+	contentType, ok := imageFormats[format]
+	if !ok {
+		return nil, gerror.Wrapf(valueobject.ErrInvalidImage, "unsupported image format: %s", format)
+	}
+	if config.Width <= 0 || config.Height <= 0 {
+		return nil, gerror.Wrap(valueobject.ErrInvalidImage, "image has no pixels")
+	}
+	if int64(config.Width)*int64(config.Height) > valueobject.MaxImagePixels {
+		return nil, gerror.Wrapf(valueobject.ErrInvalidImage,
+			"image of %dx%d pixels exceeds the limit of %d pixels", config.Width, config.Height, valueobject.MaxImagePixels,
+		)
+	}
+	return &service.Image{
+		Data:        data,
+		ContentType: contentType,
+		Width:       config.Width,
+		Height:      config.Height,
+	}, nil
+}
+
+// Thumbnails 生成各尺寸的缩略图，结果与 sizes 一一对应
+// 原图只解码一次，各尺寸的缩略图均由同一份像素数据缩放得到
+func (p *ImageProcessor) Thumbnails(img *service.Image, sizes []valueobject.ImageSize) ([]*service.Image, error) {
+	if int64(img.Width)*int64(img.Height) > valueobject.MaxImagePixels {
+		return nil, gerror.Wrapf(valueobject.ErrInvalidImage, "image of %dx%d pixels is too large", img.Width, img.Height)
+	}
+	decoded, _, err := image.Decode(bytes.NewReader(img.Data))
+	if err != nil {
+		return nil, gerror.Wrap(valueobject.ErrInvalidImage, err.Error())
+	}
+	src := toNRGBA(decoded)
+
+	contentType := "image/png"
+	if img.ContentType == "image/jpeg" {
+		contentType = "image/jpeg"
+	}
+	thumbnails := make([]*service.Image, len(sizes))
+	for i, size := range sizes {
+		width, height := fit(src.Bounds().Dx(), src.Bounds().Dy(), size.MaxWidth, size.MaxHeight)
+		dst := resize(src, width, height)
+
+		var buf bytes.Buffer
+		if contentType == "image/jpeg" {
+			err = jpeg.Encode(&buf, dst, &jpeg.Options{Quality: jpegQuality})
+		} else {
+			err = png.Encode(&buf, dst)
+		}
+		if err != nil {
+			return nil, gerror.Wrapf(err, "failed to encode %s thumbnail", size.Name)
+		}
+		thumbnails[i] = &service.Image{
+			Data:        buf.Bytes(),
+			ContentType: contentType,
+			Width:       width,
+			Height:      height,
+		}
+	}
+	return thumbnails, nil
+}
+
+// fit 计算按比例缩放到不超过最大宽高后的尺寸，不放大
+func fit(width, height, maxWidth, maxHeight int) (int, int) {
+	if width <= maxWidth && height <= maxHeight {
+		return width, height
+	}
+	// 按缩放比例较小的一边计算
+	if width*maxHeight > height*maxWidth {
+		return maxWidth, max(1, height*maxWidth/width)
+	}
+	return max(1, width*maxHeight/height), maxHeight
+}
+
+// toNRGBA 将图片统一转换为原点在 (0, 0) 的 NRGBA 以便直接访问像素
+func toNRGBA(src image.Image) *image.NRGBA {
+	if nrgba, ok := src.(*image.NRGBA); ok && nrgba.Bounds().Min == (image.Point{}) {
+		return nrgba
+	}
+	bounds := src.Bounds()
+	nrgba := image.NewNRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(nrgba, nrgba.Bounds(), src, bounds.Min, draw.Src)
+	return nrgba
+}
+
+// resize 使用区域平均法缩放图片
+// 目标图片的每个像素取源图片中对应区域所有像素的平均值，缩小时能有效避免锯齿
+func resize(nrgba *image.NRGBA, width, height int) *image.NRGBA {
+	srcWidth, srcHeight := nrgba.Bounds().Dx(), nrgba.Bounds().Dy()
+	if width == srcWidth && height == srcHeight {
+		return nrgba
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0, y1 := y*srcHeight/height, max((y+1)*srcHeight/height, y*srcHeight/height+1)
+		for x := 0; x < width; x++ {
+			x0, x1 := x*srcWidth/width, max((x+1)*srcWidth/width, x*srcWidth/width+1)
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				offset := nrgba.PixOffset(x0, sy)
+				for sx := x0; sx < x1; sx++ {
+					pixel := nrgba.Pix[offset : offset+4]
+					// 按透明度加权，避免透明像素的颜色混入
+					alpha := uint64(pixel[3])
+					r += uint64(pixel[0]) * alpha
+					g += uint64(pixel[1]) * alpha
+					b += uint64(pixel[2]) * alpha
+					a += alpha
+					n++
+					offset += 4
+				}
+			}
+
+			i := dst.PixOffset(x, y)
+			if a > 0 {
+				dst.Pix[i] = uint8(r / a)
+				dst.Pix[i+1] = uint8(g / a)
+				dst.Pix[i+2] = uint8(b / a)
+			}
+			dst.Pix[i+3] = uint8(a / n)
+		}
+	}
+	return dst
+}
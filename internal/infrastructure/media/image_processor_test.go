@@ -0,0 +1,117 @@
+package media
+
+import (
+	"image"
+	"image/color"
+	"reflect"
+	"testing"
+)
+
+func TestFit(t *testing.T) {
+	tests := []struct {
+		name                               string
+		width, height, maxWidth, maxHeight int
+		wantWidth, wantHeight              int
+	}{
+		{"smaller image is not enlarged", 100, 50, 200, 200, 100, 50},
+		{"exact size", 200, 200, 200, 200, 200, 200},
+		{"landscape limited by width", 400, 200, 200, 200, 200, 100},
+		{"portrait limited by height", 200, 400, 200, 200, 100, 200},
+		{"wide box limited by height", 400, 400, 300, 100, 100, 100},
+		{"rounds down", 1000, 333, 100, 100, 100, 33},
+		{"never shrinks to zero", 10000, 1, 100, 100, 100, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			width, height := fit(tt.width, tt.height, tt.maxWidth, tt.maxHeight)
+			if width != tt.wantWidth || height != tt.wantHeight {
+				t.Errorf("fit() = %dx%d, want %dx%d", width, height, tt.wantWidth, tt.wantHeight)
+			}
+		})
+	}
+}
+
+// newTestImage 按行优先的像素构造图片
+func newTestImage(width, height int, pixels ...color.NRGBA) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for i, pixel := range pixels {
+		img.SetNRGBA(i%width, i/width, pixel)
+	}
+	return img
+}
+
+// opaque 构造不透明的灰度像素
+func opaque(v uint8) color.NRGBA {
+	return color.NRGBA{R: v, G: v, B: v, A: 0xff}
+}
+
+func TestResize(t *testing.T) {
+	tests := []struct {
+		name          string
+		src           *image.NRGBA
+		width, height int
+		want          *image.NRGBA
+	}{
+		{
+			name:  "same size is unchanged",
+			src:   newTestImage(2, 1, opaque(10), opaque(20)),
+			width: 2, height: 1,
+			want: newTestImage(2, 1, opaque(10), opaque(20)),
+		},
+		{
+			name:  "averages the covered area",
+			src:   newTestImage(2, 2, opaque(0), opaque(100), opaque(200), opaque(100)),
+			width: 1, height: 1,
+			want: newTestImage(1, 1, opaque(100)),
+		},
+		{
+			name:  "halves each dimension",
+			src:   newTestImage(4, 1, opaque(10), opaque(20), opaque(30), opaque(40)),
+			width: 2, height: 1,
+			want: newTestImage(2, 1, opaque(15), opaque(35)),
+		},
+		{
+			name:  "uneven areas",
+			src:   newTestImage(3, 1, opaque(10), opaque(20), opaque(40)),
+			width: 2, height: 1,
+			want: newTestImage(2, 1, opaque(10), opaque(30)),
+		},
+		{
+			name:  "transparent pixels do not tint the color",
+			src:   newTestImage(2, 1, color.NRGBA{R: 0xff, A: 0xff}, color.NRGBA{G: 0xff}),
+			width: 1, height: 1,
+			want: newTestImage(1, 1, color.NRGBA{R: 0xff, A: 0x7f}),
+		},
+		{
+			name:  "fully transparent area",
+			src:   newTestImage(2, 1, color.NRGBA{R: 0xff}, color.NRGBA{G: 0xff}),
+			width: 1, height: 1,
+			want: newTestImage(1, 1, color.NRGBA{}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := resize(tt.src, tt.width, tt.height)
+			if got.Bounds() != tt.want.Bounds() {
+				t.Fatalf("resize() bounds = %v, want %v", got.Bounds(), tt.want.Bounds())
+			}
+			if !reflect.DeepEqual(got.Pix, tt.want.Pix) {
+				t.Errorf("resize() pixels = %v, want %v", got.Pix, tt.want.Pix)
+			}
+		})
+	}
+}
+
+func TestToNRGBAMovesOrigin(t *testing.T) {
+	src := image.NewNRGBA(image.Rect(5, 5, 7, 6))
+	src.SetNRGBA(5, 5, opaque(10))
+	src.SetNRGBA(6, 5, opaque(20))
+
+	got := toNRGBA(src)
+	if got.Bounds() != image.Rect(0, 0, 2, 1) {
+		t.Fatalf("toNRGBA() bounds = %v, want %v", got.Bounds(), image.Rect(0, 0, 2, 1))
+	}
+	if got.NRGBAAt(0, 0) != opaque(10) || got.NRGBAAt(1, 0) != opaque(20) {
+		t.Errorf("toNRGBA() pixels = %v", got.Pix)
+	}
+}
@@ -0,0 +1,40 @@
+package media
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+
+	"main/internal/domain/shared/service"
+)
+
+const (
+	DriverLocal = "local" // 本地文件系统
+)
+
+// Config 媒体存储配置
+type Config struct {
+	Driver  string `json:"driver"`  // 存储类型：local
+	Root    string `json:"root"`    // 本地存储的根目录
+	BaseURL string `json:"baseUrl"` // 文件访问地址前缀
+}
+
+// LoadConfig 读取配置文件中的 media 节点
+func LoadConfig(ctx context.Context) (Config, error) {
+	var cfg Config
+	if err := g.Cfg().MustGet(ctx, "media").Scan(&cfg); err != nil {
+		return cfg, gerror.Wrap(err, "failed to read media config")
+	}
+	return cfg, nil
+}
+
+// NewBlobStore 根据配置创建对象存储
+func NewBlobStore(cfg Config) (service.BlobStore, error) {
+	switch cfg.Driver {
+	case DriverLocal, "":
+		return NewLocalBlobStore(cfg.Root, cfg.BaseURL)
+	default:
+		return nil, gerror.Newf("unknown media storage driver: %s", cfg.Driver)
+	}
+}
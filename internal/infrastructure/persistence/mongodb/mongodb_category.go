@@ -0,0 +1,141 @@
+package mongodb
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"main/internal/domain/category/entity"
+	"main/internal/domain/category/repository"
+	"main/internal/domain/category/valueobject"
+)
+
+// CategoryPO 类目持久化对象
+type CategoryPO struct {
+	Id        string `bson:"_id"`
+	Name      string `bson:"name"`
+	ParentId  string `bson:"parent_id"`
+	Path      string `bson:"path"`
+	SortOrder int    `bson:"sort_order"`
+	CreatedAt int64  `bson:"created_at"`
+	UpdatedAt int64  `bson:"updated_at"`
+}
+
+// impCategoryRepository MongoDB类目持久化实现
+type impCategoryRepository struct {
+	mongoDb            *mongo.Database
+	categoryCollection *mongo.Collection
+}
+
+// NewCategoryRepository 创建MongoDB类目持久化实例
+func NewCategoryRepository(ctx context.Context, mongoDb *mongo.Database) (repository.CategoryRepository, error) {
+	return &impCategoryRepository{
+		mongoDb:            mongoDb,
+		categoryCollection: mongoDb.Collection("category"),
+	}, nil
+}
+
+// Save 保存类目
+func (imp *impCategoryRepository) Save(ctx context.Context, category *entity.Category) error {
+	po := imp.toCategoryPO(category)
+
+	opts := options.Update().SetUpsert(true)
+	_, err := imp.categoryCollection.UpdateOne(
+		ctx,
+		bson.M{"_id": po.Id},
+		bson.M{"$set": po},
+		opts,
+	)
+	return err
+}
+
+// FindById 根据Id查找类目
+func (imp *impCategoryRepository) FindById(ctx context.Context, id string) (*entity.Category, error) {
+	var po CategoryPO
+	err := imp.categoryCollection.FindOne(ctx, bson.M{"_id": id}).Decode(&po)
+	if err != nil {
+		if gerror.Is(err, mongo.ErrNoDocuments) {
+			return nil, gerror.Wrapf(valueobject.ErrCategoryNotFound, "category %s", id)
+		}
+		return nil, err
+	}
+	return imp.toEntity(&po), nil
+}
+
+// FindAll 查找所有类目
+func (imp *impCategoryRepository) FindAll(ctx context.Context) ([]*entity.Category, error) {
+	return imp.find(ctx, bson.M{})
+}
+
+// FindByParentId 查找父类目下的直接子类目，父类目 Id 为空时查找根类目
+func (imp *impCategoryRepository) FindByParentId(ctx context.Context, parentId string) ([]*entity.Category, error) {
+	return imp.find(ctx, bson.M{"parent_id": parentId})
+}
+
+// FindByPathPrefix 查找路径以指定前缀开头的类目
+// 前缀匹配的正则以 ^ 开头，可以使用 path 字段上的索引
+func (imp *impCategoryRepository) FindByPathPrefix(ctx context.Context, prefix string) ([]*entity.Category, error) {
+	return imp.find(ctx, bson.M{"path": bson.M{"$regex": "^" + regexp.QuoteMeta(prefix)}})
+}
+
+// Delete 删除类目
+func (imp *impCategoryRepository) Delete(ctx context.Context, id string) error {
+	result, err := imp.categoryCollection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return gerror.Wrapf(valueobject.ErrCategoryNotFound, "category %s", id)
+	}
+	return nil
+}
+
+// find 根据条件查找类目
+func (imp *impCategoryRepository) find(ctx context.Context, filter bson.M) ([]*entity.Category, error) {
+	cursor, err := imp.categoryCollection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var pos []CategoryPO
+	if err = cursor.All(ctx, &pos); err != nil {
+		return nil, err
+	}
+
+	categories := make([]*entity.Category, len(pos))
+	for i, po := range pos {
+		categories[i] = imp.toEntity(&po)
+	}
+	return categories, nil
+}
+
+// toCategoryPO 将领域实体转换为类目持久化对象
+func (imp *impCategoryRepository) toCategoryPO(category *entity.Category) *CategoryPO {
+	return &CategoryPO{
+		Id:        category.Id,
+		Name:      category.Name,
+		ParentId:  category.ParentId,
+		Path:      category.Path,
+		SortOrder: category.SortOrder,
+		CreatedAt: category.CreatedAt,
+		UpdatedAt: category.UpdatedAt,
+	}
+}
+
+// toEntity 将持久化对象转换为领域实体
+func (imp *impCategoryRepository) toEntity(po *CategoryPO) *entity.Category {
+	return &entity.Category{
+		Id:        po.Id,
+		Name:      po.Name,
+		ParentId:  po.ParentId,
+		Path:      po.Path,
+		SortOrder: po.SortOrder,
+		CreatedAt: po.CreatedAt,
+		UpdatedAt: po.UpdatedAt,
+	}
+}
@@ -0,0 +1,78 @@
+package mongodb
+
+import (
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+
+	sharedvo "main/internal/domain/shared/valueobject"
+)
+
+// tMoney Money值对象的反射类型
+var tMoney = reflect.TypeOf(sharedvo.Money{})
+
+// moneyDocument Money值对象在MongoDB中的存储结构
+// 历史数据只包含浮点数的 amount 字段，新数据同时写入以最小货币单位表示的 amount_minor 字段。
+// 读取时优先使用 amount_minor，缺失时回退到 amount 并按货币精度四舍五入，保证历史数据不丢失。
+type moneyDocument struct {
+	Amount      float64 `bson:"amount"`
+	AmountMinor *int64  `bson:"amount_minor,omitempty"`
+	Currency    string  `bson:"currency"`
+}
+
+// newRegistry 创建注册了领域值对象编解码器的BSON注册表
+func newRegistry() *bsoncodec.Registry {
+	registry := bson.NewRegistry()
+	registry.RegisterTypeEncoder(tMoney, bsoncodec.ValueEncoderFunc(encodeMoney))
+	registry.RegisterTypeDecoder(tMoney, bsoncodec.ValueDecoderFunc(decodeMoney))
+	return registry
+}
+
+// encodeMoney 将Money值对象编码为BSON文档
+func encodeMoney(ec bsoncodec.EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+	if !val.IsValid() || val.Type() != tMoney {
+		return bsoncodec.ValueEncoderError{Name: "encodeMoney", Types: []reflect.Type{tMoney}, Received: val}
+	}
+
+	money := val.Interface().(sharedvo.Money)
+	minorAmount := money.MinorAmount()
+	doc := moneyDocument{
+		Amount:      money.Amount(),
+		AmountMinor: &minorAmount,
+		Currency:    money.Currency(),
+	}
+
+	encoder, err := ec.LookupEncoder(reflect.TypeOf(doc))
+	if err != nil {
+		return err
+	}
+	return encoder.EncodeValue(ec, vw, reflect.ValueOf(doc))
+}
+
+// decodeMoney 将BSON文档解码为Money值对象
+func decodeMoney(dc bsoncodec.DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+	if !val.CanSet() || val.Type() != tMoney {
+		return bsoncodec.ValueDecoderError{Name: "decodeMoney", Types: []reflect.Type{tMoney}, Received: val}
+	}
+
+	var doc moneyDocument
+	decoder, err := dc.LookupDecoder(reflect.TypeOf(doc))
+	if err != nil {
+		return err
+	}
+	if err = decoder.DecodeValue(dc, vr, reflect.ValueOf(&doc).Elem()); err != nil {
+		return err
+	}
+
+	// 已保存的数据不再按货币注册表校验，避免历史数据中的空货币或未注册货币导致读取失败
+	var money *sharedvo.Money
+	if doc.AmountMinor != nil {
+		money = sharedvo.RestoreMoney(*doc.AmountMinor, doc.Currency)
+	} else {
+		money = sharedvo.RestoreMoneyFromAmount(doc.Amount, doc.Currency)
+	}
+	val.Set(reflect.ValueOf(*money))
+	return nil
+}
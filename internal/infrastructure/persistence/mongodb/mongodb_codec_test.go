@@ -0,0 +1,65 @@
+package mongodb
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	sharedvo "main/internal/domain/shared/valueobject"
+)
+
+func TestDecodeMoney(t *testing.T) {
+	tests := []struct {
+		name         string
+		doc          bson.M
+		wantMinor    int64
+		wantCurrency string
+	}{
+		{"minor amount", bson.M{"amount": 12.34, "amount_minor": int64(1234), "currency": "CNY"}, 1234, "CNY"},
+		{"minor amount takes precedence", bson.M{"amount": 99.0, "amount_minor": int64(1234), "currency": "CNY"}, 1234, "CNY"},
+		{"legacy float amount", bson.M{"amount": 12.345, "currency": "CNY"}, 1235, "CNY"},
+		{"legacy float amount in zero precision currency", bson.M{"amount": 12.5, "currency": "JPY"}, 13, "JPY"},
+		{"legacy empty currency", bson.M{"amount": 1.5, "currency": ""}, 150, sharedvo.DefaultCurrency},
+		{"legacy missing currency", bson.M{"amount": 1.5}, 150, sharedvo.DefaultCurrency},
+		{"unregistered currency is kept", bson.M{"amount_minor": int64(500), "currency": "XYZ"}, 500, "XYZ"},
+	}
+	registry := newRegistry()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := bson.Marshal(bson.M{"price": tt.doc})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var out struct {
+				Price sharedvo.Money `bson:"price"`
+			}
+			if err = bson.UnmarshalWithRegistry(registry, data, &out); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			if out.Price.MinorAmount() != tt.wantMinor || out.Price.Currency() != tt.wantCurrency {
+				t.Errorf("decoded %d %s, want %d %s", out.Price.MinorAmount(), out.Price.Currency(), tt.wantMinor, tt.wantCurrency)
+			}
+		})
+	}
+}
+
+func TestEncodeMoneyRoundTrip(t *testing.T) {
+	registry := newRegistry()
+	in := struct {
+		Price sharedvo.Money `bson:"price"`
+	}{Price: *sharedvo.MustNewMoney(12.34, "USD")}
+
+	data, err := bson.MarshalWithRegistry(registry, in)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var out struct {
+		Price sharedvo.Money `bson:"price"`
+	}
+	if err = bson.UnmarshalWithRegistry(registry, data, &out); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if !out.Price.Equals(&in.Price) {
+		t.Errorf("round trip = %v, want %v", out.Price.String(), in.Price.String())
+	}
+}
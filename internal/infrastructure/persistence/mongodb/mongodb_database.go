@@ -0,0 +1,20 @@
+package mongodb
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"main/utility/mongodb"
+)
+
+// NewDatabase 连接MongoDB并返回配置的数据库，客户端使用本包的BSON编解码注册表
+// 进程启动时创建一次并注入所有仓储，各仓储共用同一个客户端及其连接池
+func NewDatabase(ctx context.Context, cfg mongodb.Config) (*mongo.Database, error) {
+	client, err := mongodb.NewMongoClient(ctx, cfg, options.Client().SetRegistry(newRegistry()))
+	if err != nil {
+		return nil, err
+	}
+	return client.Database(cfg.Database), nil
+}
@@ -0,0 +1,293 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"main/internal/domain/flashsale/entity"
+	"main/internal/domain/flashsale/repository"
+	"main/internal/domain/flashsale/valueobject"
+	sharedvo "main/internal/domain/shared/valueobject"
+)
+
+// FlashSalePO 秒杀活动持久化对象
+type FlashSalePO struct {
+	Id        string            `bson:"_id"`
+	Name      string            `bson:"name"`
+	StartAt   int64             `bson:"start_at"`
+	EndAt     int64             `bson:"end_at"`
+	Status    string            `bson:"status"`
+	Items     []FlashSaleItemPO `bson:"items"`
+	CreatedAt int64             `bson:"created_at"`
+	UpdatedAt int64             `bson:"updated_at"`
+}
+
+// FlashSaleItemPO 秒杀活动商品持久化对象
+type FlashSaleItemPO struct {
+	ProductId    string          `bson:"product_id"`
+	SkuId        string          `bson:"sku_id"`
+	Price        *sharedvo.Money `bson:"price"`
+	Stock        int             `bson:"stock"`
+	Sold         int             `bson:"sold"`
+	PerUserLimit int             `bson:"per_user_limit"`
+}
+
+// impFlashSaleRepository MongoDB秒杀活动持久化实现
+type impFlashSaleRepository struct {
+	mongoDb             *mongo.Database
+	flashSaleCollection *mongo.Collection
+}
+
+// NewFlashSaleRepository 创建MongoDB秒杀活动持久化实例
+func NewFlashSaleRepository(ctx context.Context, mongoDb *mongo.Database) (repository.FlashSaleRepository, error) {
+	imp := &impFlashSaleRepository{
+		mongoDb:             mongoDb,
+		flashSaleCollection: mongoDb.Collection("flash_sale"),
+	}
+	if err := imp.ensureIndexes(ctx); err != nil {
+		return nil, gerror.Wrap(err, "failed to create flash sale indexes")
+	}
+	return imp, nil
+}
+
+// ensureIndexes 创建按 SKU 查找进行中活动所需的索引
+func (imp *impFlashSaleRepository) ensureIndexes(ctx context.Context) error {
+	_, err := imp.flashSaleCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "items.sku_id", Value: 1}, {Key: "end_at", Value: 1}}},
+		{Keys: bson.D{{Key: "start_at", Value: -1}}},
+	})
+	return err
+}
+
+// Save 保存新的秒杀活动
+func (imp *impFlashSaleRepository) Save(ctx context.Context, flashSale *entity.FlashSale) error {
+	po := imp.toFlashSalePO(flashSale)
+
+	_, err := imp.flashSaleCollection.InsertOne(ctx, po)
+	return err
+}
+
+// FindById 根据Id查找秒杀活动
+func (imp *impFlashSaleRepository) FindById(ctx context.Context, id string) (*entity.FlashSale, error) {
+	var po FlashSalePO
+	err := imp.flashSaleCollection.FindOne(ctx, bson.M{"_id": id}).Decode(&po)
+	if err != nil {
+		if gerror.Is(err, mongo.ErrNoDocuments) {
+			return nil, gerror.Wrapf(valueobject.ErrFlashSaleNotFound, "flash sale %s", id)
+		}
+		return nil, err
+	}
+	return imp.toEntity(&po), nil
+}
+
+// FindAll 查找所有秒杀活动，按开始时间倒序
+func (imp *impFlashSaleRepository) FindAll(ctx context.Context) ([]*entity.FlashSale, error) {
+	return imp.find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "start_at", Value: -1}}))
+}
+
+// FindBySkuIds 查找包含任一 SKU 且尚未结束的未取消活动
+func (imp *impFlashSaleRepository) FindBySkuIds(ctx context.Context, skuIds []string, now int64) ([]*entity.FlashSale, error) {
+	return imp.find(ctx, bson.M{
+		"items.sku_id": bson.M{"$in": skuIds},
+		"end_at":       bson.M{"$gt": now},
+		"status":       string(valueobject.FlashSaleStatusActive),
+	}, options.Find())
+}
+
+// FindEffective 查找在 at 时进行中且包含该 SKU 的活动，不存在时返回 nil
+func (imp *impFlashSaleRepository) FindEffective(ctx context.Context, skuId string, at int64) (*entity.FlashSale, error) {
+	var po FlashSalePO
+	err := imp.flashSaleCollection.FindOne(ctx, bson.M{
+		"items.sku_id": skuId,
+		"start_at":     bson.M{"$lte": at},
+		"end_at":       bson.M{"$gt": at},
+		"status":       string(valueobject.FlashSaleStatusActive),
+	}).Decode(&po)
+	if err != nil {
+		if gerror.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return imp.toEntity(&po), nil
+}
+
+// UpdateStatus 保存活动的状态，不影响并发变更的活动库存
+func (imp *impFlashSaleRepository) UpdateStatus(ctx context.Context, flashSale *entity.FlashSale) error {
+	result, err := imp.flashSaleCollection.UpdateOne(
+		ctx,
+		bson.M{"_id": flashSale.Id},
+		bson.M{"$set": bson.M{
+			"status":     string(flashSale.Status),
+			"updated_at": flashSale.UpdatedAt,
+		}},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return gerror.Wrapf(valueobject.ErrFlashSaleNotFound, "flash sale %s", flashSale.Id)
+	}
+	return nil
+}
+
+// ReserveStock 仅当活动在 at 时进行中且 SKU 剩余的活动库存充足时占用活动库存
+// 条件和增量在同一次原子更新中完成，条件未满足时重新加载活动给出具体原因
+func (imp *impFlashSaleRepository) ReserveStock(ctx context.Context, id string, skuId string, quantity int, at int64) error {
+	cond := bson.M{"$gte": bson.A{bson.M{"$subtract": bson.A{"$$item.stock", "$$item.sold"}}, quantity}}
+	filter := bson.M{
+		"start_at": bson.M{"$lte": at},
+		"end_at":   bson.M{"$gt": at},
+		"status":   string(valueobject.FlashSaleStatusActive),
+	}
+	updated, err := imp.change(ctx, id, skuId, filter, cond, quantity)
+	if err != nil || updated {
+		return err
+	}
+
+	flashSale, err := imp.FindById(ctx, id)
+	if err != nil {
+		return err
+	}
+	item := flashSale.ItemOf(skuId)
+	switch {
+	case item == nil:
+		return gerror.Wrapf(valueobject.ErrInvalidFlashSaleItem, "sku %s is not in flash sale %s", skuId, id)
+	case !flashSale.IsEffectiveAt(at):
+		return gerror.Wrapf(valueobject.ErrFlashSaleNotActive, "flash sale %s is not in progress", id)
+	default:
+		return gerror.Wrapf(valueobject.ErrFlashSaleSoldOut,
+			"insufficient flash sale stock for sku %s: %d remaining, %d requested", skuId, item.Remaining(), quantity,
+		)
+	}
+}
+
+// ReleaseStock 归还 SKU 占用的活动库存，活动取消或结束后同样归还
+func (imp *impFlashSaleRepository) ReleaseStock(ctx context.Context, id string, skuId string, quantity int) error {
+	cond := bson.M{"$gte": bson.A{"$$item.sold", quantity}}
+	updated, err := imp.change(ctx, id, skuId, bson.M{}, cond, -quantity)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return gerror.Wrapf(valueobject.ErrInvalidFlashSaleItem,
+			"sku %s in flash sale %s has less than %d sold", skuId, id, quantity,
+		)
+	}
+	return nil
+}
+
+// change 仅当活动满足 filter 且 SKU 的活动商品满足 cond 时原子地按 delta 增减其已占用数量，返回是否已更新
+// cond 为针对 $$item 的聚合表达式
+func (imp *impFlashSaleRepository) change(
+	ctx context.Context,
+	id string,
+	skuId string,
+	filter bson.M,
+	cond bson.M,
+	delta int,
+) (bool, error) {
+	filter["_id"] = id
+	filter["items.sku_id"] = skuId
+	filter["$expr"] = bson.M{"$anyElementTrue": bson.A{bson.M{"$map": bson.M{
+		"input": "$items",
+		"as":    "item",
+		"in": bson.M{"$and": bson.A{
+			bson.M{"$eq": bson.A{"$$item.sku_id", skuId}},
+			cond,
+		}},
+	}}}}
+
+	result, err := imp.flashSaleCollection.UpdateOne(
+		ctx,
+		filter,
+		bson.M{
+			"$inc": bson.M{"items.$[item].sold": delta},
+			"$set": bson.M{"updated_at": time.Now().UnixMilli()},
+		},
+		options.Update().SetArrayFilters(options.ArrayFilters{Filters: bson.A{bson.M{"item.sku_id": skuId}}}),
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// find 根据条件查找秒杀活动
+func (imp *impFlashSaleRepository) find(
+	ctx context.Context,
+	filter bson.M,
+	opts *options.FindOptions,
+) ([]*entity.FlashSale, error) {
+	cursor, err := imp.flashSaleCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var pos []FlashSalePO
+	if err = cursor.All(ctx, &pos); err != nil {
+		return nil, err
+	}
+
+	flashSales := make([]*entity.FlashSale, len(pos))
+	for i, po := range pos {
+		flashSales[i] = imp.toEntity(&po)
+	}
+	return flashSales, nil
+}
+
+// toFlashSalePO 将领域实体转换为持久化对象
+func (imp *impFlashSaleRepository) toFlashSalePO(flashSale *entity.FlashSale) *FlashSalePO {
+	items := make([]FlashSaleItemPO, len(flashSale.Items))
+	for i, item := range flashSale.Items {
+		items[i] = FlashSaleItemPO{
+			ProductId:    item.ProductId,
+			SkuId:        item.SkuId,
+			Price:        item.Price,
+			Stock:        item.Stock,
+			Sold:         item.Sold,
+			PerUserLimit: item.PerUserLimit,
+		}
+	}
+	return &FlashSalePO{
+		Id:        flashSale.Id,
+		Name:      flashSale.Name,
+		StartAt:   flashSale.StartAt,
+		EndAt:     flashSale.EndAt,
+		Status:    string(flashSale.Status),
+		Items:     items,
+		CreatedAt: flashSale.CreatedAt,
+		UpdatedAt: flashSale.UpdatedAt,
+	}
+}
+
+// toEntity 将持久化对象转换为领域实体
+func (imp *impFlashSaleRepository) toEntity(po *FlashSalePO) *entity.FlashSale {
+	items := make([]*entity.FlashSaleItem, len(po.Items))
+	for i, item := range po.Items {
+		items[i] = &entity.FlashSaleItem{
+			ProductId:    item.ProductId,
+			SkuId:        item.SkuId,
+			Price:        item.Price,
+			Stock:        item.Stock,
+			Sold:         item.Sold,
+			PerUserLimit: item.PerUserLimit,
+		}
+	}
+	return &entity.FlashSale{
+		Id:        po.Id,
+		Name:      po.Name,
+		StartAt:   po.StartAt,
+		EndAt:     po.EndAt,
+		Status:    valueobject.FlashSaleStatus(po.Status),
+		Items:     items,
+		CreatedAt: po.CreatedAt,
+		UpdatedAt: po.UpdatedAt,
+	}
+}
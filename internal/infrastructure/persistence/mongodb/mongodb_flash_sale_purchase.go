@@ -0,0 +1,272 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"main/internal/domain/flashsale/entity"
+	"main/internal/domain/flashsale/repository"
+	"main/internal/domain/flashsale/valueobject"
+)
+
+// PurchasePO 秒杀购买记录持久化对象
+type PurchasePO struct {
+	Id          string `bson:"_id"`
+	FlashSaleId string `bson:"flash_sale_id"`
+	OrderId     string `bson:"order_id"`
+	UserId      string `bson:"user_id"`
+	ProductId   string `bson:"product_id"`
+	SkuId       string `bson:"sku_id"`
+	Quantity    int    `bson:"quantity"`
+	State       string `bson:"state"`
+	ExpiresAt   int64  `bson:"expires_at"`
+	CreatedAt   int64  `bson:"created_at"`
+	UpdatedAt   int64  `bson:"updated_at"`
+}
+
+// impPurchaseRepository MongoDB秒杀购买记录持久化实现
+type impPurchaseRepository struct {
+	mongoDb            *mongo.Database
+	purchaseCollection *mongo.Collection
+	quotaCollection    *mongo.Collection
+}
+
+// NewPurchaseRepository 创建MongoDB秒杀购买记录持久化实例
+func NewPurchaseRepository(ctx context.Context, mongoDb *mongo.Database) (repository.PurchaseRepository, error) {
+	imp := &impPurchaseRepository{
+		mongoDb:            mongoDb,
+		purchaseCollection: mongoDb.Collection("flash_sale_purchase"),
+		quotaCollection:    mongoDb.Collection("flash_sale_quota"),
+	}
+	if err := imp.ensureIndexes(ctx); err != nil {
+		return nil, gerror.Wrap(err, "failed to create flash sale purchase indexes")
+	}
+	return imp, nil
+}
+
+// ensureIndexes 创建按订单、按活动以及查找过期购买记录所需的索引
+func (imp *impPurchaseRepository) ensureIndexes(ctx context.Context) error {
+	_, err := imp.purchaseCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "order_id", Value: 1}}},
+		{Keys: bson.D{{Key: "flash_sale_id", Value: 1}, {Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "state", Value: 1}, {Key: "expires_at", Value: 1}}},
+	})
+	return err
+}
+
+// Save 保存新的购买记录
+func (imp *impPurchaseRepository) Save(ctx context.Context, purchase *entity.Purchase) error {
+	po := imp.toPurchasePO(purchase)
+
+	_, err := imp.purchaseCollection.InsertOne(ctx, po)
+	return err
+}
+
+// FindByOrderId 查找订单的购买记录
+func (imp *impPurchaseRepository) FindByOrderId(ctx context.Context, orderId string) ([]*entity.Purchase, error) {
+	return imp.find(ctx, bson.M{"order_id": orderId}, options.Find())
+}
+
+// FindByFlashSaleId 查找活动的购买记录，按创建时间倒序
+func (imp *impPurchaseRepository) FindByFlashSaleId(ctx context.Context, flashSaleId string) ([]*entity.Purchase, error) {
+	return imp.find(
+		ctx,
+		bson.M{"flash_sale_id": flashSaleId},
+		options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}),
+	)
+}
+
+// FindExpired 查找已过期但仍处于占用中的记录，最早过期的优先
+func (imp *impPurchaseRepository) FindExpired(ctx context.Context, now int64, limit int) ([]*entity.Purchase, error) {
+	return imp.find(
+		ctx,
+		bson.M{
+			"state":      string(valueobject.PurchaseStateHeld),
+			"expires_at": bson.M{"$lte": now},
+		},
+		options.Find().SetSort(bson.D{{Key: "expires_at", Value: 1}}).SetLimit(int64(limit)),
+	)
+}
+
+// UpdateState 仅当购买记录当前处于 from 状态时保存其新状态
+func (imp *impPurchaseRepository) UpdateState(
+	ctx context.Context,
+	purchase *entity.Purchase,
+	from valueobject.PurchaseState,
+) error {
+	result, err := imp.purchaseCollection.UpdateOne(
+		ctx,
+		bson.M{"_id": purchase.Id, "state": string(from)},
+		bson.M{"$set": bson.M{
+			"state":      string(purchase.State),
+			"updated_at": purchase.UpdatedAt,
+		}},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return gerror.Wrapf(valueobject.ErrPurchaseConflict,
+			"flash sale purchase %s is no longer %s", purchase.Id, from,
+		)
+	}
+	return nil
+}
+
+// Commit 仅当购买记录处于占用中且在 now 时尚未过期时将其保存为已确认
+func (imp *impPurchaseRepository) Commit(ctx context.Context, purchase *entity.Purchase, now int64) error {
+	result, err := imp.purchaseCollection.UpdateOne(
+		ctx,
+		bson.M{
+			"_id":        purchase.Id,
+			"state":      string(valueobject.PurchaseStateHeld),
+			"expires_at": bson.M{"$gt": now},
+		},
+		bson.M{"$set": bson.M{
+			"state":      string(valueobject.PurchaseStateCommitted),
+			"updated_at": purchase.UpdatedAt,
+		}},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return gerror.Wrapf(valueobject.ErrPurchaseConflict,
+			"flash sale purchase %s is no longer held or has expired", purchase.Id,
+		)
+	}
+	return nil
+}
+
+// ClaimQuota 仅当用户已占用的名额加上 quantity 不超过 limit 时原子地占用名额
+// 名额记录以活动、SKU 和用户的组合键为主键，不存在时随更新一起创建；
+// 记录已存在但名额不足时条件不匹配，插入会因主键重复而失败。
+// 并发首次占用时落后的一方同样会遇到主键重复，因此以不插入的方式重试一次再判断是否超出限购
+func (imp *impPurchaseRepository) ClaimQuota(
+	ctx context.Context,
+	flashSaleId string,
+	skuId string,
+	userId string,
+	quantity int,
+	limit int,
+) error {
+	filter := bson.M{
+		"_id":      quotaKey(flashSaleId, skuId, userId),
+		"quantity": bson.M{"$lte": limit - quantity},
+	}
+	update := bson.M{
+		"$inc": bson.M{"quantity": quantity},
+		"$set": bson.M{"updated_at": time.Now().UnixMilli()},
+		"$setOnInsert": bson.M{
+			"flash_sale_id": flashSaleId,
+			"sku_id":        skuId,
+			"user_id":       userId,
+		},
+	}
+	_, err := imp.quotaCollection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if !mongo.IsDuplicateKeyError(err) {
+		return err
+	}
+
+	result, err := imp.quotaCollection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return gerror.Wrapf(valueobject.ErrPurchaseLimitExceeded,
+			"user %s cannot buy %d more of sku %s in flash sale %s, limited to %d",
+			userId, quantity, skuId, flashSaleId, limit,
+		)
+	}
+	return nil
+}
+
+// ReturnQuota 归还用户占用的名额，不限购的活动商品没有名额记录，归还时忽略
+func (imp *impPurchaseRepository) ReturnQuota(
+	ctx context.Context,
+	flashSaleId string,
+	skuId string,
+	userId string,
+	quantity int,
+) error {
+	_, err := imp.quotaCollection.UpdateOne(
+		ctx,
+		bson.M{
+			"_id":      quotaKey(flashSaleId, skuId, userId),
+			"quantity": bson.M{"$gte": quantity},
+		},
+		bson.M{
+			"$inc": bson.M{"quantity": -quantity},
+			"$set": bson.M{"updated_at": time.Now().UnixMilli()},
+		},
+	)
+	return err
+}
+
+// quotaKey 限购名额记录的主键
+func quotaKey(flashSaleId, skuId, userId string) string {
+	return flashSaleId + ":" + skuId + ":" + userId
+}
+
+// find 根据条件查找购买记录
+func (imp *impPurchaseRepository) find(
+	ctx context.Context,
+	filter bson.M,
+	opts *options.FindOptions,
+) ([]*entity.Purchase, error) {
+	cursor, err := imp.purchaseCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var pos []PurchasePO
+	if err = cursor.All(ctx, &pos); err != nil {
+		return nil, err
+	}
+
+	purchases := make([]*entity.Purchase, len(pos))
+	for i, po := range pos {
+		purchases[i] = imp.toEntity(&po)
+	}
+	return purchases, nil
+}
+
+// toPurchasePO 将领域实体转换为持久化对象
+func (imp *impPurchaseRepository) toPurchasePO(purchase *entity.Purchase) *PurchasePO {
+	return &PurchasePO{
+		Id:          purchase.Id,
+		FlashSaleId: purchase.FlashSaleId,
+		OrderId:     purchase.OrderId,
+		UserId:      purchase.UserId,
+		ProductId:   purchase.ProductId,
+		SkuId:       purchase.SkuId,
+		Quantity:    purchase.Quantity,
+		State:       string(purchase.State),
+		ExpiresAt:   purchase.ExpiresAt,
+		CreatedAt:   purchase.CreatedAt,
+		UpdatedAt:   purchase.UpdatedAt,
+	}
+}
+
+// toEntity 将持久化对象转换为领域实体
+func (imp *impPurchaseRepository) toEntity(po *PurchasePO) *entity.Purchase {
+	return &entity.Purchase{
+		Id:          po.Id,
+		FlashSaleId: po.FlashSaleId,
+		OrderId:     po.OrderId,
+		UserId:      po.UserId,
+		ProductId:   po.ProductId,
+		SkuId:       po.SkuId,
+		Quantity:    po.Quantity,
+		State:       valueobject.PurchaseState(po.State),
+		ExpiresAt:   po.ExpiresAt,
+		CreatedAt:   po.CreatedAt,
+		UpdatedAt:   po.UpdatedAt,
+	}
+}
@@ -0,0 +1,291 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"main/internal/domain/inventory/entity"
+	"main/internal/domain/inventory/repository"
+	"main/internal/domain/inventory/valueobject"
+)
+
+// InventoryPO 库存持久化对象
+type InventoryPO struct {
+	Id          string            `bson:"_id"`
+	ProductId   string            `bson:"product_id"`
+	WarehouseId string            `bson:"warehouse_id"`
+	Items       []InventoryItemPO `bson:"items"`
+	UpdatedAt   int64             `bson:"updated_at"`
+}
+
+// InventoryItemPO SKU 库存持久化对象
+type InventoryItemPO struct {
+	SkuId    string `bson:"sku_id"`
+	OnHand   int    `bson:"on_hand"`
+	Reserved int    `bson:"reserved"`
+}
+
+// impInventoryRepository MongoDB库存持久化实现
+type impInventoryRepository struct {
+	mongoDb             *mongo.Database
+	inventoryCollection *mongo.Collection
+}
+
+// NewInventoryRepository 创建MongoDB库存持久化实例
+func NewInventoryRepository(ctx context.Context, mongoDb *mongo.Database) (repository.InventoryRepository, error) {
+	imp := &impInventoryRepository{
+		mongoDb:             mongoDb,
+		inventoryCollection: mongoDb.Collection("inventory"),
+	}
+	if err := imp.ensureIndexes(ctx); err != nil {
+		return nil, gerror.Wrap(err, "failed to create inventory indexes")
+	}
+	return imp, nil
+}
+
+// ensureIndexes 创建每个商品在每个仓库只有一条库存记录的唯一索引，以及按仓库查找的索引
+func (imp *impInventoryRepository) ensureIndexes(ctx context.Context) error {
+	_, err := imp.inventoryCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "product_id", Value: 1}, {Key: "warehouse_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{Keys: bson.D{{Key: "warehouse_id", Value: 1}}},
+	})
+	return err
+}
+
+// FindByProductId 查找商品在所有仓库的库存
+func (imp *impInventoryRepository) FindByProductId(ctx context.Context, productId string) ([]*entity.Inventory, error) {
+	return imp.find(ctx, bson.M{"product_id": productId})
+}
+
+// FindByProductIds 查找多个商品在所有仓库的库存
+func (imp *impInventoryRepository) FindByProductIds(ctx context.Context, productIds []string) ([]*entity.Inventory, error) {
+	return imp.find(ctx, bson.M{"product_id": bson.M{"$in": productIds}})
+}
+
+// FindByWarehouseId 查找仓库中所有商品的库存
+func (imp *impInventoryRepository) FindByWarehouseId(ctx context.Context, warehouseId string) ([]*entity.Inventory, error) {
+	return imp.find(ctx, bson.M{"warehouse_id": warehouseId})
+}
+
+// Adjust 按增量调整 SKU 在仓库中的在库数量
+// 先确保库存记录和 SKU 库存项存在，再以调整后不少于预占数量为条件原子地更新
+func (imp *impInventoryRepository) Adjust(
+	ctx context.Context,
+	productId string,
+	warehouseId string,
+	skuId string,
+	delta int,
+) (*entity.Inventory, error) {
+	if err := imp.ensureItem(ctx, productId, warehouseId, skuId); err != nil {
+		return nil, err
+	}
+	cond := bson.M{"$gte": bson.A{bson.M{"$add": bson.A{"$$item.on_hand", delta}}, "$$item.reserved"}}
+	inventory, err := imp.change(ctx, productId, warehouseId, skuId, cond, bson.M{"on_hand": delta})
+	if err != nil {
+		return nil, err
+	}
+	if inventory == nil {
+		return nil, imp.diagnose(ctx, productId, warehouseId, func(inv *entity.Inventory) error {
+			return inv.Adjust(skuId, delta)
+		})
+	}
+	return inventory, nil
+}
+
+// Reserve 仅当 SKU 在仓库中的可售数量充足时预占
+func (imp *impInventoryRepository) Reserve(ctx context.Context, productId, warehouseId, skuId string, quantity int) error {
+	cond := bson.M{"$gte": bson.A{bson.M{"$subtract": bson.A{"$$item.on_hand", "$$item.reserved"}}, quantity}}
+	inventory, err := imp.change(ctx, productId, warehouseId, skuId, cond, bson.M{"reserved": quantity})
+	if err != nil {
+		return err
+	}
+	if inventory == nil {
+		return imp.diagnose(ctx, productId, warehouseId, func(inv *entity.Inventory) error {
+			return gerror.Wrapf(valueobject.ErrInsufficientInventory,
+				"insufficient inventory for sku %s in warehouse %s: %d available, %d requested",
+				skuId, warehouseId, inv.Available(skuId), quantity,
+			)
+		})
+	}
+	return nil
+}
+
+// Release 释放 SKU 在仓库中预占的库存
+func (imp *impInventoryRepository) Release(ctx context.Context, productId, warehouseId, skuId string, quantity int) error {
+	return imp.settle(ctx, productId, warehouseId, skuId, quantity, bson.M{"reserved": -quantity})
+}
+
+// Commit 确认 SKU 在仓库中预占的库存，在库数量和预占数量同时减少
+func (imp *impInventoryRepository) Commit(ctx context.Context, productId, warehouseId, skuId string, quantity int) error {
+	return imp.settle(ctx, productId, warehouseId, skuId, quantity, bson.M{"on_hand": -quantity, "reserved": -quantity})
+}
+
+// settle 以预占数量不少于 quantity 为条件释放或确认预占
+func (imp *impInventoryRepository) settle(
+	ctx context.Context,
+	productId string,
+	warehouseId string,
+	skuId string,
+	quantity int,
+	inc bson.M,
+) error {
+	cond := bson.M{"$gte": bson.A{"$$item.reserved", quantity}}
+	inventory, err := imp.change(ctx, productId, warehouseId, skuId, cond, inc)
+	if err != nil {
+		return err
+	}
+	if inventory == nil {
+		return gerror.Wrapf(valueobject.ErrInvalidInventory,
+			"sku %s in warehouse %s has less than %d reserved", skuId, warehouseId, quantity,
+		)
+	}
+	return nil
+}
+
+// change 仅当 SKU 库存项满足 cond 时原子地按 inc 增减其字段，返回更新后的库存，条件未满足时返回 nil
+// cond 为针对 $$item 的聚合表达式
+func (imp *impInventoryRepository) change(
+	ctx context.Context,
+	productId string,
+	warehouseId string,
+	skuId string,
+	cond bson.M,
+	inc bson.M,
+) (*entity.Inventory, error) {
+	update := bson.M{}
+	for field, delta := range inc {
+		update["items.$[item]."+field] = delta
+	}
+
+	var po InventoryPO
+	err := imp.inventoryCollection.FindOneAndUpdate(
+		ctx,
+		bson.M{
+			"product_id":   productId,
+			"warehouse_id": warehouseId,
+			"items.sku_id": skuId,
+			"$expr": bson.M{"$anyElementTrue": bson.A{bson.M{"$map": bson.M{
+				"input": "$items",
+				"as":    "item",
+				"in": bson.M{"$and": bson.A{
+					bson.M{"$eq": bson.A{"$$item.sku_id", skuId}},
+					cond,
+				}},
+			}}}},
+		},
+		bson.M{
+			"$inc": update,
+			"$set": bson.M{"updated_at": time.Now().UnixMilli()},
+		},
+		options.FindOneAndUpdate().
+			SetArrayFilters(options.ArrayFilters{Filters: bson.A{bson.M{"item.sku_id": skuId}}}).
+			SetReturnDocument(options.After),
+	).Decode(&po)
+	if err != nil {
+		if gerror.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return imp.toEntity(&po), nil
+}
+
+// ensureItem 确保商品在仓库中有库存记录且包含该 SKU 的库存项
+// 库存记录由商品和仓库唯一确定，以两者组合作为 Id；
+// 并发创建同一记录时唯一索引保证只有一条，重复键错误可以忽略
+func (imp *impInventoryRepository) ensureItem(ctx context.Context, productId, warehouseId, skuId string) error {
+	key := bson.M{"product_id": productId, "warehouse_id": warehouseId}
+	_, err := imp.inventoryCollection.UpdateOne(
+		ctx,
+		key,
+		bson.M{"$setOnInsert": bson.M{
+			"_id":        productId + ":" + warehouseId,
+			"items":      bson.A{},
+			"updated_at": time.Now().UnixMilli(),
+		}},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil && !mongo.IsDuplicateKeyError(err) {
+		return err
+	}
+
+	// 仅当 SKU 库存项不存在时追加，避免并发追加出重复项
+	_, err = imp.inventoryCollection.UpdateOne(
+		ctx,
+		bson.M{"product_id": productId, "warehouse_id": warehouseId, "items.sku_id": bson.M{"$ne": skuId}},
+		bson.M{"$push": bson.M{"items": InventoryItemPO{SkuId: skuId}}},
+	)
+	return err
+}
+
+// diagnose 条件更新未命中时重新加载库存，由 check 给出具体原因
+func (imp *impInventoryRepository) diagnose(
+	ctx context.Context,
+	productId string,
+	warehouseId string,
+	check func(inv *entity.Inventory) error,
+) error {
+	var po InventoryPO
+	err := imp.inventoryCollection.FindOne(ctx, bson.M{"product_id": productId, "warehouse_id": warehouseId}).Decode(&po)
+	if err != nil {
+		if gerror.Is(err, mongo.ErrNoDocuments) {
+			return gerror.Wrapf(valueobject.ErrInventoryNotFound,
+				"product %s has no inventory in warehouse %s", productId, warehouseId,
+			)
+		}
+		return err
+	}
+	if err = check(imp.toEntity(&po)); err != nil {
+		return err
+	}
+	return gerror.Wrapf(valueobject.ErrInsufficientInventory,
+		"inventory of product %s in warehouse %s is being modified concurrently", productId, warehouseId,
+	)
+}
+
+// find 根据条件查找库存
+func (imp *impInventoryRepository) find(ctx context.Context, filter bson.M) ([]*entity.Inventory, error) {
+	cursor, err := imp.inventoryCollection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var pos []InventoryPO
+	if err = cursor.All(ctx, &pos); err != nil {
+		return nil, err
+	}
+
+	inventories := make([]*entity.Inventory, len(pos))
+	for i, po := range pos {
+		inventories[i] = imp.toEntity(&po)
+	}
+	return inventories, nil
+}
+
+// toEntity 将持久化对象转换为领域实体
+func (imp *impInventoryRepository) toEntity(po *InventoryPO) *entity.Inventory {
+	items := make([]*entity.InventoryItem, len(po.Items))
+	for i, item := range po.Items {
+		items[i] = &entity.InventoryItem{
+			SkuId:    item.SkuId,
+			OnHand:   item.OnHand,
+			Reserved: item.Reserved,
+		}
+	}
+	return &entity.Inventory{
+		Id:          po.Id,
+		ProductId:   po.ProductId,
+		WarehouseId: po.WarehouseId,
+		Items:       items,
+		UpdatedAt:   po.UpdatedAt,
+	}
+}
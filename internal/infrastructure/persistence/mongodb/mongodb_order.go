@@ -5,7 +5,6 @@ import (
 
 	"github.com/gogf/gf/v2/errors/gerror"
 	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 
@@ -13,34 +12,43 @@ import (
 	"main/internal/domain/order/repository"
 	"main/internal/domain/order/valueobject"
 	sharedvo "main/internal/domain/shared/valueobject"
-	"main/utility/mongodb"
 )
 
 // OrderPO 订单持久化对象
 type OrderPO struct {
-	Id          string        `bson:"_id"`
-	UserId      string        `bson:"user_id"`
-	Items       []OrderItemPO `bson:"items"`
-	TotalAmount MoneyPO       `bson:"total_amount"`
-	Status      string        `bson:"status"`
-	Remark      string        `bson:"remark"`
-	CreatedAt   int64         `bson:"created_at"`
-	UpdatedAt   int64         `bson:"updated_at"`
+	Id             string          `bson:"_id"`
+	UserId         string          `bson:"user_id"`
+	Items          []OrderItemPO   `bson:"items"`
+	TotalAmount    *sharedvo.Money `bson:"total_amount"`
+	DiscountAmount *sharedvo.Money `bson:"discount_amount,omitempty"`
+	Status         string          `bson:"status"`
+	Remark         string          `bson:"remark"`
+	CreatedAt      int64           `bson:"created_at"`
+	UpdatedAt      int64           `bson:"updated_at"`
 }
 
 // OrderItemPO 订单项持久化对象
 type OrderItemPO struct {
-	Id          string  `bson:"_id"`
-	ProductId   string  `bson:"product_id"`
-	Quantity    int     `bson:"quantity"`
-	Price       MoneyPO `bson:"price"`
-	ProductName string  `bson:"product_name"`
+	Id          string          `bson:"_id"`
+	ProductId   string          `bson:"product_id"`
+	SkuId       string          `bson:"sku_id"`
+	SkuName     string          `bson:"sku_name,omitempty"`
+	Quantity    int             `bson:"quantity"`
+	Price       *sharedvo.Money `bson:"price"`
+	Discount    *sharedvo.Money `bson:"discount,omitempty"`
+	ProductName string          `bson:"product_name"`
+	// Components 套装订单项的组件，普通订单项为空
+	Components []OrderItemComponentPO `bson:"components,omitempty"`
 }
 
-// MoneyPO Money值对象的持久化对象
-type MoneyPO struct {
-	Amount   float64 `bson:"amount"`
-	Currency string  `bson:"currency"`
+// OrderItemComponentPO 套装订单项组件持久化对象
+type OrderItemComponentPO struct {
+	ProductId   string          `bson:"product_id"`
+	ProductName string          `bson:"product_name"`
+	SkuId       string          `bson:"sku_id"`
+	SkuName     string          `bson:"sku_name,omitempty"`
+	Quantity    int             `bson:"quantity"`
+	Price       *sharedvo.Money `bson:"price"`
 }
 
 // impOrderRepository MongoDB订单持久化实现
@@ -50,12 +58,7 @@ type impOrderRepository struct {
 }
 
 // NewOrderRepository 创建MongoDB订单持久化实例
-func NewOrderRepository(ctx context.Context, cfg mongodb.Config) (repository.OrderRepository, error) {
-	client, err := mongodb.NewMongoClient(ctx, cfg)
-	if err != nil {
-		return nil, err
-	}
-	mongoDb := client.Database(cfg.Database)
+func NewOrderRepository(ctx context.Context, mongoDb *mongo.Database) (repository.OrderRepository, error) {
 	return &impOrderRepository{
 		mongoDb:         mongoDb,
 		orderCollection: mongoDb.Collection("order"),
@@ -65,13 +68,6 @@ func NewOrderRepository(ctx context.Context, cfg mongodb.Config) (repository.Ord
 // Save 保存订单
 func (imp *impOrderRepository) Save(ctx context.Context, order *entity.Order) error {
 	po := imp.toOrderPO(order)
-
-	// 如果是新订单（ID为空），生成新的ID
-	if po.Id == "" {
-		po.Id = primitive.NewObjectID().Hex()
-		order.Id = po.Id // 更新领域实体的ID
-	}
-
 	opts := options.Update().SetUpsert(true)
 	_, err := imp.orderCollection.UpdateOne(
 		ctx,
@@ -98,7 +94,25 @@ func (imp *impOrderRepository) FindById(ctx context.Context, id string) (*entity
 
 // FindByUserId 查找用户的所有订单
 func (imp *impOrderRepository) FindByUserId(ctx context.Context, userId string) ([]*entity.Order, error) {
-	cursor, err := imp.orderCollection.Find(ctx, bson.M{"user_id": userId})
+	return imp.find(ctx, bson.M{"user_id": userId})
+}
+
+// FindByUserIdAndStatus 查找用户指定状态的订单，状态为空时返回用户的所有订单
+func (imp *impOrderRepository) FindByUserIdAndStatus(
+	ctx context.Context,
+	userId string,
+	status valueobject.OrderStatus,
+) ([]*entity.Order, error) {
+	filter := bson.M{"user_id": userId}
+	if status != "" {
+		filter["status"] = string(status)
+	}
+	return imp.find(ctx, filter)
+}
+
+// find 根据条件查找订单
+func (imp *impOrderRepository) find(ctx context.Context, filter bson.M) ([]*entity.Order, error) {
+	cursor, err := imp.orderCollection.Find(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -117,41 +131,56 @@ func (imp *impOrderRepository) FindByUserId(ctx context.Context, userId string)
 	return orders, nil
 }
 
+// ExistsOpenByProductId 检查是否存在包含该商品（包括作为套装组件）且尚未送达或取消的订单
+func (imp *impOrderRepository) ExistsOpenByProductId(ctx context.Context, productId string) (bool, error) {
+	statuses := make([]string, len(valueobject.OpenOrderStatuses))
+	for i, status := range valueobject.OpenOrderStatuses {
+		statuses[i] = string(status)
+	}
+	count, err := imp.orderCollection.CountDocuments(ctx, bson.M{
+		"$or": bson.A{
+			bson.M{"items.product_id": productId},
+			bson.M{"items.components.product_id": productId},
+		},
+		"status": bson.M{"$in": statuses},
+	}, options.Count().SetLimit(1))
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
 // toOrderPO 将领域实体转换为订单持久化对象
 func (imp *impOrderRepository) toOrderPO(order *entity.Order) *OrderPO {
 	items := make([]OrderItemPO, len(order.Items))
 	for i, item := range order.Items {
-		// 如果订单项没有ID，生成新的ID
-		itemId := item.Id
-		if itemId == "" {
-			itemId = primitive.NewObjectID().Hex()
-			item.Id = itemId // 更新领域实体的ID
+		components := make([]OrderItemComponentPO, len(item.Components))
+		for j, component := range item.Components {
+			components[j] = OrderItemComponentPO(*component)
 		}
-
 		items[i] = OrderItemPO{
-			Id:          itemId,
+			Id:          item.Id,
 			ProductId:   item.ProductId,
 			ProductName: item.ProductName,
+			SkuId:       item.SkuId,
+			SkuName:     item.SkuName,
 			Quantity:    item.Quantity,
-			Price: MoneyPO{
-				Amount:   item.Price.Amount(),
-				Currency: item.Price.Currency(),
-			},
+			Price:       item.Price,
+			Discount:    item.Discount,
+			Components:  components,
 		}
 	}
 
 	return &OrderPO{
-		Id:     order.Id,
-		UserId: order.UserId,
-		Items:  items,
-		TotalAmount: MoneyPO{
-			Amount:   order.TotalAmount.Amount(),
-			Currency: order.TotalAmount.Currency(),
-		},
-		Status:    string(order.Status),
-		Remark:    order.Remark,
-		CreatedAt: order.CreatedAt,
-		UpdatedAt: order.UpdatedAt,
+		Id:             order.Id,
+		UserId:         order.UserId,
+		Items:          items,
+		TotalAmount:    order.TotalAmount,
+		DiscountAmount: order.DiscountAmount,
+		Status:         string(order.Status),
+		Remark:         order.Remark,
+		CreatedAt:      order.CreatedAt,
+		UpdatedAt:      order.UpdatedAt,
 	}
 }
 
@@ -159,24 +188,36 @@ func (imp *impOrderRepository) toOrderPO(order *entity.Order) *OrderPO {
 func (imp *impOrderRepository) toEntity(po *OrderPO) *entity.Order {
 	items := make([]*entity.OrderItem, len(po.Items))
 	for i, item := range po.Items {
+		// 引入 SKU 之前的订单项以商品 Id 作为 SKU Id，与商品仓储对旧数据的处理保持一致
+		if item.SkuId == "" {
+			item.SkuId = item.ProductId
+		}
 		items[i] = &entity.OrderItem{
 			Id:          item.Id,
 			ProductId:   item.ProductId,
 			ProductName: item.ProductName,
+			SkuId:       item.SkuId,
+			SkuName:     item.SkuName,
 			Quantity:    item.Quantity,
-			Price:       sharedvo.NewMoney(item.Price.Amount, item.Price.Currency),
+			Price:       item.Price,
+			Discount:    item.Discount,
+		}
+		for _, component := range item.Components {
+			c := entity.OrderItemComponent(component)
+			items[i].Components = append(items[i].Components, &c)
 		}
 	}
 
 	order := &entity.Order{
-		Id:          po.Id,
-		UserId:      po.UserId,
-		Items:       items,
-		TotalAmount: sharedvo.NewMoney(po.TotalAmount.Amount, po.TotalAmount.Currency),
-		Status:      valueobject.OrderStatus(po.Status),
-		Remark:      po.Remark,
-		CreatedAt:   po.CreatedAt,
-		UpdatedAt:   po.UpdatedAt,
+		Id:             po.Id,
+		UserId:         po.UserId,
+		Items:          items,
+		TotalAmount:    po.TotalAmount,
+		DiscountAmount: po.DiscountAmount,
+		Status:         valueobject.OrderStatus(po.Status),
+		Remark:         po.Remark,
+		CreatedAt:      po.CreatedAt,
+		UpdatedAt:      po.UpdatedAt,
 	}
 
 	return order
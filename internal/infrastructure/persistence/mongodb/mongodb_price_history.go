@@ -0,0 +1,127 @@
+package mongodb
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"main/internal/domain/product/entity"
+	"main/internal/domain/product/repository"
+	sharedvo "main/internal/domain/shared/valueobject"
+)
+
+// PriceChangePO 价格历史持久化对象
+type PriceChangePO struct {
+	Id        string          `bson:"_id"`
+	ProductId string          `bson:"product_id"`
+	SkuId     string          `bson:"sku_id"`
+	OldPrice  *sharedvo.Money `bson:"old_price"`
+	NewPrice  *sharedvo.Money `bson:"new_price"`
+	Actor     string          `bson:"actor"`
+	Reference string          `bson:"reference,omitempty"`
+	Note      string          `bson:"note,omitempty"`
+	CreatedAt int64           `bson:"created_at"`
+}
+
+// impPriceHistoryRepository MongoDB价格历史持久化实现
+type impPriceHistoryRepository struct {
+	mongoDb           *mongo.Database
+	historyCollection *mongo.Collection
+}
+
+// NewPriceHistoryRepository 创建MongoDB价格历史持久化实例
+func NewPriceHistoryRepository(ctx context.Context, mongoDb *mongo.Database) (repository.PriceHistoryRepository, error) {
+	imp := &impPriceHistoryRepository{
+		mongoDb:           mongoDb,
+		historyCollection: mongoDb.Collection("price_history"),
+	}
+	if err := imp.ensureIndexes(ctx); err != nil {
+		return nil, gerror.Wrap(err, "failed to create price history indexes")
+	}
+	return imp, nil
+}
+
+// ensureIndexes 创建按商品、按 SKU 查询价格历史的索引
+func (imp *impPriceHistoryRepository) ensureIndexes(ctx context.Context) error {
+	_, err := imp.historyCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "product_id", Value: 1}, {Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "sku_id", Value: 1}, {Key: "created_at", Value: -1}}},
+	})
+	return err
+}
+
+// Append 追加价格历史，保持传入的顺序
+func (imp *impPriceHistoryRepository) Append(ctx context.Context, changes ...*entity.PriceChange) error {
+	docs := make([]interface{}, len(changes))
+	for i, change := range changes {
+		docs[i] = imp.toPriceChangePO(change)
+	}
+	_, err := imp.historyCollection.InsertMany(ctx, docs, options.InsertMany().SetOrdered(true))
+	return err
+}
+
+// FindByProductId 查找商品的价格历史，按时间倒序
+func (imp *impPriceHistoryRepository) FindByProductId(
+	ctx context.Context,
+	productId string,
+	skuId string,
+	limit int,
+) ([]*entity.PriceChange, error) {
+	filter := bson.M{"product_id": productId}
+	if skuId != "" {
+		filter["sku_id"] = skuId
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := imp.historyCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var pos []PriceChangePO
+	if err = cursor.All(ctx, &pos); err != nil {
+		return nil, err
+	}
+
+	changes := make([]*entity.PriceChange, len(pos))
+	for i, po := range pos {
+		changes[i] = imp.toEntity(&po)
+	}
+	return changes, nil
+}
+
+// toPriceChangePO 将领域实体转换为持久化对象
+func (imp *impPriceHistoryRepository) toPriceChangePO(change *entity.PriceChange) *PriceChangePO {
+	return &PriceChangePO{
+		Id:        change.Id,
+		ProductId: change.ProductId,
+		SkuId:     change.SkuId,
+		OldPrice:  change.OldPrice,
+		NewPrice:  change.NewPrice,
+		Actor:     change.Actor,
+		Reference: change.Reference,
+		Note:      change.Note,
+		CreatedAt: change.CreatedAt,
+	}
+}
+
+// toEntity 将持久化对象转换为领域实体
+func (imp *impPriceHistoryRepository) toEntity(po *PriceChangePO) *entity.PriceChange {
+	return &entity.PriceChange{
+		Id:        po.Id,
+		ProductId: po.ProductId,
+		SkuId:     po.SkuId,
+		OldPrice:  po.OldPrice,
+		NewPrice:  po.NewPrice,
+		Actor:     po.Actor,
+		Reference: po.Reference,
+		Note:      po.Note,
+		CreatedAt: po.CreatedAt,
+	}
+}
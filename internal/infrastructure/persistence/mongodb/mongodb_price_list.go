@@ -0,0 +1,183 @@
+package mongodb
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"main/internal/domain/pricing/entity"
+	"main/internal/domain/pricing/repository"
+	"main/internal/domain/pricing/valueobject"
+	sharedvo "main/internal/domain/shared/valueobject"
+)
+
+// PriceListPO 价目表持久化对象
+type PriceListPO struct {
+	Id            string             `bson:"_id"`
+	Name          string             `bson:"name"`
+	CustomerGroup string             `bson:"customer_group"`
+	Currency      string             `bson:"currency"`
+	Priority      int                `bson:"priority"`
+	ValidFrom     int64              `bson:"valid_from"`
+	ValidTo       int64              `bson:"valid_to"`
+	Status        string             `bson:"status"`
+	Entries       []PriceListEntryPO `bson:"entries"`
+	CreatedAt     int64              `bson:"created_at"`
+	UpdatedAt     int64              `bson:"updated_at"`
+}
+
+// PriceListEntryPO 价目表条目持久化对象
+type PriceListEntryPO struct {
+	ProductId string        `bson:"product_id"`
+	Tiers     []PriceTierPO `bson:"tiers"`
+}
+
+// PriceTierPO 阶梯价格持久化对象
+type PriceTierPO struct {
+	MinQuantity int             `bson:"min_quantity"`
+	Price       *sharedvo.Money `bson:"price"`
+}
+
+// impPriceListRepository MongoDB价目表持久化实现
+type impPriceListRepository struct {
+	mongoDb             *mongo.Database
+	priceListCollection *mongo.Collection
+}
+
+// NewPriceListRepository 创建MongoDB价目表持久化实例
+func NewPriceListRepository(ctx context.Context, mongoDb *mongo.Database) (repository.PriceListRepository, error) {
+	return &impPriceListRepository{
+		mongoDb:             mongoDb,
+		priceListCollection: mongoDb.Collection("price_list"),
+	}, nil
+}
+
+// Save 保存价目表
+func (imp *impPriceListRepository) Save(ctx context.Context, priceList *entity.PriceList) error {
+	po := imp.toPriceListPO(priceList)
+
+	opts := options.Update().SetUpsert(true)
+	_, err := imp.priceListCollection.UpdateOne(
+		ctx,
+		bson.M{"_id": po.Id},
+		bson.M{"$set": po},
+		opts,
+	)
+	return err
+}
+
+// FindById 根据Id查找价目表
+func (imp *impPriceListRepository) FindById(ctx context.Context, id string) (*entity.PriceList, error) {
+	var po PriceListPO
+	err := imp.priceListCollection.FindOne(ctx, bson.M{"_id": id}).Decode(&po)
+	if err != nil {
+		if gerror.Is(err, mongo.ErrNoDocuments) {
+			return nil, valueobject.ErrPriceListNotFound
+		}
+		return nil, err
+	}
+	return imp.toEntity(&po), nil
+}
+
+// FindAll 查找所有价目表
+func (imp *impPriceListRepository) FindAll(ctx context.Context) ([]*entity.PriceList, error) {
+	return imp.find(ctx, bson.M{})
+}
+
+// FindByCustomerGroup 查找适用于客户分组的价目表，包括适用于所有客户的价目表
+func (imp *impPriceListRepository) FindByCustomerGroup(ctx context.Context, customerGroup string) ([]*entity.PriceList, error) {
+	return imp.find(ctx, bson.M{"customer_group": bson.M{"$in": bson.A{"", customerGroup}}})
+}
+
+// Delete 删除价目表
+func (imp *impPriceListRepository) Delete(ctx context.Context, id string) error {
+	result, err := imp.priceListCollection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return valueobject.ErrPriceListNotFound
+	}
+	return nil
+}
+
+// find 根据条件查找价目表
+func (imp *impPriceListRepository) find(ctx context.Context, filter bson.M) ([]*entity.PriceList, error) {
+	cursor, err := imp.priceListCollection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var pos []PriceListPO
+	if err = cursor.All(ctx, &pos); err != nil {
+		return nil, err
+	}
+
+	priceLists := make([]*entity.PriceList, len(pos))
+	for i, po := range pos {
+		priceLists[i] = imp.toEntity(&po)
+	}
+	return priceLists, nil
+}
+
+// toPriceListPO 将领域实体转换为价目表持久化对象
+func (imp *impPriceListRepository) toPriceListPO(priceList *entity.PriceList) *PriceListPO {
+	entries := make([]PriceListEntryPO, len(priceList.Entries))
+	for i, entry := range priceList.Entries {
+		tiers := make([]PriceTierPO, len(entry.Tiers))
+		for j, tier := range entry.Tiers {
+			tiers[j] = PriceTierPO{
+				MinQuantity: tier.MinQuantity,
+				Price:       tier.Price,
+			}
+		}
+		entries[i] = PriceListEntryPO{
+			ProductId: entry.ProductId,
+			Tiers:     tiers,
+		}
+	}
+
+	return &PriceListPO{
+		Id:            priceList.Id,
+		Name:          priceList.Name,
+		CustomerGroup: priceList.CustomerGroup,
+		Currency:      priceList.Currency,
+		Priority:      priceList.Priority,
+		ValidFrom:     priceList.ValidFrom,
+		ValidTo:       priceList.ValidTo,
+		Status:        string(priceList.Status),
+		Entries:       entries,
+		CreatedAt:     priceList.CreatedAt,
+		UpdatedAt:     priceList.UpdatedAt,
+	}
+}
+
+// toEntity 将持久化对象转换为领域实体
+func (imp *impPriceListRepository) toEntity(po *PriceListPO) *entity.PriceList {
+	entries := make([]*entity.PriceListEntry, len(po.Entries))
+	for i, entry := range po.Entries {
+		tiers := make([]*valueobject.PriceTier, len(entry.Tiers))
+		for j, tier := range entry.Tiers {
+			tiers[j] = valueobject.NewPriceTier(tier.MinQuantity, tier.Price)
+		}
+		entries[i] = entity.NewPriceListEntry(entry.ProductId, tiers)
+	}
+
+	return &entity.PriceList{
+		Id:            po.Id,
+		Name:          po.Name,
+		CustomerGroup: po.CustomerGroup,
+		Currency:      po.Currency,
+		Priority:      po.Priority,
+		ValidFrom:     po.ValidFrom,
+		ValidTo:       po.ValidTo,
+		Status:        valueobject.PriceListStatus(po.Status),
+		Entries:       entries,
+		CreatedAt:     po.CreatedAt,
+		UpdatedAt:     po.UpdatedAt,
+	}
+}
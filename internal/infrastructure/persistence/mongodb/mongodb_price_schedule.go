@@ -0,0 +1,232 @@
+package mongodb
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"main/internal/domain/product/entity"
+	"main/internal/domain/product/repository"
+	"main/internal/domain/product/valueobject"
+	sharedvo "main/internal/domain/shared/valueobject"
+)
+
+// PriceSchedulePO 定时调价持久化对象
+type PriceSchedulePO struct {
+	Id            string          `bson:"_id"`
+	ProductId     string          `bson:"product_id"`
+	SkuId         string          `bson:"sku_id"`
+	Price         *sharedvo.Money `bson:"price"`
+	OriginalPrice *sharedvo.Money `bson:"original_price,omitempty"`
+	StartAt       int64           `bson:"start_at"`
+	EndAt         int64           `bson:"end_at"`
+	Status        string          `bson:"status"`
+	Actor         string          `bson:"actor"`
+	Note          string          `bson:"note,omitempty"`
+	CreatedAt     int64           `bson:"created_at"`
+	UpdatedAt     int64           `bson:"updated_at"`
+
+	// DueAt 下一次需要调度器处理的时间，未开始时为开始时间，进行中时为结束时间，仅用于查找到期的定时调价
+	DueAt int64 `bson:"due_at,omitempty"`
+}
+
+// impPriceScheduleRepository MongoDB定时调价持久化实现
+type impPriceScheduleRepository struct {
+	mongoDb            *mongo.Database
+	scheduleCollection *mongo.Collection
+}
+
+// NewPriceScheduleRepository 创建MongoDB定时调价持久化实例
+func NewPriceScheduleRepository(ctx context.Context, mongoDb *mongo.Database) (repository.PriceScheduleRepository, error) {
+	imp := &impPriceScheduleRepository{
+		mongoDb:            mongoDb,
+		scheduleCollection: mongoDb.Collection("price_schedule"),
+	}
+	if err := imp.ensureIndexes(ctx); err != nil {
+		return nil, gerror.Wrap(err, "failed to create price schedule indexes")
+	}
+	return imp, nil
+}
+
+// ensureIndexes 创建按商品、按 SKU 查询以及查找到期定时调价所需的索引
+func (imp *impPriceScheduleRepository) ensureIndexes(ctx context.Context) error {
+	_, err := imp.scheduleCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "product_id", Value: 1}, {Key: "start_at", Value: 1}}},
+		{Keys: bson.D{{Key: "sku_id", Value: 1}, {Key: "status", Value: 1}}},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "due_at", Value: 1}}},
+	})
+	return err
+}
+
+// Save 保存新的定时调价
+func (imp *impPriceScheduleRepository) Save(ctx context.Context, schedule *entity.PriceSchedule) error {
+	po := imp.toPriceSchedulePO(schedule)
+
+	_, err := imp.scheduleCollection.InsertOne(ctx, po)
+	return err
+}
+
+// FindById 根据Id查找定时调价
+func (imp *impPriceScheduleRepository) FindById(ctx context.Context, id string) (*entity.PriceSchedule, error) {
+	var po PriceSchedulePO
+	err := imp.scheduleCollection.FindOne(ctx, bson.M{"_id": id}).Decode(&po)
+	if err != nil {
+		if gerror.Is(err, mongo.ErrNoDocuments) {
+			return nil, gerror.Wrapf(valueobject.ErrPriceScheduleNotFound, "price schedule %s not found", id)
+		}
+		return nil, err
+	}
+	return imp.toEntity(&po), nil
+}
+
+// FindByProductId 查找商品的定时调价，按开始时间排序
+func (imp *impPriceScheduleRepository) FindByProductId(ctx context.Context, productId string) ([]*entity.PriceSchedule, error) {
+	return imp.find(
+		ctx,
+		bson.M{"product_id": productId},
+		options.Find().SetSort(bson.D{{Key: "start_at", Value: 1}, {Key: "_id", Value: 1}}),
+	)
+}
+
+// FindOpenBySkuIds 查找 SKU 尚未结束的定时调价，按开始时间排序
+func (imp *impPriceScheduleRepository) FindOpenBySkuIds(ctx context.Context, skuIds []string) ([]*entity.PriceSchedule, error) {
+	return imp.find(
+		ctx,
+		bson.M{
+			"sku_id": bson.M{"$in": skuIds},
+			"status": bson.M{"$in": bson.A{
+				string(valueobject.PriceSchedulePending),
+				string(valueobject.PriceScheduleActive),
+			}},
+		},
+		options.Find().SetSort(bson.D{{Key: "start_at", Value: 1}}),
+	)
+}
+
+// FindDue 查找到期的定时调价，最早到期的优先
+func (imp *impPriceScheduleRepository) FindDue(ctx context.Context, at int64, limit int) ([]*entity.PriceSchedule, error) {
+	return imp.find(
+		ctx,
+		bson.M{
+			"status": bson.M{"$in": bson.A{
+				string(valueobject.PriceSchedulePending),
+				string(valueobject.PriceScheduleActive),
+			}},
+			"due_at": bson.M{"$gt": 0, "$lte": at},
+		},
+		options.Find().SetSort(bson.D{{Key: "due_at", Value: 1}}).SetLimit(int64(limit)),
+	)
+}
+
+// UpdateStatus 仅当定时调价当前处于 from 状态时保存其新状态和原价
+func (imp *impPriceScheduleRepository) UpdateStatus(
+	ctx context.Context,
+	schedule *entity.PriceSchedule,
+	from valueobject.PriceScheduleStatus,
+) error {
+	po := imp.toPriceSchedulePO(schedule)
+	set := bson.M{
+		"status":     po.Status,
+		"updated_at": po.UpdatedAt,
+	}
+	unset := bson.M{}
+	if po.OriginalPrice != nil {
+		set["original_price"] = po.OriginalPrice
+	} else {
+		unset["original_price"] = ""
+	}
+	if po.DueAt > 0 {
+		set["due_at"] = po.DueAt
+	} else {
+		unset["due_at"] = ""
+	}
+	update := bson.M{"$set": set}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+
+	result, err := imp.scheduleCollection.UpdateOne(
+		ctx,
+		bson.M{"_id": schedule.Id, "status": string(from)},
+		update,
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return gerror.Wrapf(valueobject.ErrPriceScheduleConflict,
+			"price schedule %s is no longer %s", schedule.Id, from,
+		)
+	}
+	return nil
+}
+
+// find 根据条件查找定时调价
+func (imp *impPriceScheduleRepository) find(
+	ctx context.Context,
+	filter bson.M,
+	opts *options.FindOptions,
+) ([]*entity.PriceSchedule, error) {
+	cursor, err := imp.scheduleCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var pos []PriceSchedulePO
+	if err = cursor.All(ctx, &pos); err != nil {
+		return nil, err
+	}
+
+	schedules := make([]*entity.PriceSchedule, len(pos))
+	for i, po := range pos {
+		schedules[i] = imp.toEntity(&po)
+	}
+	return schedules, nil
+}
+
+// toPriceSchedulePO 将领域实体转换为持久化对象
+func (imp *impPriceScheduleRepository) toPriceSchedulePO(schedule *entity.PriceSchedule) *PriceSchedulePO {
+	po := &PriceSchedulePO{
+		Id:            schedule.Id,
+		ProductId:     schedule.ProductId,
+		SkuId:         schedule.SkuId,
+		Price:         schedule.Price,
+		OriginalPrice: schedule.OriginalPrice,
+		StartAt:       schedule.StartAt,
+		EndAt:         schedule.EndAt,
+		Status:        string(schedule.Status),
+		Actor:         schedule.Actor,
+		Note:          schedule.Note,
+		CreatedAt:     schedule.CreatedAt,
+		UpdatedAt:     schedule.UpdatedAt,
+	}
+	switch schedule.Status {
+	case valueobject.PriceSchedulePending:
+		po.DueAt = schedule.StartAt
+	case valueobject.PriceScheduleActive:
+		po.DueAt = schedule.EndAt
+	}
+	return po
+}
+
+// toEntity 将持久化对象转换为领域实体
+func (imp *impPriceScheduleRepository) toEntity(po *PriceSchedulePO) *entity.PriceSchedule {
+	return &entity.PriceSchedule{
+		Id:            po.Id,
+		ProductId:     po.ProductId,
+		SkuId:         po.SkuId,
+		Price:         po.Price,
+		OriginalPrice: po.OriginalPrice,
+		StartAt:       po.StartAt,
+		EndAt:         po.EndAt,
+		Status:        valueobject.PriceScheduleStatus(po.Status),
+		Actor:         po.Actor,
+		Note:          po.Note,
+		CreatedAt:     po.CreatedAt,
+		UpdatedAt:     po.UpdatedAt,
+	}
+}
@@ -7,7 +7,6 @@ import (
 	"main/internal/domain/product/repository"
 	"main/internal/domain/product/valueobject"
 	sharedvo "main/internal/domain/shared/valueobject"
-	"main/utility/mongodb"
 
 	"github.com/gogf/gf/v2/errors/gerror"
 	"go.mongodb.org/mongo-driver/bson"
@@ -17,14 +16,77 @@ import (
 
 // ProductPO 商品持久化对象
 type ProductPO struct {
-	Id          string  `bson:"_id"`
-	Name        string  `bson:"name"`
-	Description string  `bson:"description"`
-	Price       MoneyPO `bson:"price"`
-	Stock       int     `bson:"stock"`
-	Status      string  `bson:"status"`
-	CreatedAt   int64   `bson:"created_at"`
-	UpdatedAt   int64   `bson:"updated_at"`
+	Id          string           `bson:"_id"`
+	Name        string           `bson:"name"`
+	Description string           `bson:"description"`
+	CategoryId  string           `bson:"category_id,omitempty"`
+	SKUs        []SKUPO          `bson:"skus"`
+	Images      []ProductImagePO `bson:"images,omitempty"`
+	Status      string           `bson:"status"`
+	// Type 商品类型，为空表示引入套装之前的普通商品
+	Type       string              `bson:"type,omitempty"`
+	Components []BundleComponentPO `bson:"components,omitempty"`
+	// LowStockThreshold 低库存阈值，0 表示不预警
+	LowStockThreshold int   `bson:"low_stock_threshold,omitempty"`
+	CreatedAt         int64 `bson:"created_at"`
+	UpdatedAt         int64 `bson:"updated_at"`
+	DeletedAt         int64 `bson:"deleted_at,omitempty"`
+	// Version 乐观锁版本号，每次写入均递增，旧数据没有该字段时视为 0
+	Version int64 `bson:"version"`
+
+	// 由审核通过的评价汇总得到的评分，仅通过 UpdateRating 变更
+	RatingCount   int     `bson:"rating_count,omitempty"`
+	RatingAverage float64 `bson:"rating_average,omitempty"`
+
+	// 由 SKU 汇总得到的冗余字段，仅用于搜索过滤和排序
+	MinPrice   *sharedvo.Money `bson:"min_price,omitempty"`
+	TotalStock int             `bson:"total_stock"` // 可售数量总和，套装为由组件推算的可售数量
+
+	// 引入 SKU 之前商品级的价格和库存，仅用于读取旧数据
+	Price *sharedvo.Money `bson:"price,omitempty"`
+	Stock int             `bson:"stock,omitempty"`
+}
+
+// SKUPO SKU 持久化对象
+type SKUPO struct {
+	Id       string          `bson:"_id"`
+	Options  []SKUOptionPO   `bson:"options"`
+	Price    *sharedvo.Money `bson:"price"`
+	Stock    int             `bson:"stock"`
+	Reserved int             `bson:"reserved"`
+	Barcode  string          `bson:"barcode,omitempty"`
+	// ExternalId 外部编码，用于批量导入时匹配
+	ExternalId string `bson:"external_id,omitempty"`
+}
+
+// SKUOptionPO SKU 规格选项持久化对象
+type SKUOptionPO struct {
+	Name  string `bson:"name"`
+	Value string `bson:"value"`
+}
+
+// BundleComponentPO 套装组件持久化对象
+type BundleComponentPO struct {
+	ProductId string `bson:"product_id"`
+	SkuId     string `bson:"sku_id"`
+	Quantity  int    `bson:"quantity"`
+}
+
+// ProductImagePO 商品图片持久化对象
+type ProductImagePO struct {
+	Id         string                 `bson:"_id"`
+	Original   ImageFilePO            `bson:"original"`
+	Thumbnails map[string]ImageFilePO `bson:"thumbnails"`
+	CreatedAt  int64                  `bson:"created_at"`
+}
+
+// ImageFilePO 图片文件持久化对象
+type ImageFilePO struct {
+	Key         string `bson:"key"`
+	URL         string `bson:"url"`
+	ContentType string `bson:"content_type"`
+	Width       int    `bson:"width"`
+	Height      int    `bson:"height"`
 }
 
 // impProductRepository MongoDB商品持久化实现
@@ -34,21 +96,54 @@ type impProductRepository struct {
 }
 
 // NewProductRepository 创建MongoDB商品持久化实例
-func NewProductRepository(ctx context.Context, cfg mongodb.Config) (repository.ProductRepository, error) {
-	client, err := mongodb.NewMongoClient(ctx, cfg)
-	if err != nil {
-		return nil, err
-	}
-	mongoDb := client.Database(cfg.Database)
-	return &impProductRepository{
+func NewProductRepository(ctx context.Context, mongoDb *mongo.Database) (repository.ProductRepository, error) {
+	imp := &impProductRepository{
 		mongoDb:           mongoDb,
 		productCollection: mongoDb.Collection("product"),
-	}, nil
+	}
+	if err := imp.ensureIndexes(ctx); err != nil {
+		return nil, gerror.Wrap(err, "failed to create product indexes")
+	}
+	return imp, nil
+}
+
+// ensureIndexes 创建商品搜索所需的索引，索引已存在时不做任何操作
+func (imp *impProductRepository) ensureIndexes(ctx context.Context) error {
+	_, err := imp.productCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			// 关键词搜索的全文索引，名称的权重高于描述
+			Keys: bson.D{{Key: "name", Value: "text"}, {Key: "description", Value: "text"}},
+			Options: options.Index().
+				SetName("product_text").
+				SetWeights(bson.M{"name": 10, "description": 1}),
+		},
+		{Keys: bson.D{{Key: "category_id", Value: 1}}},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: -1}}},
+		{
+			// 仅索引已删除的商品，用于清除超过保留期的商品
+			Keys: bson.D{{Key: "deleted_at", Value: 1}},
+			Options: options.Index().SetPartialFilterExpression(bson.M{
+				"status": string(valueobject.ProductStatusDeleted),
+			}),
+		},
+		{Keys: bson.D{{Key: "skus._id", Value: 1}}},
+		{Keys: bson.D{{Key: "components.product_id", Value: 1}}, Options: options.Index().SetSparse(true)},
+		{Keys: bson.D{{Key: "skus.external_id", Value: 1}}, Options: options.Index().SetSparse(true)},
+		{
+			// 仅索引设置了低库存阈值的商品
+			Keys: bson.D{{Key: "low_stock_threshold", Value: 1}},
+			Options: options.Index().SetPartialFilterExpression(bson.M{
+				"low_stock_threshold": bson.M{"$gt": 0},
+			}),
+		},
+	})
+	return err
 }
 
 // Save 保存商品
 func (imp *impProductRepository) Save(ctx context.Context, product *entity.Product) error {
 	po := imp.toProductPO(product)
+	po.Version = product.Version + 1
 	opts := options.Update().SetUpsert(true)
 	_, err := imp.productCollection.UpdateOne(
 		ctx,
@@ -56,7 +151,11 @@ func (imp *impProductRepository) Save(ctx context.Context, product *entity.Produ
 		bson.M{"$set": po},
 		opts,
 	)
-	return err
+	if err != nil {
+		return err
+	}
+	product.Version = po.Version
+	return nil
 }
 
 // FindById 根据Id查找商品
@@ -72,9 +171,83 @@ func (imp *impProductRepository) FindById(ctx context.Context, id string) (*enti
 	return imp.toEntity(&po), nil
 }
 
-// FindAll 查找所有商品
+// FindBySKUId 根据 SKU Id 查找所属商品
+// 引入 SKU 之前的旧数据以商品 Id 作为其唯一 SKU 的 Id
+func (imp *impProductRepository) FindBySKUId(ctx context.Context, skuId string) (*entity.Product, error) {
+	po, err := imp.findPOBySKUId(ctx, skuId)
+	if err != nil {
+		return nil, err
+	}
+	return imp.toEntity(po), nil
+}
+
+// findPOBySKUId 根据 SKU Id 查找所属商品的持久化对象
+func (imp *impProductRepository) findPOBySKUId(ctx context.Context, skuId string) (*ProductPO, error) {
+	var po ProductPO
+	err := imp.productCollection.FindOne(ctx, bson.M{
+		"$or": bson.A{
+			bson.M{"skus._id": skuId},
+			bson.M{"_id": skuId, "skus": bson.M{"$exists": false}},
+		},
+	}).Decode(&po)
+	if err != nil {
+		if gerror.Is(err, mongo.ErrNoDocuments) {
+			return nil, gerror.Wrapf(valueobject.ErrSKUNotFound, "sku %s not found", skuId)
+		}
+		return nil, err
+	}
+	return &po, nil
+}
+
+// FindByCategoryIds 查找属于指定类目的商品，不包括已删除的商品
+func (imp *impProductRepository) FindByCategoryIds(ctx context.Context, categoryIds []string) ([]*entity.Product, error) {
+	return imp.find(ctx, bson.M{
+		"category_id": bson.M{"$in": categoryIds},
+		"status":      bson.M{"$ne": string(valueobject.ProductStatusDeleted)},
+	})
+}
+
+// FindByExternalIds 查找包含指定外部编码 SKU 的商品，包括已删除的商品
+func (imp *impProductRepository) FindByExternalIds(ctx context.Context, externalIds []string) ([]*entity.Product, error) {
+	return imp.find(ctx, bson.M{"skus.external_id": bson.M{"$in": externalIds}})
+}
+
+// FindLowStock 查找可售数量总和不高于低库存阈值的商品
+func (imp *impProductRepository) FindLowStock(ctx context.Context) ([]*entity.Product, error) {
+	return imp.find(ctx, bson.M{
+		"low_stock_threshold": bson.M{"$gt": 0},
+		"status":              bson.M{"$ne": string(valueobject.ProductStatusDeleted)},
+		"$expr":               bson.M{"$lte": bson.A{"$total_stock", "$low_stock_threshold"}},
+	})
+}
+
+// FindBundlesByComponents 查找以指定商品为组件的未删除套装
+func (imp *impProductRepository) FindBundlesByComponents(ctx context.Context, productIds []string) ([]*entity.Product, error) {
+	return imp.find(ctx, bson.M{
+		"type":                  string(valueobject.ProductTypeBundle),
+		"components.product_id": bson.M{"$in": productIds},
+		"status":                bson.M{"$ne": string(valueobject.ProductStatusDeleted)},
+	})
+}
+
+// FindAll 查找所有未删除的商品
 func (imp *impProductRepository) FindAll(ctx context.Context) ([]*entity.Product, error) {
-	cursor, err := imp.productCollection.Find(ctx, bson.M{})
+	return imp.find(ctx, bson.M{"status": bson.M{"$ne": string(valueobject.ProductStatusDeleted)}})
+}
+
+// FindDeleted 查找已删除的商品，按删除时间排序
+// 指定 deletedBefore 时不包括没有记录删除时间的旧数据
+func (imp *impProductRepository) FindDeleted(ctx context.Context, deletedBefore int64) ([]*entity.Product, error) {
+	filter := bson.M{"status": string(valueobject.ProductStatusDeleted)}
+	if deletedBefore > 0 {
+		filter["deleted_at"] = bson.M{"$gt": 0, "$lt": deletedBefore}
+	}
+	return imp.find(ctx, filter, options.Find().SetSort(bson.D{{Key: "deleted_at", Value: 1}}))
+}
+
+// find 按条件查找商品
+func (imp *impProductRepository) find(ctx context.Context, filter bson.M, opts ...*options.FindOptions) ([]*entity.Product, error) {
+	cursor, err := imp.productCollection.Find(ctx, filter, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -93,61 +266,214 @@ func (imp *impProductRepository) FindAll(ctx context.Context) ([]*entity.Product
 }
 
 // Update 更新商品
+// 以读取时的版本号作为条件整体替换文档，商品在读取后被预占库存等操作并发修改时
+// 返回 ErrConcurrentModification，避免覆盖这些修改，由调用方重新读取后重试
 func (imp *impProductRepository) Update(ctx context.Context, product *entity.Product) error {
 	po := imp.toProductPO(product)
+	po.Version = product.Version + 1
 	result, err := imp.productCollection.ReplaceOne(
 		ctx,
-		bson.M{"_id": po.Id},
+		bson.M{"_id": po.Id, "version": versionFilter(product.Version)},
 		po,
 	)
 	if err != nil {
 		return err
 	}
 	if result.MatchedCount == 0 {
-		return gerror.New("product not found")
+		if _, err = imp.FindById(ctx, po.Id); err != nil {
+			return err
+		}
+		return gerror.Wrapf(valueobject.ErrConcurrentModification,
+			"product %s has been modified since version %d", po.Id, product.Version,
+		)
+	}
+	product.Version = po.Version
+	return nil
+}
+
+// versionFilter 匹配指定版本号的条件，旧数据没有版本号字段时视为版本 0
+func versionFilter(version int64) interface{} {
+	if version == 0 {
+		return bson.M{"$in": bson.A{0, nil}}
+	}
+	return version
+}
+
+// versionIncrement 递增版本号的更新操作，用于只修改部分字段的原子更新
+var versionIncrement = bson.M{"version": 1}
+
+// versionIncrementStage 在更新管道中递增版本号的阶段
+var versionIncrementStage = bson.D{{Key: "$set", Value: bson.M{
+	"version": bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$version", 0}}, 1}},
+}}}
+
+// UpdateBundleAvailable 只更新套装的可售数量，套装的 total_stock 保存由组件推算的可售数量
+func (imp *impProductRepository) UpdateBundleAvailable(ctx context.Context, id string, available int) error {
+	result, err := imp.productCollection.UpdateOne(
+		ctx,
+		bson.M{"_id": id, "type": string(valueobject.ProductTypeBundle)},
+		bson.M{
+			"$set": bson.M{"total_stock": available},
+			"$inc": versionIncrement,
+		},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return valueobject.ErrProductNotFound
+	}
+	return nil
+}
+
+// UpdateRating 只更新商品的评分汇总，不影响并发修改的其他字段
+func (imp *impProductRepository) UpdateRating(ctx context.Context, id string, rating valueobject.ProductRating) error {
+	result, err := imp.productCollection.UpdateOne(
+		ctx,
+		bson.M{"_id": id},
+		bson.M{
+			"$set": bson.M{
+				"rating_count":   rating.Count,
+				"rating_average": rating.Average,
+			},
+			"$inc": versionIncrement,
+		},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return valueobject.ErrProductNotFound
 	}
 	return nil
 }
 
-// Delete 删除商品
+// Delete 永久删除已删除状态的商品
+// 以状态作为删除条件，避免清除并发恢复的商品
 func (imp *impProductRepository) Delete(ctx context.Context, id string) error {
-	result, err := imp.productCollection.DeleteOne(ctx, bson.M{"_id": id})
+	result, err := imp.productCollection.DeleteOne(ctx, bson.M{
+		"_id":    id,
+		"status": string(valueobject.ProductStatusDeleted),
+	})
 	if err != nil {
 		return err
 	}
 	if result.DeletedCount == 0 {
-		return gerror.New("product not found")
+		return gerror.Wrapf(valueobject.ErrProductNotFound, "deleted product %s not found", id)
 	}
 	return nil
 }
 
 // toProductPO 将领域实体转换为商品持久化对象
 func (imp *impProductRepository) toProductPO(product *entity.Product) *ProductPO {
+	skus := make([]SKUPO, len(product.SKUs))
+	for i, sku := range product.SKUs {
+		options := make([]SKUOptionPO, len(sku.Options))
+		for j, option := range sku.Options {
+			options[j] = SKUOptionPO{
+				Name:  option.Name,
+				Value: option.Value,
+			}
+		}
+		skus[i] = SKUPO{
+			Id:       sku.Id,
+			Options:  options,
+			Price:    sku.Price,
+			Stock:    sku.Stock,
+			Reserved: sku.Reserved,
+			Barcode:  sku.Barcode,
+
+			ExternalId: sku.ExternalId,
+		}
+	}
+
+	images := make([]ProductImagePO, len(product.Images))
+	for i, image := range product.Images {
+		images[i] = toProductImagePO(image)
+	}
+
+	components := make([]BundleComponentPO, len(product.Components))
+	for i, component := range product.Components {
+		components[i] = BundleComponentPO(component)
+	}
+
 	return &ProductPO{
-		Id:          product.Id,
-		Name:        product.Name,
-		Description: product.Description,
-		Price: MoneyPO{
-			Amount:   product.Price.Amount(),
-			Currency: product.Price.Currency(),
-		},
-		Stock:     product.Stock,
-		Status:    string(product.Status),
-		CreatedAt: product.CreatedAt,
-		UpdatedAt: product.UpdatedAt,
+		Id:                product.Id,
+		Name:              product.Name,
+		Description:       product.Description,
+		CategoryId:        product.CategoryId,
+		SKUs:              skus,
+		Images:            images,
+		Status:            string(product.Status),
+		Type:              string(product.Type),
+		Components:        components,
+		LowStockThreshold: product.LowStockThreshold,
+		RatingCount:       product.Rating.Count,
+		RatingAverage:     product.Rating.Average,
+		CreatedAt:         product.CreatedAt,
+		UpdatedAt:         product.UpdatedAt,
+		DeletedAt:         product.DeletedAt,
+		Version:           product.Version,
+		MinPrice:          product.MinPrice(),
+		TotalStock:        product.TotalAvailable(),
 	}
 }
 
 // toEntity 将持久化对象转换为领域实体
 func (imp *impProductRepository) toEntity(po *ProductPO) *entity.Product {
-	return entity.NewProduct(
+	skus := make([]*entity.SKU, len(po.SKUs))
+	for i, sku := range po.SKUs {
+		options := make(valueobject.SKUOptions, len(sku.Options))
+		for j, option := range sku.Options {
+			options[j] = valueobject.SKUOption{
+				Name:  option.Name,
+				Value: option.Value,
+			}
+		}
+		skus[i] = entity.NewSKU(sku.Id, options, sku.Price, sku.Stock, sku.Barcode)
+		skus[i].Reserved = sku.Reserved
+		skus[i].ExternalId = sku.ExternalId
+	}
+
+	// 旧数据没有 SKU，将商品级的价格和库存视为一个无规格的 SKU
+	if len(po.SKUs) == 0 && po.Price != nil {
+		skus = append(skus, entity.NewSKU(po.Id, nil, po.Price, po.Stock, ""))
+	}
+
+	product := entity.NewProduct(
 		po.Id,
 		po.Name,
 		po.Description,
-		sharedvo.NewMoney(po.Price.Amount, po.Price.Currency),
-		po.Stock,
+		po.CategoryId,
+		skus,
 		valueobject.ProductStatus(po.Status),
 		po.CreatedAt,
 		po.UpdatedAt,
 	)
+	if po.Type != "" {
+		product.Type = valueobject.ProductType(po.Type)
+	}
+	for _, component := range po.Components {
+		product.Components = append(product.Components, valueobject.BundleComponent(component))
+	}
+	if product.IsBundle() {
+		product.BundleAvailable = po.TotalStock
+	}
+	product.LowStockThreshold = po.LowStockThreshold
+	product.DeletedAt = po.DeletedAt
+	product.Version = po.Version
+	product.Rating = valueobject.ProductRating{Count: po.RatingCount, Average: po.RatingAverage}
+	for _, image := range po.Images {
+		thumbnails := make(map[string]valueobject.ImageFile, len(image.Thumbnails))
+		for name, thumbnail := range image.Thumbnails {
+			thumbnails[name] = valueobject.ImageFile(thumbnail)
+		}
+		product.Images = append(product.Images, entity.NewProductImage(
+			image.Id,
+			valueobject.ImageFile(image.Original),
+			thumbnails,
+			image.CreatedAt,
+		))
+	}
+	return product
 }
@@ -0,0 +1,114 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"main/internal/domain/product/entity"
+	"main/internal/domain/product/valueobject"
+)
+
+// AddImage 原子地将图片追加到商品图片列表末尾
+// 以图片列表中不存在第 MaxProductImages 张作为条件，并发上传时数量限制同样有效
+func (imp *impProductRepository) AddImage(ctx context.Context, productId string, image *entity.ProductImage) error {
+	result, err := imp.productCollection.UpdateOne(
+		ctx,
+		bson.M{
+			"_id": productId,
+			fmt.Sprintf("images.%d", valueobject.MaxProductImages-1): bson.M{"$exists": false},
+		},
+		bson.M{
+			"$push": bson.M{"images": toProductImagePO(image)},
+			"$inc":  versionIncrement,
+		},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		if _, err = imp.FindById(ctx, productId); err != nil {
+			return err
+		}
+		return gerror.Wrapf(valueobject.ErrTooManyImages,
+			"product %s already has %d images", productId, valueobject.MaxProductImages,
+		)
+	}
+	return nil
+}
+
+// RemoveImage 原子地从商品图片列表中移除图片
+func (imp *impProductRepository) RemoveImage(ctx context.Context, productId string, imageId string) error {
+	result, err := imp.productCollection.UpdateOne(
+		ctx,
+		bson.M{"_id": productId, "images._id": imageId},
+		bson.M{
+			"$pull": bson.M{"images": bson.M{"_id": imageId}},
+			"$inc":  versionIncrement,
+		},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return gerror.Wrapf(valueobject.ErrImageNotFound, "image %s not found in product %s", imageId, productId)
+	}
+	return nil
+}
+
+// ReorderImages 原子地按给定顺序重新排列商品图片
+// 以图片数量和图片 Id 集合均未变化作为条件，避免覆盖并发上传或移除的图片
+func (imp *impProductRepository) ReorderImages(ctx context.Context, productId string, imageIds []string) error {
+	if len(imageIds) == 0 {
+		return nil
+	}
+	result, err := imp.productCollection.UpdateOne(
+		ctx,
+		bson.M{
+			"_id":        productId,
+			"images":     bson.M{"$size": len(imageIds)},
+			"images._id": bson.M{"$all": imageIds},
+		},
+		mongo.Pipeline{{{Key: "$set", Value: bson.M{"images": bson.M{"$map": bson.M{
+			"input": imageIds,
+			"as":    "id",
+			"in": bson.M{"$arrayElemAt": bson.A{
+				bson.M{"$filter": bson.M{
+					"input": "$images",
+					"as":    "image",
+					"cond":  bson.M{"$eq": bson.A{"$$image._id", "$$id"}},
+				}},
+				0,
+			}},
+		}}}}}, versionIncrementStage},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		if _, err = imp.FindById(ctx, productId); err != nil {
+			return err
+		}
+		return gerror.Wrapf(valueobject.ErrInvalidImageOrder,
+			"images of product %s have been changed concurrently", productId,
+		)
+	}
+	return nil
+}
+
+// toProductImagePO 将商品图片转换为持久化对象
+func toProductImagePO(image *entity.ProductImage) ProductImagePO {
+	thumbnails := make(map[string]ImageFilePO, len(image.Thumbnails))
+	for name, thumbnail := range image.Thumbnails {
+		thumbnails[name] = ImageFilePO(thumbnail)
+	}
+	return ProductImagePO{
+		Id:         image.Id,
+		Original:   ImageFilePO(image.Original),
+		Thumbnails: thumbnails,
+		CreatedAt:  image.CreatedAt,
+	}
+}
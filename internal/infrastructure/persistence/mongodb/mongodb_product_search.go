@@ -0,0 +1,179 @@
+package mongodb
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"main/internal/domain/product/entity"
+	"main/internal/domain/product/repository"
+	"main/internal/domain/product/valueobject"
+)
+
+// productSortFields 排序字段对应的文档字段
+var productSortFields = map[valueobject.ProductSortField]string{
+	valueobject.ProductSortCreatedAt: "created_at",
+	valueobject.ProductSortPrice:     "min_price.amount_minor",
+	valueobject.ProductSortName:      "name",
+}
+
+// productCursor 商品搜索的分页游标
+// 按字段排序时记录上一页最后一个商品的排序值和Id（键集分页），
+// 按相关度排序时相关度无法作为查询条件，退化为记录偏移量
+type productCursor struct {
+	Int    int64  `json:"i,omitempty"`
+	Str    string `json:"s,omitempty"`
+	Id     string `json:"id,omitempty"`
+	Offset int64  `json:"o,omitempty"`
+}
+
+// encode 编码为不透明的游标字符串
+func (c productCursor) encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeProductCursor 解码游标字符串
+func decodeProductCursor(cursor string) (*productCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, gerror.Wrap(valueobject.ErrInvalidSearchCriteria, "malformed cursor")
+	}
+	var c productCursor
+	if err = json.Unmarshal(data, &c); err != nil {
+		return nil, gerror.Wrap(valueobject.ErrInvalidSearchCriteria, "malformed cursor")
+	}
+	return &c, nil
+}
+
+// Search 按条件搜索商品
+func (imp *impProductRepository) Search(
+	ctx context.Context,
+	criteria valueobject.ProductSearchCriteria,
+) (*repository.ProductSearchResult, error) {
+	var cursor *productCursor
+	if criteria.Cursor != "" {
+		var err error
+		if cursor, err = decodeProductCursor(criteria.Cursor); err != nil {
+			return nil, err
+		}
+	}
+
+	// 1. 构造过滤条件
+	filter := imp.searchFilter(criteria)
+
+	// 2. 构造排序和分页条件，多查一条用于判断是否还有下一页
+	opts := options.Find().SetLimit(int64(criteria.Limit) + 1)
+	if criteria.SortBy == valueobject.ProductSortRelevance {
+		score := bson.M{"$meta": "textScore"}
+		opts.SetProjection(bson.M{"score": score})
+		opts.SetSort(bson.D{{Key: "score", Value: score}, {Key: "_id", Value: 1}})
+		if cursor != nil {
+			opts.SetSkip(cursor.Offset)
+		}
+	} else {
+		field := productSortFields[criteria.SortBy]
+		direction, compare := 1, "$gt"
+		if criteria.SortDirection == valueobject.SortDesc {
+			direction, compare = -1, "$lt"
+		}
+		opts.SetSort(bson.D{{Key: field, Value: direction}, {Key: "_id", Value: direction}})
+		if cursor != nil {
+			value := cursor.sortValue(criteria.SortBy)
+			filter["$or"] = bson.A{
+				bson.M{field: bson.M{compare: value}},
+				bson.M{field: value, "_id": bson.M{compare: cursor.Id}},
+			}
+		}
+	}
+
+	// 3. 执行查询
+	mongoCursor, err := imp.productCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer mongoCursor.Close(ctx)
+
+	var pos []ProductPO
+	if err = mongoCursor.All(ctx, &pos); err != nil {
+		return nil, err
+	}
+
+	// 4. 生成下一页游标
+	result := &repository.ProductSearchResult{}
+	if len(pos) > criteria.Limit {
+		pos = pos[:criteria.Limit]
+		last := pos[len(pos)-1]
+		next := productCursor{Id: last.Id}
+		switch criteria.SortBy {
+		case valueobject.ProductSortRelevance:
+			next.Offset = int64(criteria.Limit)
+			if cursor != nil {
+				next.Offset += cursor.Offset
+			}
+		case valueobject.ProductSortName:
+			next.Str = last.Name
+		case valueobject.ProductSortPrice:
+			if last.MinPrice != nil {
+				next.Int = last.MinPrice.MinorAmount()
+			}
+		default:
+			next.Int = last.CreatedAt
+		}
+		result.NextCursor = next.encode()
+	}
+
+	result.Products = make([]*entity.Product, len(pos))
+	for i, po := range pos {
+		result.Products[i] = imp.toEntity(&po)
+	}
+	return result, nil
+}
+
+// searchFilter 根据搜索条件构造过滤条件
+func (imp *impProductRepository) searchFilter(criteria valueobject.ProductSearchCriteria) bson.M {
+	filter := bson.M{}
+	if criteria.Keyword != "" {
+		filter["$text"] = bson.M{"$search": criteria.Keyword}
+	}
+	if criteria.Status != "" {
+		filter["status"] = string(criteria.Status)
+	} else {
+		filter["status"] = bson.M{"$ne": string(valueobject.ProductStatusDeleted)}
+	}
+	if len(criteria.CategoryIds) > 0 {
+		filter["category_id"] = bson.M{"$in": criteria.CategoryIds}
+	}
+	if criteria.InStock {
+		filter["total_stock"] = bson.M{"$gt": 0}
+	}
+
+	// 任一 SKU 的价格落在区间内即匹配，价格按最小货币单位比较
+	if criteria.MinPrice != nil || criteria.MaxPrice != nil {
+		amount := bson.M{}
+		price := bson.M{}
+		if criteria.MinPrice != nil {
+			amount["$gte"] = criteria.MinPrice.MinorAmount()
+			price["price.currency"] = criteria.MinPrice.Currency()
+		}
+		if criteria.MaxPrice != nil {
+			amount["$lte"] = criteria.MaxPrice.MinorAmount()
+			price["price.currency"] = criteria.MaxPrice.Currency()
+		}
+		price["price.amount_minor"] = amount
+		filter["skus"] = bson.M{"$elemMatch": price}
+	}
+	return filter
+}
+
+// sortValue 获取游标中与排序字段对应的排序值
+func (c *productCursor) sortValue(sortBy valueobject.ProductSortField) interface{} {
+	if sortBy == valueobject.ProductSortName {
+		return c.Str
+	}
+	return c.Int
+}
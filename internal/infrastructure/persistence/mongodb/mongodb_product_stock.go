@@ -0,0 +1,271 @@
+package mongodb
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"main/internal/domain/product/entity"
+	"main/internal/domain/product/valueobject"
+)
+
+// maxStockRetries 库存条件更新未命中但重新检查又满足条件时的最大重试次数
+// 这种情况说明库存在两次操作之间被并发修改
+const maxStockRetries = 3
+
+// stockOperation 库存操作
+type stockOperation struct {
+	// filter 执行更新的前提条件
+	filter func(skuId string, quantity int) bson.M
+	// pipeline 更新 SKU 库存、汇总可售数量并联动商品状态的更新管道
+	pipeline func(skuId string, quantity int) mongo.Pipeline
+	// apply 对应的领域操作，用于条件未满足时给出具体原因
+	apply func(product *entity.Product, skuId string, quantity int) error
+}
+
+// reservedExpr SKU 预占数量的表达式，旧数据没有该字段时视为 0
+const reservedExpr = "$$sku.reserved"
+
+// reserveOperation 预占库存：商品在售、不是套装且 SKU 可售数量充足时增加预占，可售总数为 0 时标记售罄
+var reserveOperation = stockOperation{
+	filter: func(skuId string, quantity int) bson.M {
+		return bson.M{
+			"status":   string(valueobject.ProductStatusOnSale),
+			"type":     bson.M{"$ne": string(valueobject.ProductTypeBundle)},
+			"skus._id": skuId,
+			// 可售数量 = 在库数量 - 预占数量，需要比较同一个 SKU 的两个字段
+			"$expr": bson.M{"$anyElementTrue": bson.A{bson.M{"$map": bson.M{
+				"input": "$skus",
+				"as":    "sku",
+				"in": bson.M{"$and": bson.A{
+					bson.M{"$eq": bson.A{"$$sku._id", skuId}},
+					bson.M{"$gte": bson.A{availableExpr, quantity}},
+				}},
+			}}}},
+		}
+	},
+	pipeline: func(skuId string, quantity int) mongo.Pipeline {
+		return stockPipeline(skuId, 0, quantity, bson.M{"$and": bson.A{
+			bson.M{"$eq": bson.A{"$status", string(valueobject.ProductStatusOnSale)}},
+			bson.M{"$lte": bson.A{"$total_stock", 0}},
+		}}, valueobject.ProductStatusSoldOut)
+	},
+	apply: (*entity.Product).ReserveStock,
+}
+
+// releaseOperation 释放预占：售罄的商品重新有可售库存后恢复在售
+var releaseOperation = stockOperation{
+	filter: reservedFilter,
+	pipeline: func(skuId string, quantity int) mongo.Pipeline {
+		return stockPipeline(skuId, 0, -quantity, bson.M{"$and": bson.A{
+			bson.M{"$eq": bson.A{"$status", string(valueobject.ProductStatusSoldOut)}},
+			bson.M{"$gt": bson.A{"$total_stock", 0}},
+		}}, valueobject.ProductStatusOnSale)
+	},
+	apply: (*entity.Product).ReleaseStock,
+}
+
+// commitOperation 确认预占：在库数量和预占数量同时减少，可售数量和商品状态不变
+var commitOperation = stockOperation{
+	filter: reservedFilter,
+	pipeline: func(skuId string, quantity int) mongo.Pipeline {
+		return stockPipeline(skuId, -quantity, -quantity, nil, "")
+	},
+	apply: (*entity.Product).CommitStock,
+}
+
+// adjustOperation 调整在库数量：套装不持有库存，调整后不能少于预占数量，并按可售总数联动在售与售罄状态
+var adjustOperation = stockOperation{
+	filter: func(skuId string, delta int) bson.M {
+		return bson.M{
+			"type":     bson.M{"$ne": string(valueobject.ProductTypeBundle)},
+			"skus._id": skuId,
+			"$expr": bson.M{"$anyElementTrue": bson.A{bson.M{"$map": bson.M{
+				"input": "$skus",
+				"as":    "sku",
+				"in": bson.M{"$and": bson.A{
+					bson.M{"$eq": bson.A{"$$sku._id", skuId}},
+					bson.M{"$gte": bson.A{availableExpr, -delta}},
+				}},
+			}}}},
+		}
+	},
+	pipeline: func(skuId string, delta int) mongo.Pipeline {
+		if delta > 0 {
+			return stockPipeline(skuId, delta, 0, bson.M{"$and": bson.A{
+				bson.M{"$eq": bson.A{"$status", string(valueobject.ProductStatusSoldOut)}},
+				bson.M{"$gt": bson.A{"$total_stock", 0}},
+			}}, valueobject.ProductStatusOnSale)
+		}
+		return stockPipeline(skuId, delta, 0, bson.M{"$and": bson.A{
+			bson.M{"$eq": bson.A{"$status", string(valueobject.ProductStatusOnSale)}},
+			bson.M{"$lte": bson.A{"$total_stock", 0}},
+		}}, valueobject.ProductStatusSoldOut)
+	},
+	apply: (*entity.Product).AdjustStock,
+}
+
+// availableExpr SKU 可售数量的表达式
+var availableExpr = bson.M{"$subtract": bson.A{"$$sku.stock", bson.M{"$ifNull": bson.A{reservedExpr, 0}}}}
+
+// reservedFilter SKU 预占数量不少于 quantity 的条件
+func reservedFilter(skuId string, quantity int) bson.M {
+	return bson.M{"skus": bson.M{"$elemMatch": bson.M{
+		"_id":      skuId,
+		"reserved": bson.M{"$gte": quantity},
+	}}}
+}
+
+// stockPipeline 构造库存更新管道
+// 在同一次原子更新中调整 SKU 的在库和预占数量、重新汇总可售总数 total_stock，
+// 并在 statusCond 成立时将商品变更为 status，statusCond 为空时不变更状态；
+// 同时递增版本号，使读取后整体保存商品的操作能够发现库存已被修改
+func stockPipeline(
+	skuId string,
+	stockDelta int,
+	reservedDelta int,
+	statusCond bson.M,
+	status valueobject.ProductStatus,
+) mongo.Pipeline {
+	pipeline := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{"skus": bson.M{"$map": bson.M{
+			"input": "$skus",
+			"as":    "sku",
+			"in": bson.M{"$cond": bson.A{
+				bson.M{"$eq": bson.A{"$$sku._id", skuId}},
+				bson.M{"$mergeObjects": bson.A{"$$sku", bson.M{
+					"stock":    bson.M{"$add": bson.A{"$$sku.stock", stockDelta}},
+					"reserved": bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{reservedExpr, 0}}, reservedDelta}},
+				}}},
+				"$$sku",
+			}},
+		}}}}},
+		{{Key: "$set", Value: bson.M{"total_stock": bson.M{"$sum": bson.M{"$map": bson.M{
+			"input": "$skus",
+			"as":    "sku",
+			"in":    availableExpr,
+		}}}}}},
+		versionIncrementStage,
+	}
+	if statusCond != nil {
+		pipeline = append(pipeline, bson.D{{Key: "$set", Value: bson.M{
+			"status": bson.M{"$cond": bson.A{statusCond, string(status), "$status"}},
+		}}})
+	}
+	return pipeline
+}
+
+// ReserveStock 原子地预占库存
+// 每个 SKU 的预占是一次带条件的原子更新；某个 SKU 预占失败时，
+// 释放此前已预占的 SKU，保证多个 SKU 要么全部预占，要么全部不预占
+func (imp *impProductRepository) ReserveStock(
+	ctx context.Context,
+	items valueobject.StockItems,
+) ([]*entity.Product, error) {
+	befores := make([]*entity.Product, 0, len(items))
+	for i, item := range items {
+		before, err := imp.changeStock(ctx, item, reserveOperation)
+		if err != nil {
+			for _, reserved := range items[:i] {
+				if _, rollbackErr := imp.changeStock(ctx, reserved, releaseOperation); rollbackErr != nil {
+					return nil, gerror.Wrapf(err,
+						"failed to roll back reservation of sku %s: %v", reserved.SkuId, rollbackErr,
+					)
+				}
+			}
+			return nil, err
+		}
+		befores = append(befores, before)
+	}
+	return befores, nil
+}
+
+// ReleaseStock 原子地释放预占的库存
+func (imp *impProductRepository) ReleaseStock(
+	ctx context.Context,
+	items valueobject.StockItems,
+) ([]*entity.Product, error) {
+	return imp.changeStockItems(ctx, items, releaseOperation)
+}
+
+// CommitStock 原子地确认预占的库存
+func (imp *impProductRepository) CommitStock(
+	ctx context.Context,
+	items valueobject.StockItems,
+) ([]*entity.Product, error) {
+	return imp.changeStockItems(ctx, items, commitOperation)
+}
+
+// AdjustStock 原子地按增量调整 SKU 的在库数量
+func (imp *impProductRepository) AdjustStock(ctx context.Context, skuId string, delta int) (*entity.Product, error) {
+	return imp.changeStock(ctx, valueobject.StockItem{SkuId: skuId, Quantity: delta}, adjustOperation)
+}
+
+// changeStockItems 依次对各 SKU 执行库存操作
+// 释放和确认针对的是已成功预占的库存，正常情况下不会失败，因此失败时不回滚
+func (imp *impProductRepository) changeStockItems(
+	ctx context.Context,
+	items valueobject.StockItems,
+	op stockOperation,
+) ([]*entity.Product, error) {
+	befores := make([]*entity.Product, 0, len(items))
+	for _, item := range items {
+		before, err := imp.changeStock(ctx, item, op)
+		if err != nil {
+			return nil, err
+		}
+		befores = append(befores, before)
+	}
+	return befores, nil
+}
+
+// changeStock 对单个 SKU 执行带条件的原子库存更新，返回更新前的商品
+func (imp *impProductRepository) changeStock(
+	ctx context.Context,
+	item valueobject.StockItem,
+	op stockOperation,
+) (*entity.Product, error) {
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.Before)
+	for attempt := 0; ; attempt++ {
+		var po ProductPO
+		err := imp.productCollection.FindOneAndUpdate(
+			ctx,
+			op.filter(item.SkuId, item.Quantity),
+			op.pipeline(item.SkuId, item.Quantity),
+			opts,
+		).Decode(&po)
+		if err == nil {
+			return imp.toEntity(&po), nil
+		}
+		if !gerror.Is(err, mongo.ErrNoDocuments) {
+			return nil, err
+		}
+
+		// 条件未满足，重新加载商品，由领域规则给出具体原因
+		current, err := imp.findPOBySKUId(ctx, item.SkuId)
+		if err != nil {
+			return nil, err
+		}
+		if err = op.apply(imp.toEntity(current), item.SkuId, item.Quantity); err != nil {
+			return nil, err
+		}
+
+		// 引入 SKU 之前的旧数据无法按 SKU 条件更新，先迁移为 SKU 结构再重试
+		// 迁移时版本号冲突说明商品已被并发修改，重新执行即可
+		if len(current.SKUs) == 0 {
+			err = imp.Update(ctx, imp.toEntity(current))
+			if err != nil && !gerror.Is(err, valueobject.ErrConcurrentModification) {
+				return nil, gerror.Wrap(err, "failed to migrate legacy product")
+			}
+			continue
+		}
+		if attempt >= maxStockRetries {
+			return nil, gerror.Wrapf(valueobject.ErrInsufficientStock,
+				"stock of sku %s is being modified concurrently", item.SkuId,
+			)
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package mongodb
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"main/internal/domain/product/entity"
+	"main/internal/domain/product/repository"
+	"main/internal/domain/product/valueobject"
+)
+
+// ReservationPO 库存预占持久化对象
+type ReservationPO struct {
+	Id          string `bson:"_id"`
+	OrderId     string `bson:"order_id"`
+	ProductId   string `bson:"product_id"`
+	SkuId       string `bson:"sku_id"`
+	WarehouseId string `bson:"warehouse_id,omitempty"`
+	Quantity    int    `bson:"quantity"`
+	State       string `bson:"state"`
+	ExpiresAt   int64  `bson:"expires_at"`
+	CreatedAt   int64  `bson:"created_at"`
+	UpdatedAt   int64  `bson:"updated_at"`
+}
+
+// impReservationRepository MongoDB库存预占持久化实现
+type impReservationRepository struct {
+	mongoDb               *mongo.Database
+	reservationCollection *mongo.Collection
+}
+
+// NewReservationRepository 创建MongoDB库存预占持久化实例
+func NewReservationRepository(ctx context.Context, mongoDb *mongo.Database) (repository.ReservationRepository, error) {
+	imp := &impReservationRepository{
+		mongoDb:               mongoDb,
+		reservationCollection: mongoDb.Collection("stock_reservation"),
+	}
+	if err := imp.ensureIndexes(ctx); err != nil {
+		return nil, gerror.Wrap(err, "failed to create stock reservation indexes")
+	}
+	return imp, nil
+}
+
+// ensureIndexes 创建按订单、按商品以及查找过期预占所需的索引
+func (imp *impReservationRepository) ensureIndexes(ctx context.Context) error {
+	_, err := imp.reservationCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "order_id", Value: 1}}},
+		{Keys: bson.D{{Key: "product_id", Value: 1}, {Key: "state", Value: 1}}},
+		{Keys: bson.D{{Key: "state", Value: 1}, {Key: "expires_at", Value: 1}}},
+	})
+	return err
+}
+
+// Save 保存新的预占记录
+func (imp *impReservationRepository) Save(ctx context.Context, reservation *entity.Reservation) error {
+	po := imp.toReservationPO(reservation)
+
+	_, err := imp.reservationCollection.InsertOne(ctx, po)
+	return err
+}
+
+// FindByOrderId 查找订单的预占记录
+func (imp *impReservationRepository) FindByOrderId(ctx context.Context, orderId string) ([]*entity.Reservation, error) {
+	return imp.find(ctx, bson.M{"order_id": orderId}, options.Find())
+}
+
+// FindByProductId 查找商品的预占记录，按创建时间倒序
+func (imp *impReservationRepository) FindByProductId(
+	ctx context.Context,
+	productId string,
+	state valueobject.ReservationState,
+) ([]*entity.Reservation, error) {
+	filter := bson.M{"product_id": productId}
+	if state != "" {
+		filter["state"] = string(state)
+	}
+	return imp.find(ctx, filter, options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}))
+}
+
+// FindExpired 查找已过期但仍处于预占中的记录，最早过期的优先
+func (imp *impReservationRepository) FindExpired(ctx context.Context, now int64, limit int) ([]*entity.Reservation, error) {
+	return imp.find(
+		ctx,
+		bson.M{
+			"state":      string(valueobject.ReservationStateHeld),
+			"expires_at": bson.M{"$lte": now},
+		},
+		options.Find().SetSort(bson.D{{Key: "expires_at", Value: 1}}).SetLimit(int64(limit)),
+	)
+}
+
+// UpdateState 仅当预占记录当前处于 from 状态时保存其新状态
+func (imp *impReservationRepository) UpdateState(
+	ctx context.Context,
+	reservation *entity.Reservation,
+	from valueobject.ReservationState,
+) error {
+	result, err := imp.reservationCollection.UpdateOne(
+		ctx,
+		bson.M{"_id": reservation.Id, "state": string(from)},
+		bson.M{"$set": bson.M{
+			"state":      string(reservation.State),
+			"updated_at": reservation.UpdatedAt,
+		}},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return gerror.Wrapf(valueobject.ErrReservationConflict,
+			"reservation %s is no longer %s", reservation.Id, from,
+		)
+	}
+	return nil
+}
+
+// Commit 仅当预占记录处于预占中且在 now 时尚未过期时将其保存为已确认
+func (imp *impReservationRepository) Commit(ctx context.Context, reservation *entity.Reservation, now int64) error {
+	result, err := imp.reservationCollection.UpdateOne(
+		ctx,
+		bson.M{
+			"_id":        reservation.Id,
+			"state":      string(valueobject.ReservationStateHeld),
+			"expires_at": bson.M{"$gt": now},
+		},
+		bson.M{"$set": bson.M{
+			"state":      string(valueobject.ReservationStateCommitted),
+			"updated_at": reservation.UpdatedAt,
+		}},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return gerror.Wrapf(valueobject.ErrReservationConflict,
+			"reservation %s is no longer held or has expired", reservation.Id,
+		)
+	}
+	return nil
+}
+
+// find 根据条件查找预占记录
+func (imp *impReservationRepository) find(
+	ctx context.Context,
+	filter bson.M,
+	opts *options.FindOptions,
+) ([]*entity.Reservation, error) {
+	cursor, err := imp.reservationCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var pos []ReservationPO
+	if err = cursor.All(ctx, &pos); err != nil {
+		return nil, err
+	}
+
+	reservations := make([]*entity.Reservation, len(pos))
+	for i, po := range pos {
+		reservations[i] = imp.toEntity(&po)
+	}
+	return reservations, nil
+}
+
+// toReservationPO 将领域实体转换为持久化对象
+func (imp *impReservationRepository) toReservationPO(reservation *entity.Reservation) *ReservationPO {
+	return &ReservationPO{
+		Id:          reservation.Id,
+		OrderId:     reservation.OrderId,
+		ProductId:   reservation.ProductId,
+		SkuId:       reservation.SkuId,
+		WarehouseId: reservation.WarehouseId,
+		Quantity:    reservation.Quantity,
+		State:       string(reservation.State),
+		ExpiresAt:   reservation.ExpiresAt,
+		CreatedAt:   reservation.CreatedAt,
+		UpdatedAt:   reservation.UpdatedAt,
+	}
+}
+
+// toEntity 将持久化对象转换为领域实体
+func (imp *impReservationRepository) toEntity(po *ReservationPO) *entity.Reservation {
+	return &entity.Reservation{
+		Id:          po.Id,
+		OrderId:     po.OrderId,
+		ProductId:   po.ProductId,
+		SkuId:       po.SkuId,
+		WarehouseId: po.WarehouseId,
+		Quantity:    po.Quantity,
+		State:       valueobject.ReservationState(po.State),
+		ExpiresAt:   po.ExpiresAt,
+		CreatedAt:   po.CreatedAt,
+		UpdatedAt:   po.UpdatedAt,
+	}
+}
@@ -0,0 +1,254 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"main/internal/domain/review/entity"
+	"main/internal/domain/review/repository"
+	"main/internal/domain/review/valueobject"
+)
+
+// ReviewPO 评价持久化对象
+type ReviewPO struct {
+	Id             string `bson:"_id"`
+	ProductId      string `bson:"product_id"`
+	SkuId          string `bson:"sku_id"`
+	OrderId        string `bson:"order_id"`
+	OrderItemId    string `bson:"order_item_id,omitempty"` // 引入订单项 Id 之前的旧评价没有该字段
+	UserId         string `bson:"user_id"`
+	Rating         int    `bson:"rating"`
+	Content        string `bson:"content"`
+	Status         string `bson:"status"`
+	Moderator      string `bson:"moderator,omitempty"`
+	ModerationNote string `bson:"moderation_note,omitempty"`
+	ModeratedAt    int64  `bson:"moderated_at,omitempty"`
+	CreatedAt      int64  `bson:"created_at"`
+	UpdatedAt      int64  `bson:"updated_at"`
+}
+
+// impReviewRepository MongoDB评价持久化实现
+type impReviewRepository struct {
+	mongoDb          *mongo.Database
+	reviewCollection *mongo.Collection
+}
+
+// NewReviewRepository 创建MongoDB评价持久化实例
+func NewReviewRepository(ctx context.Context, mongoDb *mongo.Database) (repository.ReviewRepository, error) {
+	imp := &impReviewRepository{
+		mongoDb:          mongoDb,
+		reviewCollection: mongoDb.Collection("review"),
+	}
+	if err := imp.ensureIndexes(ctx); err != nil {
+		return nil, gerror.Wrap(err, "failed to create review indexes")
+	}
+	return imp, nil
+}
+
+// legacyOrderSkuIndex 以订单和 SKU 确定订单项的旧唯一索引，
+// 同一订单中同一 SKU 的多个订单项无法分别评价，已由订单项 Id 的唯一索引取代
+const legacyOrderSkuIndex = "order_id_1_sku_id_1"
+
+// ensureIndexes 创建保证每个订单项只有一条评价的唯一索引，以及按商品、按用户和按状态分页所需的索引
+func (imp *impReviewRepository) ensureIndexes(ctx context.Context) error {
+	if _, err := imp.reviewCollection.Indexes().DropOne(ctx, legacyOrderSkuIndex); err != nil && !isIndexNotFound(err) {
+		return err
+	}
+	_, err := imp.reviewCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			// 旧评价没有订单项 Id，不参与唯一性约束
+			Keys: bson.D{{Key: "order_item_id", Value: 1}},
+			Options: options.Index().SetUnique(true).SetPartialFilterExpression(bson.M{
+				"order_item_id": bson.M{"$exists": true},
+			}),
+		},
+		{Keys: bson.D{{Key: "product_id", Value: 1}, {Key: "status", Value: 1}, {Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: -1}}},
+	})
+	return err
+}
+
+// Save 保存新的评价
+func (imp *impReviewRepository) Save(ctx context.Context, review *entity.Review) error {
+	po := imp.toReviewPO(review)
+
+	if _, err := imp.reviewCollection.InsertOne(ctx, po); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return gerror.Wrapf(valueobject.ErrDuplicateReview,
+				"item %s of order %s has already been reviewed", review.OrderItemId, review.OrderId,
+			)
+		}
+		return err
+	}
+	return nil
+}
+
+// FindById 根据Id查找评价
+func (imp *impReviewRepository) FindById(ctx context.Context, id string) (*entity.Review, error) {
+	var po ReviewPO
+	err := imp.reviewCollection.FindOne(ctx, bson.M{"_id": id}).Decode(&po)
+	if err != nil {
+		if gerror.Is(err, mongo.ErrNoDocuments) {
+			return nil, gerror.Wrapf(valueobject.ErrReviewNotFound, "review %s", id)
+		}
+		return nil, err
+	}
+	return imp.toEntity(&po), nil
+}
+
+// Find 按条件分页查找评价，按创建时间倒序
+func (imp *impReviewRepository) Find(
+	ctx context.Context,
+	criteria valueobject.ReviewCriteria,
+) ([]*entity.Review, int, error) {
+	filter := bson.M{}
+	if criteria.ProductId != "" {
+		filter["product_id"] = criteria.ProductId
+	}
+	if criteria.UserId != "" {
+		filter["user_id"] = criteria.UserId
+	}
+	if criteria.Status != "" {
+		filter["status"] = string(criteria.Status)
+	}
+	if criteria.Rating != 0 {
+		filter["rating"] = criteria.Rating
+	}
+
+	total, err := imp.reviewCollection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	cursor, err := imp.reviewCollection.Find(ctx, filter, options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(int64(criteria.Offset())).
+		SetLimit(int64(criteria.PerPage)),
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var pos []ReviewPO
+	if err = cursor.All(ctx, &pos); err != nil {
+		return nil, 0, err
+	}
+	reviews := make([]*entity.Review, len(pos))
+	for i, po := range pos {
+		reviews[i] = imp.toEntity(&po)
+	}
+	return reviews, int(total), nil
+}
+
+// UpdateModeration 仅当评价当前处于 from 状态时保存其审核结果
+func (imp *impReviewRepository) UpdateModeration(
+	ctx context.Context,
+	review *entity.Review,
+	from valueobject.ReviewStatus,
+) error {
+	result, err := imp.reviewCollection.UpdateOne(
+		ctx,
+		bson.M{"_id": review.Id, "status": string(from)},
+		bson.M{"$set": bson.M{
+			"status":          string(review.Status),
+			"moderator":       review.Moderator,
+			"moderation_note": review.ModerationNote,
+			"moderated_at":    review.ModeratedAt,
+			"updated_at":      review.UpdatedAt,
+		}},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return gerror.Wrapf(valueobject.ErrReviewConflict, "review %s is no longer %s", review.Id, from)
+	}
+	return nil
+}
+
+// SummarizeApproved 汇总商品审核通过的评价，返回评价数和评分总和
+func (imp *impReviewRepository) SummarizeApproved(ctx context.Context, productId string) (int, int, error) {
+	cursor, err := imp.reviewCollection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"product_id": productId,
+			"status":     string(valueobject.ReviewStatusApproved),
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   nil,
+			"count": bson.M{"$sum": 1},
+			"total": bson.M{"$sum": "$rating"},
+		}}},
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		Count int `bson:"count"`
+		Total int `bson:"total"`
+	}
+	if err = cursor.All(ctx, &results); err != nil {
+		return 0, 0, err
+	}
+	if len(results) == 0 {
+		return 0, 0, nil
+	}
+	return results[0].Count, results[0].Total, nil
+}
+
+// toReviewPO 将领域实体转换为持久化对象
+func (imp *impReviewRepository) toReviewPO(review *entity.Review) *ReviewPO {
+	return &ReviewPO{
+		Id:             review.Id,
+		ProductId:      review.ProductId,
+		SkuId:          review.SkuId,
+		OrderId:        review.OrderId,
+		OrderItemId:    review.OrderItemId,
+		UserId:         review.UserId,
+		Rating:         review.Rating,
+		Content:        review.Content,
+		Status:         string(review.Status),
+		Moderator:      review.Moderator,
+		ModerationNote: review.ModerationNote,
+		ModeratedAt:    review.ModeratedAt,
+		CreatedAt:      review.CreatedAt,
+		UpdatedAt:      review.UpdatedAt,
+	}
+}
+
+// toEntity 将持久化对象转换为领域实体
+func (imp *impReviewRepository) toEntity(po *ReviewPO) *entity.Review {
+	return &entity.Review{
+		Id:             po.Id,
+		ProductId:      po.ProductId,
+		SkuId:          po.SkuId,
+		OrderId:        po.OrderId,
+		OrderItemId:    po.OrderItemId,
+		UserId:         po.UserId,
+		Rating:         po.Rating,
+		Content:        po.Content,
+		Status:         valueobject.ReviewStatus(po.Status),
+		Moderator:      po.Moderator,
+		ModerationNote: po.ModerationNote,
+		ModeratedAt:    po.ModeratedAt,
+		CreatedAt:      po.CreatedAt,
+		UpdatedAt:      po.UpdatedAt,
+	}
+}
+
+// isIndexNotFound 判断删除索引的错误是否因为索引或集合不存在
+func isIndexNotFound(err error) bool {
+	var commandErr mongo.CommandError
+	if !errors.As(err, &commandErr) {
+		return false
+	}
+	// 26: NamespaceNotFound，27: IndexNotFound
+	return commandErr.Code == 26 || commandErr.Code == 27
+}
@@ -0,0 +1,214 @@
+package mongodb
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"main/internal/domain/product/entity"
+	"main/internal/domain/product/repository"
+	"main/internal/domain/product/valueobject"
+)
+
+// StockMovementPO 库存流水持久化对象
+type StockMovementPO struct {
+	Id              string `bson:"_id"`
+	ProductId       string `bson:"product_id"`
+	SkuId           string `bson:"sku_id"`
+	WarehouseId     string `bson:"warehouse_id,omitempty"`
+	Reason          string `bson:"reason"`
+	OnHandDelta     int    `bson:"on_hand_delta"`
+	ReservedDelta   int    `bson:"reserved_delta"`
+	OnHandBalance   int    `bson:"on_hand_balance"`
+	ReservedBalance int    `bson:"reserved_balance"`
+	Actor           string `bson:"actor"`
+	Reference       string `bson:"reference,omitempty"`
+	Note            string `bson:"note,omitempty"`
+	CreatedAt       int64  `bson:"created_at"`
+}
+
+// impStockMovementRepository MongoDB库存流水持久化实现
+type impStockMovementRepository struct {
+	mongoDb            *mongo.Database
+	movementCollection *mongo.Collection
+}
+
+// NewStockMovementRepository 创建MongoDB库存流水持久化实例
+func NewStockMovementRepository(ctx context.Context, mongoDb *mongo.Database) (repository.StockMovementRepository, error) {
+	imp := &impStockMovementRepository{
+		mongoDb:            mongoDb,
+		movementCollection: mongoDb.Collection("stock_movement"),
+	}
+	if err := imp.ensureIndexes(ctx); err != nil {
+		return nil, gerror.Wrap(err, "failed to create stock movement indexes")
+	}
+	return imp, nil
+}
+
+// ensureIndexes 创建按商品、按 SKU 查询流水以及按关联单据追溯的索引
+func (imp *impStockMovementRepository) ensureIndexes(ctx context.Context) error {
+	_, err := imp.movementCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "product_id", Value: 1}, {Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "sku_id", Value: 1}, {Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "reference", Value: 1}}, Options: options.Index().SetSparse(true)},
+	})
+	return err
+}
+
+// Append 追加库存流水，保持传入的顺序
+func (imp *impStockMovementRepository) Append(ctx context.Context, movements ...*entity.StockMovement) error {
+	docs := make([]interface{}, len(movements))
+	for i, movement := range movements {
+		docs[i] = imp.toStockMovementPO(movement)
+	}
+	_, err := imp.movementCollection.InsertMany(ctx, docs, options.InsertMany().SetOrdered(true))
+	return err
+}
+
+// FindByProductId 查找商品的库存流水，按时间倒序
+// 同一毫秒内的流水按 ObjectID 倒序，与追加顺序相反
+func (imp *impStockMovementRepository) FindByProductId(
+	ctx context.Context,
+	productId string,
+	skuId string,
+	limit int,
+) ([]*entity.StockMovement, error) {
+	filter := bson.M{"product_id": productId}
+	if skuId != "" {
+		filter["sku_id"] = skuId
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := imp.movementCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var pos []StockMovementPO
+	if err = cursor.All(ctx, &pos); err != nil {
+		return nil, err
+	}
+
+	movements := make([]*entity.StockMovement, len(pos))
+	for i, po := range pos {
+		movements[i] = imp.toEntity(&po)
+	}
+	return movements, nil
+}
+
+// SumByProductId 按 SKU 汇总商品的库存流水
+func (imp *impStockMovementRepository) SumByProductId(
+	ctx context.Context,
+	productId string,
+) (map[string]valueobject.StockLevel, error) {
+	cursor, err := imp.movementCollection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"product_id": productId}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":      "$sku_id",
+			"on_hand":  bson.M{"$sum": "$on_hand_delta"},
+			"reserved": bson.M{"$sum": "$reserved_delta"},
+		}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var rows []struct {
+		SkuId    string `bson:"_id"`
+		OnHand   int    `bson:"on_hand"`
+		Reserved int    `bson:"reserved"`
+	}
+	if err = cursor.All(ctx, &rows); err != nil {
+		return nil, err
+	}
+
+	levels := make(map[string]valueobject.StockLevel, len(rows))
+	for _, row := range rows {
+		levels[row.SkuId] = valueobject.StockLevel{OnHand: row.OnHand, Reserved: row.Reserved}
+	}
+	return levels, nil
+}
+
+// SumSales 按商品汇总自 since 起订单确认出库的数量
+func (imp *impStockMovementRepository) SumSales(
+	ctx context.Context,
+	productIds []string,
+	since int64,
+) (map[string]int, error) {
+	cursor, err := imp.movementCollection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"product_id": bson.M{"$in": productIds},
+			"reason":     string(valueobject.MovementSale),
+			"created_at": bson.M{"$gte": since},
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":  "$product_id",
+			"sold": bson.M{"$sum": bson.M{"$multiply": bson.A{"$on_hand_delta", -1}}},
+		}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var rows []struct {
+		ProductId string `bson:"_id"`
+		Sold      int    `bson:"sold"`
+	}
+	if err = cursor.All(ctx, &rows); err != nil {
+		return nil, err
+	}
+
+	sales := make(map[string]int, len(rows))
+	for _, row := range rows {
+		sales[row.ProductId] = row.Sold
+	}
+	return sales, nil
+}
+
+// toStockMovementPO 将领域实体转换为持久化对象
+func (imp *impStockMovementRepository) toStockMovementPO(movement *entity.StockMovement) *StockMovementPO {
+	return &StockMovementPO{
+		Id:              movement.Id,
+		ProductId:       movement.ProductId,
+		SkuId:           movement.SkuId,
+		WarehouseId:     movement.WarehouseId,
+		Reason:          string(movement.Reason),
+		OnHandDelta:     movement.OnHandDelta,
+		ReservedDelta:   movement.ReservedDelta,
+		OnHandBalance:   movement.Balance.OnHand,
+		ReservedBalance: movement.Balance.Reserved,
+		Actor:           movement.Actor,
+		Reference:       movement.Reference,
+		Note:            movement.Note,
+		CreatedAt:       movement.CreatedAt,
+	}
+}
+
+// toEntity 将持久化对象转换为领域实体
+func (imp *impStockMovementRepository) toEntity(po *StockMovementPO) *entity.StockMovement {
+	return &entity.StockMovement{
+		Id:            po.Id,
+		ProductId:     po.ProductId,
+		SkuId:         po.SkuId,
+		WarehouseId:   po.WarehouseId,
+		Reason:        valueobject.MovementReason(po.Reason),
+		OnHandDelta:   po.OnHandDelta,
+		ReservedDelta: po.ReservedDelta,
+		Balance: valueobject.StockLevel{
+			OnHand:   po.OnHandBalance,
+			Reserved: po.ReservedBalance,
+		},
+		Actor:     po.Actor,
+		Reference: po.Reference,
+		Note:      po.Note,
+		CreatedAt: po.CreatedAt,
+	}
+}
@@ -0,0 +1,145 @@
+package mongodb
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"main/internal/domain/inventory/entity"
+	"main/internal/domain/inventory/repository"
+	"main/internal/domain/inventory/valueobject"
+)
+
+// WarehousePO 仓库持久化对象
+type WarehousePO struct {
+	Id        string  `bson:"_id"`
+	Code      string  `bson:"code"`
+	Name      string  `bson:"name"`
+	Latitude  float64 `bson:"latitude"`
+	Longitude float64 `bson:"longitude"`
+	Priority  int     `bson:"priority"`
+	Active    bool    `bson:"active"`
+	CreatedAt int64   `bson:"created_at"`
+	UpdatedAt int64   `bson:"updated_at"`
+}
+
+// impWarehouseRepository MongoDB仓库持久化实现
+type impWarehouseRepository struct {
+	mongoDb             *mongo.Database
+	warehouseCollection *mongo.Collection
+}
+
+// NewWarehouseRepository 创建MongoDB仓库持久化实例
+func NewWarehouseRepository(ctx context.Context, mongoDb *mongo.Database) (repository.WarehouseRepository, error) {
+	imp := &impWarehouseRepository{
+		mongoDb:             mongoDb,
+		warehouseCollection: mongoDb.Collection("warehouse"),
+	}
+	if err := imp.ensureIndexes(ctx); err != nil {
+		return nil, gerror.Wrap(err, "failed to create warehouse indexes")
+	}
+	return imp, nil
+}
+
+// ensureIndexes 创建仓库编码的唯一索引
+func (imp *impWarehouseRepository) ensureIndexes(ctx context.Context) error {
+	_, err := imp.warehouseCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "code", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	return err
+}
+
+// Save 保存新仓库
+func (imp *impWarehouseRepository) Save(ctx context.Context, warehouse *entity.Warehouse) error {
+	po := imp.toWarehousePO(warehouse)
+
+	if _, err := imp.warehouseCollection.InsertOne(ctx, po); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return gerror.Wrapf(valueobject.ErrWarehouseExists, "warehouse %s", warehouse.Code)
+		}
+		return err
+	}
+	return nil
+}
+
+// FindById 根据Id查找仓库
+func (imp *impWarehouseRepository) FindById(ctx context.Context, id string) (*entity.Warehouse, error) {
+	var po WarehousePO
+	err := imp.warehouseCollection.FindOne(ctx, bson.M{"_id": id}).Decode(&po)
+	if err != nil {
+		if gerror.Is(err, mongo.ErrNoDocuments) {
+			return nil, gerror.Wrapf(valueobject.ErrWarehouseNotFound, "warehouse %s", id)
+		}
+		return nil, err
+	}
+	return imp.toEntity(&po), nil
+}
+
+// FindAll 查找所有仓库，按优先级排序
+func (imp *impWarehouseRepository) FindAll(ctx context.Context) ([]*entity.Warehouse, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "priority", Value: 1}, {Key: "code", Value: 1}})
+	cursor, err := imp.warehouseCollection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var pos []WarehousePO
+	if err = cursor.All(ctx, &pos); err != nil {
+		return nil, err
+	}
+
+	warehouses := make([]*entity.Warehouse, len(pos))
+	for i, po := range pos {
+		warehouses[i] = imp.toEntity(&po)
+	}
+	return warehouses, nil
+}
+
+// Update 更新仓库
+func (imp *impWarehouseRepository) Update(ctx context.Context, warehouse *entity.Warehouse) error {
+	result, err := imp.warehouseCollection.ReplaceOne(ctx, bson.M{"_id": warehouse.Id}, imp.toWarehousePO(warehouse))
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return gerror.Wrapf(valueobject.ErrWarehouseNotFound, "warehouse %s", warehouse.Id)
+	}
+	return nil
+}
+
+// toWarehousePO 将领域实体转换为仓库持久化对象
+func (imp *impWarehouseRepository) toWarehousePO(warehouse *entity.Warehouse) *WarehousePO {
+	return &WarehousePO{
+		Id:        warehouse.Id,
+		Code:      warehouse.Code,
+		Name:      warehouse.Name,
+		Latitude:  warehouse.Location.Latitude,
+		Longitude: warehouse.Location.Longitude,
+		Priority:  warehouse.Priority,
+		Active:    warehouse.Active,
+		CreatedAt: warehouse.CreatedAt,
+		UpdatedAt: warehouse.UpdatedAt,
+	}
+}
+
+// toEntity 将持久化对象转换为领域实体
+func (imp *impWarehouseRepository) toEntity(po *WarehousePO) *entity.Warehouse {
+	return &entity.Warehouse{
+		Id:   po.Id,
+		Code: po.Code,
+		Name: po.Name,
+		Location: valueobject.Location{
+			Latitude:  po.Latitude,
+			Longitude: po.Longitude,
+		},
+		Priority:  po.Priority,
+		Active:    po.Active,
+		CreatedAt: po.CreatedAt,
+		UpdatedAt: po.UpdatedAt,
+	}
+}
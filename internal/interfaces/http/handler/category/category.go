@@ -0,0 +1,17 @@
+package category
+
+import (
+	categoryapp "main/internal/application/category"
+)
+
+// Category 类目控制器
+type Category struct {
+	categoryApp *categoryapp.CategoryApplication
+}
+
+// NewCategory 创建类目控制器实例
+func NewCategory(categoryApp *categoryapp.CategoryApplication) *Category {
+	return &Category{
+		categoryApp: categoryApp,
+	}
+}
@@ -0,0 +1,38 @@
+package category
+
+import (
+	"context"
+
+	categoryapp "main/internal/application/category"
+	"main/internal/domain/category/entity"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// CreateReq 创建类目请求
+type CreateReq struct {
+	g.Meta    `path:"/categories" method:"post" tags:"类目" summary:"创建类目"`
+	Name      string `v:"required" json:"name" dc:"类目名称"`
+	ParentId  string `json:"parentId" dc:"父类目Id，为空时创建根类目"`
+	SortOrder int    `json:"sortOrder" dc:"同级排序，数值越小越靠前"`
+}
+
+// CreateRes 创建类目响应
+type CreateRes struct {
+	*entity.Category
+}
+
+// Create 创建类目
+func (c *Category) Create(ctx context.Context, req *CreateReq) (res *CreateRes, err error) {
+	category, err := c.categoryApp.CreateCategory(ctx, categoryapp.CreateCategoryCommand{
+		Name:      req.Name,
+		ParentId:  req.ParentId,
+		SortOrder: req.SortOrder,
+	})
+	if err != nil {
+		return nil, gerror.NewCode(gcode.CodeOperationFailed, err.Error())
+	}
+	return &CreateRes{Category: category}, nil
+}
@@ -0,0 +1,28 @@
+package category
+
+import (
+	"context"
+
+	categoryapp "main/internal/application/category"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// DeleteReq 删除类目请求
+type DeleteReq struct {
+	g.Meta `path:"/categories/{id}" method:"delete" tags:"类目" summary:"删除类目"`
+	Id     string `v:"required" path:"id" dc:"类目Id"`
+}
+
+// DeleteRes 删除类目响应
+type DeleteRes struct{}
+
+// Delete 删除类目，只能删除没有子类目且没有商品的类目
+func (c *Category) Delete(ctx context.Context, req *DeleteReq) (res *DeleteRes, err error) {
+	if err := c.categoryApp.DeleteCategory(ctx, categoryapp.DeleteCategoryCommand{Id: req.Id}); err != nil {
+		return nil, gerror.NewCode(gcode.CodeOperationFailed, err.Error())
+	}
+	return &DeleteRes{}, nil
+}
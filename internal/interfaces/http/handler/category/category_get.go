@@ -0,0 +1,32 @@
+package category
+
+import (
+	"context"
+
+	categoryapp "main/internal/application/category"
+	"main/internal/domain/category/entity"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// GetReq 获取类目请求
+type GetReq struct {
+	g.Meta `path:"/categories/{id}" method:"get" tags:"类目" summary:"获取类目详情"`
+	Id     string `v:"required" path:"id" dc:"类目Id"`
+}
+
+// GetRes 获取类目响应
+type GetRes struct {
+	*entity.Category
+}
+
+// Get 获取类目详情
+func (c *Category) Get(ctx context.Context, req *GetReq) (res *GetRes, err error) {
+	category, err := c.categoryApp.GetCategory(ctx, categoryapp.GetCategoryQuery{Id: req.Id})
+	if err != nil {
+		return nil, gerror.NewCode(gcode.CodeOperationFailed, err.Error())
+	}
+	return &GetRes{Category: category}, nil
+}
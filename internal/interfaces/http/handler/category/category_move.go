@@ -0,0 +1,38 @@
+package category
+
+import (
+	"context"
+
+	categoryapp "main/internal/application/category"
+	"main/internal/domain/category/entity"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// MoveReq 移动类目请求
+type MoveReq struct {
+	g.Meta    `path:"/categories/{id}/move" method:"post" tags:"类目" summary:"移动类目"`
+	Id        string `v:"required" path:"id" dc:"类目Id"`
+	ParentId  string `json:"parentId" dc:"新的父类目Id，为空时移动为根类目"`
+	SortOrder int    `json:"sortOrder" dc:"在新的同级类目中的排序"`
+}
+
+// MoveRes 移动类目响应
+type MoveRes struct {
+	*entity.Category
+}
+
+// Move 将类目连同其子树移动到新的父类目下
+func (c *Category) Move(ctx context.Context, req *MoveReq) (res *MoveRes, err error) {
+	category, err := c.categoryApp.MoveCategory(ctx, categoryapp.MoveCategoryCommand{
+		Id:        req.Id,
+		ParentId:  req.ParentId,
+		SortOrder: req.SortOrder,
+	})
+	if err != nil {
+		return nil, gerror.NewCode(gcode.CodeOperationFailed, err.Error())
+	}
+	return &MoveRes{Category: category}, nil
+}
@@ -0,0 +1,41 @@
+package category
+
+import (
+	"context"
+
+	categoryapp "main/internal/application/category"
+	productentity "main/internal/domain/product/entity"
+	productvo "main/internal/domain/product/valueobject"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// ListProductsReq 获取类目商品列表请求
+type ListProductsReq struct {
+	g.Meta `path:"/categories/{id}/products" method:"get" tags:"类目" summary:"获取类目子树下的商品"`
+	Id     string `v:"required" path:"id" dc:"类目Id"`
+	Status string `query:"status" v:"in:draft,on_sale,off_sale,sold_out,deleted" dc:"商品状态，为空时不过滤"`
+}
+
+// ListProductsRes 获取类目商品列表响应
+type ListProductsRes struct {
+	List  []*productentity.Product `json:"list"`
+	Total int                      `json:"total"`
+}
+
+// ListProducts 获取类目及其所有后代类目下的商品
+func (c *Category) ListProducts(ctx context.Context, req *ListProductsReq) (res *ListProductsRes, err error) {
+	products, err := c.categoryApp.ListCategoryProducts(ctx, categoryapp.ListCategoryProductsQuery{
+		CategoryId: req.Id,
+		Status:     productvo.ProductStatus(req.Status),
+	})
+	if err != nil {
+		return nil, gerror.NewCode(gcode.CodeOperationFailed, err.Error())
+	}
+	return &ListProductsRes{
+		List:  products,
+		Total: len(products),
+	}, nil
+}
@@ -0,0 +1,36 @@
+package category
+
+import (
+	"context"
+
+	categoryapp "main/internal/application/category"
+	"main/internal/domain/category/entity"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// RenameReq 重命名类目请求
+type RenameReq struct {
+	g.Meta `path:"/categories/{id}" method:"put" tags:"类目" summary:"重命名类目"`
+	Id     string `v:"required" path:"id" dc:"类目Id"`
+	Name   string `v:"required" json:"name" dc:"类目名称"`
+}
+
+// RenameRes 重命名类目响应
+type RenameRes struct {
+	*entity.Category
+}
+
+// Rename 重命名类目
+func (c *Category) Rename(ctx context.Context, req *RenameReq) (res *RenameRes, err error) {
+	category, err := c.categoryApp.RenameCategory(ctx, categoryapp.RenameCategoryCommand{
+		Id:   req.Id,
+		Name: req.Name,
+	})
+	if err != nil {
+		return nil, gerror.NewCode(gcode.CodeOperationFailed, err.Error())
+	}
+	return &RenameRes{Category: category}, nil
+}
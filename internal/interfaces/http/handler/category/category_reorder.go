@@ -0,0 +1,36 @@
+package category
+
+import (
+	"context"
+
+	categoryapp "main/internal/application/category"
+	"main/internal/domain/category/entity"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// ReorderReq 重排同级类目请求
+type ReorderReq struct {
+	g.Meta      `path:"/categories/order" method:"put" tags:"类目" summary:"重排同级类目"`
+	ParentId    string   `json:"parentId" dc:"父类目Id，为空时重排根类目"`
+	CategoryIds []string `v:"required" json:"categoryIds" dc:"父类目下所有直接子类目的新顺序"`
+}
+
+// ReorderRes 重排同级类目响应
+type ReorderRes struct {
+	List []*entity.Category `json:"list"`
+}
+
+// Reorder 重排同级类目
+func (c *Category) Reorder(ctx context.Context, req *ReorderReq) (res *ReorderRes, err error) {
+	categories, err := c.categoryApp.ReorderCategories(ctx, categoryapp.ReorderCategoriesCommand{
+		ParentId:    req.ParentId,
+		CategoryIds: req.CategoryIds,
+	})
+	if err != nil {
+		return nil, gerror.NewCode(gcode.CodeOperationFailed, err.Error())
+	}
+	return &ReorderRes{List: categories}, nil
+}
@@ -0,0 +1,30 @@
+package category
+
+import (
+	"context"
+
+	categoryapp "main/internal/application/category"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// TreeReq 获取类目树请求
+type TreeReq struct {
+	g.Meta `path:"/categories/tree" method:"get" tags:"类目" summary:"获取类目树"`
+}
+
+// TreeRes 获取类目树响应
+type TreeRes struct {
+	List []*categoryapp.CategoryNode `json:"list"`
+}
+
+// Tree 获取完整的类目树
+func (c *Category) Tree(ctx context.Context, req *TreeReq) (res *TreeRes, err error) {
+	tree, err := c.categoryApp.GetCategoryTree(ctx)
+	if err != nil {
+		return nil, gerror.NewCode(gcode.CodeOperationFailed, err.Error())
+	}
+	return &TreeRes{List: tree}, nil
+}
@@ -0,0 +1,17 @@
+package flashsale
+
+import (
+	"main/internal/application/flashsale"
+)
+
+// FlashSale 秒杀活动管理控制器
+type FlashSale struct {
+	flashSaleApp *flashsale.FlashSaleApplication
+}
+
+// NewFlashSale 创建秒杀活动管理控制器实例
+func NewFlashSale(flashSaleApp *flashsale.FlashSaleApplication) *FlashSale {
+	return &FlashSale{
+		flashSaleApp: flashSaleApp,
+	}
+}
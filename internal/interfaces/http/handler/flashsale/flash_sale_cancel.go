@@ -0,0 +1,32 @@
+package flashsale
+
+import (
+	"context"
+
+	"main/internal/application/flashsale"
+	"main/internal/domain/flashsale/entity"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// CancelReq 取消秒杀活动请求
+type CancelReq struct {
+	g.Meta `path:"/admin/flash-sales/{id}/cancel" method:"post" tags:"秒杀" summary:"取消秒杀活动"`
+	Id     string `v:"required" path:"id" dc:"秒杀活动Id"`
+}
+
+// CancelRes 取消秒杀活动响应
+type CancelRes struct {
+	*entity.FlashSale
+}
+
+// Cancel 取消秒杀活动，已下单的订单不受影响
+func (c *FlashSale) Cancel(ctx context.Context, req *CancelReq) (res *CancelRes, err error) {
+	flashSale, err := c.flashSaleApp.CancelFlashSale(ctx, flashsale.CancelFlashSaleCommand{Id: req.Id})
+	if err != nil {
+		return nil, gerror.NewCode(gcode.CodeOperationFailed, err.Error())
+	}
+	return &CancelRes{FlashSale: flashSale}, nil
+}
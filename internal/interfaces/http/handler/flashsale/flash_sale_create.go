@@ -0,0 +1,57 @@
+package flashsale
+
+import (
+	"context"
+
+	"main/internal/application/flashsale"
+	"main/internal/domain/flashsale/entity"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// ItemReq 秒杀活动商品请求
+type ItemReq struct {
+	SkuId        string  `v:"required" json:"skuId" dc:"SKU Id"`
+	Price        float64 `v:"required|min:0" json:"price" dc:"秒杀价，货币与 SKU 价格一致"`
+	Stock        int     `v:"required|min:1" json:"stock" dc:"活动库存"`
+	PerUserLimit int     `v:"min:0" json:"perUserLimit" dc:"每个用户可购买的数量，0 表示不限购"`
+}
+
+// CreateReq 创建秒杀活动请求
+type CreateReq struct {
+	g.Meta  `path:"/admin/flash-sales" method:"post" tags:"秒杀" summary:"创建秒杀活动"`
+	Name    string    `v:"required" json:"name" dc:"活动名称"`
+	StartAt int64     `v:"required|min:1" json:"startAt" dc:"开始时间（毫秒）"`
+	EndAt   int64     `v:"required|min:1" json:"endAt" dc:"结束时间（毫秒）"`
+	Items   []ItemReq `v:"required" json:"items" dc:"活动商品"`
+}
+
+// CreateRes 创建秒杀活动响应
+type CreateRes struct {
+	*entity.FlashSale
+}
+
+// Create 创建秒杀活动
+func (c *FlashSale) Create(ctx context.Context, req *CreateReq) (res *CreateRes, err error) {
+	items := make([]flashsale.FlashSaleItemCommand, len(req.Items))
+	for i, item := range req.Items {
+		items[i] = flashsale.FlashSaleItemCommand{
+			SkuId:        item.SkuId,
+			Price:        item.Price,
+			Stock:        item.Stock,
+			PerUserLimit: item.PerUserLimit,
+		}
+	}
+	flashSale, err := c.flashSaleApp.CreateFlashSale(ctx, flashsale.CreateFlashSaleCommand{
+		Name:    req.Name,
+		StartAt: req.StartAt,
+		EndAt:   req.EndAt,
+		Items:   items,
+	})
+	if err != nil {
+		return nil, gerror.NewCode(gcode.CodeOperationFailed, err.Error())
+	}
+	return &CreateRes{FlashSale: flashSale}, nil
+}
@@ -0,0 +1,32 @@
+package flashsale
+
+import (
+	"context"
+
+	"main/internal/application/flashsale"
+	"main/internal/domain/flashsale/entity"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// GetReq 获取秒杀活动请求
+type GetReq struct {
+	g.Meta `path:"/admin/flash-sales/{id}" method:"get" tags:"秒杀" summary:"获取秒杀活动详情"`
+	Id     string `v:"required" path:"id" dc:"秒杀活动Id"`
+}
+
+// GetRes 获取秒杀活动响应
+type GetRes struct {
+	*entity.FlashSale
+}
+
+// Get 获取秒杀活动详情，包括各商品已占用的活动库存
+func (c *FlashSale) Get(ctx context.Context, req *GetReq) (res *GetRes, err error) {
+	flashSale, err := c.flashSaleApp.GetFlashSale(ctx, flashsale.GetFlashSaleQuery{Id: req.Id})
+	if err != nil {
+		return nil, gerror.NewCode(gcode.CodeOperationFailed, err.Error())
+	}
+	return &GetRes{FlashSale: flashSale}, nil
+}
@@ -0,0 +1,34 @@
+package flashsale
+
+import (
+	"context"
+
+	"main/internal/domain/flashsale/entity"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// ListReq 获取秒杀活动列表请求
+type ListReq struct {
+	g.Meta `path:"/admin/flash-sales" method:"get" tags:"秒杀" summary:"获取秒杀活动列表"`
+}
+
+// ListRes 获取秒杀活动列表响应
+type ListRes struct {
+	List  []*entity.FlashSale `json:"list"`
+	Total int                 `json:"total"`
+}
+
+// List 获取秒杀活动列表
+func (c *FlashSale) List(ctx context.Context, req *ListReq) (res *ListRes, err error) {
+	flashSales, err := c.flashSaleApp.ListFlashSales(ctx)
+	if err != nil {
+		return nil, gerror.NewCode(gcode.CodeOperationFailed, err.Error())
+	}
+	return &ListRes{
+		List:  flashSales,
+		Total: len(flashSales),
+	}, nil
+}
@@ -0,0 +1,36 @@
+package flashsale
+
+import (
+	"context"
+
+	"main/internal/application/flashsale"
+	"main/internal/domain/flashsale/entity"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// PurchasesReq 获取秒杀活动购买记录请求
+type PurchasesReq struct {
+	g.Meta `path:"/admin/flash-sales/{id}/purchases" method:"get" tags:"秒杀" summary:"获取秒杀活动购买记录"`
+	Id     string `v:"required" path:"id" dc:"秒杀活动Id"`
+}
+
+// PurchasesRes 获取秒杀活动购买记录响应
+type PurchasesRes struct {
+	List  []*entity.Purchase `json:"list"`
+	Total int                `json:"total"`
+}
+
+// Purchases 获取秒杀活动的购买记录，按下单时间倒序
+func (c *FlashSale) Purchases(ctx context.Context, req *PurchasesReq) (res *PurchasesRes, err error) {
+	purchases, err := c.flashSaleApp.ListPurchases(ctx, flashsale.ListPurchasesQuery{FlashSaleId: req.Id})
+	if err != nil {
+		return nil, gerror.NewCode(gcode.CodeOperationFailed, err.Error())
+	}
+	return &PurchasesRes{
+		List:  purchases,
+		Total: len(purchases),
+	}, nil
+}
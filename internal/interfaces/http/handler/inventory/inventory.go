@@ -0,0 +1,17 @@
+package inventory
+
+import (
+	inventoryapp "main/internal/application/inventory"
+)
+
+// Inventory 仓库与库存控制器
+type Inventory struct {
+	inventoryApp *inventoryapp.InventoryApplication
+}
+
+// NewInventory 创建仓库与库存控制器实例
+func NewInventory(inventoryApp *inventoryapp.InventoryApplication) *Inventory {
+	return &Inventory{
+		inventoryApp: inventoryApp,
+	}
+}
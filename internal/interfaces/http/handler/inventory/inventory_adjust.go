@@ -0,0 +1,49 @@
+package inventory
+
+import (
+	"context"
+
+	inventoryapp "main/internal/application/inventory"
+	"main/internal/domain/inventory/entity"
+	productvo "main/internal/domain/product/valueobject"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// AdjustReq 调整库存请求
+type AdjustReq struct {
+	g.Meta      `path:"/inventory/adjust" method:"post" tags:"库存" summary:"调整仓库库存"`
+	ProductId   string `v:"required" json:"productId" dc:"商品Id"`
+	WarehouseId string `v:"required" json:"warehouseId" dc:"仓库Id"`
+	SkuId       string `v:"required" json:"skuId" dc:"SKU Id"`
+	Delta       int    `v:"required|ne:0" json:"delta" dc:"在库数量的增量，入库为正，出库或损耗为负"`
+	Reason      string `v:"required|in:inbound,adjustment,return" json:"reason" dc:"变动原因"`
+	Actor       string `v:"required" json:"actor" dc:"操作人"`
+	Reference   string `json:"reference" dc:"关联单据，如入库单号、退货单号"`
+	Note        string `json:"note" dc:"备注"`
+}
+
+// AdjustRes 调整库存响应
+type AdjustRes struct {
+	*entity.Inventory
+}
+
+// Adjust 调整 SKU 在仓库中的在库数量，商品的汇总库存同步调整并记录库存流水
+func (i *Inventory) Adjust(ctx context.Context, req *AdjustReq) (res *AdjustRes, err error) {
+	inventory, err := i.inventoryApp.AdjustInventory(ctx, inventoryapp.AdjustInventoryCommand{
+		ProductId:   req.ProductId,
+		WarehouseId: req.WarehouseId,
+		SkuId:       req.SkuId,
+		Delta:       req.Delta,
+		Reason:      productvo.MovementReason(req.Reason),
+		Actor:       req.Actor,
+		Reference:   req.Reference,
+		Note:        req.Note,
+	})
+	if err != nil {
+		return nil, gerror.NewCode(gcode.CodeOperationFailed, err.Error())
+	}
+	return &AdjustRes{Inventory: inventory}, nil
+}
@@ -0,0 +1,31 @@
+package inventory
+
+import (
+	"context"
+
+	inventoryapp "main/internal/application/inventory"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// AvailabilityReq 获取商品分仓可售数量请求
+type AvailabilityReq struct {
+	g.Meta    `path:"/inventory/products/{productId}" method:"get" tags:"库存" summary:"商品分仓可售数量"`
+	ProductId string `v:"required" path:"productId" dc:"商品Id"`
+}
+
+// AvailabilityRes 获取商品分仓可售数量响应
+type AvailabilityRes struct {
+	*inventoryapp.ProductAvailabilityView
+}
+
+// Availability 获取商品各 SKU 在所有仓库的汇总可售数量及分仓明细
+func (i *Inventory) Availability(ctx context.Context, req *AvailabilityReq) (res *AvailabilityRes, err error) {
+	view, err := i.inventoryApp.GetProductAvailability(ctx, req.ProductId)
+	if err != nil {
+		return nil, gerror.NewCode(gcode.CodeOperationFailed, err.Error())
+	}
+	return &AvailabilityRes{ProductAvailabilityView: view}, nil
+}
@@ -0,0 +1,46 @@
+package inventory
+
+import (
+	"context"
+
+	inventoryapp "main/internal/application/inventory"
+	"main/internal/domain/inventory/entity"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// TransferReq 调拨库存请求
+type TransferReq struct {
+	g.Meta          `path:"/inventory/transfer" method:"post" tags:"库存" summary:"仓库间调拨库存"`
+	ProductId       string `v:"required" json:"productId" dc:"商品Id"`
+	SkuId           string `v:"required" json:"skuId" dc:"SKU Id"`
+	FromWarehouseId string `v:"required" json:"fromWarehouseId" dc:"调出仓库Id"`
+	ToWarehouseId   string `v:"required|different:FromWarehouseId" json:"toWarehouseId" dc:"调入仓库Id"`
+	Quantity        int    `v:"required|min:1" json:"quantity" dc:"调拨数量"`
+	Actor           string `v:"required" json:"actor" dc:"操作人"`
+	Reference       string `json:"reference" dc:"关联单据，如调拨单号"`
+}
+
+// TransferRes 调拨库存响应
+type TransferRes struct {
+	List []*entity.Inventory `json:"list"` // 调出仓库和调入仓库调拨后的库存
+}
+
+// Transfer 将 SKU 的可售库存从一个仓库调拨到另一个仓库
+func (i *Inventory) Transfer(ctx context.Context, req *TransferReq) (res *TransferRes, err error) {
+	inventories, err := i.inventoryApp.TransferInventory(ctx, inventoryapp.TransferInventoryCommand{
+		ProductId:       req.ProductId,
+		SkuId:           req.SkuId,
+		FromWarehouseId: req.FromWarehouseId,
+		ToWarehouseId:   req.ToWarehouseId,
+		Quantity:        req.Quantity,
+		Actor:           req.Actor,
+		Reference:       req.Reference,
+	})
+	if err != nil {
+		return nil, gerror.NewCode(gcode.CodeOperationFailed, err.Error())
+	}
+	return &TransferRes{List: inventories}, nil
+}
@@ -0,0 +1,42 @@
+package inventory
+
+import (
+	"context"
+
+	inventoryapp "main/internal/application/inventory"
+	"main/internal/domain/inventory/entity"
+	"main/internal/domain/inventory/valueobject"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// CreateWarehouseReq 创建仓库请求
+type CreateWarehouseReq struct {
+	g.Meta    `path:"/warehouses" method:"post" tags:"库存" summary:"创建仓库"`
+	Code      string  `v:"required" json:"code" dc:"仓库编码，全局唯一"`
+	Name      string  `v:"required" json:"name" dc:"仓库名称"`
+	Latitude  float64 `v:"between:-90,90" json:"latitude" dc:"纬度"`
+	Longitude float64 `v:"between:-180,180" json:"longitude" dc:"经度"`
+	Priority  int     `json:"priority" dc:"优先级，数值越小越优先"`
+}
+
+// CreateWarehouseRes 创建仓库响应
+type CreateWarehouseRes struct {
+	*entity.Warehouse
+}
+
+// CreateWarehouse 创建仓库
+func (i *Inventory) CreateWarehouse(ctx context.Context, req *CreateWarehouseReq) (res *CreateWarehouseRes, err error) {
+	warehouse, err := i.inventoryApp.CreateWarehouse(ctx, inventoryapp.CreateWarehouseCommand{
+		Code:     req.Code,
+		Name:     req.Name,
+		Location: valueobject.Location{Latitude: req.Latitude, Longitude: req.Longitude},
+		Priority: req.Priority,
+	})
+	if err != nil {
+		return nil, gerror.NewCode(gcode.CodeOperationFailed, err.Error())
+	}
+	return &CreateWarehouseRes{Warehouse: warehouse}, nil
+}
@@ -0,0 +1,30 @@
+package inventory
+
+import (
+	"context"
+
+	"main/internal/domain/inventory/entity"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// ListWarehousesReq 获取仓库列表请求
+type ListWarehousesReq struct {
+	g.Meta `path:"/warehouses" method:"get" tags:"库存" summary:"仓库列表"`
+}
+
+// ListWarehousesRes 获取仓库列表响应
+type ListWarehousesRes struct {
+	List []*entity.Warehouse `json:"list"`
+}
+
+// ListWarehouses 获取所有仓库，按优先级排序
+func (i *Inventory) ListWarehouses(ctx context.Context, req *ListWarehousesReq) (res *ListWarehousesRes, err error) {
+	warehouses, err := i.inventoryApp.ListWarehouses(ctx)
+	if err != nil {
+		return nil, gerror.NewCode(gcode.CodeOperationFailed, err.Error())
+	}
+	return &ListWarehousesRes{List: warehouses}, nil
+}
@@ -0,0 +1,44 @@
+package inventory
+
+import (
+	"context"
+
+	inventoryapp "main/internal/application/inventory"
+	"main/internal/domain/inventory/entity"
+	"main/internal/domain/inventory/valueobject"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// UpdateWarehouseReq 更新仓库请求
+type UpdateWarehouseReq struct {
+	g.Meta    `path:"/warehouses/{id}" method:"put" tags:"库存" summary:"更新仓库"`
+	Id        string  `v:"required" path:"id" dc:"仓库Id"`
+	Name      string  `v:"required" json:"name" dc:"仓库名称"`
+	Latitude  float64 `v:"between:-90,90" json:"latitude" dc:"纬度"`
+	Longitude float64 `v:"between:-180,180" json:"longitude" dc:"经度"`
+	Priority  int     `json:"priority" dc:"优先级，数值越小越优先"`
+	Active    bool    `json:"active" dc:"是否参与订单分配"`
+}
+
+// UpdateWarehouseRes 更新仓库响应
+type UpdateWarehouseRes struct {
+	*entity.Warehouse
+}
+
+// UpdateWarehouse 更新仓库
+func (i *Inventory) UpdateWarehouse(ctx context.Context, req *UpdateWarehouseReq) (res *UpdateWarehouseRes, err error) {
+	warehouse, err := i.inventoryApp.UpdateWarehouse(ctx, inventoryapp.UpdateWarehouseCommand{
+		Id:       req.Id,
+		Name:     req.Name,
+		Location: valueobject.Location{Latitude: req.Latitude, Longitude: req.Longitude},
+		Priority: req.Priority,
+		Active:   req.Active,
+	})
+	if err != nil {
+		return nil, gerror.NewCode(gcode.CodeOperationFailed, err.Error())
+	}
+	return &UpdateWarehouseRes{Warehouse: warehouse}, nil
+}
@@ -0,0 +1,17 @@
+package pricelist
+
+import (
+	"main/internal/application/pricing"
+)
+
+// PriceList 价目表管理控制器
+type PriceList struct {
+	priceListApp *pricing.PriceListApplication
+}
+
+// NewPriceList 创建价目表管理控制器实例
+func NewPriceList(priceListApp *pricing.PriceListApplication) *PriceList {
+	return &PriceList{
+		priceListApp: priceListApp,
+	}
+}
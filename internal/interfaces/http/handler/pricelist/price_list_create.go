@@ -0,0 +1,44 @@
+package pricelist
+
+import (
+	"context"
+
+	"main/internal/application/pricing"
+	"main/internal/domain/pricing/entity"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// CreateReq 创建价目表请求
+type CreateReq struct {
+	g.Meta        `path:"/admin/price-lists" method:"post" tags:"价目表" summary:"创建价目表"`
+	Name          string `v:"required" json:"name" dc:"价目表名称"`
+	CustomerGroup string `json:"customerGroup" dc:"客户分组，为空表示适用于所有客户"`
+	Currency      string `v:"required|currency" json:"currency" dc:"价格货币"`
+	Priority      int    `json:"priority" dc:"优先级，数值越大越优先"`
+	ValidFrom     int64  `v:"min:0" json:"validFrom" dc:"生效时间（毫秒），0 表示立即生效"`
+	ValidTo       int64  `v:"min:0" json:"validTo" dc:"失效时间（毫秒），0 表示长期有效"`
+}
+
+// CreateRes 创建价目表响应
+type CreateRes struct {
+	*entity.PriceList
+}
+
+// Create 创建价目表
+func (c *PriceList) Create(ctx context.Context, req *CreateReq) (res *CreateRes, err error) {
+	priceList, err := c.priceListApp.CreatePriceList(ctx, pricing.CreatePriceListCommand{
+		Name:          req.Name,
+		CustomerGroup: req.CustomerGroup,
+		Currency:      req.Currency,
+		Priority:      req.Priority,
+		ValidFrom:     req.ValidFrom,
+		ValidTo:       req.ValidTo,
+	})
+	if err != nil {
+		return nil, gerror.NewCode(gcode.CodeOperationFailed, err.Error())
+	}
+	return &CreateRes{PriceList: priceList}, nil
+}
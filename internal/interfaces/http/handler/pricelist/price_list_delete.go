@@ -0,0 +1,28 @@
+package pricelist
+
+import (
+	"context"
+
+	"main/internal/application/pricing"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// DeleteReq 删除价目表请求
+type DeleteReq struct {
+	g.Meta `path:"/admin/price-lists/{id}" method:"delete" tags:"价目表" summary:"删除价目表"`
+	Id     string `v:"required" path:"id" dc:"价目表Id"`
+}
+
+// DeleteRes 删除价目表响应
+type DeleteRes struct{}
+
+// Delete 删除价目表
+func (c *PriceList) Delete(ctx context.Context, req *DeleteReq) (res *DeleteRes, err error) {
+	if err := c.priceListApp.DeletePriceList(ctx, pricing.DeletePriceListCommand{Id: req.Id}); err != nil {
+		return nil, gerror.NewCode(gcode.CodeOperationFailed, err.Error())
+	}
+	return &DeleteRes{}, nil
+}
@@ -0,0 +1,76 @@
+package pricelist
+
+import (
+	"context"
+
+	"main/internal/application/pricing"
+	"main/internal/domain/pricing/entity"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// PriceTierReq 阶梯价格请求
+type PriceTierReq struct {
+	MinQuantity int     `v:"required|min:1" json:"minQuantity" dc:"起订数量"`
+	Price       float64 `v:"required|min:0" json:"price" dc:"单价"`
+}
+
+// SetEntryReq 设置商品阶梯价格请求
+type SetEntryReq struct {
+	g.Meta    `path:"/admin/price-lists/{id}/entries/{productId}" method:"put" tags:"价目表" summary:"设置商品阶梯价格"`
+	Id        string         `v:"required" path:"id" dc:"价目表Id"`
+	ProductId string         `v:"required" path:"productId" dc:"商品Id"`
+	Tiers     []PriceTierReq `v:"required" json:"tiers" dc:"阶梯价格"`
+}
+
+// SetEntryRes 设置商品阶梯价格响应
+type SetEntryRes struct {
+	*entity.PriceList
+}
+
+// SetEntry 设置商品阶梯价格
+func (c *PriceList) SetEntry(ctx context.Context, req *SetEntryReq) (res *SetEntryRes, err error) {
+	tiers := make([]pricing.PriceTierCommand, len(req.Tiers))
+	for i, tier := range req.Tiers {
+		tiers[i] = pricing.PriceTierCommand{
+			MinQuantity: tier.MinQuantity,
+			Price:       tier.Price,
+		}
+	}
+
+	priceList, err := c.priceListApp.SetPriceListEntry(ctx, pricing.SetPriceListEntryCommand{
+		PriceListId: req.Id,
+		ProductId:   req.ProductId,
+		Tiers:       tiers,
+	})
+	if err != nil {
+		return nil, gerror.NewCode(gcode.CodeOperationFailed, err.Error())
+	}
+	return &SetEntryRes{PriceList: priceList}, nil
+}
+
+// RemoveEntryReq 移除商品价格请求
+type RemoveEntryReq struct {
+	g.Meta    `path:"/admin/price-lists/{id}/entries/{productId}" method:"delete" tags:"价目表" summary:"移除商品价格"`
+	Id        string `v:"required" path:"id" dc:"价目表Id"`
+	ProductId string `v:"required" path:"productId" dc:"商品Id"`
+}
+
+// RemoveEntryRes 移除商品价格响应
+type RemoveEntryRes struct {
+	*entity.PriceList
+}
+
+// RemoveEntry 移除商品价格
+func (c *PriceList) RemoveEntry(ctx context.Context, req *RemoveEntryReq) (res *RemoveEntryRes, err error) {
+	priceList, err := c.priceListApp.RemovePriceListEntry(ctx, pricing.RemovePriceListEntryCommand{
+		PriceListId: req.Id,
+		ProductId:   req.ProductId,
+	})
+	if err != nil {
+		return nil, gerror.NewCode(gcode.CodeOperationFailed, err.Error())
+	}
+	return &RemoveEntryRes{PriceList: priceList}, nil
+}
@@ -0,0 +1,32 @@
+package pricelist
+
+import (
+	"context"
+
+	"main/internal/application/pricing"
+	"main/internal/domain/pricing/entity"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// GetReq 获取价目表请求
+type GetReq struct {
+	g.Meta `path:"/admin/price-lists/{id}" method:"get" tags:"价目表" summary:"获取价目表详情"`
+	Id     string `v:"required" path:"id" dc:"价目表Id"`
+}
+
+// GetRes 获取价目表响应
+type GetRes struct {
+	*entity.PriceList
+}
+
+// Get 获取价目表详情
+func (c *PriceList) Get(ctx context.Context, req *GetReq) (res *GetRes, err error) {
+	priceList, err := c.priceListApp.GetPriceList(ctx, pricing.GetPriceListQuery{Id: req.Id})
+	if err != nil {
+		return nil, gerror.NewCode(gcode.CodeOperationFailed, err.Error())
+	}
+	return &GetRes{PriceList: priceList}, nil
+}
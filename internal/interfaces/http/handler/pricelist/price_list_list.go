@@ -0,0 +1,38 @@
+package pricelist
+
+import (
+	"context"
+
+	"main/internal/application/pricing"
+	"main/internal/domain/pricing/entity"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// ListReq 获取价目表列表请求
+type ListReq struct {
+	g.Meta        `path:"/admin/price-lists" method:"get" tags:"价目表" summary:"获取价目表列表"`
+	CustomerGroup string `query:"customerGroup" dc:"客户分组，为空时返回所有价目表"`
+}
+
+// ListRes 获取价目表列表响应
+type ListRes struct {
+	List  []*entity.PriceList `json:"list"`
+	Total int                 `json:"total"`
+}
+
+// List 获取价目表列表
+func (c *PriceList) List(ctx context.Context, req *ListReq) (res *ListRes, err error) {
+	priceLists, err := c.priceListApp.ListPriceLists(ctx, pricing.ListPriceListsQuery{
+		CustomerGroup: req.CustomerGroup,
+	})
+	if err != nil {
+		return nil, gerror.NewCode(gcode.CodeOperationFailed, err.Error())
+	}
+	return &ListRes{
+		List:  priceLists,
+		Total: len(priceLists),
+	}, nil
+}
@@ -0,0 +1,47 @@
+package pricelist
+
+import (
+	"context"
+
+	"main/internal/application/pricing"
+	"main/internal/domain/pricing/entity"
+	"main/internal/domain/pricing/valueobject"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// UpdateReq 更新价目表请求
+type UpdateReq struct {
+	g.Meta        `path:"/admin/price-lists/{id}" method:"put" tags:"价目表" summary:"更新价目表"`
+	Id            string `v:"required" path:"id" dc:"价目表Id"`
+	Name          string `v:"required" json:"name" dc:"价目表名称"`
+	CustomerGroup string `json:"customerGroup" dc:"客户分组，为空表示适用于所有客户"`
+	Priority      int    `json:"priority" dc:"优先级，数值越大越优先"`
+	ValidFrom     int64  `v:"min:0" json:"validFrom" dc:"生效时间（毫秒），0 表示立即生效"`
+	ValidTo       int64  `v:"min:0" json:"validTo" dc:"失效时间（毫秒），0 表示长期有效"`
+	Status        string `v:"required|in:active,inactive" json:"status" dc:"状态"`
+}
+
+// UpdateRes 更新价目表响应
+type UpdateRes struct {
+	*entity.PriceList
+}
+
+// Update 更新价目表
+func (c *PriceList) Update(ctx context.Context, req *UpdateReq) (res *UpdateRes, err error) {
+	priceList, err := c.priceListApp.UpdatePriceList(ctx, pricing.UpdatePriceListCommand{
+		Id:            req.Id,
+		Name:          req.Name,
+		CustomerGroup: req.CustomerGroup,
+		Priority:      req.Priority,
+		ValidFrom:     req.ValidFrom,
+		ValidTo:       req.ValidTo,
+		Status:        valueobject.PriceListStatus(req.Status),
+	})
+	if err != nil {
+		return nil, gerror.NewCode(gcode.CodeOperationFailed, err.Error())
+	}
+	return &UpdateRes{PriceList: priceList}, nil
+}
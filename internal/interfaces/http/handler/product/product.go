@@ -0,0 +1,17 @@
+package product
+
+import (
+	productapp "main/internal/application/product"
+)
+
+// Product 商品控制器
+type Product struct {
+	productApp *productapp.ProductApplicationService
+}
+
+// NewProduct 创建商品控制器实例
+func NewProduct(productApp *productapp.ProductApplicationService) *Product {
+	return &Product{
+		productApp: productApp,
+	}
+}
@@ -0,0 +1,47 @@
+package product
+
+import (
+	"context"
+
+	productapp "main/internal/application/product"
+	"main/internal/domain/product/entity"
+	"main/internal/domain/product/valueobject"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// AdjustStockReq 登记库存变动请求
+type AdjustStockReq struct {
+	g.Meta    `path:"/products/{id}/stock/movements" method:"post" tags:"商品" summary:"登记库存变动"`
+	Id        string `v:"required" path:"id" dc:"商品Id"`
+	SkuId     string `v:"required" json:"skuId" dc:"SKU Id"`
+	Delta     int    `v:"required|ne:0" json:"delta" dc:"在库数量的增量，入库和退货必须为正"`
+	Reason    string `v:"required|in:inbound,adjustment,return" json:"reason" dc:"变动原因"`
+	Actor     string `v:"required" json:"actor" dc:"操作人"`
+	Reference string `json:"reference" dc:"关联单据，如入库单号、退货单号"`
+	Note      string `json:"note" dc:"备注"`
+}
+
+// AdjustStockRes 登记库存变动响应
+type AdjustStockRes struct {
+	*entity.Product
+}
+
+// AdjustStock 登记 SKU 的库存变动并记录库存流水
+func (p *Product) AdjustStock(ctx context.Context, req *AdjustStockReq) (res *AdjustStockRes, err error) {
+	product, err := p.productApp.AdjustStock(ctx, productapp.AdjustStockCommand{
+		ProductId: req.Id,
+		SkuId:     req.SkuId,
+		Delta:     req.Delta,
+		Reason:    valueobject.MovementReason(req.Reason),
+		Actor:     req.Actor,
+		Reference: req.Reference,
+		Note:      req.Note,
+	})
+	if err != nil {
+		return nil, gerror.NewCode(gcode.CodeOperationFailed, err.Error())
+	}
+	return &AdjustStockRes{Product: product}, nil
+}
@@ -0,0 +1,36 @@
+package product
+
+import (
+	"context"
+
+	productapp "main/internal/application/product"
+	"main/internal/domain/product/entity"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// AssignCategoryReq 商品归类请求
+type AssignCategoryReq struct {
+	g.Meta     `path:"/products/{id}/category" method:"put" tags:"商品" summary:"商品归类"`
+	Id         string `v:"required" path:"id" dc:"商品Id"`
+	CategoryId string `json:"categoryId" dc:"类目Id，为空时取消分类"`
+}
+
+// AssignCategoryRes 商品归类响应
+type AssignCategoryRes struct {
+	*entity.Product
+}
+
+// AssignCategory 商品归类
+func (p *Product) AssignCategory(ctx context.Context, req *AssignCategoryReq) (res *AssignCategoryRes, err error) {
+	product, err := p.productApp.AssignCategory(ctx, productapp.AssignCategoryCommand{
+		Id:         req.Id,
+		CategoryId: req.CategoryId,
+	})
+	if err != nil {
+		return nil, gerror.NewCode(gcode.CodeOperationFailed, err.Error())
+	}
+	return &AssignCategoryRes{Product: product}, nil
+}
@@ -0,0 +1,36 @@
+package product
+
+import (
+	"context"
+
+	productapp "main/internal/application/product"
+	"main/internal/domain/product/entity"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// SetBundleComponentsReq 替换套装组件请求
+type SetBundleComponentsReq struct {
+	g.Meta     `path:"/products/{id}/components" method:"put" tags:"商品" summary:"替换套装组件"`
+	Id         string               `v:"required" path:"id" dc:"套装Id"`
+	Components []BundleComponentReq `v:"required" json:"components" dc:"组件"`
+}
+
+// SetBundleComponentsRes 替换套装组件响应
+type SetBundleComponentsRes struct {
+	*entity.Product
+}
+
+// SetBundleComponents 替换套装的组件，已下单的订单不受影响
+func (p *Product) SetBundleComponents(ctx context.Context, req *SetBundleComponentsReq) (res *SetBundleComponentsRes, err error) {
+	bundle, err := p.productApp.SetBundleComponents(ctx, productapp.SetBundleComponentsCommand{
+		Id:         req.Id,
+		Components: bundleComponentCommands(req.Components),
+	})
+	if err != nil {
+		return nil, gerror.NewCode(gcode.CodeOperationFailed, err.Error())
+	}
+	return &SetBundleComponentsRes{Product: bundle}, nil
+}
@@ -0,0 +1,62 @@
+package product
+
+import (
+	"context"
+
+	productapp "main/internal/application/product"
+	"main/internal/domain/product/entity"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// BundleComponentReq 套装组件请求
+type BundleComponentReq struct {
+	SkuId    string `v:"required" json:"skuId" dc:"组件 SKU Id"`
+	Quantity int    `v:"required|min:1" json:"quantity" dc:"每套包含的数量"`
+}
+
+// CreateBundleReq 创建套装请求
+type CreateBundleReq struct {
+	g.Meta      `path:"/products/bundles" method:"post" tags:"商品" summary:"创建套装"`
+	Name        string               `v:"required" json:"name" dc:"套装名称"`
+	Description string               `json:"description" dc:"套装描述"`
+	CategoryId  string               `json:"categoryId" dc:"类目Id，为空表示未分类"`
+	Price       float64              `v:"required|min:0" json:"price" dc:"套装价"`
+	Currency    string               `json:"currency" dc:"价格货币，为空时使用默认货币，须与组件价格货币一致"`
+	Components  []BundleComponentReq `v:"required" json:"components" dc:"组件"`
+}
+
+// CreateBundleRes 创建套装响应
+type CreateBundleRes struct {
+	*entity.Product
+}
+
+// CreateBundle 创建套装，套装不持有库存，下单时预占各组件的库存
+func (p *Product) CreateBundle(ctx context.Context, req *CreateBundleReq) (res *CreateBundleRes, err error) {
+	bundle, err := p.productApp.CreateBundle(ctx, productapp.CreateBundleCommand{
+		Name:        req.Name,
+		Description: req.Description,
+		CategoryId:  req.CategoryId,
+		Price:       req.Price,
+		Currency:    req.Currency,
+		Components:  bundleComponentCommands(req.Components),
+	})
+	if err != nil {
+		return nil, gerror.NewCode(gcode.CodeOperationFailed, err.Error())
+	}
+	return &CreateBundleRes{Product: bundle}, nil
+}
+
+// bundleComponentCommands 转换套装组件请求
+func bundleComponentCommands(reqs []BundleComponentReq) []productapp.BundleComponentCommand {
+	cmds := make([]productapp.BundleComponentCommand, len(reqs))
+	for i, req := range reqs {
+		cmds[i] = productapp.BundleComponentCommand{
+			SkuId:    req.SkuId,
+			Quantity: req.Quantity,
+		}
+	}
+	return cmds
+}
@@ -0,0 +1,31 @@
+package product
+
+import (
+	"context"
+
+	productapp "main/internal/application/product"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// GetBundleReq 获取套装请求
+type GetBundleReq struct {
+	g.Meta `path:"/products/{id}/bundle" method:"get" tags:"商品" summary:"获取套装组件及可售数量"`
+	Id     string `v:"required" path:"id" dc:"套装Id"`
+}
+
+// GetBundleRes 获取套装响应
+type GetBundleRes struct {
+	*productapp.BundleView
+}
+
+// GetBundle 获取套装的组件及其库存，套装的可售数量由组件的可售数量推算
+func (p *Product) GetBundle(ctx context.Context, req *GetBundleReq) (res *GetBundleRes, err error) {
+	view, err := p.productApp.GetBundle(ctx, productapp.GetBundleQuery{Id: req.Id})
+	if err != nil {
+		return nil, gerror.NewCode(gcode.CodeOperationFailed, err.Error())
+	}
+	return &GetBundleRes{BundleView: view}, nil
+}
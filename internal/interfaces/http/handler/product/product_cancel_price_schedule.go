@@ -0,0 +1,38 @@
+package product
+
+import (
+	"context"
+
+	productapp "main/internal/application/product"
+	"main/internal/domain/product/entity"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// CancelPriceScheduleReq 取消定时调价请求
+type CancelPriceScheduleReq struct {
+	g.Meta     `path:"/products/{id}/prices/schedules/{scheduleId}/cancel" method:"post" tags:"商品" summary:"取消定时调价"`
+	Id         string `v:"required" path:"id" dc:"商品Id"`
+	ScheduleId string `v:"required" path:"scheduleId" dc:"定时调价Id"`
+	Actor      string `v:"required" json:"actor" dc:"操作人，已开始的定时调价恢复原价时以其名义记录价格历史"`
+}
+
+// CancelPriceScheduleRes 取消定时调价响应
+type CancelPriceScheduleRes struct {
+	*entity.PriceSchedule
+}
+
+// CancelPriceSchedule 取消定时调价，已开始的定时调价恢复原价
+func (p *Product) CancelPriceSchedule(ctx context.Context, req *CancelPriceScheduleReq) (res *CancelPriceScheduleRes, err error) {
+	schedule, err := p.productApp.CancelPriceSchedule(ctx, productapp.CancelPriceScheduleCommand{
+		ProductId: req.Id,
+		Id:        req.ScheduleId,
+		Actor:     req.Actor,
+	})
+	if err != nil {
+		return nil, gerror.NewCode(gcode.CodeOperationFailed, err.Error())
+	}
+	return &CancelPriceScheduleRes{PriceSchedule: schedule}, nil
+}
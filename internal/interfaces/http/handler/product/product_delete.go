@@ -0,0 +1,33 @@
+package product
+
+import (
+	"context"
+
+	productapp "main/internal/application/product"
+	"main/internal/domain/product/entity"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// DeleteReq 删除商品请求
+type DeleteReq struct {
+	g.Meta `path:"/products/{id}" method:"delete" tags:"商品" summary:"删除商品"`
+	Id     string `v:"required" path:"id" dc:"商品Id"`
+}
+
+// DeleteRes 删除商品响应
+type DeleteRes struct {
+	*entity.Product
+}
+
+// Delete 删除商品
+// 在售商品需先下架才能删除
+func (p *Product) Delete(ctx context.Context, req *DeleteReq) (res *DeleteRes, err error) {
+	product, err := p.productApp.DeleteProduct(ctx, productapp.DeleteProductCommand{Id: req.Id})
+	if err != nil {
+		return nil, gerror.NewCode(gcode.CodeOperationFailed, err.Error())
+	}
+	return &DeleteRes{Product: product}, nil
+}
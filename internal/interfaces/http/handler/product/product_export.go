@@ -0,0 +1,57 @@
+package product
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	productapp "main/internal/application/product"
+	"main/internal/domain/product/valueobject"
+	sharedvo "main/internal/domain/shared/valueobject"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// ExportReq 导出商品请求
+type ExportReq struct {
+	g.Meta `path:"/products/export" method:"get" tags:"商品" summary:"导出商品"`
+	Format string `json:"format" v:"in:csv,json" d:"csv" dc:"文件格式"`
+	Status string `json:"status" dc:"商品状态，为空时导出除已删除外的全部商品"`
+	Locale string `json:"locale" v:"in:zh-CN,en-US,ja-JP,de-DE" dc:"CSV 中价格的地区格式，为空时使用默认地区"`
+}
+
+// ExportRes 导出商品响应，文件内容直接写入响应体
+type ExportRes struct{}
+
+// Export 导出商品目录，格式与批量导入相同
+func (p *Product) Export(ctx context.Context, req *ExportReq) (res *ExportRes, err error) {
+	format, err := productapp.ParseCatalogFormat(req.Format, "")
+	if err != nil {
+		return nil, gerror.NewCode(gcode.CodeInvalidParameter, err.Error())
+	}
+
+	rows, err := p.productApp.ExportCatalog(ctx, productapp.ExportCatalogQuery{
+		Status: valueobject.ProductStatus(req.Status),
+	})
+	if err != nil {
+		return nil, gerror.NewCode(gcode.CodeOperationFailed, err.Error())
+	}
+
+	var buf bytes.Buffer
+	if err = productapp.EncodeCatalog(format, &buf, rows, sharedvo.Locale(req.Locale)); err != nil {
+		return nil, gerror.NewCode(gcode.CodeOperationFailed, err.Error())
+	}
+
+	// 写入响应缓冲区，统一响应中间件不再包装
+	r := g.RequestFromCtx(ctx)
+	contentType := "text/csv; charset=utf-8"
+	if format == productapp.CatalogFormatJSON {
+		contentType = "application/json; charset=utf-8"
+	}
+	r.Response.Header().Set("Content-Type", contentType)
+	r.Response.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="products.%s"`, format))
+	r.Response.Write(buf.Bytes())
+	return nil, nil
+}
@@ -0,0 +1,56 @@
+package product
+
+import (
+	"context"
+
+	productapp "main/internal/application/product"
+	sharedvo "main/internal/domain/shared/valueobject"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+)
+
+// ImportReq 批量导入商品请求
+type ImportReq struct {
+	g.Meta `path:"/products/import" method:"post" mime:"multipart/form-data" tags:"商品" summary:"批量导入商品"`
+	File   *ghttp.UploadFile `v:"required" p:"file" type:"file" dc:"商品目录文件，每个 SKU 一行，按外部编码新增或更新"`
+	Format string            `p:"format" v:"in:csv,json" dc:"文件格式，为空时根据文件扩展名判断"`
+	Locale string            `p:"locale" v:"in:zh-CN,en-US,ja-JP,de-DE" dc:"CSV 中价格的地区格式，为空时使用默认地区"`
+	DryRun bool              `p:"dryRun" dc:"仅校验并返回逐行报告，不保存"`
+	Actor  string            `p:"actor" v:"required" dc:"操作人，库存变动以其名义记录流水"`
+}
+
+// ImportRes 批量导入商品响应
+type ImportRes struct {
+	*productapp.CatalogImportReport
+}
+
+// Import 从 CSV 或 JSON 文件批量导入商品
+func (p *Product) Import(ctx context.Context, req *ImportReq) (res *ImportRes, err error) {
+	format, err := productapp.ParseCatalogFormat(req.Format, req.File.Filename)
+	if err != nil {
+		return nil, gerror.NewCode(gcode.CodeInvalidParameter, err.Error())
+	}
+	file, err := req.File.Open()
+	if err != nil {
+		return nil, gerror.NewCode(gcode.CodeInvalidParameter, err.Error())
+	}
+	defer file.Close()
+
+	rows, err := productapp.DecodeCatalog(format, file, sharedvo.Locale(req.Locale))
+	if err != nil {
+		return nil, gerror.NewCode(gcode.CodeInvalidParameter, err.Error())
+	}
+
+	report, err := p.productApp.ImportCatalog(ctx, productapp.ImportCatalogCommand{
+		Rows:   rows,
+		DryRun: req.DryRun,
+		Actor:  req.Actor,
+	})
+	if err != nil {
+		return nil, gerror.NewCode(gcode.CodeOperationFailed, err.Error())
+	}
+	return &ImportRes{CatalogImportReport: report}, nil
+}
@@ -0,0 +1,36 @@
+package product
+
+import (
+	"context"
+
+	productapp "main/internal/application/product"
+	"main/internal/domain/product/entity"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// SetLowStockThresholdReq 设置低库存阈值请求
+type SetLowStockThresholdReq struct {
+	g.Meta    `path:"/products/{id}/low-stock-threshold" method:"put" tags:"商品" summary:"设置低库存阈值"`
+	Id        string `v:"required" path:"id" dc:"商品Id"`
+	Threshold int    `v:"min:0" json:"threshold" dc:"低库存阈值，可售数量不高于该值时预警，为 0 表示不预警"`
+}
+
+// SetLowStockThresholdRes 设置低库存阈值响应
+type SetLowStockThresholdRes struct {
+	*entity.Product
+}
+
+// SetLowStockThreshold 设置商品的低库存阈值
+func (p *Product) SetLowStockThreshold(ctx context.Context, req *SetLowStockThresholdReq) (res *SetLowStockThresholdRes, err error) {
+	product, err := p.productApp.SetLowStockThreshold(ctx, productapp.SetLowStockThresholdCommand{
+		Id:        req.Id,
+		Threshold: req.Threshold,
+	})
+	if err != nil {
+		return nil, gerror.NewCode(gcode.CodeOperationFailed, err.Error())
+	}
+	return &SetLowStockThresholdRes{Product: product}, nil
+}
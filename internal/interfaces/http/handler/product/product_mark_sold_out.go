@@ -0,0 +1,32 @@
+package product
+
+import (
+	"context"
+
+	productapp "main/internal/application/product"
+	"main/internal/domain/product/entity"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// MarkSoldOutReq 标记商品售罄请求
+type MarkSoldOutReq struct {
+	g.Meta `path:"/products/{id}/mark-sold-out" method:"post" tags:"商品" summary:"标记商品售罄"`
+	Id     string `v:"required" path:"id" dc:"商品Id"`
+}
+
+// MarkSoldOutRes 标记商品售罄响应
+type MarkSoldOutRes struct {
+	*entity.Product
+}
+
+// MarkSoldOut 标记商品售罄
+func (p *Product) MarkSoldOut(ctx context.Context, req *MarkSoldOutReq) (res *MarkSoldOutRes, err error) {
+	product, err := p.productApp.MarkProductSoldOut(ctx, productapp.MarkProductSoldOutCommand{Id: req.Id})
+	if err != nil {
+		return nil, gerror.NewCode(gcode.CodeOperationFailed, err.Error())
+	}
+	return &MarkSoldOutRes{Product: product}, nil
+}
@@ -0,0 +1,38 @@
+package product
+
+import (
+	"context"
+
+	productapp "main/internal/application/product"
+	"main/internal/domain/product/entity"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// PriceHistoryReq 获取价格历史请求
+type PriceHistoryReq struct {
+	g.Meta `path:"/products/{id}/prices/history" method:"get" tags:"商品" summary:"价格历史"`
+	Id     string `v:"required" path:"id" dc:"商品Id"`
+	SkuId  string `json:"skuId" dc:"SKU Id，为空时返回所有 SKU 的价格历史"`
+	Limit  int    `json:"limit" v:"between:1,500" d:"50" dc:"返回条数"`
+}
+
+// PriceHistoryRes 获取价格历史响应
+type PriceHistoryRes struct {
+	List []*entity.PriceChange `json:"list"`
+}
+
+// PriceHistory 获取商品的价格历史，按时间倒序
+func (p *Product) PriceHistory(ctx context.Context, req *PriceHistoryReq) (res *PriceHistoryRes, err error) {
+	changes, err := p.productApp.ListPriceHistory(ctx, productapp.ListPriceHistoryQuery{
+		ProductId: req.Id,
+		SkuId:     req.SkuId,
+		Limit:     req.Limit,
+	})
+	if err != nil {
+		return nil, gerror.NewCode(gcode.CodeOperationFailed, err.Error())
+	}
+	return &PriceHistoryRes{List: changes}, nil
+}
@@ -0,0 +1,34 @@
+package product
+
+import (
+	"context"
+
+	productapp "main/internal/application/product"
+	"main/internal/domain/product/entity"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// PriceSchedulesReq 获取定时调价请求
+type PriceSchedulesReq struct {
+	g.Meta `path:"/products/{id}/prices/schedules" method:"get" tags:"商品" summary:"定时调价列表"`
+	Id     string `v:"required" path:"id" dc:"商品Id"`
+}
+
+// PriceSchedulesRes 获取定时调价响应
+type PriceSchedulesRes struct {
+	List []*entity.PriceSchedule `json:"list"`
+}
+
+// PriceSchedules 获取商品的定时调价，按开始时间排序
+func (p *Product) PriceSchedules(ctx context.Context, req *PriceSchedulesReq) (res *PriceSchedulesRes, err error) {
+	schedules, err := p.productApp.ListPriceSchedules(ctx, productapp.ListPriceSchedulesQuery{
+		ProductId: req.Id,
+	})
+	if err != nil {
+		return nil, gerror.NewCode(gcode.CodeOperationFailed, err.Error())
+	}
+	return &PriceSchedulesRes{List: schedules}, nil
+}
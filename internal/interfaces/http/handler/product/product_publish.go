@@ -0,0 +1,32 @@
+package product
+
+import (
+	"context"
+
+	productapp "main/internal/application/product"
+	"main/internal/domain/product/entity"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// PublishReq 发布商品请求
+type PublishReq struct {
+	g.Meta `path:"/products/{id}/publish" method:"post" tags:"商品" summary:"发布商品"`
+	Id     string `v:"required" path:"id" dc:"商品Id"`
+}
+
+// PublishRes 发布商品响应
+type PublishRes struct {
+	*entity.Product
+}
+
+// Publish 发布商品
+func (p *Product) Publish(ctx context.Context, req *PublishReq) (res *PublishRes, err error) {
+	product, err := p.productApp.PublishProduct(ctx, productapp.PublishProductCommand{Id: req.Id})
+	if err != nil {
+		return nil, gerror.NewCode(gcode.CodeOperationFailed, err.Error())
+	}
+	return &PublishRes{Product: product}, nil
+}
@@ -0,0 +1,40 @@
+package product
+
+import (
+	"context"
+
+	productapp "main/internal/application/product"
+	"main/internal/domain/product/valueobject"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// ReconcileStockReq 核对库存请求
+type ReconcileStockReq struct {
+	g.Meta `path:"/products/{id}/stock/reconcile" method:"post" tags:"商品" summary:"核对库存与流水"`
+	Id     string `v:"required" path:"id" dc:"商品Id"`
+	Actor  string `v:"required" json:"actor" dc:"操作人，补记的期初流水以其名义记录"`
+}
+
+// ReconcileStockRes 核对库存响应
+type ReconcileStockRes struct {
+	Consistent    bool                           `json:"consistent" dc:"库存与流水是否一致"`
+	Discrepancies []valueobject.StockDiscrepancy `json:"discrepancies"`
+}
+
+// ReconcileStock 核对商品各 SKU 的库存与库存流水的汇总
+func (p *Product) ReconcileStock(ctx context.Context, req *ReconcileStockReq) (res *ReconcileStockRes, err error) {
+	discrepancies, err := p.productApp.ReconcileStock(ctx, productapp.ReconcileStockCommand{
+		ProductId: req.Id,
+		Actor:     req.Actor,
+	})
+	if err != nil {
+		return nil, gerror.NewCode(gcode.CodeOperationFailed, err.Error())
+	}
+	return &ReconcileStockRes{
+		Consistent:    len(discrepancies) == 0,
+		Discrepancies: discrepancies,
+	}, nil
+}
@@ -0,0 +1,36 @@
+package product
+
+import (
+	"context"
+
+	productapp "main/internal/application/product"
+	"main/internal/domain/product/entity"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// RemoveImageReq 移除商品图片请求
+type RemoveImageReq struct {
+	g.Meta  `path:"/products/{id}/images/{imageId}" method:"delete" tags:"商品" summary:"移除商品图片"`
+	Id      string `v:"required" path:"id" dc:"商品Id"`
+	ImageId string `v:"required" path:"imageId" dc:"图片Id"`
+}
+
+// RemoveImageRes 移除商品图片响应
+type RemoveImageRes struct {
+	*entity.Product
+}
+
+// RemoveImage 移除商品图片
+func (p *Product) RemoveImage(ctx context.Context, req *RemoveImageReq) (res *RemoveImageRes, err error) {
+	product, err := p.productApp.RemoveImage(ctx, productapp.RemoveImageCommand{
+		ProductId: req.Id,
+		ImageId:   req.ImageId,
+	})
+	if err != nil {
+		return nil, gerror.NewCode(gcode.CodeOperationFailed, err.Error())
+	}
+	return &RemoveImageRes{Product: product}, nil
+}
@@ -0,0 +1,36 @@
+package product
+
+import (
+	"context"
+
+	productapp "main/internal/application/product"
+	"main/internal/domain/product/entity"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// ReorderImagesReq 调整商品图片顺序请求
+type ReorderImagesReq struct {
+	g.Meta   `path:"/products/{id}/images/order" method:"put" tags:"商品" summary:"调整商品图片顺序"`
+	Id       string   `v:"required" path:"id" dc:"商品Id"`
+	ImageIds []string `v:"required" json:"imageIds" dc:"全部图片Id的新顺序，第一张为主图"`
+}
+
+// ReorderImagesRes 调整商品图片顺序响应
+type ReorderImagesRes struct {
+	*entity.Product
+}
+
+// ReorderImages 调整商品图片顺序
+func (p *Product) ReorderImages(ctx context.Context, req *ReorderImagesReq) (res *ReorderImagesRes, err error) {
+	product, err := p.productApp.ReorderImages(ctx, productapp.ReorderImagesCommand{
+		ProductId: req.Id,
+		ImageIds:  req.ImageIds,
+	})
+	if err != nil {
+		return nil, gerror.NewCode(gcode.CodeOperationFailed, err.Error())
+	}
+	return &ReorderImagesRes{Product: product}, nil
+}
@@ -0,0 +1,33 @@
+package product
+
+import (
+	"context"
+
+	productapp "main/internal/application/product"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// ReplenishmentReq 补货报表请求
+type ReplenishmentReq struct {
+	g.Meta `path:"/products/replenishment" method:"get" tags:"商品" summary:"补货报表"`
+	Days   int `json:"days" v:"between:1,365" d:"30" dc:"计算销售速度的统计天数"`
+}
+
+// ReplenishmentRes 补货报表响应
+type ReplenishmentRes struct {
+	List []productapp.ReplenishmentItem `json:"list"`
+}
+
+// Replenishment 获取低库存商品及其近期销售速度
+func (p *Product) Replenishment(ctx context.Context, req *ReplenishmentReq) (res *ReplenishmentRes, err error) {
+	items, err := p.productApp.GetReplenishmentReport(ctx, productapp.ReplenishmentReportQuery{
+		Days: req.Days,
+	})
+	if err != nil {
+		return nil, gerror.NewCode(gcode.CodeOperationFailed, err.Error())
+	}
+	return &ReplenishmentRes{List: items}, nil
+}
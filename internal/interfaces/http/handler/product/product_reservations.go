@@ -0,0 +1,36 @@
+package product
+
+import (
+	"context"
+
+	productapp "main/internal/application/product"
+	"main/internal/domain/product/valueobject"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// ReservationsReq 获取商品库存预占请求
+type ReservationsReq struct {
+	g.Meta `path:"/products/{id}/reservations" method:"get" tags:"商品" summary:"商品库存预占"`
+	Id     string `v:"required" path:"id" dc:"商品Id"`
+	State  string `json:"state" v:"in:held,committed,released" dc:"预占状态，为空时返回所有状态"`
+}
+
+// ReservationsRes 获取商品库存预占响应
+type ReservationsRes struct {
+	*productapp.ProductReservationView
+}
+
+// Reservations 获取商品各 SKU 的在库、预占和可售数量以及预占记录
+func (p *Product) Reservations(ctx context.Context, req *ReservationsReq) (res *ReservationsRes, err error) {
+	view, err := p.productApp.GetProductReservations(ctx, productapp.GetProductReservationsQuery{
+		ProductId: req.Id,
+		State:     valueobject.ReservationState(req.State),
+	})
+	if err != nil {
+		return nil, gerror.NewCode(gcode.CodeOperationFailed, err.Error())
+	}
+	return &ReservationsRes{ProductReservationView: view}, nil
+}
@@ -0,0 +1,33 @@
+package product
+
+import (
+	"context"
+
+	productapp "main/internal/application/product"
+	"main/internal/domain/product/entity"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// RestoreReq 恢复已删除的商品请求
+type RestoreReq struct {
+	g.Meta `path:"/products/{id}/restore" method:"post" tags:"商品" summary:"恢复已删除的商品"`
+	Id     string `v:"required" path:"id" dc:"商品Id"`
+}
+
+// RestoreRes 恢复已删除的商品响应
+type RestoreRes struct {
+	*entity.Product
+}
+
+// Restore 恢复已删除的商品
+// 恢复后的商品处于下架状态，需重新发布才能销售
+func (p *Product) Restore(ctx context.Context, req *RestoreReq) (res *RestoreRes, err error) {
+	product, err := p.productApp.RestoreProduct(ctx, productapp.RestoreProductCommand{Id: req.Id})
+	if err != nil {
+		return nil, gerror.NewCode(gcode.CodeOperationFailed, err.Error())
+	}
+	return &RestoreRes{Product: product}, nil
+}
@@ -0,0 +1,48 @@
+package product
+
+import (
+	"context"
+
+	productapp "main/internal/application/product"
+	"main/internal/domain/product/entity"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// SchedulePriceReq 定时调价请求
+type SchedulePriceReq struct {
+	g.Meta   `path:"/products/{id}/prices/schedules" method:"post" tags:"商品" summary:"定时调价"`
+	Id       string  `v:"required" path:"id" dc:"商品Id"`
+	SkuId    string  `v:"required" json:"skuId" dc:"SKU Id"`
+	Price    float64 `v:"required|min:0" json:"price" dc:"调整后的价格"`
+	Currency string  `json:"currency" dc:"价格货币，为空时使用默认货币"`
+	StartAt  int64   `v:"required|min:1" json:"startAt" dc:"开始时间（毫秒）"`
+	EndAt    int64   `v:"min:0" json:"endAt" dc:"结束时间（毫秒），为 0 表示永久调价，不恢复原价"`
+	Actor    string  `v:"required" json:"actor" dc:"操作人"`
+	Note     string  `json:"note" dc:"备注，如促销活动名称"`
+}
+
+// SchedulePriceRes 定时调价响应
+type SchedulePriceRes struct {
+	*entity.PriceSchedule
+}
+
+// SchedulePrice 创建定时调价，到开始时间时应用，到结束时间时恢复原价
+func (p *Product) SchedulePrice(ctx context.Context, req *SchedulePriceReq) (res *SchedulePriceRes, err error) {
+	schedule, err := p.productApp.SchedulePriceChange(ctx, productapp.SchedulePriceChangeCommand{
+		ProductId: req.Id,
+		SkuId:     req.SkuId,
+		Price:     req.Price,
+		Currency:  req.Currency,
+		StartAt:   req.StartAt,
+		EndAt:     req.EndAt,
+		Actor:     req.Actor,
+		Note:      req.Note,
+	})
+	if err != nil {
+		return nil, gerror.NewCode(gcode.CodeOperationFailed, err.Error())
+	}
+	return &SchedulePriceRes{PriceSchedule: schedule}, nil
+}
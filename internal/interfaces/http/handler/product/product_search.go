@@ -0,0 +1,59 @@
+package product
+
+import (
+	"context"
+
+	productapp "main/internal/application/product"
+	"main/internal/domain/product/entity"
+	"main/internal/domain/product/valueobject"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// SearchReq 搜索商品请求
+type SearchReq struct {
+	g.Meta        `path:"/products/search" method:"get" tags:"商品" summary:"搜索商品"`
+	Keyword       string   `json:"keyword" dc:"关键词，匹配商品名称和描述"`
+	Status        string   `json:"status" v:"in:draft,on_sale,off_sale,sold_out,deleted" dc:"商品状态，为空时不包括已删除的商品"`
+	CategoryId    string   `json:"categoryId" dc:"类目Id，包含其所有后代类目"`
+	MinPrice      *float64 `json:"minPrice" v:"min:0" dc:"最低价格"`
+	MaxPrice      *float64 `json:"maxPrice" v:"min:0" dc:"最高价格"`
+	Currency      string   `json:"currency" dc:"价格区间的货币，为空时使用默认货币"`
+	InStock       bool     `json:"inStock" dc:"是否仅返回有库存的商品"`
+	SortBy        string   `json:"sortBy" v:"in:relevance,created_at,price,name" dc:"排序字段"`
+	SortDirection string   `json:"sortDirection" v:"in:asc,desc" dc:"排序方向"`
+	Cursor        string   `json:"cursor" dc:"上一页返回的游标"`
+	Limit         int      `json:"limit" v:"between:1,100" d:"20" dc:"每页数量"`
+}
+
+// SearchRes 搜索商品响应
+type SearchRes struct {
+	List       []*entity.Product `json:"list"`
+	NextCursor string            `json:"nextCursor" dc:"下一页游标，为空表示没有更多数据"`
+}
+
+// Search 搜索商品
+func (p *Product) Search(ctx context.Context, req *SearchReq) (res *SearchRes, err error) {
+	result, err := p.productApp.SearchProducts(ctx, productapp.SearchProductsQuery{
+		Keyword:       req.Keyword,
+		Status:        valueobject.ProductStatus(req.Status),
+		CategoryId:    req.CategoryId,
+		MinPrice:      req.MinPrice,
+		MaxPrice:      req.MaxPrice,
+		Currency:      req.Currency,
+		InStock:       req.InStock,
+		SortBy:        valueobject.ProductSortField(req.SortBy),
+		SortDirection: valueobject.SortDirection(req.SortDirection),
+		Cursor:        req.Cursor,
+		Limit:         req.Limit,
+	})
+	if err != nil {
+		return nil, gerror.NewCode(gcode.CodeOperationFailed, err.Error())
+	}
+	return &SearchRes{
+		List:       result.Products,
+		NextCursor: result.NextCursor,
+	}, nil
+}
@@ -0,0 +1,38 @@
+package product
+
+import (
+	"context"
+
+	productapp "main/internal/application/product"
+	"main/internal/domain/product/entity"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// StockMovementsReq 获取库存流水请求
+type StockMovementsReq struct {
+	g.Meta `path:"/products/{id}/stock/movements" method:"get" tags:"商品" summary:"库存流水"`
+	Id     string `v:"required" path:"id" dc:"商品Id"`
+	SkuId  string `json:"skuId" dc:"SKU Id，为空时返回所有 SKU 的流水"`
+	Limit  int    `json:"limit" v:"between:1,500" d:"50" dc:"返回条数"`
+}
+
+// StockMovementsRes 获取库存流水响应
+type StockMovementsRes struct {
+	List []*entity.StockMovement `json:"list"`
+}
+
+// StockMovements 获取商品的库存流水，按时间倒序
+func (p *Product) StockMovements(ctx context.Context, req *StockMovementsReq) (res *StockMovementsRes, err error) {
+	movements, err := p.productApp.ListStockMovements(ctx, productapp.ListStockMovementsQuery{
+		ProductId: req.Id,
+		SkuId:     req.SkuId,
+		Limit:     req.Limit,
+	})
+	if err != nil {
+		return nil, gerror.NewCode(gcode.CodeOperationFailed, err.Error())
+	}
+	return &StockMovementsRes{List: movements}, nil
+}
@@ -0,0 +1,32 @@
+package product
+
+import (
+	"context"
+
+	productapp "main/internal/application/product"
+	"main/internal/domain/product/entity"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// TakeOffSaleReq 下架商品请求
+type TakeOffSaleReq struct {
+	g.Meta `path:"/products/{id}/take-off-sale" method:"post" tags:"商品" summary:"下架商品"`
+	Id     string `v:"required" path:"id" dc:"商品Id"`
+}
+
+// TakeOffSaleRes 下架商品响应
+type TakeOffSaleRes struct {
+	*entity.Product
+}
+
+// TakeOffSale 下架商品
+func (p *Product) TakeOffSale(ctx context.Context, req *TakeOffSaleReq) (res *TakeOffSaleRes, err error) {
+	product, err := p.productApp.TakeProductOffSale(ctx, productapp.TakeProductOffSaleCommand{Id: req.Id})
+	if err != nil {
+		return nil, gerror.NewCode(gcode.CodeOperationFailed, err.Error())
+	}
+	return &TakeOffSaleRes{Product: product}, nil
+}
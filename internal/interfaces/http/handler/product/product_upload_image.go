@@ -0,0 +1,51 @@
+package product
+
+import (
+	"context"
+	"io"
+
+	productapp "main/internal/application/product"
+	"main/internal/domain/product/entity"
+	"main/internal/domain/product/valueobject"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+)
+
+// UploadImageReq 上传商品图片请求
+type UploadImageReq struct {
+	g.Meta `path:"/products/{id}/images" method:"post" mime:"multipart/form-data" tags:"商品" summary:"上传商品图片"`
+	Id     string            `v:"required" path:"id" dc:"商品Id"`
+	File   *ghttp.UploadFile `v:"required" p:"file" type:"file" dc:"图片文件，支持 JPEG、PNG、GIF"`
+}
+
+// UploadImageRes 上传商品图片响应
+type UploadImageRes struct {
+	*entity.Product
+}
+
+// UploadImage 上传商品图片
+func (p *Product) UploadImage(ctx context.Context, req *UploadImageReq) (res *UploadImageRes, err error) {
+	file, err := req.File.Open()
+	if err != nil {
+		return nil, gerror.NewCode(gcode.CodeInvalidParameter, err.Error())
+	}
+	defer file.Close()
+
+	// 多读一个字节，由领域服务判断是否超出大小限制
+	data, err := io.ReadAll(io.LimitReader(file, valueobject.MaxImageBytes+1))
+	if err != nil {
+		return nil, gerror.NewCode(gcode.CodeInvalidParameter, err.Error())
+	}
+
+	product, err := p.productApp.UploadImage(ctx, productapp.UploadImageCommand{
+		ProductId: req.Id,
+		Data:      data,
+	})
+	if err != nil {
+		return nil, gerror.NewCode(gcode.CodeOperationFailed, err.Error())
+	}
+	return &UploadImageRes{Product: product}, nil
+}
@@ -0,0 +1,17 @@
+package report
+
+import (
+	"main/internal/application/order"
+)
+
+// Report 报表控制器
+type Report struct {
+	orderApp *order.OrderApplication
+}
+
+// NewReport 创建报表控制器实例
+func NewReport(orderApp *order.OrderApplication) *Report {
+	return &Report{
+		orderApp: orderApp,
+	}
+}
@@ -0,0 +1,56 @@
+package report
+
+import (
+	"context"
+
+	"main/internal/application/order"
+	sharedvo "main/internal/domain/shared/valueobject"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// RefundAllocationReq 部分退款分摊请求
+type RefundAllocationReq struct {
+	g.Meta  `path:"/reports/orders/{orderId}/refund-allocation" method:"get" tags:"报表" summary:"分摊部分退款到订单项"`
+	OrderId string  `v:"required" path:"orderId" dc:"订单Id"`
+	Amount  float64 `v:"required|min:0.01" query:"amount" dc:"退款金额，以订单货币表示"`
+}
+
+// ItemRefundRes 订单项分摊到的退款金额
+type ItemRefundRes struct {
+	OrderItemId string          `json:"orderItemId"`
+	SkuId       string          `json:"skuId"`
+	Amount      *sharedvo.Money `json:"amount"`
+}
+
+// RefundAllocationRes 部分退款分摊响应
+type RefundAllocationRes struct {
+	OrderId string          `json:"orderId"`
+	Items   []ItemRefundRes `json:"items"`
+}
+
+// RefundAllocation 按订单项实付金额比例分摊部分退款，各订单项金额之和等于退款金额
+func (c *Report) RefundAllocation(ctx context.Context, req *RefundAllocationReq) (res *RefundAllocationRes, err error) {
+	refunds, err := c.orderApp.AllocateRefund(ctx, order.AllocateRefundQuery{
+		OrderId: req.OrderId,
+		Amount:  req.Amount,
+	})
+	if err != nil {
+		return nil, gerror.NewCode(gcode.CodeOperationFailed, err.Error())
+	}
+
+	res = &RefundAllocationRes{
+		OrderId: req.OrderId,
+		Items:   make([]ItemRefundRes, len(refunds)),
+	}
+	for i, refund := range refunds {
+		res.Items[i] = ItemRefundRes{
+			OrderItemId: refund.OrderItemId,
+			SkuId:       refund.SkuId,
+			Amount:      refund.Amount,
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,65 @@
+package report
+
+import (
+	"context"
+
+	"main/internal/application/order"
+	"main/internal/domain/order/valueobject"
+	sharedvo "main/internal/domain/shared/valueobject"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// UserOrderTotalReq 用户订单金额汇总请求
+type UserOrderTotalReq struct {
+	g.Meta   `path:"/reports/users/{userId}/order-total" method:"get" tags:"报表" summary:"汇总用户订单金额"`
+	UserId   string `v:"required" path:"userId" dc:"用户Id"`
+	Status   string `query:"status" v:"in:created,paid,shipping,delivered,cancelled" dc:"订单状态，为空时不过滤"`
+	Currency string `query:"currency" v:"currency" dc:"汇总使用的货币，为空时使用默认货币"`
+}
+
+// ConversionRes 订单金额的换算记录
+type ConversionRes struct {
+	Source *sharedvo.Money `json:"source"` // 订单原始金额
+	Result *sharedvo.Money `json:"result"` // 换算后的金额
+	Rate   float64         `json:"rate"`   // 使用的汇率
+	RateAt int64           `json:"rateAt"` // 汇率的生效时间（毫秒）
+}
+
+// UserOrderTotalRes 用户订单金额汇总响应
+type UserOrderTotalRes struct {
+	UserId      string          `json:"userId"`
+	OrderCount  int             `json:"orderCount"`
+	Total       *sharedvo.Money `json:"total"`
+	Conversions []ConversionRes `json:"conversions"`
+}
+
+// UserOrderTotal 汇总用户订单金额，不同货币的订单按当前汇率换算为同一货币
+func (c *Report) UserOrderTotal(ctx context.Context, req *UserOrderTotalReq) (res *UserOrderTotalRes, err error) {
+	total, err := c.orderApp.GetUserOrderTotal(ctx, order.GetUserOrderTotalQuery{
+		UserId:   req.UserId,
+		Status:   valueobject.OrderStatus(req.Status),
+		Currency: req.Currency,
+	})
+	if err != nil {
+		return nil, gerror.NewCode(gcode.CodeOperationFailed, err.Error())
+	}
+
+	res = &UserOrderTotalRes{
+		UserId:      total.UserId,
+		OrderCount:  total.OrderCount,
+		Total:       total.Total,
+		Conversions: make([]ConversionRes, len(total.Conversions)),
+	}
+	for i, conversion := range total.Conversions {
+		res.Conversions[i] = ConversionRes{
+			Source: conversion.Source,
+			Result: conversion.Result,
+			Rate:   conversion.Rate.Rate(),
+			RateAt: conversion.Rate.Timestamp().UnixMilli(),
+		}
+	}
+	return res, nil
+}
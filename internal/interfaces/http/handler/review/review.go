@@ -0,0 +1,36 @@
+package review
+
+import (
+	"main/internal/application/review"
+	"main/internal/domain/review/entity"
+)
+
+// Review 商品评价控制器
+type Review struct {
+	reviewApp *review.ReviewApplication
+}
+
+// NewReview 创建商品评价控制器实例
+func NewReview(reviewApp *review.ReviewApplication) *Review {
+	return &Review{
+		reviewApp: reviewApp,
+	}
+}
+
+// PageRes 评价分页响应
+type PageRes struct {
+	List    []*entity.Review `json:"list"`
+	Total   int              `json:"total"`
+	Page    int              `json:"page"`
+	PerPage int              `json:"perPage"`
+}
+
+// newPageRes 转换评价分页结果
+func newPageRes(page *review.ReviewPage) *PageRes {
+	return &PageRes{
+		List:    page.Reviews,
+		Total:   page.Total,
+		Page:    page.Page,
+		PerPage: page.PerPage,
+	}
+}
@@ -0,0 +1,42 @@
+package review
+
+import (
+	"context"
+
+	"main/internal/application/review"
+	"main/internal/domain/review/entity"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// CreateReq 评价订单项请求
+type CreateReq struct {
+	g.Meta      `path:"/reviews" method:"post" tags:"评价" summary:"评价已送达订单中的商品"`
+	UserId      string `v:"required" json:"userId" dc:"用户Id"`
+	OrderId     string `v:"required" json:"orderId" dc:"订单Id，订单须已送达"`
+	OrderItemId string `v:"required" json:"orderItemId" dc:"被评价的订单项Id"`
+	Rating      int    `v:"required|between:1,5" json:"rating" dc:"评分，1 到 5"`
+	Content     string `json:"content" dc:"评价内容"`
+}
+
+// CreateRes 评价订单项响应
+type CreateRes struct {
+	*entity.Review
+}
+
+// Create 评价订单项，每个订单项只能评价一次，评价审核通过后才对外展示
+func (c *Review) Create(ctx context.Context, req *CreateReq) (res *CreateRes, err error) {
+	created, err := c.reviewApp.CreateReview(ctx, review.CreateReviewCommand{
+		UserId:      req.UserId,
+		OrderId:     req.OrderId,
+		OrderItemId: req.OrderItemId,
+		Rating:      req.Rating,
+		Content:     req.Content,
+	})
+	if err != nil {
+		return nil, gerror.NewCode(gcode.CodeOperationFailed, err.Error())
+	}
+	return &CreateRes{Review: created}, nil
+}
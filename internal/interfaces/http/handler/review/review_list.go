@@ -0,0 +1,40 @@
+package review
+
+import (
+	"context"
+
+	"main/internal/application/review"
+	"main/internal/domain/review/valueobject"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// ListReq 评价管理列表请求
+type ListReq struct {
+	g.Meta    `path:"/admin/reviews" method:"get" tags:"评价" summary:"获取评价列表"`
+	ProductId string `json:"productId" dc:"商品Id，为空时不过滤"`
+	Status    string `v:"in:pending,approved,rejected" json:"status" dc:"审核状态，为空时不过滤"`
+	Page      int    `v:"min:0" json:"page" dc:"页码，从 1 开始"`
+	PerPage   int    `v:"between:0,100" json:"perPage" dc:"每页数量，默认 20"`
+}
+
+// ListRes 评价管理列表响应
+type ListRes struct {
+	*PageRes
+}
+
+// List 分页获取评价，用于评价审核
+func (c *Review) List(ctx context.Context, req *ListReq) (res *ListRes, err error) {
+	page, err := c.reviewApp.ListReviews(ctx, review.ListReviewsQuery{
+		ProductId: req.ProductId,
+		Status:    valueobject.ReviewStatus(req.Status),
+		Page:      req.Page,
+		PerPage:   req.PerPage,
+	})
+	if err != nil {
+		return nil, gerror.NewCode(gcode.CodeOperationFailed, err.Error())
+	}
+	return &ListRes{PageRes: newPageRes(page)}, nil
+}
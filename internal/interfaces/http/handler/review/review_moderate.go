@@ -0,0 +1,77 @@
+package review
+
+import (
+	"context"
+
+	"main/internal/application/review"
+	"main/internal/domain/review/entity"
+	"main/internal/domain/review/valueobject"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// ApproveReq 审核通过评价请求
+type ApproveReq struct {
+	g.Meta    `path:"/admin/reviews/{id}/approve" method:"post" tags:"评价" summary:"审核通过评价"`
+	Id        string `v:"required" path:"id" dc:"评价Id"`
+	Moderator string `v:"required" json:"moderator" dc:"审核人"`
+	Note      string `json:"note" dc:"审核备注"`
+}
+
+// ApproveRes 审核通过评价响应
+type ApproveRes struct {
+	*entity.Review
+}
+
+// Approve 审核通过评价，评价开始对外展示并计入商品评分
+func (c *Review) Approve(ctx context.Context, req *ApproveReq) (res *ApproveRes, err error) {
+	moderated, err := c.moderate(ctx, req.Id, valueobject.ReviewStatusApproved, req.Moderator, req.Note)
+	if err != nil {
+		return nil, err
+	}
+	return &ApproveRes{Review: moderated}, nil
+}
+
+// RejectReq 拒绝评价请求
+type RejectReq struct {
+	g.Meta    `path:"/admin/reviews/{id}/reject" method:"post" tags:"评价" summary:"拒绝评价"`
+	Id        string `v:"required" path:"id" dc:"评价Id"`
+	Moderator string `v:"required" json:"moderator" dc:"审核人"`
+	Note      string `json:"note" dc:"拒绝原因"`
+}
+
+// RejectRes 拒绝评价响应
+type RejectRes struct {
+	*entity.Review
+}
+
+// Reject 拒绝评价，已通过的评价被拒绝后不再展示并从商品评分中移除
+func (c *Review) Reject(ctx context.Context, req *RejectReq) (res *RejectRes, err error) {
+	moderated, err := c.moderate(ctx, req.Id, valueobject.ReviewStatusRejected, req.Moderator, req.Note)
+	if err != nil {
+		return nil, err
+	}
+	return &RejectRes{Review: moderated}, nil
+}
+
+// moderate 审核评价
+func (c *Review) moderate(
+	ctx context.Context,
+	id string,
+	status valueobject.ReviewStatus,
+	moderator string,
+	note string,
+) (*entity.Review, error) {
+	moderated, err := c.reviewApp.ModerateReview(ctx, review.ModerateReviewCommand{
+		Id:        id,
+		Status:    status,
+		Moderator: moderator,
+		Note:      note,
+	})
+	if err != nil {
+		return nil, gerror.NewCode(gcode.CodeOperationFailed, err.Error())
+	}
+	return moderated, nil
+}
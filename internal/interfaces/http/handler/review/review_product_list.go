@@ -0,0 +1,39 @@
+package review
+
+import (
+	"context"
+
+	"main/internal/application/review"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// ProductListReq 获取商品评价请求
+type ProductListReq struct {
+	g.Meta  `path:"/products/{id}/reviews" method:"get" tags:"评价" summary:"获取商品评价"`
+	Id      string `v:"required" path:"id" dc:"商品Id"`
+	Rating  int    `v:"between:0,5" json:"rating" dc:"评分，为 0 时不过滤"`
+	Page    int    `v:"min:0" json:"page" dc:"页码，从 1 开始"`
+	PerPage int    `v:"between:0,100" json:"perPage" dc:"每页数量，默认 20"`
+}
+
+// ProductListRes 获取商品评价响应
+type ProductListRes struct {
+	*PageRes
+}
+
+// ProductList 分页获取商品审核通过的评价，按评价时间倒序
+func (c *Review) ProductList(ctx context.Context, req *ProductListReq) (res *ProductListRes, err error) {
+	page, err := c.reviewApp.ListProductReviews(ctx, review.ListProductReviewsQuery{
+		ProductId: req.Id,
+		Rating:    req.Rating,
+		Page:      req.Page,
+		PerPage:   req.PerPage,
+	})
+	if err != nil {
+		return nil, gerror.NewCode(gcode.CodeOperationFailed, err.Error())
+	}
+	return &ProductListRes{PageRes: newPageRes(page)}, nil
+}
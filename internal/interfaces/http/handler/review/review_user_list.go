@@ -0,0 +1,37 @@
+package review
+
+import (
+	"context"
+
+	"main/internal/application/review"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// UserListReq 获取用户评价请求
+type UserListReq struct {
+	g.Meta  `path:"/users/{userId}/reviews" method:"get" tags:"评价" summary:"获取用户提交的评价"`
+	UserId  string `v:"required" path:"userId" dc:"用户Id"`
+	Page    int    `v:"min:0" json:"page" dc:"页码，从 1 开始"`
+	PerPage int    `v:"between:0,100" json:"perPage" dc:"每页数量，默认 20"`
+}
+
+// UserListRes 获取用户评价响应
+type UserListRes struct {
+	*PageRes
+}
+
+// UserList 分页获取用户提交的评价，包括待审核和被拒绝的评价
+func (c *Review) UserList(ctx context.Context, req *UserListReq) (res *UserListRes, err error) {
+	page, err := c.reviewApp.ListUserReviews(ctx, review.ListUserReviewsQuery{
+		UserId:  req.UserId,
+		Page:    req.Page,
+		PerPage: req.PerPage,
+	})
+	if err != nil {
+		return nil, gerror.NewCode(gcode.CodeOperationFailed, err.Error())
+	}
+	return &UserListRes{PageRes: newPageRes(page)}, nil
+}
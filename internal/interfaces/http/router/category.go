@@ -0,0 +1,49 @@
+package router
+
+import (
+	categoryapp "main/internal/application/category"
+	categoryHandler "main/internal/interfaces/http/handler/category"
+
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/os/gctx"
+)
+
+// registerCategoryRoutes 注册类目相关路由
+func registerCategoryRoutes(group *ghttp.RouterGroup) {
+	// 初始化依赖
+	ctx := gctx.GetInitCtx()
+	categoryApp := categoryapp.NewCategoryApplication(
+		newCategoryService(ctx),
+		newProductService(ctx),
+	)
+
+	// 创建处理器
+	handler := categoryHandler.NewCategory(categoryApp)
+
+	// 注册路由
+	group.Group("/categories", func(group *ghttp.RouterGroup) {
+		// 创建类目
+		group.POST("/", handler.Create)
+
+		// 类目树
+		group.GET("/tree", handler.Tree)
+
+		// 重排同级类目
+		group.PUT("/order", handler.Reorder)
+
+		// 获取类目详情
+		group.GET("/{id}", handler.Get)
+
+		// 重命名类目
+		group.PUT("/{id}", handler.Rename)
+
+		// 移动类目
+		group.POST("/{id}/move", handler.Move)
+
+		// 删除类目
+		group.DELETE("/{id}", handler.Delete)
+
+		// 类目子树下的商品
+		group.GET("/{id}/products", handler.ListProducts)
+	})
+}
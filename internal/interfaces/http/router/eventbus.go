@@ -0,0 +1,8 @@
+package router
+
+import (
+	"main/internal/infrastructure/eventbus"
+)
+
+// eventBus 进程内共享的事件总线，各模块的领域服务通过它发布和订阅领域事件
+var eventBus eventbus.EventBus = eventbus.NewSimpleEventBus()
@@ -0,0 +1,62 @@
+package router
+
+import (
+	"context"
+
+	flashsaleapp "main/internal/application/flashsale"
+	flashSaleHandler "main/internal/interfaces/http/handler/flashsale"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/os/gctx"
+	"github.com/gogf/gf/v2/os/gtimer"
+)
+
+// registerFlashSaleRoutes 注册秒杀活动管理相关路由
+func registerFlashSaleRoutes(group *ghttp.RouterGroup) {
+	// 初始化依赖
+	ctx := gctx.GetInitCtx()
+	flashSaleApp := flashsaleapp.NewFlashSaleApplication(newFlashSaleService(ctx), newProductService(ctx))
+
+	// 创建处理器
+	handler := flashSaleHandler.NewFlashSale(flashSaleApp)
+
+	// 注册路由
+	group.Group("/admin/flash-sales", func(group *ghttp.RouterGroup) {
+		// 创建秒杀活动
+		group.POST("/", handler.Create)
+
+		// 秒杀活动列表
+		group.GET("/", handler.List)
+
+		// 获取秒杀活动详情
+		group.GET("/{id}", handler.Get)
+
+		// 取消秒杀活动
+		group.POST("/{id}/cancel", handler.Cancel)
+
+		// 秒杀活动购买记录
+		group.GET("/{id}/purchases", handler.Purchases)
+	})
+}
+
+// startFlashSaleExpiry 启动定时任务，定期归还超时未支付订单占用的秒杀活动库存和限购名额
+// 与库存预占使用相同的检查间隔
+func startFlashSaleExpiry() {
+	ctx := gctx.GetInitCtx()
+	flashSaleService := newFlashSaleService(ctx)
+
+	interval := g.Cfg().MustGet(ctx, "reservation.sweepInterval").Duration()
+	if interval <= 0 {
+		interval = defaultSweepInterval
+	}
+	gtimer.AddSingleton(ctx, interval, func(ctx context.Context) {
+		released, err := flashSaleService.ReleaseExpired(ctx)
+		if err != nil {
+			g.Log().Errorf(ctx, "failed to release expired flash sale purchases: %+v", err)
+		}
+		if released > 0 {
+			g.Log().Infof(ctx, "released %d expired flash sale purchases", released)
+		}
+	})
+}
@@ -0,0 +1,31 @@
+package router
+
+import (
+	"context"
+	"sync"
+
+	sharedservice "main/internal/domain/shared/service"
+	"main/internal/infrastructure/idgen"
+
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+var (
+	idGeneratorOnce sync.Once
+	idGenerator     sharedservice.IdGenerator
+)
+
+// sharedIdGenerator 返回进程内共享的 Id 生成器，首次调用时按配置创建
+// 各模块的领域服务必须共用同一实例，Snowflake 才能在同一毫秒内按序列号区分 Id
+func sharedIdGenerator(ctx context.Context) sharedservice.IdGenerator {
+	idGeneratorOnce.Do(func() {
+		cfg, err := idgen.LoadConfig(ctx)
+		if err != nil {
+			g.Log().Fatalf(ctx, "failed to load id generator config: %+v", err)
+		}
+		if idGenerator, err = idgen.NewIdGenerator(cfg); err != nil {
+			g.Log().Fatalf(ctx, "failed to create id generator: %+v", err)
+		}
+	})
+	return idGenerator
+}
@@ -0,0 +1,45 @@
+package router
+
+import (
+	inventoryapp "main/internal/application/inventory"
+	inventoryHandler "main/internal/interfaces/http/handler/inventory"
+
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/os/gctx"
+)
+
+// registerInventoryRoutes 注册仓库与库存相关路由
+func registerInventoryRoutes(group *ghttp.RouterGroup) {
+	// 初始化依赖
+	ctx := gctx.GetInitCtx()
+	inventoryApp := inventoryapp.NewInventoryApplication(
+		newWarehouseService(ctx),
+		newInventoryService(ctx),
+		newProductService(ctx),
+	)
+
+	// 创建处理器
+	handler := inventoryHandler.NewInventory(inventoryApp)
+
+	// 注册路由
+	group.Group("/warehouses", func(group *ghttp.RouterGroup) {
+		// 创建仓库
+		group.POST("/", handler.CreateWarehouse)
+
+		// 仓库列表
+		group.GET("/", handler.ListWarehouses)
+
+		// 更新仓库
+		group.PUT("/{id}", handler.UpdateWarehouse)
+	})
+	group.Group("/inventory", func(group *ghttp.RouterGroup) {
+		// 商品分仓可售数量
+		group.GET("/products/{productId}", handler.Availability)
+
+		// 调整仓库库存
+		group.POST("/adjust", handler.Adjust)
+
+		// 仓库间调拨库存
+		group.POST("/transfer", handler.Transfer)
+	})
+}
@@ -0,0 +1,29 @@
+package router
+
+import (
+	"context"
+
+	"main/internal/infrastructure/media"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/os/gctx"
+)
+
+// mediaConfig 读取媒体存储配置
+func mediaConfig(ctx context.Context) media.Config {
+	cfg, err := media.LoadConfig(ctx)
+	if err != nil {
+		g.Log().Fatalf(ctx, "failed to load media config: %+v", err)
+	}
+	return cfg
+}
+
+// registerMediaRoutes 注册媒体文件的静态访问路径
+// 仅本地存储需要由本服务提供文件访问
+func registerMediaRoutes(server *ghttp.Server) {
+	cfg := mediaConfig(gctx.GetInitCtx())
+	if cfg.Driver == media.DriverLocal || cfg.Driver == "" {
+		server.AddStaticPath(cfg.BaseURL, cfg.Root)
+	}
+}
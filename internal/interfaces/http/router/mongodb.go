@@ -0,0 +1,38 @@
+package router
+
+import (
+	"context"
+	"sync"
+
+	"main/internal/infrastructure/persistence/mongodb"
+	mongoutil "main/utility/mongodb"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var (
+	mongoDatabaseOnce sync.Once
+	mongoDatabase     *mongo.Database
+)
+
+// mongoConfig 读取 MongoDB 配置
+func mongoConfig(ctx context.Context) mongoutil.Config {
+	var cfg mongoutil.Config
+	if err := g.Cfg().MustGet(ctx, "mongodb").Scan(&cfg); err != nil {
+		g.Log().Fatalf(ctx, "failed to load mongodb config: %+v", err)
+	}
+	return cfg
+}
+
+// sharedMongoDatabase 返回进程内共享的 MongoDB 数据库，首次调用时按配置连接
+// 所有仓储共用同一个客户端，避免每个仓储各自建立连接池
+func sharedMongoDatabase(ctx context.Context) *mongo.Database {
+	mongoDatabaseOnce.Do(func() {
+		var err error
+		if mongoDatabase, err = mongodb.NewDatabase(ctx, mongoConfig(ctx)); err != nil {
+			g.Log().Fatalf(ctx, "failed to connect to mongodb: %+v", err)
+		}
+	})
+	return mongoDatabase
+}
@@ -0,0 +1,51 @@
+package router
+
+import (
+	"main/internal/application/pricing"
+	"main/internal/domain/pricing/service"
+	"main/internal/infrastructure/persistence/mongodb"
+	priceListHandler "main/internal/interfaces/http/handler/pricelist"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/os/gctx"
+)
+
+// registerPriceListRoutes 注册价目表管理相关路由
+func registerPriceListRoutes(group *ghttp.RouterGroup) {
+	// 初始化依赖
+	ctx := gctx.GetInitCtx()
+	priceListRepo, err := mongodb.NewPriceListRepository(ctx, sharedMongoDatabase(ctx))
+	if err != nil {
+		g.Log().Fatalf(ctx, "failed to create price list repository: %+v", err)
+	}
+	pricingService := service.NewPricingService(priceListRepo, sharedIdGenerator(ctx))
+	priceListApp := pricing.NewPriceListApplication(pricingService)
+
+	// 创建处理器
+	handler := priceListHandler.NewPriceList(priceListApp)
+
+	// 注册路由
+	group.Group("/admin/price-lists", func(group *ghttp.RouterGroup) {
+		// 创建价目表
+		group.POST("/", handler.Create)
+
+		// 价目表列表
+		group.GET("/", handler.List)
+
+		// 获取价目表详情
+		group.GET("/{id}", handler.Get)
+
+		// 更新价目表
+		group.PUT("/{id}", handler.Update)
+
+		// 删除价目表
+		group.DELETE("/{id}", handler.Delete)
+
+		// 设置商品阶梯价格
+		group.PUT("/{id}/entries/{productId}", handler.SetEntry)
+
+		// 移除商品价格
+		group.DELETE("/{id}/entries/{productId}", handler.RemoveEntry)
+	})
+}
@@ -0,0 +1,33 @@
+package router
+
+import (
+	"context"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gctx"
+	"github.com/gogf/gf/v2/os/gtimer"
+)
+
+// defaultPriceScheduleInterval 未配置时检查到期定时调价的默认间隔
+const defaultPriceScheduleInterval = time.Minute
+
+// startPriceScheduler 启动定时任务，定期应用到开始时间的定时调价并恢复到结束时间的定时调价
+func startPriceScheduler() {
+	ctx := gctx.GetInitCtx()
+	scheduleService := newPriceScheduleService(ctx)
+
+	interval := g.Cfg().MustGet(ctx, "priceSchedule.interval").Duration()
+	if interval <= 0 {
+		interval = defaultPriceScheduleInterval
+	}
+	gtimer.AddSingleton(ctx, interval, func(ctx context.Context) {
+		processed, err := scheduleService.RunDue(ctx)
+		if err != nil {
+			g.Log().Errorf(ctx, "failed to run due price schedules: %+v", err)
+		}
+		if processed > 0 {
+			g.Log().Infof(ctx, "processed %d due price schedules", processed)
+		}
+	})
+}
@@ -0,0 +1,99 @@
+package router
+
+import (
+	productapp "main/internal/application/product"
+	productHandler "main/internal/interfaces/http/handler/product"
+
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/os/gctx"
+)
+
+// registerProductRoutes 注册商品相关路由
+func registerProductRoutes(group *ghttp.RouterGroup) {
+	// 初始化依赖
+	ctx := gctx.GetInitCtx()
+	productApp := productapp.NewProductApplicationService(
+		newProductService(ctx),
+		newProductImageService(ctx),
+		newReservationService(ctx),
+		newPriceScheduleService(ctx),
+		newCategoryService(ctx),
+	)
+
+	// 创建处理器
+	handler := productHandler.NewProduct(productApp)
+
+	// 注册路由
+	group.Group("/products", func(group *ghttp.RouterGroup) {
+		// 搜索商品
+		group.GET("/search", handler.Search)
+
+		// 批量导入商品
+		group.POST("/import", handler.Import)
+
+		// 导出商品
+		group.GET("/export", handler.Export)
+
+		// 补货报表
+		group.GET("/replenishment", handler.Replenishment)
+
+		// 创建套装
+		group.POST("/bundles", handler.CreateBundle)
+
+		// 发布商品
+		group.POST("/{id}/publish", handler.Publish)
+
+		// 下架商品
+		group.POST("/{id}/take-off-sale", handler.TakeOffSale)
+
+		// 标记商品售罄
+		group.POST("/{id}/mark-sold-out", handler.MarkSoldOut)
+
+		// 删除商品
+		group.DELETE("/{id}", handler.Delete)
+
+		// 恢复已删除的商品
+		group.POST("/{id}/restore", handler.Restore)
+
+		// 商品归类
+		group.PUT("/{id}/category", handler.AssignCategory)
+
+		// 套装组件及可售数量
+		group.GET("/{id}/bundle", handler.GetBundle)
+
+		// 替换套装组件
+		group.PUT("/{id}/components", handler.SetBundleComponents)
+
+		// 商品库存预占
+		group.GET("/{id}/reservations", handler.Reservations)
+
+		// 设置低库存阈值
+		group.PUT("/{id}/low-stock-threshold", handler.SetLowStockThreshold)
+
+		// 库存流水
+		group.GET("/{id}/stock/movements", handler.StockMovements)
+
+		// 登记库存变动
+		group.POST("/{id}/stock/movements", handler.AdjustStock)
+
+		// 核对库存与流水
+		group.POST("/{id}/stock/reconcile", handler.ReconcileStock)
+
+		// 价格历史
+		group.GET("/{id}/prices/history", handler.PriceHistory)
+
+		// 定时调价
+		group.GET("/{id}/prices/schedules", handler.PriceSchedules)
+		group.POST("/{id}/prices/schedules", handler.SchedulePrice)
+		group.POST("/{id}/prices/schedules/{scheduleId}/cancel", handler.CancelPriceSchedule)
+
+		// 上传商品图片
+		group.POST("/{id}/images", handler.UploadImage)
+
+		// 调整商品图片顺序
+		group.PUT("/{id}/images/order", handler.ReorderImages)
+
+		// 移除商品图片
+		group.DELETE("/{id}/images/{imageId}", handler.RemoveImage)
+	})
+}
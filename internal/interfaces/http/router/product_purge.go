@@ -0,0 +1,46 @@
+package router
+
+import (
+	"context"
+	"time"
+
+	orderapp "main/internal/application/order"
+	productservice "main/internal/domain/product/service"
+	"main/internal/infrastructure/persistence/mongodb"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gctx"
+	"github.com/gogf/gf/v2/os/gtimer"
+)
+
+// defaultProductPurgeInterval 未配置时检查超过保留期的已删除商品的默认间隔
+const defaultProductPurgeInterval = time.Hour
+
+// startProductPurge 启动定时任务，定期永久清除删除时间超过保留期的商品
+func startProductPurge() {
+	ctx := gctx.GetInitCtx()
+	productRepo, err := mongodb.NewProductRepository(ctx, sharedMongoDatabase(ctx))
+	if err != nil {
+		g.Log().Fatalf(ctx, "failed to create product repository: %+v", err)
+	}
+	purgeService := productservice.NewProductPurgeService(
+		productRepo,
+		newProductImageService(ctx),
+		orderapp.NewOrderReferences(newOrderRepository(ctx)),
+		g.Cfg().MustGet(ctx, "productPurge.retention").Duration(),
+	)
+
+	interval := g.Cfg().MustGet(ctx, "productPurge.interval").Duration()
+	if interval <= 0 {
+		interval = defaultProductPurgeInterval
+	}
+	gtimer.AddSingleton(ctx, interval, func(ctx context.Context) {
+		purged, err := purgeService.PurgeExpired(ctx)
+		if err != nil {
+			g.Log().Errorf(ctx, "failed to purge deleted products: %+v", err)
+		}
+		if purged > 0 {
+			g.Log().Infof(ctx, "purged %d deleted products", purged)
+		}
+	})
+}
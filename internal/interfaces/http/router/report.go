@@ -0,0 +1,26 @@
+package router
+
+import (
+	reportHandler "main/internal/interfaces/http/handler/report"
+
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/os/gctx"
+)
+
+// registerReportRoutes 注册报表相关路由
+func registerReportRoutes(group *ghttp.RouterGroup) {
+	// 初始化依赖
+	ctx := gctx.GetInitCtx()
+
+	// 创建处理器
+	handler := reportHandler.NewReport(newOrderApplication(ctx))
+
+	// 注册路由
+	group.Group("/reports", func(group *ghttp.RouterGroup) {
+		// 用户订单金额汇总，按汇总货币换算
+		group.GET("/users/{userId}/order-total", handler.UserOrderTotal)
+
+		// 部分退款按订单项实付金额分摊
+		group.GET("/orders/{orderId}/refund-allocation", handler.RefundAllocation)
+	})
+}
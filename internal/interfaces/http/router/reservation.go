@@ -0,0 +1,38 @@
+package router
+
+import (
+	"context"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gctx"
+	"github.com/gogf/gf/v2/os/gtimer"
+)
+
+// defaultSweepInterval 未配置时检查过期预占的默认间隔
+const defaultSweepInterval = time.Minute
+
+// reservationHoldDuration 读取库存预占的保留时长，未配置时由领域服务使用默认值
+func reservationHoldDuration(ctx context.Context) time.Duration {
+	return g.Cfg().MustGet(ctx, "reservation.holdDuration").Duration()
+}
+
+// startReservationExpiry 启动定时任务，定期释放超时未支付订单的库存预占
+func startReservationExpiry() {
+	ctx := gctx.GetInitCtx()
+	reservationService := newReservationService(ctx)
+
+	interval := g.Cfg().MustGet(ctx, "reservation.sweepInterval").Duration()
+	if interval <= 0 {
+		interval = defaultSweepInterval
+	}
+	gtimer.AddSingleton(ctx, interval, func(ctx context.Context) {
+		released, err := reservationService.ReleaseExpired(ctx)
+		if err != nil {
+			g.Log().Errorf(ctx, "failed to release expired stock reservations: %+v", err)
+		}
+		if released > 0 {
+			g.Log().Infof(ctx, "released %d expired stock reservations", released)
+		}
+	})
+}
@@ -0,0 +1,50 @@
+package router
+
+import (
+	reviewapp "main/internal/application/review"
+	reviewservice "main/internal/domain/review/service"
+	"main/internal/infrastructure/persistence/mongodb"
+	reviewHandler "main/internal/interfaces/http/handler/review"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/os/gctx"
+)
+
+// registerReviewRoutes 注册商品评价相关路由
+func registerReviewRoutes(group *ghttp.RouterGroup) {
+	// 初始化依赖
+	ctx := gctx.GetInitCtx()
+	reviewRepo, err := mongodb.NewReviewRepository(ctx, sharedMongoDatabase(ctx))
+	if err != nil {
+		g.Log().Fatalf(ctx, "failed to create review repository: %+v", err)
+	}
+	reviewApp := reviewapp.NewReviewApplication(
+		reviewservice.NewReviewService(reviewRepo, newOrderRepository(ctx), sharedIdGenerator(ctx)),
+		newProductService(ctx),
+	)
+
+	// 创建处理器
+	handler := reviewHandler.NewReview(reviewApp)
+
+	// 注册路由
+	// 评价订单项
+	group.POST("/reviews", handler.Create)
+
+	// 商品评价列表，仅包含审核通过的评价
+	group.GET("/products/{id}/reviews", handler.ProductList)
+
+	// 用户提交的评价
+	group.GET("/users/{userId}/reviews", handler.UserList)
+
+	group.Group("/admin/reviews", func(group *ghttp.RouterGroup) {
+		// 评价列表
+		group.GET("/", handler.List)
+
+		// 审核通过评价
+		group.POST("/{id}/approve", handler.Approve)
+
+		// 拒绝评价
+		group.POST("/{id}/reject", handler.Reject)
+	})
+}
@@ -2,6 +2,7 @@ package router
 
 import (
 	"main/internal/interfaces/api/middleware"
+	_ "main/internal/interfaces/http/validation"
 
 	"github.com/gogf/gf/v2/frame/g"
 	"github.com/gogf/gf/v2/net/ghttp"
@@ -23,9 +24,31 @@ func Register(server *ghttp.Server) {
 
 		// 注册模块路由
 		registerOrderRoutes(group)
+		registerProductRoutes(group)
+		registerCategoryRoutes(group)
+		registerPriceListRoutes(group)
+		registerInventoryRoutes(group)
+		registerFlashSaleRoutes(group)
+		registerReviewRoutes(group)
+		registerReportRoutes(group)
 		// TODO: 注册其他模块路由
 	})
 
+	// 注册媒体文件访问路径
+	registerMediaRoutes(server)
+
+	// 启动过期库存预占的释放任务
+	startReservationExpiry()
+
+	// 启动定时调价任务
+	startPriceScheduler()
+
+	// 启动过期秒杀名额的归还任务
+	startFlashSaleExpiry()
+
+	// 启动已删除商品的清除任务
+	startProductPurge()
+
 	// 注册 OpenAPI 路由
 	server.Group("/", func(group *ghttp.RouterGroup) {
 		group.GET("/api.json", func(r *ghttp.Request) {
@@ -0,0 +1,156 @@
+package router
+
+import (
+	"context"
+
+	inventoryapp "main/internal/application/inventory"
+	orderapp "main/internal/application/order"
+	categoryservice "main/internal/domain/category/service"
+	flashsaleservice "main/internal/domain/flashsale/service"
+	inventoryservice "main/internal/domain/inventory/service"
+	orderrepository "main/internal/domain/order/repository"
+	orderservice "main/internal/domain/order/service"
+	pricingservice "main/internal/domain/pricing/service"
+	productservice "main/internal/domain/product/service"
+	sharedservice "main/internal/domain/shared/service"
+	"main/internal/infrastructure/exchangerate"
+	"main/internal/infrastructure/media"
+	"main/internal/infrastructure/persistence/mongodb"
+
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// newProductService 创建商品领域服务，被多个模块的路由共用
+func newProductService(ctx context.Context) *productservice.ProductService {
+	productRepo, err := mongodb.NewProductRepository(ctx, sharedMongoDatabase(ctx))
+	if err != nil {
+		g.Log().Fatalf(ctx, "failed to create product repository: %+v", err)
+	}
+	movementRepo, err := mongodb.NewStockMovementRepository(ctx, sharedMongoDatabase(ctx))
+	if err != nil {
+		g.Log().Fatalf(ctx, "failed to create stock movement repository: %+v", err)
+	}
+	priceHistoryRepo, err := mongodb.NewPriceHistoryRepository(ctx, sharedMongoDatabase(ctx))
+	if err != nil {
+		g.Log().Fatalf(ctx, "failed to create price history repository: %+v", err)
+	}
+	return productservice.NewProductService(productRepo, movementRepo, priceHistoryRepo, eventBus, sharedIdGenerator(ctx))
+}
+
+// newPriceScheduleService 创建定时调价服务，被多个模块的路由共用
+func newPriceScheduleService(ctx context.Context) *productservice.PriceScheduleService {
+	scheduleRepo, err := mongodb.NewPriceScheduleRepository(ctx, sharedMongoDatabase(ctx))
+	if err != nil {
+		g.Log().Fatalf(ctx, "failed to create price schedule repository: %+v", err)
+	}
+	return productservice.NewPriceScheduleService(newProductService(ctx), scheduleRepo, sharedIdGenerator(ctx))
+}
+
+// newProductImageService 创建商品图片服务
+func newProductImageService(ctx context.Context) *productservice.ProductImageService {
+	productRepo, err := mongodb.NewProductRepository(ctx, sharedMongoDatabase(ctx))
+	if err != nil {
+		g.Log().Fatalf(ctx, "failed to create product repository: %+v", err)
+	}
+	blobStore, err := media.NewBlobStore(mediaConfig(ctx))
+	if err != nil {
+		g.Log().Fatalf(ctx, "failed to create blob store: %+v", err)
+	}
+	return productservice.NewProductImageService(productRepo, blobStore, media.NewImageProcessor())
+}
+
+// newReservationService 创建库存预占领域服务，被多个模块的路由共用
+func newReservationService(ctx context.Context) *productservice.ReservationService {
+	reservationRepo, err := mongodb.NewReservationRepository(ctx, sharedMongoDatabase(ctx))
+	if err != nil {
+		g.Log().Fatalf(ctx, "failed to create stock reservation repository: %+v", err)
+	}
+	return productservice.NewReservationService(
+		newProductService(ctx),
+		reservationRepo,
+		inventoryapp.NewWarehouseStock(newInventoryService(ctx)),
+		reservationHoldDuration(ctx),
+		sharedIdGenerator(ctx),
+	)
+}
+
+// newFlashSaleService 创建秒杀领域服务，被多个模块的路由共用
+// 秒杀名额与库存预占使用相同的保留时长
+func newFlashSaleService(ctx context.Context) *flashsaleservice.FlashSaleService {
+	flashSaleRepo, err := mongodb.NewFlashSaleRepository(ctx, sharedMongoDatabase(ctx))
+	if err != nil {
+		g.Log().Fatalf(ctx, "failed to create flash sale repository: %+v", err)
+	}
+	purchaseRepo, err := mongodb.NewPurchaseRepository(ctx, sharedMongoDatabase(ctx))
+	if err != nil {
+		g.Log().Fatalf(ctx, "failed to create flash sale purchase repository: %+v", err)
+	}
+	return flashsaleservice.NewFlashSaleService(flashSaleRepo, purchaseRepo, reservationHoldDuration(ctx), sharedIdGenerator(ctx))
+}
+
+// newOrderRepository 创建订单仓储，被多个模块的路由共用
+func newOrderRepository(ctx context.Context) orderrepository.OrderRepository {
+	orderRepo, err := mongodb.NewOrderRepository(ctx, sharedMongoDatabase(ctx))
+	if err != nil {
+		g.Log().Fatalf(ctx, "failed to create order repository: %+v", err)
+	}
+	return orderRepo
+}
+
+// newOrderApplication 创建订单应用服务
+func newOrderApplication(ctx context.Context) *orderapp.OrderApplication {
+	priceListRepo, err := mongodb.NewPriceListRepository(ctx, sharedMongoDatabase(ctx))
+	if err != nil {
+		g.Log().Fatalf(ctx, "failed to create price list repository: %+v", err)
+	}
+	return orderapp.NewOrderApplication(
+		orderservice.NewOrderService(newOrderRepository(ctx), eventBus, sharedIdGenerator(ctx)),
+		newProductService(ctx),
+		newReservationService(ctx),
+		newPriceScheduleService(ctx),
+		newFlashSaleService(ctx),
+		newInventoryService(ctx),
+		pricingservice.NewPricingService(priceListRepo, sharedIdGenerator(ctx)),
+		newCurrencyConverter(ctx),
+	)
+}
+
+// newCurrencyConverter 创建货币换算领域服务，汇率提供者由配置文件的 exchangeRate 节点决定
+func newCurrencyConverter(ctx context.Context) *sharedservice.CurrencyConverter {
+	provider, err := exchangerate.NewProviderFromConfig(ctx)
+	if err != nil {
+		g.Log().Fatalf(ctx, "failed to create exchange rate provider: %+v", err)
+	}
+	return sharedservice.NewCurrencyConverter(provider)
+}
+
+// newWarehouseService 创建仓库领域服务
+func newWarehouseService(ctx context.Context) *inventoryservice.WarehouseService {
+	warehouseRepo, err := mongodb.NewWarehouseRepository(ctx, sharedMongoDatabase(ctx))
+	if err != nil {
+		g.Log().Fatalf(ctx, "failed to create warehouse repository: %+v", err)
+	}
+	return inventoryservice.NewWarehouseService(warehouseRepo, sharedIdGenerator(ctx))
+}
+
+// newInventoryService 创建库存领域服务，被多个模块的路由共用
+func newInventoryService(ctx context.Context) *inventoryservice.InventoryService {
+	inventoryRepo, err := mongodb.NewInventoryRepository(ctx, sharedMongoDatabase(ctx))
+	if err != nil {
+		g.Log().Fatalf(ctx, "failed to create inventory repository: %+v", err)
+	}
+	warehouseRepo, err := mongodb.NewWarehouseRepository(ctx, sharedMongoDatabase(ctx))
+	if err != nil {
+		g.Log().Fatalf(ctx, "failed to create warehouse repository: %+v", err)
+	}
+	return inventoryservice.NewInventoryService(inventoryRepo, warehouseRepo)
+}
+
+// newCategoryService 创建类目领域服务，被多个模块的路由共用
+func newCategoryService(ctx context.Context) *categoryservice.CategoryService {
+	categoryRepo, err := mongodb.NewCategoryRepository(ctx, sharedMongoDatabase(ctx))
+	if err != nil {
+		g.Log().Fatalf(ctx, "failed to create category repository: %+v", err)
+	}
+	return categoryservice.NewCategoryService(categoryRepo, sharedIdGenerator(ctx))
+}
@@ -0,0 +1,30 @@
+package validation
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/util/gvalid"
+
+	sharedvo "main/internal/domain/shared/valueobject"
+)
+
+// RuleCurrency 货币代码校验规则名称
+// 在请求结构体中使用，如：`v:"currency"`
+const RuleCurrency = "currency"
+
+func init() {
+	gvalid.RegisterRule(RuleCurrency, checkCurrency)
+}
+
+// checkCurrency 校验货币代码是否在货币注册表中，空值交由 required 规则处理
+func checkCurrency(ctx context.Context, in gvalid.RuleFuncInput) error {
+	code := in.Value.String()
+	if code == "" || sharedvo.IsValidCurrency(code) {
+		return nil
+	}
+	if in.Message != "" {
+		return gerror.New(in.Message)
+	}
+	return gerror.Newf("unsupported currency: %s", code)
+}
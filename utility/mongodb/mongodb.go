@@ -12,8 +12,11 @@ type Config struct {
 	Database string
 }
 
-func NewMongoClient(ctx context.Context, cfg Config) (*mongo.Client, error) {
-	client, err := mongo.Connect(ctx, options.Client().ApplyURI(cfg.URI))
+// NewMongoClient 创建MongoDB客户端
+// opts 中的选项会覆盖默认选项，如自定义的BSON编解码注册表
+func NewMongoClient(ctx context.Context, cfg Config, opts ...*options.ClientOptions) (*mongo.Client, error) {
+	opts = append([]*options.ClientOptions{options.Client().ApplyURI(cfg.URI)}, opts...)
+	client, err := mongo.Connect(ctx, opts...)
 	if err != nil {
 		return nil, err
 	}